@@ -45,10 +45,18 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlasapikey"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlasdatabaseuser"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlasdatafederation"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlasdeployment"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlasfederatedauth"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlasnetworkcontainer"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlasnetworkpeering"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlasprivateendpoint"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlasproject"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlasteam"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/connectionsecret"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/stalled"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/watch"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
 	// +kubebuilder:scaffold:imports
@@ -83,6 +91,12 @@ func main() {
 
 	ctrl.SetLogger(zapr.NewLogger(logger))
 
+	operationTimeouts, err := stalled.ParseTimeouts(config.OperationTimeouts)
+	if err != nil {
+		setupLog.Error(err, "invalid --stalled-operation-timeouts")
+		os.Exit(1)
+	}
+
 	syncPeriod := time.Hour * 3
 
 	var cacheFunc cache.NewCacheFunc
@@ -137,6 +151,8 @@ func main() {
 		ResourceWatcher:  watch.NewResourceWatcher(),
 		GlobalPredicates: globalPredicates,
 		EventRecorder:    mgr.GetEventRecorderFor("AtlasDeployment"),
+
+		StalledOperationTimeouts: operationTimeouts,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AtlasDeployment")
 		os.Exit(1)
@@ -151,6 +167,7 @@ func main() {
 		GlobalAPISecret:  config.GlobalAPISecret,
 		GlobalPredicates: globalPredicates,
 		EventRecorder:    mgr.GetEventRecorderFor("AtlasProject"),
+		WatchNodes:       config.WatchedNamespaces[""],
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AtlasProject")
 		os.Exit(1)
@@ -169,6 +186,105 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "AtlasDatabaseUser")
 		os.Exit(1)
 	}
+
+	if err = (&atlasdatafederation.AtlasDataFederationReconciler{
+		Client:           mgr.GetClient(),
+		Log:              logger.Named("controllers").Named("AtlasDataFederation").Sugar(),
+		Scheme:           mgr.GetScheme(),
+		AtlasDomain:      config.AtlasDomain,
+		ResourceWatcher:  watch.NewResourceWatcher(),
+		GlobalAPISecret:  config.GlobalAPISecret,
+		GlobalPredicates: globalPredicates,
+		EventRecorder:    mgr.GetEventRecorderFor("AtlasDataFederation"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AtlasDataFederation")
+		os.Exit(1)
+	}
+
+	if err = (&atlasapikey.AtlasAPIKeyReconciler{
+		Client:           mgr.GetClient(),
+		Log:              logger.Named("controllers").Named("AtlasAPIKey").Sugar(),
+		Scheme:           mgr.GetScheme(),
+		AtlasDomain:      config.AtlasDomain,
+		ResourceWatcher:  watch.NewResourceWatcher(),
+		GlobalAPISecret:  config.GlobalAPISecret,
+		GlobalPredicates: globalPredicates,
+		EventRecorder:    mgr.GetEventRecorderFor("AtlasAPIKey"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AtlasAPIKey")
+		os.Exit(1)
+	}
+
+	if err = (&atlasteam.AtlasTeamReconciler{
+		Client:           mgr.GetClient(),
+		Log:              logger.Named("controllers").Named("AtlasTeam").Sugar(),
+		Scheme:           mgr.GetScheme(),
+		AtlasDomain:      config.AtlasDomain,
+		ResourceWatcher:  watch.NewResourceWatcher(),
+		GlobalAPISecret:  config.GlobalAPISecret,
+		GlobalPredicates: globalPredicates,
+		EventRecorder:    mgr.GetEventRecorderFor("AtlasTeam"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AtlasTeam")
+		os.Exit(1)
+	}
+
+	if err = (&atlasfederatedauth.AtlasFederatedAuthReconciler{
+		Client:           mgr.GetClient(),
+		Log:              logger.Named("controllers").Named("AtlasFederatedAuth").Sugar(),
+		Scheme:           mgr.GetScheme(),
+		AtlasDomain:      config.AtlasDomain,
+		ResourceWatcher:  watch.NewResourceWatcher(),
+		GlobalAPISecret:  config.GlobalAPISecret,
+		GlobalPredicates: globalPredicates,
+		EventRecorder:    mgr.GetEventRecorderFor("AtlasFederatedAuth"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AtlasFederatedAuth")
+		os.Exit(1)
+	}
+
+	if err = (&atlasprivateendpoint.AtlasPrivateEndpointReconciler{
+		Client:           mgr.GetClient(),
+		Log:              logger.Named("controllers").Named("AtlasPrivateEndpoint").Sugar(),
+		Scheme:           mgr.GetScheme(),
+		AtlasDomain:      config.AtlasDomain,
+		ResourceWatcher:  watch.NewResourceWatcher(),
+		GlobalAPISecret:  config.GlobalAPISecret,
+		GlobalPredicates: globalPredicates,
+		EventRecorder:    mgr.GetEventRecorderFor("AtlasPrivateEndpoint"),
+		Provisioner:      atlasprivateendpoint.NoopProvisioner{},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AtlasPrivateEndpoint")
+		os.Exit(1)
+	}
+
+	if err = (&atlasnetworkcontainer.AtlasNetworkContainerReconciler{
+		Client:           mgr.GetClient(),
+		Log:              logger.Named("controllers").Named("AtlasNetworkContainer").Sugar(),
+		Scheme:           mgr.GetScheme(),
+		AtlasDomain:      config.AtlasDomain,
+		ResourceWatcher:  watch.NewResourceWatcher(),
+		GlobalAPISecret:  config.GlobalAPISecret,
+		GlobalPredicates: globalPredicates,
+		EventRecorder:    mgr.GetEventRecorderFor("AtlasNetworkContainer"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AtlasNetworkContainer")
+		os.Exit(1)
+	}
+
+	if err = (&atlasnetworkpeering.AtlasNetworkPeeringReconciler{
+		Client:           mgr.GetClient(),
+		Log:              logger.Named("controllers").Named("AtlasNetworkPeering").Sugar(),
+		Scheme:           mgr.GetScheme(),
+		AtlasDomain:      config.AtlasDomain,
+		ResourceWatcher:  watch.NewResourceWatcher(),
+		GlobalAPISecret:  config.GlobalAPISecret,
+		GlobalPredicates: globalPredicates,
+		EventRecorder:    mgr.GetEventRecorderFor("AtlasNetworkPeering"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AtlasNetworkPeering")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("health", healthz.Ping); err != nil {
@@ -197,6 +313,7 @@ type Config struct {
 	GlobalAPISecret      client.ObjectKey
 	LogLevel             string
 	LogEncoder           string
+	OperationTimeouts    string
 }
 
 // ParseConfiguration fills the 'OperatorConfig' from the flags passed to the program
@@ -213,6 +330,9 @@ func parseConfiguration() Config {
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.StringVar(&config.LogLevel, "log-level", "info", "Log level. Available values: debug | info | warn | error | dpanic | panic | fatal")
 	flag.StringVar(&config.LogEncoder, "log-encoder", "json", "Log encoder. Available values: json | console")
+	flag.StringVar(&config.OperationTimeouts, "stalled-operation-timeouts", "Creating=1h,Updating=3h,Repairing=3h,Deleting=1h,UpgradeSnapshot=2h",
+		"The timeouts of the long-running Atlas operations of the AtlasDeployment resources by the kinds of operations after which the operation is reported as stalled. "+
+			"Restoring and TenantUpgrade default to 6 hours and 1 hour, the other kinds without the timeout default to 20 minutes.")
 	appVersion := flag.Bool("v", false, "prints application version")
 	flag.Parse()
 
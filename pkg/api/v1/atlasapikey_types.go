@@ -0,0 +1,155 @@
+/*
+Copyright 2023 MongoDB.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+)
+
+func init() {
+	SchemeBuilder.Register(&AtlasAPIKey{}, &AtlasAPIKeyList{})
+}
+
+// APIKeyRotationAnnotation requests the rotation of the API key. The key is rotated every time the value of the
+// annotation changes.
+const APIKeyRotationAnnotation = "mongodb.com/atlas-api-key-rotation"
+
+// APIKeyRole is the project role granted to the API key
+// +kubebuilder:validation:Enum=GROUP_OWNER;GROUP_CLUSTER_MANAGER;GROUP_DATA_ACCESS_ADMIN;GROUP_DATA_ACCESS_READ_WRITE;GROUP_DATA_ACCESS_READ_ONLY;GROUP_READ_ONLY;GROUP_SEARCH_INDEX_EDITOR
+type APIKeyRole string
+
+// APIKeySpec defines the desired state of a project API key in Atlas
+type APIKeySpec struct {
+	// Project is a reference to AtlasProject resource the API key is granted access to
+	Project common.ResourceRefNamespaced `json:"projectRef"`
+
+	// Description of the API key in Atlas.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=250
+	Description string `json:"description"`
+
+	// Roles granted to the API key in the project.
+	// +kubebuilder:validation:MinItems=1
+	Roles []APIKeyRole `json:"roles"`
+
+	// AccessList is the list of IP addresses or CIDR blocks the API key can be used from.
+	// The API key can be used from any address if the list is empty.
+	// +optional
+	AccessList []APIKeyAccessListEntry `json:"accessList,omitempty"`
+
+	// SecretRef is the name of the Secret the operator writes the API key to. The Secret is created in the namespace
+	// of the AtlasAPIKey resource and has the same format as the operator connection Secret.
+	SecretRef common.ResourceRef `json:"secretRef"`
+}
+
+// APIKeyAccessListEntry is the address the API key can be used from. Either IPAddress or CIDRBlock must be specified.
+type APIKeyAccessListEntry struct {
+	// IPAddress is the single IP address the API key can be used from.
+	// +optional
+	IPAddress string `json:"ipAddress,omitempty"`
+
+	// CIDRBlock is the range of IP addresses the API key can be used from.
+	// +optional
+	CIDRBlock string `json:"cidrBlock,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Description",type=string,JSONPath=`.spec.description`
+// +kubebuilder:printcolumn:name="Public Key",type=string,JSONPath=`.status.publicKey`
+
+// AtlasAPIKey is the Schema for the Atlas project API keys
+type AtlasAPIKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   APIKeySpec          `json:"spec,omitempty"`
+	Status status.APIKeyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AtlasAPIKeyList contains a list of AtlasAPIKey
+type AtlasAPIKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AtlasAPIKey `json:"items"`
+}
+
+func (k AtlasAPIKey) AtlasProjectObjectKey() client.ObjectKey {
+	ns := k.Namespace
+	if k.Spec.Project.Namespace != "" {
+		ns = k.Spec.Project.Namespace
+	}
+	return kube.ObjectKey(ns, k.Spec.Project.Name)
+}
+
+// SecretObjectKey returns the key of the Secret the API key is written to
+func (k AtlasAPIKey) SecretObjectKey() client.ObjectKey {
+	return kube.ObjectKey(k.Namespace, k.Spec.SecretRef.Name)
+}
+
+// RotationRequest returns the value of the rotation annotation
+func (k AtlasAPIKey) RotationRequest() string {
+	return k.GetAnnotations()[APIKeyRotationAnnotation]
+}
+
+func (k *AtlasAPIKey) GetStatus() status.Status {
+	return k.Status
+}
+
+func (k *AtlasAPIKey) UpdateStatus(conditions []status.Condition, options ...status.Option) {
+	k.Status.Conditions = conditions
+	k.Status.ObservedGeneration = k.ObjectMeta.Generation
+
+	for _, o := range options {
+		// This will fail if the Option passed is incorrect - which is expected
+		v := o.(status.AtlasAPIKeyStatusOption)
+		v(&k.Status)
+	}
+}
+
+// ************************************ Builder methods *************************************************
+
+func NewAPIKey(namespace, name, projectName, description, secretName string) *AtlasAPIKey {
+	return &AtlasAPIKey{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: APIKeySpec{
+			Project:     common.ResourceRefNamespaced{Name: projectName},
+			Description: description,
+			SecretRef:   common.ResourceRef{Name: secretName},
+		},
+	}
+}
+
+func (k *AtlasAPIKey) WithRoles(roles ...APIKeyRole) *AtlasAPIKey {
+	k.Spec.Roles = append(k.Spec.Roles, roles...)
+	return k
+}
+
+func (k *AtlasAPIKey) WithAccessListEntry(entry APIKeyAccessListEntry) *AtlasAPIKey {
+	k.Spec.AccessList = append(k.Spec.AccessList, entry)
+	return k
+}
@@ -20,3 +20,11 @@ type AtlasCustomResource interface {
 var _ AtlasCustomResource = &AtlasProject{}
 
 var _ AtlasCustomResource = &AtlasDeployment{}
+
+var _ AtlasCustomResource = &AtlasDataFederation{}
+var _ AtlasCustomResource = &AtlasAPIKey{}
+var _ AtlasCustomResource = &AtlasFederatedAuth{}
+var _ AtlasCustomResource = &AtlasIPAccessListEntry{}
+var _ AtlasCustomResource = &AtlasPrivateEndpoint{}
+var _ AtlasCustomResource = &AtlasNetworkContainer{}
+var _ AtlasCustomResource = &AtlasNetworkPeering{}
@@ -19,6 +19,7 @@ package v1
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"go.mongodb.org/atlas/mongodbatlas"
 	corev1 "k8s.io/api/core/v1"
@@ -48,6 +49,28 @@ const (
 	DataLakeScopeType   ScopeType = "DATA_LAKE"
 )
 
+const (
+	// ExternalDatabaseName is the authentication database of the users whose credentials are managed outside of Atlas
+	ExternalDatabaseName = "$external"
+
+	// NoneAuthType is the value of the authentication type fields meaning the corresponding method is not used
+	NoneAuthType = "NONE"
+)
+
+const (
+	X509TypeManaged  = "MANAGED"
+	X509TypeCustomer = "CUSTOMER"
+
+	AWSIAMTypeUser = "USER"
+	AWSIAMTypeRole = "ROLE"
+
+	LDAPAuthTypeUser  = "USER"
+	LDAPAuthTypeGroup = "GROUP"
+
+	OIDCAuthTypeIDPGroup = "IDP_GROUP"
+	OIDCAuthTypeUser     = "USER"
+)
+
 // AtlasDatabaseUserSpec defines the desired state of Database User in Atlas
 type AtlasDatabaseUserSpec struct {
 	// Project is a reference to AtlasProject resource the user belongs to
@@ -81,6 +104,28 @@ type AtlasDatabaseUserSpec struct {
 
 	// X509Type is X.509 method by which the database authenticates the provided username
 	X509Type string `json:"x509Type,omitempty"`
+
+	// X509Certificate configures the certificate Atlas issues for the user if the x509Type is MANAGED
+	// +optional
+	X509Certificate *X509CertificateSpec `json:"x509Certificate,omitempty"`
+
+	// AWSIAMType is the AWS IAM entity the database user authenticates as. The username must be the ARN of
+	// the IAM user or role and the databaseName must be '$external'.
+	// +kubebuilder:validation:Enum=NONE;USER;ROLE
+	// +optional
+	AWSIAMType string `json:"awsIAMType,omitempty"`
+
+	// LDAPAuthType is the LDAP entity the database user represents. The databaseName must be '$external' for
+	// LDAP users and 'admin' for LDAP groups.
+	// +kubebuilder:validation:Enum=NONE;USER;GROUP
+	// +optional
+	LDAPAuthType string `json:"ldapAuthType,omitempty"`
+
+	// OIDCAuthType is the OIDC federated identity the database user represents. The username must be prefixed
+	// with the identity provider ID. The databaseName must be '$external' for OIDC users and 'admin' for groups.
+	// +kubebuilder:validation:Enum=NONE;IDP_GROUP;USER
+	// +optional
+	OIDCAuthType string `json:"oidcAuthType,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -121,6 +166,22 @@ type RoleSpec struct {
 	CollectionName string `json:"collectionName,omitempty"`
 }
 
+// X509CertificateSpec configures the Atlas-managed X.509 certificate of the database user.
+// The certificate is stored in a Secret of 'kubernetes.io/tls' type and gets renewed when less than a third of its
+// validity period remains.
+type X509CertificateSpec struct {
+	// MonthsUntilExpiration is the number of months the certificate is valid for.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=24
+	// +kubebuilder:default=3
+	// +optional
+	MonthsUntilExpiration int `json:"monthsUntilExpiration,omitempty"`
+
+	// SecretRef is a reference to the Secret the certificate is stored in. Defaults to '<name>-x509-certificate'.
+	// +optional
+	SecretRef *common.ResourceRef `json:"secretRef,omitempty"`
+}
+
 // ScopeSpec if present a database user only have access to the indicated resource (Cluster or Atlas Data Lake)
 // if none is given then it has access to all.
 // It's highly recommended to restrict the access of the database users only to a limited set of resources.
@@ -148,6 +209,26 @@ func (p AtlasDatabaseUser) PasswordSecretObjectKey() *client.ObjectKey {
 	return nil
 }
 
+// HasManagedCertificate returns true if Atlas issues the X.509 certificate for the user
+func (p AtlasDatabaseUser) HasManagedCertificate() bool {
+	return p.Spec.X509Type == X509TypeManaged
+}
+
+func (p AtlasDatabaseUser) X509CertificateSecretObjectKey() client.ObjectKey {
+	if p.Spec.X509Certificate != nil && p.Spec.X509Certificate.SecretRef != nil {
+		return kube.ObjectKey(p.Namespace, p.Spec.X509Certificate.SecretRef.Name)
+	}
+	return kube.ObjectKey(p.Namespace, p.Name+"-x509-certificate")
+}
+
+// X509CertificateMonthsUntilExpiration returns the validity period of the managed certificate
+func (p AtlasDatabaseUser) X509CertificateMonthsUntilExpiration() int {
+	if p.Spec.X509Certificate == nil || p.Spec.X509Certificate.MonthsUntilExpiration == 0 {
+		return 3
+	}
+	return p.Spec.X509Certificate.MonthsUntilExpiration
+}
+
 func (p *AtlasDatabaseUser) GetStatus() status.Status {
 	return p.Status
 }
@@ -182,6 +263,28 @@ func (p *AtlasDatabaseUser) ReadPassword(kubeClient client.Client) (string, erro
 	return "", nil
 }
 
+// ExternalAuthTypes returns the names of the passwordless authentication methods enabled for the user
+func (p AtlasDatabaseUser) ExternalAuthTypes() []string {
+	var types []string
+	for name, value := range map[string]string{
+		"x509Type":     p.Spec.X509Type,
+		"awsIAMType":   p.Spec.AWSIAMType,
+		"ldapAuthType": p.Spec.LDAPAuthType,
+		"oidcAuthType": p.Spec.OIDCAuthType,
+	} {
+		if value != "" && value != NoneAuthType {
+			types = append(types, name)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// IsPasswordless returns true if the user's credentials are managed outside of Atlas
+func (p AtlasDatabaseUser) IsPasswordless() bool {
+	return len(p.ExternalAuthTypes()) > 0
+}
+
 // ToAtlas converts the AtlasDatabaseUser to native Atlas client format. Reads the password from the Secret
 func (p AtlasDatabaseUser) ToAtlas(kubeClient client.Client) (*mongodbatlas.DatabaseUser, error) {
 	password, err := p.ReadPassword(kubeClient)
@@ -0,0 +1,264 @@
+/*
+Copyright 2023 MongoDB.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+)
+
+func init() {
+	SchemeBuilder.Register(&AtlasDataFederation{}, &AtlasDataFederationList{})
+}
+
+type DataFederationStoreProvider string
+
+const (
+	DataFederationStoreAtlas DataFederationStoreProvider = "atlas"
+	DataFederationStoreS3    DataFederationStoreProvider = "s3"
+	DataFederationStoreHTTP  DataFederationStoreProvider = "http"
+)
+
+// DataFederationSpec defines the desired state of a Federated Database Instance in Atlas
+type DataFederationSpec struct {
+	// Project is a reference to AtlasProject resource the federated database instance belongs to
+	Project common.ResourceRefNamespaced `json:"projectRef"`
+
+	// Name of the federated database instance in Atlas. Cannot be changed after creation.
+	Name string `json:"name"`
+
+	// CloudProviderConfig configures the cloud provider access used by S3 stores.
+	// +optional
+	CloudProviderConfig *DataFederationCloudProviderConfig `json:"cloudProviderConfig,omitempty"`
+
+	// DataProcessRegion is the cloud provider region to which clients connect to process the data.
+	// +optional
+	DataProcessRegion *DataFederationDataProcessRegion `json:"dataProcessRegion,omitempty"`
+
+	// Storage is the configuration of the data stores and their mapping to databases and collections.
+	// +optional
+	Storage *DataFederationStorage `json:"storage,omitempty"`
+}
+
+type DataFederationCloudProviderConfig struct {
+	// AWS configures the access to S3 buckets through one of the project cloud provider access roles
+	AWS *DataFederationAWSConfig `json:"aws,omitempty"`
+}
+
+type DataFederationAWSConfig struct {
+	// IamAssumedRoleArn is the ARN of the AWS IAM role configured in the project 'cloudProviderAccessRoles'.
+	// The operator resolves it into the Atlas role ID. Either this or RoleID must be specified.
+	// +optional
+	IamAssumedRoleArn string `json:"iamAssumedRoleArn,omitempty"`
+	// RoleID is the unique identifier of the cloud provider access role in Atlas.
+	// +optional
+	RoleID string `json:"roleId,omitempty"`
+	// TestS3Bucket is the name of the S3 bucket used by Atlas to validate the access.
+	TestS3Bucket string `json:"testS3Bucket"`
+}
+
+type DataFederationDataProcessRegion struct {
+	// +kubebuilder:validation:Enum=AWS
+	CloudProvider string `json:"cloudProvider"`
+	Region        string `json:"region"`
+}
+
+type DataFederationStorage struct {
+	Databases []DataFederationDatabase `json:"databases,omitempty"`
+	Stores    []DataFederationStore    `json:"stores,omitempty"`
+}
+
+type DataFederationStore struct {
+	// Name of the store. Referenced by the data sources of collections.
+	Name string `json:"name"`
+	// Provider defines where the data is stored.
+	// +kubebuilder:validation:Enum=atlas;s3;http
+	Provider DataFederationStoreProvider `json:"provider"`
+
+	// ClusterName is the name of the Atlas deployment the store points to. Only for the 'atlas' provider.
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+	// ProjectID is the Atlas project of the deployment. Defaults to the project of the federated database instance.
+	// Only for the 'atlas' provider.
+	// +optional
+	ProjectID string `json:"projectId,omitempty"`
+	// ReadPreference is the read preference used to read from the deployment. Only for the 'atlas' provider.
+	// +optional
+	ReadPreference *DataFederationReadPreference `json:"readPreference,omitempty"`
+
+	// Bucket is the name of the S3 bucket. Only for the 's3' provider.
+	// +optional
+	Bucket string `json:"bucket,omitempty"`
+	// Region is the AWS region of the S3 bucket. Only for the 's3' provider.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// Prefix is the prefix prepended to the path when searching for files in the bucket. Only for the 's3' provider.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// Delimiter is the delimiter that separates the path segments. Only for the 's3' provider.
+	// +optional
+	Delimiter string `json:"delimiter,omitempty"`
+	// IncludeTags determines whether to use S3 tags on the files as additional partition attributes.
+	// Only for the 's3' provider.
+	// +optional
+	IncludeTags *bool `json:"includeTags,omitempty"`
+	// AdditionalStorageClasses is the list of additional S3 storage classes to include. Only for the 's3' provider.
+	// +optional
+	AdditionalStorageClasses []string `json:"additionalStorageClasses,omitempty"`
+	// Public allows reading from a public S3 bucket without cloud provider access. Only for the 's3' provider.
+	// +optional
+	Public *bool `json:"public,omitempty"`
+
+	// URLs is the list of publicly accessible files. Only for the 'http' provider.
+	// +optional
+	URLs []string `json:"urls,omitempty"`
+	// DefaultFormat is the format used for files without an extension. Only for the 'http' provider.
+	// +optional
+	DefaultFormat string `json:"defaultFormat,omitempty"`
+	// AllowInsecure allows the use of the plain HTTP scheme. Only for the 'http' provider.
+	// +optional
+	AllowInsecure *bool `json:"allowInsecure,omitempty"`
+}
+
+type DataFederationReadPreference struct {
+	// +kubebuilder:validation:Enum=primary;primaryPreferred;secondary;secondaryPreferred;nearest
+	Mode                string `json:"mode,omitempty"`
+	MaxStalenessSeconds int    `json:"maxStalenessSeconds,omitempty"`
+}
+
+type DataFederationDatabase struct {
+	Name                   string                     `json:"name"`
+	Collections            []DataFederationCollection `json:"collections,omitempty"`
+	Views                  []DataFederationView       `json:"views,omitempty"`
+	MaxWildcardCollections int                        `json:"maxWildcardCollections,omitempty"`
+}
+
+type DataFederationCollection struct {
+	// Name of the collection. Use '*' to create wildcard collections.
+	Name        string                     `json:"name"`
+	DataSources []DataFederationDataSource `json:"dataSources,omitempty"`
+}
+
+type DataFederationDataSource struct {
+	// StoreName is the name of the store from 'storage.stores' the data source reads from.
+	StoreName string `json:"storeName"`
+	// Database is the name of the database in the Atlas deployment. Only for 'atlas' stores.
+	Database string `json:"database,omitempty"`
+	// Collection is the name of the collection in the Atlas deployment. Only for 'atlas' stores.
+	Collection string `json:"collection,omitempty"`
+	// CollectionRegex is the regex pattern used to build wildcard collections. Only for 'atlas' stores.
+	CollectionRegex string `json:"collectionRegex,omitempty"`
+	// Path is the file path of the data in the store. Only for 's3' stores.
+	Path string `json:"path,omitempty"`
+	// URLs is the list of files to read from. Only for 'http' stores.
+	URLs []string `json:"urls,omitempty"`
+	// DefaultFormat is the format used for files without an extension.
+	DefaultFormat string `json:"defaultFormat,omitempty"`
+	// AllowInsecure allows the use of the plain HTTP scheme. Only for 'http' stores.
+	AllowInsecure *bool `json:"allowInsecure,omitempty"`
+	// ProvenanceFieldName is the name of the field that includes the provenance of the documents.
+	ProvenanceFieldName string `json:"provenanceFieldName,omitempty"`
+}
+
+type DataFederationView struct {
+	Name string `json:"name"`
+	// Source is the name of the source collection of the view.
+	Source string `json:"source"`
+	// Pipeline is the aggregation pipeline stages, as JSON, to apply to the source collection.
+	Pipeline string `json:"pipeline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Name",type=string,JSONPath=`.spec.name`
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+
+// AtlasDataFederation is the Schema for the Atlas Data Federation API
+type AtlasDataFederation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DataFederationSpec          `json:"spec,omitempty"`
+	Status status.DataFederationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AtlasDataFederationList contains a list of AtlasDataFederation
+type AtlasDataFederationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AtlasDataFederation `json:"items"`
+}
+
+func (c AtlasDataFederation) AtlasProjectObjectKey() client.ObjectKey {
+	ns := c.Namespace
+	if c.Spec.Project.Namespace != "" {
+		ns = c.Spec.Project.Namespace
+	}
+	return kube.ObjectKey(ns, c.Spec.Project.Name)
+}
+
+func (c *AtlasDataFederation) GetStatus() status.Status {
+	return c.Status
+}
+
+func (c *AtlasDataFederation) UpdateStatus(conditions []status.Condition, options ...status.Option) {
+	c.Status.Conditions = conditions
+	c.Status.ObservedGeneration = c.ObjectMeta.Generation
+
+	for _, o := range options {
+		// This will fail if the Option passed is incorrect - which is expected
+		v := o.(status.AtlasDataFederationStatusOption)
+		v(&c.Status)
+	}
+}
+
+// ************************************ Builder methods *************************************************
+
+func NewDataFederationInstance(namespace, name, nameInAtlas, projectName string) *AtlasDataFederation {
+	return &AtlasDataFederation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: DataFederationSpec{
+			Project: common.ResourceRefNamespaced{Name: projectName},
+			Name:    nameInAtlas,
+		},
+	}
+}
+
+func (c *AtlasDataFederation) WithStore(store DataFederationStore) *AtlasDataFederation {
+	if c.Spec.Storage == nil {
+		c.Spec.Storage = &DataFederationStorage{}
+	}
+	c.Spec.Storage.Stores = append(c.Spec.Storage.Stores, store)
+	return c
+}
+
+func (c *AtlasDataFederation) WithDatabase(database DataFederationDatabase) *AtlasDataFederation {
+	if c.Spec.Storage == nil {
+		c.Spec.Storage = &DataFederationStorage{}
+	}
+	c.Spec.Storage.Databases = append(c.Spec.Storage.Databases, database)
+	return c
+}
@@ -72,6 +72,36 @@ type AtlasDeploymentSpec struct {
 	// ProcessArgs allows to modify Advanced Configuration Options
 	// +optional
 	ProcessArgs *ProcessArgs `json:"processArgs,omitempty"`
+
+	// OnlineArchives is a list of rules archiving the data of the deployment to the Online Archive.
+	// Not supported by serverless deployments.
+	// +optional
+	OnlineArchives []OnlineArchive `json:"onlineArchives,omitempty"`
+
+	// Schedule pauses and resumes the deployment periodically. The paused flag of the deployment must not be set
+	// together with the schedule. Not supported by serverless deployments.
+	// +optional
+	Schedule *DeploymentSchedule `json:"schedule,omitempty"`
+
+	// ScalingProfiles override the instance sizes of the advanced deployment while they are active. The first active
+	// profile in the list is applied.
+	// +optional
+	ScalingProfiles []ScalingProfile `json:"scalingProfiles,omitempty"`
+
+	// MajorVersionUpgrade makes the changes of the MongoDB major version of the advanced deployment go through the
+	// managed upgrade with the pre-flight checks. The major version is changed right away if not set.
+	// +optional
+	MajorVersionUpgrade *MajorVersionUpgrade `json:"majorVersionUpgrade,omitempty"`
+
+	// DeletionPolicy configures the snapshot taken before the advanced deployment is deleted from Atlas and whether
+	// the backups are retained. Not supported by serverless deployments.
+	// +optional
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// RestoreFrom restores the backup of another deployment into the new advanced deployment once it's IDLE. The
+	// deployment becomes ready once the restore completes. Not supported by serverless deployments.
+	// +optional
+	RestoreFrom *RestoreFrom `json:"restoreFrom,omitempty"`
 }
 
 type DeploymentSpec struct {
@@ -169,10 +199,17 @@ type AdvancedDeploymentSpec struct {
 	ReplicationSpecs     []*AdvancedReplicationSpec `json:"replicationSpecs,omitempty"`
 	RootCertType         string                     `json:"rootCertType,omitempty"`
 	VersionReleaseSystem string                     `json:"versionReleaseSystem,omitempty"`
+	// Flag that indicates whether termination protection is enabled on the deployment. If enabled, Atlas rejects the
+	// deletion of the deployment and the operator requires the deletion confirmation annotation to delete it.
+	// +optional
+	TerminationProtectionEnabled *bool `json:"terminationProtectionEnabled,omitempty"`
 	// +optional
 	CustomZoneMapping []CustomZoneMapping `json:"customZoneMapping,omitempty"`
 	// +optional
 	ManagedNamespaces []ManagedNamespace `json:"managedNamespaces,omitempty"`
+	// Atlas Search indexes of the deployment
+	// +optional
+	SearchIndexes []SearchIndex `json:"searchIndexes,omitempty"`
 }
 
 // ToAtlas converts the AdvancedDeploymentSpec to native Atlas client ToAtlas format.
@@ -207,6 +244,11 @@ type ServerlessSpec struct {
 	// Configuration for the provisioned hosts on which MongoDB runs. The available options are specific to the cloud service provider.
 	ProviderSettings *ProviderSettingsSpec `json:"providerSettings"`
 
+	// Flag that indicates whether termination protection is enabled on the serverless instance. If enabled, Atlas
+	// rejects the deletion of the instance and the operator requires the deletion confirmation annotation to delete it.
+	// +optional
+	TerminationProtectionEnabled *bool `json:"terminationProtectionEnabled,omitempty"`
+
 	PrivateEndpoints []ServerlessPrivateEndpoint `json:"privateEndpoints,omitempty"`
 }
 
@@ -0,0 +1,230 @@
+/*
+Copyright 2023 MongoDB.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+func init() {
+	SchemeBuilder.Register(&AtlasFederatedAuth{}, &AtlasFederatedAuthList{})
+}
+
+// AtlasFederatedAuthSpec defines the desired federated authentication settings of the Atlas organization the
+// connection Secret belongs to. The identity providers must be created in the Atlas UI first, the operator manages
+// their settings and the way the organization is connected to them.
+type AtlasFederatedAuthSpec struct {
+	// ConnectionSecret is the name of the Kubernetes Secret in the namespace of the resource which contains the
+	// information about the way to connect to the Atlas organization (organization ID, API keys). The default Operator
+	// connection configuration will be used if not provided.
+	// +optional
+	ConnectionSecret *common.ResourceRef `json:"connectionSecretRef,omitempty"`
+
+	// Enabled connects the organization to the federation. The organization is disconnected if set to false.
+	// +kubebuilder:default:=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// IdentityProviderID is the ID of the SAML identity provider the users log in to the Atlas UI with.
+	// +optional
+	IdentityProviderID string `json:"identityProviderId,omitempty"`
+
+	// DataAccessIdentityProviderIDs are the IDs of the OIDC identity providers the database users authenticate with.
+	// +optional
+	DataAccessIdentityProviderIDs []string `json:"dataAccessIdentityProviderIds,omitempty"`
+
+	// IdentityProviders configures the identity providers of the federation.
+	// +optional
+	IdentityProviders []FederatedIdentityProvider `json:"identityProviders,omitempty"`
+
+	// DomainAllowList is the list of domains the users of the organization must belong to if domain restriction is
+	// enabled.
+	// +optional
+	DomainAllowList []string `json:"domainAllowList,omitempty"`
+
+	// DomainRestrictionEnabled prevents the users outside the DomainAllowList from accessing the organization.
+	// +optional
+	DomainRestrictionEnabled bool `json:"domainRestrictionEnabled,omitempty"`
+
+	// PostAuthRoleGrants are the organization roles granted to the users after they authenticate.
+	// +optional
+	PostAuthRoleGrants []string `json:"postAuthRoleGrants,omitempty"`
+
+	// RoleMappings map the groups of the identity provider to the Atlas organization and project roles.
+	// The role mappings removed from the spec are removed from the organization. The role mappings created in Atlas
+	// are kept unless they are specified here, then the operator manages them.
+	// +optional
+	RoleMappings []FederatedRoleMapping `json:"roleMappings,omitempty"`
+}
+
+// FederatedIdentityProvider configures an existing identity provider of the federation
+type FederatedIdentityProvider struct {
+	// ID is the unique identifier of the identity provider in Atlas.
+	ID string `json:"id"`
+
+	// DisplayName is the human-readable name of the identity provider.
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Description of the identity provider.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// IssuerURI is the unique string identifying the identity provider.
+	// +optional
+	IssuerURI string `json:"issuerUri,omitempty"`
+
+	// AssociatedDomains are the domains whose users are routed to the identity provider.
+	// +optional
+	AssociatedDomains []string `json:"associatedDomains,omitempty"`
+
+	// SSODebugEnabled enables the SSO debugging of a SAML identity provider.
+	// +optional
+	SSODebugEnabled *bool `json:"ssoDebugEnabled,omitempty"`
+
+	// OIDC configures the workforce OIDC identity provider used for the database access.
+	// +optional
+	OIDC *FederatedOIDCSettings `json:"oidc,omitempty"`
+}
+
+// FederatedOIDCSettings are the settings of the workforce OIDC identity provider
+type FederatedOIDCSettings struct {
+	// Audience is the identifier of the intended recipient of the token.
+	Audience string `json:"audience"`
+
+	// ClientID is the identifier the identity provider assigned to Atlas.
+	ClientID string `json:"clientId"`
+
+	// AuthorizationType specifies whether the database users are authorized by the groups or by the user identity.
+	// +kubebuilder:validation:Enum=GROUP;USER
+	// +kubebuilder:default:=GROUP
+	// +optional
+	AuthorizationType string `json:"authorizationType,omitempty"`
+
+	// GroupsClaim is the name of the token claim containing the IdP groups of the user.
+	// +optional
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+
+	// UserClaim is the name of the token claim containing the user identifier.
+	// +optional
+	UserClaim string `json:"userClaim,omitempty"`
+
+	// RequestedScopes are the scopes requested from the identity provider in addition to the default ones.
+	// +optional
+	RequestedScopes []string `json:"requestedScopes,omitempty"`
+}
+
+// FederatedRoleMapping grants the Atlas roles to the members of the identity provider group
+type FederatedRoleMapping struct {
+	// ExternalGroupName is the name of the identity provider group.
+	ExternalGroupName string `json:"externalGroupName"`
+
+	// RoleAssignments are the roles granted to the group members.
+	// +kubebuilder:validation:MinItems=1
+	RoleAssignments []FederatedRoleAssignment `json:"roleAssignments"`
+}
+
+// FederatedRoleAssignment is the organization role or, if the project is referenced, the project role.
+type FederatedRoleAssignment struct {
+	// Role is the name of the organization (ORG_*) or project (GROUP_*) role.
+	Role string `json:"role"`
+
+	// ProjectRef is a reference to AtlasProject resource the project role is granted in.
+	// +optional
+	ProjectRef *common.ResourceRefNamespaced `json:"projectRef,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Enabled",type=boolean,JSONPath=`.spec.enabled`
+// +kubebuilder:printcolumn:name="Federation",type=string,JSONPath=`.status.federationSettingsId`
+
+// AtlasFederatedAuth is the Schema for the federated authentication settings of an Atlas organization
+type AtlasFederatedAuth struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AtlasFederatedAuthSpec          `json:"spec,omitempty"`
+	Status status.AtlasFederatedAuthStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AtlasFederatedAuthList contains a list of AtlasFederatedAuth
+type AtlasFederatedAuthList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AtlasFederatedAuth `json:"items"`
+}
+
+func (f *AtlasFederatedAuth) ConnectionSecretObjectKey() *client.ObjectKey {
+	if f.Spec.ConnectionSecret != nil {
+		key := kube.ObjectKey(f.Namespace, f.Spec.ConnectionSecret.Name)
+		return &key
+	}
+	return nil
+}
+
+// IsEnabled returns true unless the organization is disconnected from the federation explicitly
+func (f *AtlasFederatedAuth) IsEnabled() bool {
+	return f.Spec.Enabled == nil || *f.Spec.Enabled
+}
+
+func (f *AtlasFederatedAuth) GetStatus() status.Status {
+	return f.Status
+}
+
+func (f *AtlasFederatedAuth) UpdateStatus(conditions []status.Condition, options ...status.Option) {
+	f.Status.Conditions = conditions
+	f.Status.ObservedGeneration = f.ObjectMeta.Generation
+
+	for _, o := range options {
+		// This will fail if the Option passed is incorrect - which is expected
+		v := o.(status.AtlasFederatedAuthStatusOption)
+		v(&f.Status)
+	}
+}
+
+// ************************************ Builder methods *************************************************
+
+func NewFederatedAuth(namespace, name string) *AtlasFederatedAuth {
+	return &AtlasFederatedAuth{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: AtlasFederatedAuthSpec{
+			Enabled: toptr.MakePtr(true),
+		},
+	}
+}
+
+func (f *AtlasFederatedAuth) WithIdentityProvider(id string) *AtlasFederatedAuth {
+	f.Spec.IdentityProviderID = id
+	return f
+}
+
+func (f *AtlasFederatedAuth) WithRoleMapping(externalGroupName string, assignments ...FederatedRoleAssignment) *AtlasFederatedAuth {
+	f.Spec.RoleMappings = append(f.Spec.RoleMappings, FederatedRoleMapping{ExternalGroupName: externalGroupName, RoleAssignments: assignments})
+	return f
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2023 MongoDB.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+)
+
+func init() {
+	SchemeBuilder.Register(&AtlasIPAccessListEntry{}, &AtlasIPAccessListEntryList{})
+}
+
+// IPAccessListEntrySpec defines the desired state of a single IP Access List entry of the project
+type IPAccessListEntrySpec struct {
+	// Project is a reference to AtlasProject resource the entry is added to. The project must allow the entries from
+	// the namespace of this resource if it lives in another namespace.
+	Project common.ResourceRefNamespaced `json:"projectRef"`
+
+	project.IPAccessList `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Project",type=string,JSONPath=`.spec.projectRef.name`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.entryStatus`
+
+// AtlasIPAccessListEntry is the Schema for the IP Access List entries managed outside of the AtlasProject.
+// The entries are reconciled by the controller of the referenced AtlasProject.
+type AtlasIPAccessListEntry struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IPAccessListEntrySpec          `json:"spec,omitempty"`
+	Status status.IPAccessListEntryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AtlasIPAccessListEntryList contains a list of AtlasIPAccessListEntry
+type AtlasIPAccessListEntryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AtlasIPAccessListEntry `json:"items"`
+}
+
+func (e AtlasIPAccessListEntry) AtlasProjectObjectKey() client.ObjectKey {
+	return *e.Spec.Project.GetObject(e.Namespace)
+}
+
+func (e *AtlasIPAccessListEntry) GetStatus() status.Status {
+	return e.Status
+}
+
+func (e *AtlasIPAccessListEntry) UpdateStatus(conditions []status.Condition, options ...status.Option) {
+	e.Status.Conditions = conditions
+	e.Status.ObservedGeneration = e.ObjectMeta.Generation
+
+	for _, o := range options {
+		// This will fail if the Option passed is incorrect - which is expected
+		v := o.(status.AtlasIPAccessListEntryStatusOption)
+		v(&e.Status)
+	}
+}
+
+// ************************************ Builder methods *************************************************
+
+func NewIPAccessListEntry(namespace, name, projectName string, entry project.IPAccessList) *AtlasIPAccessListEntry {
+	return &AtlasIPAccessListEntry{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: IPAccessListEntrySpec{
+			Project:      common.ResourceRefNamespaced{Name: projectName},
+			IPAccessList: entry,
+		},
+	}
+}
+
+func (e *AtlasIPAccessListEntry) WithProjectNamespace(namespace string) *AtlasIPAccessListEntry {
+	e.Spec.Project.Namespace = namespace
+	return e
+}
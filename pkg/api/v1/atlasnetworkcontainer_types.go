@@ -0,0 +1,109 @@
+/*
+Copyright 2023 MongoDB.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+)
+
+func init() {
+	SchemeBuilder.Register(&AtlasNetworkContainer{}, &AtlasNetworkContainerList{})
+}
+
+// AtlasNetworkContainerSpec defines the desired state of a network peering container of the project
+type AtlasNetworkContainerSpec struct {
+	// Project is a reference to AtlasProject resource the container belongs to
+	Project common.ResourceRefNamespaced `json:"projectRef"`
+
+	// Cloud provider of the container.
+	// +kubebuilder:validation:Enum=AWS;GCP;AZURE
+	Provider provider.ProviderName `json:"provider"`
+
+	// Region is the provider region of the container. It's required for AWS and Azure and isn't used for GCP as
+	// the GCP containers span all regions.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// AtlasCIDRBlock is the CIDR block that Atlas uses for the clusters in the container.
+	AtlasCIDRBlock string `json:"atlasCidrBlock"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.spec.provider`
+// +kubebuilder:printcolumn:name="Region",type=string,JSONPath=`.spec.region`
+// +kubebuilder:printcolumn:name="CIDR",type=string,JSONPath=`.spec.atlasCidrBlock`
+// +kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.id`
+
+// AtlasNetworkContainer is the Schema for the network peering containers of the Atlas projects
+type AtlasNetworkContainer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AtlasNetworkContainerSpec          `json:"spec,omitempty"`
+	Status status.AtlasNetworkContainerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AtlasNetworkContainerList contains a list of AtlasNetworkContainer
+type AtlasNetworkContainerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AtlasNetworkContainer `json:"items"`
+}
+
+func (c AtlasNetworkContainer) AtlasProjectObjectKey() client.ObjectKey {
+	return *c.Spec.Project.GetObject(c.Namespace)
+}
+
+func (c *AtlasNetworkContainer) GetStatus() status.Status {
+	return c.Status
+}
+
+func (c *AtlasNetworkContainer) UpdateStatus(conditions []status.Condition, options ...status.Option) {
+	c.Status.Conditions = conditions
+	c.Status.ObservedGeneration = c.ObjectMeta.Generation
+
+	for _, o := range options {
+		// This will fail if the Option passed is incorrect - which is expected
+		v := o.(status.AtlasNetworkContainerStatusOption)
+		v(&c.Status)
+	}
+}
+
+// ************************************ Builder methods *************************************************
+
+func NewNetworkContainer(namespace, name, projectName string, providerName provider.ProviderName, region, atlasCIDRBlock string) *AtlasNetworkContainer {
+	return &AtlasNetworkContainer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: AtlasNetworkContainerSpec{
+			Project:        common.ResourceRefNamespaced{Name: projectName},
+			Provider:       providerName,
+			Region:         region,
+			AtlasCIDRBlock: atlasCIDRBlock,
+		},
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2023 MongoDB.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+)
+
+func init() {
+	SchemeBuilder.Register(&AtlasNetworkPeering{}, &AtlasNetworkPeeringList{})
+}
+
+// AtlasNetworkPeeringSpec defines the desired state of a network peering connection of the project
+type AtlasNetworkPeeringSpec struct {
+	// Project is a reference to AtlasProject resource the peering connection belongs to
+	Project common.ResourceRefNamespaced `json:"projectRef"`
+
+	// Container is a reference to AtlasNetworkContainer resource the peering connection uses. Either containerRef or
+	// containerId must be specified.
+	// +optional
+	Container *common.ResourceRefNamespaced `json:"containerRef,omitempty"`
+
+	// ContainerID is the ID of the Atlas container which isn't managed by an AtlasNetworkContainer resource.
+	// +optional
+	ContainerID string `json:"containerId,omitempty"`
+
+	// Cloud provider of the peering connection.
+	// +kubebuilder:validation:Enum=AWS;GCP;AZURE
+	Provider provider.ProviderName `json:"provider"`
+
+	// AccepterRegionName is the provider region name of user's vpc. Applicable only for AWS.
+	// +optional
+	AccepterRegionName string `json:"accepterRegionName,omitempty"`
+	// AccountID of the user's vpc. Applicable only for AWS.
+	// +optional
+	AWSAccountID string `json:"awsAccountId,omitempty"`
+	// User VPC CIDR. Applicable only for AWS.
+	// +optional
+	RouteTableCIDRBlock string `json:"routeTableCidrBlock,omitempty"`
+	// AWS VPC ID. Applicable only for AWS.
+	// +optional
+	VpcID string `json:"vpcId,omitempty"`
+	// AzureDirectoryID is the unique identifier for an Azure AD directory. Applicable only for Azure.
+	// +optional
+	AzureDirectoryID string `json:"azureDirectoryId,omitempty"`
+	// AzureSubscriptionID is the unique identifier of the Azure subscription in which the VNet resides. Applicable only for Azure.
+	// +optional
+	AzureSubscriptionID string `json:"azureSubscriptionId,omitempty"`
+	// ResourceGroupName is the name of your Azure resource group. Applicable only for Azure.
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+	// VNetName is name of your Azure VNet. Applicable only for Azure.
+	// +optional
+	VNetName string `json:"vnetName,omitempty"`
+	// User GCP Project ID. Applicable only for GCP.
+	// +optional
+	GCPProjectID string `json:"gcpProjectId,omitempty"`
+	// GCP Network Peer Name. Applicable only for GCP.
+	// +optional
+	NetworkName string `json:"networkName,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.spec.provider`
+// +kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.id`
+// +kubebuilder:printcolumn:name="Connection ID",type=string,JSONPath=`.status.connectionId`
+
+// AtlasNetworkPeering is the Schema for the network peering connections of the Atlas projects
+type AtlasNetworkPeering struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AtlasNetworkPeeringSpec          `json:"spec,omitempty"`
+	Status status.AtlasNetworkPeeringStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AtlasNetworkPeeringList contains a list of AtlasNetworkPeering
+type AtlasNetworkPeeringList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AtlasNetworkPeering `json:"items"`
+}
+
+func (p AtlasNetworkPeering) AtlasProjectObjectKey() client.ObjectKey {
+	return *p.Spec.Project.GetObject(p.Namespace)
+}
+
+// ContainerObjectKey returns the key of the referenced AtlasNetworkContainer or nil if the peering connection uses
+// the container specified by ID.
+func (p AtlasNetworkPeering) ContainerObjectKey() *client.ObjectKey {
+	if p.Spec.Container == nil {
+		return nil
+	}
+	return p.Spec.Container.GetObject(p.Namespace)
+}
+
+// NetworkPeer returns the peering connection in the same shape as the network peers of the project.
+func (p AtlasNetworkPeering) NetworkPeer(containerID string) NetworkPeer {
+	return NetworkPeer{
+		AccepterRegionName:  p.Spec.AccepterRegionName,
+		AWSAccountID:        p.Spec.AWSAccountID,
+		ContainerID:         containerID,
+		ProviderName:        p.Spec.Provider,
+		RouteTableCIDRBlock: p.Spec.RouteTableCIDRBlock,
+		VpcID:               p.Spec.VpcID,
+		AzureDirectoryID:    p.Spec.AzureDirectoryID,
+		AzureSubscriptionID: p.Spec.AzureSubscriptionID,
+		ResourceGroupName:   p.Spec.ResourceGroupName,
+		VNetName:            p.Spec.VNetName,
+		GCPProjectID:        p.Spec.GCPProjectID,
+		NetworkName:         p.Spec.NetworkName,
+	}
+}
+
+func (p *AtlasNetworkPeering) GetStatus() status.Status {
+	return p.Status
+}
+
+func (p *AtlasNetworkPeering) UpdateStatus(conditions []status.Condition, options ...status.Option) {
+	p.Status.Conditions = conditions
+	p.Status.ObservedGeneration = p.ObjectMeta.Generation
+
+	for _, o := range options {
+		// This will fail if the Option passed is incorrect - which is expected
+		v := o.(status.AtlasNetworkPeeringStatusOption)
+		v(&p.Status)
+	}
+}
+
+// ************************************ Builder methods *************************************************
+
+func NewNetworkPeering(namespace, name, projectName string, providerName provider.ProviderName) *AtlasNetworkPeering {
+	return &AtlasNetworkPeering{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: AtlasNetworkPeeringSpec{
+			Project:  common.ResourceRefNamespaced{Name: projectName},
+			Provider: providerName,
+		},
+	}
+}
+
+func (p *AtlasNetworkPeering) WithContainerRef(name string) *AtlasNetworkPeering {
+	p.Spec.Container = &common.ResourceRefNamespaced{Name: name}
+	return p
+}
+
+func (p *AtlasNetworkPeering) WithContainerID(containerID string) *AtlasNetworkPeering {
+	p.Spec.ContainerID = containerID
+	return p
+}
+
+func (p *AtlasNetworkPeering) WithAWSVpc(accountID, region, vpcID, cidr string) *AtlasNetworkPeering {
+	p.Spec.AWSAccountID = accountID
+	p.Spec.AccepterRegionName = region
+	p.Spec.VpcID = vpcID
+	p.Spec.RouteTableCIDRBlock = cidr
+	return p
+}
+
+func (p *AtlasNetworkPeering) WithGCPNetwork(gcpProjectID, networkName string) *AtlasNetworkPeering {
+	p.Spec.GCPProjectID = gcpProjectID
+	p.Spec.NetworkName = networkName
+	return p
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2023 MongoDB.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+)
+
+func init() {
+	SchemeBuilder.Register(&AtlasPrivateEndpoint{}, &AtlasPrivateEndpointList{})
+}
+
+const (
+	// PrivateEndpointIDAnnotation is written back by the external tools (for example ACK or Crossplane) which created
+	// the AWS VPC endpoint or the Azure private endpoint for the Atlas service published in the status.
+	PrivateEndpointIDAnnotation = "mongodb.com/atlas-private-endpoint-id"
+	// PrivateEndpointIPAnnotation is the private IP address of the Azure private endpoint written back together with
+	// PrivateEndpointIDAnnotation.
+	PrivateEndpointIPAnnotation = "mongodb.com/atlas-private-endpoint-ip"
+)
+
+// AtlasPrivateEndpointSpec defines the desired state of a private endpoint of the project
+type AtlasPrivateEndpointSpec struct {
+	// Project is a reference to AtlasProject resource the private endpoint belongs to
+	Project common.ResourceRefNamespaced `json:"projectRef"`
+
+	// Cloud provider of the private endpoint service.
+	// +kubebuilder:validation:Enum=AWS;GCP;AZURE
+	Provider provider.ProviderName `json:"provider"`
+
+	// Cloud provider region for which you want to create the private endpoint service.
+	Region string `json:"region"`
+
+	// Interface is the endpoint created in the cloud provider. If not specified, the endpoint is created by the cloud
+	// provisioner of the operator or is taken from the annotations written back by the external tools.
+	// +optional
+	Interface *PrivateEndpointInterface `json:"interface,omitempty"`
+}
+
+// PrivateEndpointInterface is the endpoint created in the cloud provider which connects to the Atlas service.
+type PrivateEndpointInterface struct {
+	// Unique identifier of the private endpoint you created in your AWS VPC or Azure Vnet.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// Private IP address of the private endpoint network interface you created in your Azure VNet.
+	// +optional
+	IP string `json:"ip,omitempty"`
+	// Unique identifier of the Google Cloud project in which you created your endpoints.
+	// +optional
+	GCPProjectID string `json:"gcpProjectId,omitempty"`
+	// Unique identifier of the endpoint group. The endpoint group encompasses all of the endpoints that you created in Google Cloud.
+	// +optional
+	EndpointGroupName string `json:"endpointGroupName,omitempty"`
+	// Collection of individual private endpoints that comprise your endpoint group.
+	// +optional
+	Endpoints GCPEndpoints `json:"endpoints,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.spec.provider`
+// +kubebuilder:printcolumn:name="Region",type=string,JSONPath=`.spec.region`
+// +kubebuilder:printcolumn:name="Service Name",type=string,JSONPath=`.status.serviceName`
+
+// AtlasPrivateEndpoint is the Schema for the private endpoints of the Atlas projects
+type AtlasPrivateEndpoint struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AtlasPrivateEndpointSpec          `json:"spec,omitempty"`
+	Status status.AtlasPrivateEndpointStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AtlasPrivateEndpointList contains a list of AtlasPrivateEndpoint
+type AtlasPrivateEndpointList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AtlasPrivateEndpoint `json:"items"`
+}
+
+func (pe AtlasPrivateEndpoint) AtlasProjectObjectKey() client.ObjectKey {
+	return *pe.Spec.Project.GetObject(pe.Namespace)
+}
+
+// Identifier matches the private endpoint with the Atlas private endpoint services the same way as the private
+// endpoints of the project
+func (pe AtlasPrivateEndpoint) Identifier() interface{} {
+	return PrivateEndpoint{Provider: pe.Spec.Provider, Region: pe.Spec.Region}.Identifier()
+}
+
+// InterfaceFromAnnotations returns the endpoint written back to the annotations by the external tools or nil if
+// there is none.
+func (pe AtlasPrivateEndpoint) InterfaceFromAnnotations() *PrivateEndpointInterface {
+	id := pe.GetAnnotations()[PrivateEndpointIDAnnotation]
+	if id == "" {
+		return nil
+	}
+	return &PrivateEndpointInterface{ID: id, IP: pe.GetAnnotations()[PrivateEndpointIPAnnotation]}
+}
+
+func (pe *AtlasPrivateEndpoint) GetStatus() status.Status {
+	return pe.Status
+}
+
+func (pe *AtlasPrivateEndpoint) UpdateStatus(conditions []status.Condition, options ...status.Option) {
+	pe.Status.Conditions = conditions
+	pe.Status.ObservedGeneration = pe.ObjectMeta.Generation
+
+	for _, o := range options {
+		// This will fail if the Option passed is incorrect - which is expected
+		v := o.(status.AtlasPrivateEndpointStatusOption)
+		v(&pe.Status)
+	}
+}
+
+// ************************************ Builder methods *************************************************
+
+func NewPrivateEndpoint(namespace, name, projectName string, providerName provider.ProviderName, region string) *AtlasPrivateEndpoint {
+	return &AtlasPrivateEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: AtlasPrivateEndpointSpec{
+			Project:  common.ResourceRefNamespaced{Name: projectName},
+			Provider: providerName,
+			Region:   region,
+		},
+	}
+}
+
+func (pe *AtlasPrivateEndpoint) WithInterface(endpoint PrivateEndpointInterface) *AtlasPrivateEndpoint {
+	pe.Spec.Interface = &endpoint
+	return pe
+}
@@ -56,6 +56,16 @@ type AtlasProjectSpec struct {
 	// +optional
 	ProjectIPAccessList []project.IPAccessList `json:"projectIpAccessList,omitempty"`
 
+	// IPAccessListSources add the IP addresses of the Kubernetes nodes, Services and ConfigMaps to the
+	// ProjectIPAccessList. The entries are kept in sync with the cluster, for example when the nodes are replaced.
+	// +optional
+	IPAccessListSources []project.IPAccessListSource `json:"ipAccessListSources,omitempty"`
+
+	// IPAccessListEntryNamespaces are the namespaces, other than the namespace of the project, allowed to add the
+	// entries to the IP Access List with the AtlasIPAccessListEntry resources referencing this project.
+	// +optional
+	IPAccessListEntryNamespaces []string `json:"ipAccessListEntryNamespaces,omitempty"`
+
 	// MaintenanceWindow allows to specify a preferred time in the week to run maintenance operations. See more
 	// information at https://www.mongodb.com/docs/atlas/reference/api/maintenance-windows/
 	// +optional
@@ -63,6 +73,11 @@ type AtlasProjectSpec struct {
 
 	// PrivateEndpoints is a list of Private Endpoints configured for the current Project.
 	PrivateEndpoints []PrivateEndpoint `json:"privateEndpoints,omitempty"`
+	// RegionalizedPrivateEndpoints enables the regionalized private endpoints mode required to connect to the
+	// multi-region sharded clusters through the private endpoints in each region. The setting isn't managed if it's
+	// not specified.
+	// +optional
+	RegionalizedPrivateEndpoints *bool `json:"regionalizedPrivateEndpoints,omitempty"`
 	// CloudProviderAccessRoles is a list of Cloud Provider Access Roles configured for the current Project.
 	CloudProviderAccessRoles []CloudProviderAccessRole `json:"cloudProviderAccessRoles,omitempty"`
 
@@ -110,6 +125,10 @@ type AtlasProjectSpec struct {
 	// Teams enable you to grant project access roles to multiple users.
 	// +optional
 	Teams []Team `json:"teams,omitempty"`
+
+	// LDAP configures the authentication and authorization of the database users with LDAP over TLS/SSL
+	// +optional
+	LDAP *LDAPConfiguration `json:"ldap,omitempty"`
 }
 
 const hiddenField = "*** redacted ***"
@@ -124,7 +143,9 @@ func (p AtlasProjectSpec) MarshalLogObject(e zapcore.ObjectEncoder) error {
 		printable.EncryptionAtRest.AwsKms.SecretAccessKey = hiddenField
 		printable.EncryptionAtRest.AwsKms.RoleID = hiddenField
 		printable.EncryptionAtRest.AzureKeyVault.Secret = hiddenField
+		printable.EncryptionAtRest.AzureKeyVault.RoleID = hiddenField
 		printable.EncryptionAtRest.GoogleCloudKms.ServiceAccountKey = hiddenField
+		printable.EncryptionAtRest.GoogleCloudKms.RoleID = hiddenField
 	}
 
 	// cleanup AlertConfigurations
@@ -207,6 +228,30 @@ func (p *AtlasProject) X509SecretObjectKey() *client.ObjectKey {
 	return p.Spec.X509CertRef.GetObject(p.Namespace)
 }
 
+// AllowsIPAccessListEntriesFrom returns true if the AtlasIPAccessListEntry resources from the namespace can add the
+// entries to the IP Access List of the project.
+func (p *AtlasProject) AllowsIPAccessListEntriesFrom(namespace string) bool {
+	if namespace == p.Namespace {
+		return true
+	}
+	for _, allowed := range p.Spec.IPAccessListEntryNamespaces {
+		if allowed == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// HasNodesIPAccessListSource returns true if any IP Access List source selects the Kubernetes Nodes.
+func (p *AtlasProject) HasNodesIPAccessListSource() bool {
+	for _, source := range p.Spec.IPAccessListSources {
+		if source.Nodes != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // ************************************ Builder methods *************************************************
 
 func NewProject(namespace, name, nameInAtlas string) *AtlasProject {
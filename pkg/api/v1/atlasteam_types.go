@@ -17,11 +17,14 @@ limitations under the License.
 package v1
 
 import (
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/common"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/compat"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
 
 	"go.mongodb.org/atlas/mongodbatlas"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // +kubebuilder:object:root=true
@@ -47,6 +50,12 @@ type TeamSpec struct {
 	Name string `json:"name"`
 	// Valid email addresses of users to add to the new team
 	Usernames []TeamUser `json:"usernames"`
+	// ConnectionSecret is the name of the Kubernetes Secret in the namespace of the team which contains the information
+	// about the way to connect to the Atlas organization (organization ID, API keys). If not provided, the connection
+	// Secret of a project referencing the team is used, or the default Operator connection configuration if none of
+	// these projects has one.
+	// +optional
+	ConnectionSecret *common.ResourceRef `json:"connectionSecretRef,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -81,6 +90,14 @@ func (in *AtlasTeam) UpdateStatus(conditions []status.Condition, options ...stat
 	}
 }
 
+func (in *AtlasTeam) ConnectionSecretObjectKey() *client.ObjectKey {
+	if in.Spec.ConnectionSecret != nil {
+		key := kube.ObjectKey(in.Namespace, in.Spec.ConnectionSecret.Name)
+		return &key
+	}
+	return nil
+}
+
 func (in *AtlasTeam) ToAtlas() (*mongodbatlas.Team, error) {
 	result := &mongodbatlas.Team{}
 	err := compat.JSONCopy(result, in.Spec)
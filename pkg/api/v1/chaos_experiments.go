@@ -0,0 +1,17 @@
+package v1
+
+const (
+	// TestFailoverAnnotation triggers the test failover of the deployment once for each new value of the annotation.
+	// Any unique value (e.g. a timestamp) can be used.
+	TestFailoverAnnotation = "mongodb.com/atlas-test-failover"
+
+	// SimulateOutageAnnotation starts the simulation of the outage of the regions listed in the annotation separated by
+	// commas, for example "US_EAST_1,US_WEST_2". The region can be prefixed by the provider, for example "AWS:US_EAST_1",
+	// otherwise the provider is taken from the region config of the deployment. The simulation is started once for
+	// each new value and is ended early if the annotation is removed.
+	SimulateOutageAnnotation = "mongodb.com/atlas-simulate-outage"
+
+	// SimulateOutageDurationAnnotation is the duration of the outage simulation, for example "30m". Defaults to 15
+	// minutes. Changing it moves the end of the simulation in progress.
+	SimulateOutageDurationAnnotation = "mongodb.com/atlas-simulate-outage-duration"
+)
@@ -1,9 +1,19 @@
 package v1
 
 type CloudProviderAccessRole struct {
-	// ProviderName is the name of the cloud provider. Currently only AWS is supported.
+	// ProviderName is the name of the cloud provider.
+	// +kubebuilder:validation:Enum=AWS;AZURE;GCP
 	ProviderName string `json:"providerName"`
-	// IamAssumedRoleArn is the ARN of the IAM role that is assumed by the Atlas cluster.
+	// IamAssumedRoleArn is the ARN of the IAM role that is assumed by the Atlas cluster. AWS only.
 	// +optional
 	IamAssumedRoleArn string `json:"iamAssumedRoleArn"`
+	// AtlasAzureAppID is the Azure Active Directory application ID of Atlas. AZURE only.
+	// +optional
+	AtlasAzureAppID string `json:"atlasAzureAppId,omitempty"`
+	// ServicePrincipalID is the UUID of the Azure service principal. AZURE only.
+	// +optional
+	ServicePrincipalID string `json:"servicePrincipalId,omitempty"`
+	// TenantID is the UUID of the Azure Active Directory tenant the service principal belongs to. AZURE only.
+	// +optional
+	TenantID string `json:"tenantId,omitempty"`
 }
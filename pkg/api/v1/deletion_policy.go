@@ -0,0 +1,30 @@
+package v1
+
+// DeletionPolicy configures what the operator does before deleting the advanced deployment from Atlas
+type DeletionPolicy struct {
+	// SnapshotBeforeDelete takes an on-demand snapshot before the deployment is deleted. The deletion waits for the
+	// snapshot to complete. Requires the cloud backup.
+	// +optional
+	SnapshotBeforeDelete bool `json:"snapshotBeforeDelete,omitempty"`
+
+	// SnapshotRetentionDays is the number of days Atlas retains the snapshot taken before the deletion. Defaults to 30.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SnapshotRetentionDays int `json:"snapshotRetentionDays,omitempty"`
+
+	// RetainBackups keeps the snapshots of the deployment in Atlas after the deployment is deleted. Defaults to true
+	// if the snapshot is taken before the deletion, otherwise Atlas deletes the snapshot together with the deployment.
+	// +optional
+	RetainBackups *bool `json:"retainBackups,omitempty"`
+}
+
+// RetainsBackups returns true if the snapshots are kept in Atlas after the deployment is deleted
+func (p *DeletionPolicy) RetainsBackups() bool {
+	if p == nil {
+		return false
+	}
+	if p.RetainBackups == nil {
+		return p.SnapshotBeforeDelete
+	}
+	return *p.RetainBackups
+}
@@ -0,0 +1,20 @@
+package v1
+
+// DeploymentKeepRunningUntilAnnotation overrides the schedule of the deployment: the deployment is kept running until
+// the time in RFC 3339 format specified in the annotation
+const DeploymentKeepRunningUntilAnnotation = "mongodb.com/atlas-keep-running-until"
+
+// DeploymentSchedule pauses and resumes the deployment periodically
+type DeploymentSchedule struct {
+	// Pause is the cron expression (minute, hour, day of month, month, day of week) of the moments the deployment is
+	// paused at, for example "0 20 * * mon-fri".
+	Pause string `json:"pause"`
+
+	// Resume is the cron expression of the moments the deployment is resumed at, for example "0 8 * * mon-fri".
+	Resume string `json:"resume"`
+
+	// TimeZone is the IANA time zone the cron expressions are evaluated in, for example "Europe/Dublin".
+	// Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
@@ -35,6 +35,7 @@ type AzureKeyVault struct {
 	KeyIdentifier     string `json:"keyIdentifier,omitempty"`     // The unique identifier of a key in an Azure Key Vault.
 	Secret            string `json:"secret,omitempty"`            // The secret associated with the Azure Key Vault specified by azureKeyVault.tenantID.
 	TenantID          string `json:"tenantID,omitempty"`          // The unique identifier for an Azure AD tenant within an Azure subscription.
+	RoleID            string `json:"roleId,omitempty"`            // ID of an Azure service principal authorized to access the Azure Key Vault. The AZURE role of the cloudProviderAccessRoles is used if not set.
 }
 
 // GoogleCloudKms specifies GCP KMS configuration details and whether Encryption at Rest is enabled for an Atlas project.
@@ -42,6 +43,7 @@ type GoogleCloudKms struct {
 	Enabled              *bool  `json:"enabled,omitempty"`              // Specifies whether Encryption at Rest is enabled for an Atlas project. To disable Encryption at Rest, pass only this parameter with a value of false. When you disable Encryption at Rest, Atlas also removes the configuration details.
 	ServiceAccountKey    string `json:"serviceAccountKey,omitempty"`    // String-formatted JSON object containing GCP KMS credentials from your GCP account.
 	KeyVersionResourceID string `json:"keyVersionResourceID,omitempty"` // 	The Key Version Resource ID from your GCP account.
+	RoleID               string `json:"roleId,omitempty"`               // ID of a GCP service account authorized to access the GCP KMS. The GCP role of the cloudProviderAccessRoles is used if not set.
 }
 
 func (e EncryptionAtRest) ToAtlas(projectID string) (*mongodbatlas.EncryptionAtRest, error) {
@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"go.mongodb.org/atlas/mongodbatlas"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+// LDAPConfiguration is the LDAP over TLS/SSL configuration of the project. The connection to the LDAP server is
+// verified by Atlas before the configuration gets applied.
+type LDAPConfiguration struct {
+	// AuthenticationEnabled specifies whether user authentication with LDAP is enabled.
+	// +optional
+	AuthenticationEnabled bool `json:"authenticationEnabled,omitempty"`
+
+	// AuthorizationEnabled specifies whether user authorization with LDAP is enabled.
+	// +optional
+	AuthorizationEnabled bool `json:"authorizationEnabled,omitempty"`
+
+	// Hostname is the hostname or IP address of the LDAP server.
+	Hostname string `json:"hostname"`
+
+	// Port is the port to which the LDAP server listens for client connections.
+	// +kubebuilder:default=636
+	// +optional
+	Port int `json:"port,omitempty"`
+
+	// BindUsername is the user DN that Atlas uses to connect to the LDAP server.
+	BindUsername string `json:"bindUsername"`
+
+	// BindPasswordSecretRef is a reference to the Secret keeping the password of the bind user in the 'password' field.
+	BindPasswordSecretRef common.ResourceRefNamespaced `json:"bindPasswordSecretRef"`
+
+	// CACertificateSecretRef is a reference to the Secret keeping the PEM-encoded CA certificate used to verify the
+	// identity of the LDAP server.
+	// +optional
+	CACertificateSecretRef *common.ResourceRefNamespaced `json:"caCertificateSecretRef,omitempty"`
+
+	// AuthzQueryTemplate is an LDAP query template that Atlas executes to obtain the LDAP groups to which the
+	// authenticated user belongs. Used only when authorization is enabled.
+	// +optional
+	AuthzQueryTemplate string `json:"authzQueryTemplate,omitempty"`
+
+	// UserToDNMapping maps the usernames provided for authentication to the LDAP Distinguished Names.
+	// +optional
+	UserToDNMapping []LDAPUserToDNMapping `json:"userToDNMapping,omitempty"`
+}
+
+// LDAPUserToDNMapping transforms the LDAP username matched by the regular expression into the Distinguished Name
+// using either the substitution or the LDAP query template.
+type LDAPUserToDNMapping struct {
+	// Match is a regular expression to match against a provided LDAP username.
+	Match string `json:"match"`
+
+	// Substitution is an LDAP Distinguished Name formatting template that converts the matched name into a DN.
+	// +optional
+	Substitution string `json:"substitution,omitempty"`
+
+	// LDAPQuery is an LDAP query formatting template that inserts the matched name into an LDAP query URI.
+	// +optional
+	LDAPQuery string `json:"ldapQuery,omitempty"`
+}
+
+func (l *LDAPConfiguration) BindPasswordSecretObjectKey(defaultNamespace string) client.ObjectKey {
+	return *l.BindPasswordSecretRef.GetObject(defaultNamespace)
+}
+
+func (l *LDAPConfiguration) CACertificateSecretObjectKey(defaultNamespace string) *client.ObjectKey {
+	return l.CACertificateSecretRef.GetObject(defaultNamespace)
+}
+
+// ToAtlas converts the LDAP configuration to the Atlas format. The bind password and the CA certificate are read
+// from the Secrets by the caller.
+func (l *LDAPConfiguration) ToAtlas(bindPassword, caCertificate string) *mongodbatlas.LDAP {
+	result := &mongodbatlas.LDAP{
+		AuthenticationEnabled: toptr.MakePtr(l.AuthenticationEnabled),
+		AuthorizationEnabled:  toptr.MakePtr(l.AuthorizationEnabled),
+		Hostname:              toptr.MakePtr(l.Hostname),
+		Port:                  toptr.MakePtr(l.Port),
+		BindUsername:          toptr.MakePtr(l.BindUsername),
+		BindPassword:          toptr.MakePtr(bindPassword),
+	}
+	if caCertificate != "" {
+		result.CaCertificate = toptr.MakePtr(caCertificate)
+	}
+	if l.AuthzQueryTemplate != "" {
+		result.AuthzQueryTemplate = toptr.MakePtr(l.AuthzQueryTemplate)
+	}
+	for _, mapping := range l.UserToDNMapping {
+		result.UserToDNMapping = append(result.UserToDNMapping, &mongodbatlas.UserToDNMapping{
+			Match:        mapping.Match,
+			Substitution: mapping.Substitution,
+			LDAPQuery:    mapping.LDAPQuery,
+		})
+	}
+	return result
+}
@@ -0,0 +1,34 @@
+package v1
+
+// MajorVersionUpgrade configures the upgrades of the MongoDB major version of the advanced deployment. The operator
+// allows upgrading only to the next major version, takes the snapshot first if requested and starts the upgrade only
+// inside the upgrade window.
+type MajorVersionUpgrade struct {
+	// SnapshotBeforeUpgrade takes an on-demand snapshot before the upgrade starts. Requires the cloud backup.
+	// +optional
+	SnapshotBeforeUpgrade bool `json:"snapshotBeforeUpgrade,omitempty"`
+
+	// SnapshotRetentionDays is the number of days Atlas retains the snapshot taken before the upgrade. Defaults to 7.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SnapshotRetentionDays int `json:"snapshotRetentionDays,omitempty"`
+
+	// Window is the recurring window the upgrade can start in. Defaults to the hour starting at the maintenance window
+	// of the project. The upgrade can start at any time if neither is configured.
+	// +optional
+	Window *UpgradeWindow `json:"window,omitempty"`
+}
+
+// UpgradeWindow is the recurring window which starts and ends on the cron schedules
+type UpgradeWindow struct {
+	// Start is the cron expression (minute, hour, day of month, month, day of week) of the moments the window opens
+	// at, for example "0 22 * * sat".
+	Start string `json:"start"`
+
+	// End is the cron expression of the moments the window closes at, for example "0 4 * * sun".
+	End string `json:"end"`
+
+	// TimeZone is the IANA time zone the cron expressions are evaluated in. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
@@ -0,0 +1,106 @@
+package v1
+
+import (
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+type OnlineArchiveCriteriaType string
+
+const (
+	OnlineArchiveCriteriaDate   OnlineArchiveCriteriaType = "DATE"
+	OnlineArchiveCriteriaCustom OnlineArchiveCriteriaType = "CUSTOM"
+)
+
+// OnlineArchive is the rule archiving the documents of a collection to the Online Archive
+type OnlineArchive struct {
+	// Name of the database containing the collection to archive.
+	DBName string `json:"dbName"`
+
+	// Name of the collection to archive.
+	CollName string `json:"collName"`
+
+	// Type of the collection to archive. Can't be changed after the Online Archive is created.
+	// +kubebuilder:validation:Enum=STANDARD;TIMESERIES
+	// +optional
+	CollectionType string `json:"collectionType,omitempty"`
+
+	// Criteria to select the documents to archive.
+	Criteria OnlineArchiveCriteria `json:"criteria"`
+
+	// Fields to use to partition the archived data. Can't be changed after the Online Archive is created.
+	// +optional
+	PartitionFields []OnlineArchivePartitionField `json:"partitionFields,omitempty"`
+
+	// Flag that indicates whether the archiving is paused.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// OnlineArchiveCriteria selects the documents to archive either by the age of a date field or by a custom query
+type OnlineArchiveCriteria struct {
+	// Type of the criteria.
+	// +kubebuilder:validation:Enum=DATE;CUSTOM
+	Type OnlineArchiveCriteriaType `json:"type"`
+
+	// Name of the date field the age of the documents is calculated from. Required for the DATE criteria.
+	// +optional
+	DateField string `json:"dateField,omitempty"`
+
+	// Format of the date field. Defaults to ISODATE in Atlas.
+	// +kubebuilder:validation:Enum=ISODATE;EPOCH_SECONDS;EPOCH_MILLIS;EPOCH_NANOSECONDS
+	// +optional
+	DateFormat string `json:"dateFormat,omitempty"`
+
+	// Number of days after which the documents are archived. Required for the DATE criteria.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ExpireAfterDays int `json:"expireAfterDays,omitempty"`
+
+	// JSON query selecting the documents to archive. Required for the CUSTOM criteria.
+	// +optional
+	Query string `json:"query,omitempty"`
+}
+
+// OnlineArchivePartitionField is the field the archived data is partitioned by
+type OnlineArchivePartitionField struct {
+	// Name of the field.
+	FieldName string `json:"fieldName"`
+
+	// Position of the field in the partition, starting from 0.
+	// +kubebuilder:validation:Minimum=0
+	Order int `json:"order"`
+}
+
+// ToAtlas converts the OnlineArchive to native Atlas client format.
+func (in *OnlineArchive) ToAtlas() *mongodbatlas.OnlineArchive {
+	result := &mongodbatlas.OnlineArchive{
+		DBName:         in.DBName,
+		CollName:       in.CollName,
+		CollectionType: in.CollectionType,
+		Criteria: &mongodbatlas.OnlineArchiveCriteria{
+			Type:       string(in.Criteria.Type),
+			DateField:  in.Criteria.DateField,
+			DateFormat: in.Criteria.DateFormat,
+			Query:      in.Criteria.Query,
+		},
+		Paused: toptr.MakePtr(in.Paused),
+	}
+	if in.Criteria.ExpireAfterDays > 0 {
+		result.Criteria.ExpireAfterDays = toptr.MakePtr(float64(in.Criteria.ExpireAfterDays))
+	}
+	for _, field := range in.PartitionFields {
+		result.PartitionFields = append(result.PartitionFields, &mongodbatlas.PartitionFields{
+			FieldName: field.FieldName,
+			Order:     toptr.MakePtr(float64(field.Order)),
+		})
+	}
+	return result
+}
+
+// Identifier is required to satisfy "Identifiable" interface
+func (in OnlineArchive) Identifier() interface{} {
+	return status.OnlineArchiveIdentifier(in.DBName, in.CollName)
+}
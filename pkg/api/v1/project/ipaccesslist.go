@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"go.mongodb.org/atlas/mongodbatlas"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/compat"
 )
@@ -26,6 +27,31 @@ type IPAccessList struct {
 	IPAddress string `json:"ipAddress,omitempty"`
 }
 
+// IPAccessListSource adds the IP addresses discovered in the Kubernetes cluster to the IP Access List. Exactly one of
+// the fields must be specified.
+type IPAccessListSource struct {
+	// Nodes selects the cluster nodes whose ExternalIP addresses are added to the IP Access List.
+	// The empty selector selects all nodes.
+	// +optional
+	Nodes *metav1.LabelSelector `json:"nodes,omitempty"`
+	// Services selects the LoadBalancer Services in the namespace of the project whose ingress IP addresses are added to
+	// the IP Access List. The empty selector selects all Services.
+	// +optional
+	Services *metav1.LabelSelector `json:"services,omitempty"`
+	// ConfigMap references the key of the ConfigMap listing the IP addresses or CIDR blocks separated by whitespaces or
+	// commas.
+	// +optional
+	ConfigMap *ConfigMapKeyRef `json:"configMap,omitempty"`
+}
+
+// ConfigMapKeyRef is a reference to the key of the ConfigMap in the namespace of the project
+type ConfigMapKeyRef struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+	// Key of the ConfigMap data.
+	Key string `json:"key"`
+}
+
 // ToAtlas converts the ProjectIPAccessList to native Atlas client format.
 func (i IPAccessList) ToAtlas() (*mongodbatlas.ProjectIPAccessList, error) {
 	result := &mongodbatlas.ProjectIPAccessList{}
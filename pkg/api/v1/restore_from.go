@@ -0,0 +1,24 @@
+package v1
+
+import "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/common"
+
+// RestoreFrom seeds the new advanced deployment with the data from the backup of another deployment. The restore
+// runs once, only for the deployment created by the operator with restoreFrom set. The existing deployments are never
+// restored: restoreFrom added to them is rejected unless Atlas already has the restore job from the source deployment
+// into the deployment. The API key of the project of the deployment must have access to the project of the source
+// deployment.
+type RestoreFrom struct {
+	// DeploymentRef is the reference to the source AtlasDeployment. The namespace defaults to the namespace of the
+	// deployment.
+	DeploymentRef common.ResourceRefNamespaced `json:"deploymentRef"`
+
+	// SnapshotID is the ID of the snapshot of the source deployment to restore. The latest completed snapshot is
+	// restored if neither the snapshot ID nor the point in time is set.
+	// +optional
+	SnapshotID string `json:"snapshotId,omitempty"`
+
+	// PointInTime restores the data of the source deployment as of the time in RFC 3339 format. Requires the
+	// continuous cloud backup of the source deployment.
+	// +optional
+	PointInTime string `json:"pointInTime,omitempty"`
+}
@@ -0,0 +1,41 @@
+package v1
+
+// DeploymentScalingProfileAnnotation activates the scaling profile of the deployment with the name specified in the
+// annotation regardless of the schedules of the profiles
+const DeploymentScalingProfileAnnotation = "mongodb.com/atlas-scaling-profile"
+
+// ScalingProfile overrides the instance sizes and the compute autoscaling bounds of the region configs of the advanced
+// deployment while the profile is active
+type ScalingProfile struct {
+	// Name of the profile. Must be unique within the deployment.
+	Name string `json:"name"`
+
+	// Start is the cron expression (minute, hour, day of month, month, day of week) of the moments the profile is
+	// activated at, for example "0 0 28 * *". The profile without the schedule is activated only by the annotation.
+	// +optional
+	Start string `json:"start,omitempty"`
+
+	// End is the cron expression of the moments the profile is deactivated at, for example "0 0 1 * *".
+	// +optional
+	End string `json:"end,omitempty"`
+
+	// TimeZone is the IANA time zone the cron expressions are evaluated in. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// RegionConfigs are the overrides of the region configs of the deployment.
+	RegionConfigs []ScalingProfileRegionConfig `json:"regionConfigs"`
+}
+
+// ScalingProfileRegionConfig overrides the region configs with the same provider and region
+type ScalingProfileRegionConfig struct {
+	ProviderName string `json:"providerName"`
+	RegionName   string `json:"regionName"`
+	// +optional
+	ElectableSpecs *Specs `json:"electableSpecs,omitempty"`
+	// +optional
+	AnalyticsSpecs *Specs `json:"analyticsSpecs,omitempty"`
+	// Compute overrides the compute autoscaling bounds of the region config.
+	// +optional
+	Compute *ComputeSpec `json:"compute,omitempty"`
+}
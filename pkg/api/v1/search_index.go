@@ -0,0 +1,108 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/compat"
+)
+
+// SearchIndexDefinitionKey is the key of the ConfigMap referenced by the search index holding the index definition
+const SearchIndexDefinitionKey = "definition"
+
+// SearchIndex is the Atlas Search index configured for a collection of the deployment.
+// The index definition is specified either inline (mappings, analyzers, synonyms) or in a ConfigMap referenced by
+// definitionRef.
+type SearchIndex struct {
+	// Name of the index. Must be unique within the collection.
+	Name string `json:"name"`
+
+	// Name of the database containing the collection.
+	Database string `json:"database"`
+
+	// Name of the collection the index is created for.
+	CollectionName string `json:"collectionName"`
+
+	// Analyzer to use when creating the index. Defaults to lucene.standard in Atlas.
+	// +optional
+	Analyzer string `json:"analyzer,omitempty"`
+
+	// Analyzer to use when searching the index. Defaults to the analyzer of the index.
+	// +optional
+	SearchAnalyzer string `json:"searchAnalyzer,omitempty"`
+
+	// Mappings of the fields of the collection.
+	// +optional
+	Mappings *SearchIndexMappings `json:"mappings,omitempty"`
+
+	// Custom analyzers to use in the index. Each element follows the Atlas Search custom analyzer syntax.
+	// +optional
+	Analyzers []apiextensionsv1.JSON `json:"analyzers,omitempty"`
+
+	// Synonym mappings to use in the index.
+	// +optional
+	Synonyms []apiextensionsv1.JSON `json:"synonyms,omitempty"`
+
+	// DefinitionRef is a reference to the ConfigMap containing the JSON index definition under the 'definition' key.
+	// The definition may contain 'analyzer', 'searchAnalyzer', 'mappings', 'analyzers' and 'synonyms'.
+	// Can't be used together with the inline definition.
+	// +optional
+	DefinitionRef *common.ResourceRefNamespaced `json:"definitionRef,omitempty"`
+}
+
+// SearchIndexMappings configures how the fields of the collection are indexed.
+type SearchIndexMappings struct {
+	// Flag that indicates whether all the fields of the collection are indexed dynamically.
+	// +optional
+	Dynamic bool `json:"dynamic,omitempty"`
+
+	// Field mappings following the Atlas Search index definition syntax.
+	// +optional
+	Fields *apiextensionsv1.JSON `json:"fields,omitempty"`
+}
+
+// HasInlineDefinition returns true if any part of the index definition is specified inline
+func (in *SearchIndex) HasInlineDefinition() bool {
+	return in.Analyzer != "" || in.SearchAnalyzer != "" || in.Mappings != nil || len(in.Analyzers) > 0 || len(in.Synonyms) > 0
+}
+
+// ToAtlas converts the inline definition of the SearchIndex to native Atlas client format.
+// The definition referenced by 'definitionRef' is not read, see SearchIndexFromDefinition.
+func (in *SearchIndex) ToAtlas() (*mongodbatlas.SearchIndex, error) {
+	result := &mongodbatlas.SearchIndex{}
+	if err := compat.JSONCopy(result, in); err != nil {
+		return nil, err
+	}
+	if result.Mappings == nil {
+		result.Mappings = &mongodbatlas.IndexMapping{}
+	}
+	return result, nil
+}
+
+// Identifier is required to satisfy "Identifiable" interface
+func (in SearchIndex) Identifier() interface{} {
+	return status.SearchIndexIdentifier(in.Database, in.CollectionName, in.Name)
+}
+
+// SearchIndexFromDefinition builds the Atlas search index from the JSON definition stored in a ConfigMap.
+// The database, collection and name are always taken from the SearchIndex.
+func (in *SearchIndex) SearchIndexFromDefinition(definition string) (*mongodbatlas.SearchIndex, error) {
+	result := &mongodbatlas.SearchIndex{}
+	if err := json.Unmarshal([]byte(definition), result); err != nil {
+		return nil, fmt.Errorf("search index %s: invalid definition: %w", in.Identifier(), err)
+	}
+	result.Name = in.Name
+	result.Database = in.Database
+	result.CollectionName = in.CollectionName
+	result.IndexID = ""
+	result.Status = ""
+	if result.Mappings == nil {
+		result.Mappings = &mongodbatlas.IndexMapping{}
+	}
+	return result, nil
+}
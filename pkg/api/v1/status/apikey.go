@@ -0,0 +1,47 @@
+package status
+
+// +k8s:deepcopy-gen=false
+
+// AtlasAPIKeyStatusOption is the option that is applied to Atlas API Key Status
+type AtlasAPIKeyStatusOption func(s *APIKeyStatus)
+
+func AtlasAPIKeyIDOption(id string) AtlasAPIKeyStatusOption {
+	return func(s *APIKeyStatus) {
+		s.ID = id
+	}
+}
+
+func AtlasAPIKeyPublicKeyOption(publicKey string) AtlasAPIKeyStatusOption {
+	return func(s *APIKeyStatus) {
+		s.PublicKey = publicKey
+	}
+}
+
+func AtlasAPIKeyRotationOption(rotation string) AtlasAPIKeyStatusOption {
+	return func(s *APIKeyStatus) {
+		s.Rotation = rotation
+	}
+}
+
+func AtlasAPIKeyPreviousIDOption(id string) AtlasAPIKeyStatusOption {
+	return func(s *APIKeyStatus) {
+		s.PreviousID = id
+	}
+}
+
+// APIKeyStatus defines the observed state of AtlasAPIKey.
+type APIKeyStatus struct {
+	Common `json:",inline"`
+
+	// ID is the unique identifier of the API key in Atlas.
+	ID string `json:"id,omitempty"`
+
+	// PublicKey is the public part of the API key.
+	PublicKey string `json:"publicKey,omitempty"`
+
+	// PreviousID is the identifier of the API key replaced by the rotation which is still to be removed from Atlas.
+	PreviousID string `json:"previousId,omitempty"`
+
+	// Rotation is the value of the rotation annotation the current API key was created for.
+	Rotation string `json:"rotation,omitempty"`
+}
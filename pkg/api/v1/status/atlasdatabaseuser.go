@@ -17,6 +17,12 @@ func AtlasDatabaseUserNameOption(name string) AtlasDatabaseUserStatusOption {
 	}
 }
 
+func AtlasDatabaseUserCertificateExpirationOption(expiration string) AtlasDatabaseUserStatusOption {
+	return func(s *AtlasDatabaseUserStatus) {
+		s.CertificateExpiration = expiration
+	}
+}
+
 // AtlasDatabaseUserStatus defines the observed state of AtlasProject
 type AtlasDatabaseUserStatus struct {
 	Common `json:",inline"`
@@ -26,4 +32,8 @@ type AtlasDatabaseUserStatus struct {
 
 	// UserName is the current name of database user.
 	UserName string `json:"name,omitempty"`
+
+	// CertificateExpiration is a timestamp in ISO 8601 date and time format in UTC when the Atlas-managed X.509
+	// certificate of the user expires
+	CertificateExpiration string `json:"certificateExpiration,omitempty"`
 }
@@ -28,9 +28,42 @@ type AtlasDeploymentStatus struct {
 
 	ManagedNamespaces []ManagedNamespace `json:"managedNamespaces,omitempty"`
 
+	// SearchIndexes contains the status of the Atlas Search indexes managed by the operator.
+	SearchIndexes []SearchIndex `json:"searchIndexes,omitempty"`
+
+	// OnlineArchives contains the status of the Online Archives managed by the operator.
+	OnlineArchives []OnlineArchive `json:"onlineArchives,omitempty"`
+
 	// MongoURIUpdated is a timestamp in ISO 8601 date and time format in UTC when the connection string was last updated.
 	// The connection string changes if you update any of the other values.
 	MongoURIUpdated string `json:"mongoURIUpdated,omitempty"`
+
+	// Schedule is the state of the deployment computed from its pause schedule.
+	Schedule *DeploymentSchedule `json:"schedule,omitempty"`
+
+	// ScalingProfile is the name of the scaling profile the deployment was last synchronized with.
+	ScalingProfile string `json:"scalingProfile,omitempty"`
+
+	// MajorVersionUpgrade is the state of the MongoDB major version upgrade in progress.
+	MajorVersionUpgrade *MajorVersionUpgrade `json:"majorVersionUpgrade,omitempty"`
+
+	// TestFailover is the state of the last test failover triggered by the annotation.
+	TestFailover *TestFailover `json:"testFailover,omitempty"`
+
+	// OutageSimulation is the state of the last outage simulation started by the annotation.
+	OutageSimulation *OutageSimulation `json:"outageSimulation,omitempty"`
+
+	// Operation is the long-running Atlas operation in progress for the deployment.
+	Operation *Operation `json:"operation,omitempty"`
+
+	// FinalSnapshotID is the ID of the snapshot taken before the deployment is deleted.
+	FinalSnapshotID string `json:"finalSnapshotId,omitempty"`
+
+	// Restore is the state of the restore of the backup the deployment is seeded with.
+	Restore *DeploymentRestore `json:"restore,omitempty"`
+
+	// TenantUpgrade is the state of the upgrade of the shared tier deployment to the dedicated tier in progress.
+	TenantUpgrade *TenantUpgrade `json:"tenantUpgrade,omitempty"`
 }
 
 const (
@@ -68,6 +101,10 @@ type ConnectionStrings struct {
 	// Atlas returns this parameter only if you created a network peering connection to this cluster.
 	// Use this URI format if your driver supports it. If it doesn't, use connectionStrings.private.
 	PrivateSrv string `json:"privateSrv,omitempty"`
+
+	// Connection string to query the deployment together with its Online Archive.
+	// Available only if the deployment has the Online Archives configured.
+	OnlineArchive string `json:"onlineArchive,omitempty"`
 }
 
 // PrivateEndpoint connection strings. Each object describes the connection strings
@@ -110,6 +147,123 @@ type Endpoint struct {
 	IP string `json:"ip,omitempty"`
 }
 
+// DeploymentSchedule is the state of the deployment computed from its pause schedule
+type DeploymentSchedule struct {
+	// Paused indicates whether the deployment is paused by the schedule.
+	Paused bool `json:"paused"`
+
+	// NextTransition is the time in ISO 8601 format in UTC when the deployment is paused or resumed next.
+	NextTransition string `json:"nextTransition,omitempty"`
+}
+
+// MajorVersionUpgrade is the state of the MongoDB major version upgrade of the deployment
+type MajorVersionUpgrade struct {
+	// TargetVersion is the major version the deployment is upgraded to.
+	TargetVersion string `json:"targetVersion"`
+
+	// SnapshotID is the ID of the on-demand snapshot taken before the upgrade.
+	SnapshotID string `json:"snapshotId,omitempty"`
+
+	// StartedAt is the time in ISO 8601 format in UTC when the upgrade was started.
+	StartedAt string `json:"startedAt,omitempty"`
+
+	// Failure is the reason the last upgrade attempt failed.
+	Failure string `json:"failure,omitempty"`
+
+	// FailedGeneration is the generation of the deployment the upgrade failed for. The upgrade is attempted again
+	// once the spec of the deployment changes.
+	FailedGeneration int64 `json:"failedGeneration,omitempty"`
+}
+
+const (
+	TestFailoverRequested = "REQUESTED"
+	TestFailoverCompleted = "COMPLETED"
+	TestFailoverFailed    = "FAILED"
+)
+
+// TestFailover is the state of the test failover of the deployment
+type TestFailover struct {
+	// Nonce is the value of the annotation the test failover was triggered by.
+	Nonce string `json:"nonce"`
+
+	// Phase of the test failover: REQUESTED, COMPLETED or FAILED.
+	Phase string `json:"phase"`
+
+	// RequestedAt is the time in ISO 8601 format in UTC when the test failover was requested.
+	RequestedAt string `json:"requestedAt,omitempty"`
+
+	// Message explains the failure of the test failover.
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	OutageSimulationComplete = "COMPLETE"
+	OutageSimulationFailed   = "FAILED"
+)
+
+// OutageSimulation is the state of the regional outage simulation of the deployment
+type OutageSimulation struct {
+	// Regions is the value of the annotation the simulation was started by.
+	Regions string `json:"regions"`
+
+	// ID of the outage simulation in Atlas.
+	ID string `json:"id,omitempty"`
+
+	// State of the outage simulation as returned by Atlas or FAILED if the simulation couldn't be started.
+	State string `json:"state"`
+
+	// StartedAt is the time in ISO 8601 format in UTC when the simulation was started.
+	StartedAt string `json:"startedAt,omitempty"`
+
+	// EndsAt is the time in ISO 8601 format in UTC when the operator ends the simulation.
+	EndsAt string `json:"endsAt,omitempty"`
+
+	// Message explains the failure of the simulation.
+	Message string `json:"message,omitempty"`
+}
+
+// TenantUpgrade is the state of the upgrade of the shared tier deployment to the dedicated tier. The upgrade can't be
+// reverted.
+type TenantUpgrade struct {
+	// From is the shared tier instance size the deployment is upgraded from.
+	From string `json:"from"`
+
+	// To is the dedicated tier instance size the deployment is upgraded to.
+	To string `json:"to"`
+
+	// StartedAt is the time in ISO 8601 format in UTC when the upgrade was requested.
+	StartedAt string `json:"startedAt,omitempty"`
+}
+
+const (
+	// RestorePending marks the deployment created with restoreFrom by the operator, only such deployments are restored
+	RestorePending    = "PENDING"
+	RestoreInProgress = "IN_PROGRESS"
+	RestoreCompleted  = "COMPLETED"
+	RestoreFailed     = "FAILED"
+)
+
+// DeploymentRestore is the state of the restore of the backup of the source deployment
+type DeploymentRestore struct {
+	// Phase of the restore: PENDING, IN_PROGRESS, COMPLETED or FAILED.
+	Phase string `json:"phase"`
+
+	// SourceProjectID is the ID of the project of the source deployment.
+	SourceProjectID string `json:"sourceProjectId,omitempty"`
+
+	// SourceDeployment is the name of the source deployment in Atlas.
+	SourceDeployment string `json:"sourceDeployment,omitempty"`
+
+	// SnapshotID is the ID of the restored snapshot. Not set for the point in time restore.
+	SnapshotID string `json:"snapshotId,omitempty"`
+
+	// JobID is the ID of the restore job in Atlas.
+	JobID string `json:"jobId,omitempty"`
+
+	// Message explains the failure of the restore.
+	Message string `json:"message,omitempty"`
+}
+
 // +k8s:deepcopy-gen=false
 
 // AtlasDeploymentStatusOption is the option that is applied to Atlas Deployment Status.
@@ -145,6 +299,29 @@ func AtlasDeploymentManagedNamespacesOption(namespaces []ManagedNamespace) Atlas
 	}
 }
 
+func AtlasDeploymentSearchIndexesOption(searchIndexes []SearchIndex) AtlasDeploymentStatusOption {
+	return func(s *AtlasDeploymentStatus) {
+		s.SearchIndexes = searchIndexes
+	}
+}
+
+func AtlasDeploymentOnlineArchivesOption(onlineArchives []OnlineArchive) AtlasDeploymentStatusOption {
+	return func(s *AtlasDeploymentStatus) {
+		s.OnlineArchives = onlineArchives
+	}
+}
+
+// AtlasDeploymentOnlineArchiveConnectionStringOption sets the Online Archive connection string. Must be applied after
+// AtlasDeploymentConnectionStringsOption as the latter replaces all the connection strings.
+func AtlasDeploymentOnlineArchiveConnectionStringOption(connectionString string) AtlasDeploymentStatusOption {
+	return func(s *AtlasDeploymentStatus) {
+		if s.ConnectionStrings == nil {
+			s.ConnectionStrings = &ConnectionStrings{}
+		}
+		s.ConnectionStrings.OnlineArchive = connectionString
+	}
+}
+
 func AtlasDeploymentMongoDBVersionOption(mongoDBVersion string) AtlasDeploymentStatusOption {
 	return func(s *AtlasDeploymentStatus) {
 		s.MongoDBVersion = mongoDBVersion
@@ -167,3 +344,57 @@ func AtlasDeploymentMongoURIUpdatedOption(mongoURIUpdated string) AtlasDeploymen
 		s.MongoURIUpdated = mongoURIUpdated
 	}
 }
+
+func AtlasDeploymentScheduleOption(schedule *DeploymentSchedule) AtlasDeploymentStatusOption {
+	return func(s *AtlasDeploymentStatus) {
+		s.Schedule = schedule
+	}
+}
+
+func AtlasDeploymentScalingProfileOption(profile string) AtlasDeploymentStatusOption {
+	return func(s *AtlasDeploymentStatus) {
+		s.ScalingProfile = profile
+	}
+}
+
+func AtlasDeploymentMajorVersionUpgradeOption(upgrade *MajorVersionUpgrade) AtlasDeploymentStatusOption {
+	return func(s *AtlasDeploymentStatus) {
+		s.MajorVersionUpgrade = upgrade
+	}
+}
+
+func AtlasDeploymentTestFailoverOption(testFailover *TestFailover) AtlasDeploymentStatusOption {
+	return func(s *AtlasDeploymentStatus) {
+		s.TestFailover = testFailover
+	}
+}
+
+func AtlasDeploymentOutageSimulationOption(outageSimulation *OutageSimulation) AtlasDeploymentStatusOption {
+	return func(s *AtlasDeploymentStatus) {
+		s.OutageSimulation = outageSimulation
+	}
+}
+
+func AtlasDeploymentOperationOption(operation *Operation) AtlasDeploymentStatusOption {
+	return func(s *AtlasDeploymentStatus) {
+		s.Operation = operation
+	}
+}
+
+func AtlasDeploymentFinalSnapshotOption(snapshotID string) AtlasDeploymentStatusOption {
+	return func(s *AtlasDeploymentStatus) {
+		s.FinalSnapshotID = snapshotID
+	}
+}
+
+func AtlasDeploymentRestoreOption(restore *DeploymentRestore) AtlasDeploymentStatusOption {
+	return func(s *AtlasDeploymentStatus) {
+		s.Restore = restore
+	}
+}
+
+func AtlasDeploymentTenantUpgradeOption(upgrade *TenantUpgrade) AtlasDeploymentStatusOption {
+	return func(s *AtlasDeploymentStatus) {
+		s.TenantUpgrade = upgrade
+	}
+}
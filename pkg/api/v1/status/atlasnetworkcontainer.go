@@ -0,0 +1,51 @@
+package status
+
+import (
+	"go.mongodb.org/atlas/mongodbatlas"
+)
+
+// +k8s:deepcopy-gen=false
+
+// AtlasNetworkContainerStatusOption is the option that is applied to Atlas Network Container Status
+type AtlasNetworkContainerStatusOption func(s *AtlasNetworkContainerStatus)
+
+func AtlasNetworkContainerProjectIDOption(projectID string) AtlasNetworkContainerStatusOption {
+	return func(s *AtlasNetworkContainerStatus) {
+		s.ProjectID = projectID
+	}
+}
+
+func AtlasNetworkContainerOption(container mongodbatlas.Container) AtlasNetworkContainerStatusOption {
+	return func(s *AtlasNetworkContainerStatus) {
+		s.ID = container.ID
+		s.Provisioned = container.Provisioned != nil && *container.Provisioned
+		s.VpcID = container.VPCID
+		s.AtlasGCPProjectID = container.GCPProjectID
+		s.AtlasNetworkName = container.NetworkName
+		s.AtlasAzureSubscriptionID = container.AzureSubscriptionID
+		s.AtlasVNetName = container.VNetName
+	}
+}
+
+// AtlasNetworkContainerStatus defines the observed state of AtlasNetworkContainer. The provider side details of the
+// Atlas network are published to let the peering connections be created.
+type AtlasNetworkContainerStatus struct {
+	Common `json:",inline"`
+
+	// ProjectID is the ID of the Atlas project the container belongs to.
+	ProjectID string `json:"projectId,omitempty"`
+	// Unique identifier of the container in Atlas.
+	ID string `json:"id,omitempty"`
+	// Provisioned is true if Atlas deployed a cluster in the container.
+	Provisioned bool `json:"provisioned,omitempty"`
+	// Unique identifier of the AWS VPC of Atlas. Applicable only for AWS.
+	VpcID string `json:"vpcId,omitempty"`
+	// Unique identifier of the GCP project of Atlas. Applicable only for GCP.
+	AtlasGCPProjectID string `json:"atlasGcpProjectId,omitempty"`
+	// Name of the GCP network of Atlas. Applicable only for GCP.
+	AtlasNetworkName string `json:"atlasNetworkName,omitempty"`
+	// Unique identifier of the Azure subscription of Atlas. Applicable only for Azure.
+	AtlasAzureSubscriptionID string `json:"atlasAzureSubscriptionId,omitempty"`
+	// Name of the Azure VNet of Atlas. Applicable only for Azure.
+	AtlasVNetName string `json:"atlasVnetName,omitempty"`
+}
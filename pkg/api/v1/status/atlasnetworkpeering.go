@@ -0,0 +1,29 @@
+package status
+
+// +k8s:deepcopy-gen=false
+
+// AtlasNetworkPeeringStatusOption is the option that is applied to Atlas Network Peering Status
+type AtlasNetworkPeeringStatusOption func(s *AtlasNetworkPeeringStatus)
+
+func AtlasNetworkPeeringProjectIDOption(projectID string) AtlasNetworkPeeringStatusOption {
+	return func(s *AtlasNetworkPeeringStatus) {
+		s.ProjectID = projectID
+	}
+}
+
+func AtlasNetworkPeeringOption(peer AtlasNetworkPeer) AtlasNetworkPeeringStatusOption {
+	return func(s *AtlasNetworkPeeringStatus) {
+		s.AtlasNetworkPeer = peer
+	}
+}
+
+// AtlasNetworkPeeringStatus defines the observed state of AtlasNetworkPeering. The provider side details of the
+// peering connection are published to let it be accepted in the cloud provider.
+type AtlasNetworkPeeringStatus struct {
+	Common `json:",inline"`
+
+	// ProjectID is the ID of the Atlas project the peering connection belongs to.
+	ProjectID string `json:"projectId,omitempty"`
+
+	AtlasNetworkPeer `json:",inline"`
+}
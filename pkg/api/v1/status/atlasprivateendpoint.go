@@ -0,0 +1,58 @@
+package status
+
+// +k8s:deepcopy-gen=false
+
+// AtlasPrivateEndpointStatusOption is the option that is applied to Atlas Private Endpoint Status
+type AtlasPrivateEndpointStatusOption func(s *AtlasPrivateEndpointStatus)
+
+func AtlasPrivateEndpointProjectIDOption(projectID string) AtlasPrivateEndpointStatusOption {
+	return func(s *AtlasPrivateEndpointStatus) {
+		s.ProjectID = projectID
+	}
+}
+
+func AtlasPrivateEndpointServiceOption(service PrivateEndpointService) AtlasPrivateEndpointStatusOption {
+	return func(s *AtlasPrivateEndpointStatus) {
+		s.PrivateEndpointService = service
+	}
+}
+
+func AtlasPrivateEndpointInterfaceOption(id, interfaceStatus string, endpoints []GCPEndpoint) AtlasPrivateEndpointStatusOption {
+	return func(s *AtlasPrivateEndpointStatus) {
+		s.InterfaceEndpointID = id
+		s.InterfaceStatus = interfaceStatus
+		s.Endpoints = endpoints
+	}
+}
+
+// AtlasPrivateEndpointStatus defines the observed state of AtlasPrivateEndpoint. The details of the Atlas private
+// endpoint service are published to let the cloud side endpoint be created.
+type AtlasPrivateEndpointStatus struct {
+	Common `json:",inline"`
+
+	// ProjectID is the ID of the Atlas project the private endpoint belongs to.
+	ProjectID string `json:"projectId,omitempty"`
+
+	PrivateEndpointService `json:",inline"`
+
+	// Unique identifier of the AWS or Azure Private Link Interface Endpoint or the GCP endpoint group.
+	InterfaceEndpointID string `json:"interfaceEndpointId,omitempty"`
+	// Status of the interface endpoint in Atlas.
+	InterfaceStatus string `json:"interfaceStatus,omitempty"`
+	// Collection of individual GCP private endpoints that comprise your network endpoint group.
+	Endpoints []GCPEndpoint `json:"endpoints,omitempty"`
+}
+
+// PrivateEndpointService is the private endpoint service Atlas manages for the provider and region.
+type PrivateEndpointService struct {
+	// Unique identifier of the private endpoint service in Atlas.
+	ServiceID string `json:"serviceId,omitempty"`
+	// Name of the AWS or Azure Private Link Service that Atlas manages.
+	ServiceName string `json:"serviceName,omitempty"`
+	// Unique identifier of the Azure Private Link Service (for AWS the same as ServiceID).
+	ServiceResourceID string `json:"serviceResourceId,omitempty"`
+	// Unique alphanumeric and special character strings that identify the service attachments associated with the GCP Private Service Connect endpoint service.
+	ServiceAttachmentNames []string `json:"serviceAttachmentNames,omitempty"`
+	// Status of the private endpoint service in Atlas.
+	ServiceStatus string `json:"serviceStatus,omitempty"`
+}
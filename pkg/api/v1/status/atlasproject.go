@@ -82,6 +82,18 @@ func AtlasProjectPrometheusOption(prometheus *Prometheus) AtlasProjectStatusOpti
 	}
 }
 
+func AtlasProjectIPAccessListEntriesOption(entries []ProjectIPAccessListEntry) AtlasProjectStatusOption {
+	return func(s *AtlasProjectStatus) {
+		s.IPAccessListEntries = entries
+	}
+}
+
+func AtlasProjectLDAPOption(ldap *LDAPStatus) AtlasProjectStatusOption {
+	return func(s *AtlasProjectStatus) {
+		s.LDAP = ldap
+	}
+}
+
 // AtlasProjectStatus defines the observed state of AtlasProject
 type AtlasProjectStatus struct {
 	Common `json:",inline"`
@@ -94,6 +106,10 @@ type AtlasProjectStatus struct {
 	// Note, that this field is updated by the Atlas Operator only after specification changes
 	ExpiredIPAccessList []project.IPAccessList `json:"expiredIpAccessList,omitempty"`
 
+	// IPAccessListEntries are the IP Access List entries added by the AtlasIPAccessListEntry resources together with
+	// the resources owning them
+	IPAccessListEntries []ProjectIPAccessListEntry `json:"ipAccessListEntries,omitempty"`
+
 	// The list of private endpoints configured for current project
 	PrivateEndpoints []ProjectPrivateEndpoint `json:"privateEndpoints,omitempty"`
 
@@ -108,7 +124,8 @@ type AtlasProjectStatus struct {
 	// AlertConfigurations contains a list of alert configuration statuses
 	AlertConfigurations []AlertConfiguration `json:"alertConfigurations,omitempty"`
 
-	// CloudProviderAccessRoles contains a list of configured cloud provider access roles. AWS support only
+	// CloudProviderAccessRoles contains a list of configured cloud provider access roles together with the authorization
+	// results of every provider
 	CloudProviderAccessRoles []CloudProviderAccessRole `json:"cloudProviderAccessRoles,omitempty"`
 
 	// CustomRoles contains a list of custom roles statuses
@@ -121,4 +138,8 @@ type AtlasProjectStatus struct {
 	// including the prometheusDiscoveryURL
 	// +optional
 	Prometheus *Prometheus `json:"prometheus,omitempty"`
+
+	// LDAP contains the status of the LDAP configuration verification
+	// +optional
+	LDAP *LDAPStatus `json:"ldap,omitempty"`
 }
@@ -2,6 +2,8 @@ package status
 
 import (
 	"go.mongodb.org/atlas/mongodbatlas"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
 )
 
 type CloudProviderAccessRole struct {
@@ -15,6 +17,10 @@ type CloudProviderAccessRole struct {
 	RoleID                     string         `json:"roleId,omitempty"`
 	Status                     string         `json:"status,omitempty"`
 	ErrorMessage               string         `json:"errorMessage,omitempty"`
+	AtlasAzureAppID            string         `json:"atlasAzureAppId,omitempty"`
+	ServicePrincipalID         string         `json:"servicePrincipalId,omitempty"`
+	TenantID                   string         `json:"tenantId,omitempty"`
+	GCPServiceAccountForAtlas  string         `json:"gcpServiceAccountForAtlas,omitempty"`
 }
 
 type FeatureUsage struct {
@@ -43,6 +49,25 @@ func NewCloudProviderAccessRole(providerName, assumedRoleArn string) CloudProvid
 	}
 }
 
+// NewAzureCloudProviderAccessRole returns the status of the Azure service principal which is yet to be created in Atlas
+func NewAzureCloudProviderAccessRole(atlasAzureAppID, servicePrincipalID, tenantID string) CloudProviderAccessRole {
+	return CloudProviderAccessRole{
+		ProviderName:       string(provider.ProviderAzure),
+		AtlasAzureAppID:    atlasAzureAppID,
+		ServicePrincipalID: servicePrincipalID,
+		TenantID:           tenantID,
+		Status:             StatusCreated,
+	}
+}
+
+// NewGCPCloudProviderAccessRole returns the status of the GCP service account which is yet to be created in Atlas
+func NewGCPCloudProviderAccessRole() CloudProviderAccessRole {
+	return CloudProviderAccessRole{
+		ProviderName: string(provider.ProviderGCP),
+		Status:       StatusCreated,
+	}
+}
+
 func (c *CloudProviderAccessRole) IsEmptyARN() bool {
 	return c.Status == StatusEmptyARN
 }
@@ -57,11 +82,59 @@ func (c *CloudProviderAccessRole) FailedToAuthorise(errorMessage string) {
 }
 
 func (c *CloudProviderAccessRole) Update(role mongodbatlas.AWSIAMRole, isEmptyArn bool) {
+	c.updateCommon(role)
+
+	if isEmptyArn {
+		c.Status = StatusEmptyARN
+	} else {
+		switch role.IAMAssumedRoleARN {
+		case "":
+			c.Status = StatusCreated
+		case c.IamAssumedRoleArn:
+			c.Status = StatusReady
+			c.ErrorMessage = ""
+		default:
+			c.Status = StatusFailed
+			c.ErrorMessage = "IAMAssumedRoleARN is different from the previous one"
+		}
+	}
+}
+
+// UpdateAzure updates the status of the Azure service principal which is ready once it's authorized
+func (c *CloudProviderAccessRole) UpdateAzure(role mongodbatlas.AWSIAMRole, atlasAzureAppID, servicePrincipalID, tenantID string) {
+	c.updateCommon(role)
+	c.AtlasAzureAppID = atlasAzureAppID
+	c.ServicePrincipalID = servicePrincipalID
+	c.TenantID = tenantID
+
+	if role.AuthorizedDate == "" {
+		c.Status = StatusCreated
+		return
+	}
+	c.Status = StatusReady
+	c.ErrorMessage = ""
+}
+
+// UpdateGCP updates the status of the GCP service account which is ready once Atlas completes provisioning it
+func (c *CloudProviderAccessRole) UpdateGCP(role mongodbatlas.AWSIAMRole, serviceAccount, provisioningStatus string) {
+	c.updateCommon(role)
+	c.GCPServiceAccountForAtlas = serviceAccount
+
+	if provisioningStatus != "COMPLETE" {
+		c.Status = StatusCreated
+		return
+	}
+	c.Status = StatusReady
+	c.ErrorMessage = ""
+}
+
+func (c *CloudProviderAccessRole) updateCommon(role mongodbatlas.AWSIAMRole) {
 	c.RoleID = role.RoleID
 	c.AtlasAssumedRoleExternalID = role.AtlasAssumedRoleExternalID
 	c.AtlasAWSAccountArn = role.AtlasAWSAccountARN
 	c.AuthorizedDate = role.AuthorizedDate
 	c.CreatedDate = role.CreatedDate
+	c.FeatureUsages = nil
 	for _, featureUsage := range role.FeatureUsages {
 		if featureUsage != nil {
 			featureUsageID, ok := featureUsage.FeatureID.(string)
@@ -73,19 +146,4 @@ func (c *CloudProviderAccessRole) Update(role mongodbatlas.AWSIAMRole, isEmptyAr
 			}
 		}
 	}
-
-	if isEmptyArn {
-		c.Status = StatusEmptyARN
-	} else {
-		switch role.IAMAssumedRoleARN {
-		case "":
-			c.Status = StatusCreated
-		case c.IamAssumedRoleArn:
-			c.Status = StatusReady
-			c.ErrorMessage = ""
-		default:
-			c.Status = StatusFailed
-			c.ErrorMessage = "IAMAssumedRoleARN is different from the previous one"
-		}
-	}
 }
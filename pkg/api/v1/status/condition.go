@@ -34,20 +34,22 @@ const (
 
 // AtlasProject condition types
 const (
-	ProjectReadyType                ConditionType = "ProjectReady"
-	IPAccessListReadyType           ConditionType = "IPAccessListReady"
-	MaintenanceWindowReadyType      ConditionType = "MaintenanceWindowReady"
-	PrivateEndpointServiceReadyType ConditionType = "PrivateEndpointServiceReady"
-	PrivateEndpointReadyType        ConditionType = "PrivateEndpointReady"
-	NetworkPeerReadyType            ConditionType = "NetworkPeerReady"
-	CloudProviderAccessReadyType    ConditionType = "CloudProviderAccessReady"
-	IntegrationReadyType            ConditionType = "ThirdPartyIntegrationReady"
-	AlertConfigurationReadyType     ConditionType = "AlertConfigurationReadyType"
-	EncryptionAtRestReadyType       ConditionType = "EncryptionAtRestReady"
-	AuditingReadyType               ConditionType = "AuditingReady"
-	ProjectSettingsReadyType        ConditionType = "ProjectSettingsReady"
-	ProjectCustomRolesReadyType     ConditionType = "ProjectCustomRolesReady"
-	ProjectTeamsReadyType           ConditionType = "ProjectTeamsReady"
+	ProjectReadyType                     ConditionType = "ProjectReady"
+	IPAccessListReadyType                ConditionType = "IPAccessListReady"
+	MaintenanceWindowReadyType           ConditionType = "MaintenanceWindowReady"
+	PrivateEndpointServiceReadyType      ConditionType = "PrivateEndpointServiceReady"
+	PrivateEndpointReadyType             ConditionType = "PrivateEndpointReady"
+	RegionalizedPrivateEndpointReadyType ConditionType = "RegionalizedPrivateEndpointReady"
+	NetworkPeerReadyType                 ConditionType = "NetworkPeerReady"
+	CloudProviderAccessReadyType         ConditionType = "CloudProviderAccessReady"
+	IntegrationReadyType                 ConditionType = "ThirdPartyIntegrationReady"
+	AlertConfigurationReadyType          ConditionType = "AlertConfigurationReadyType"
+	EncryptionAtRestReadyType            ConditionType = "EncryptionAtRestReady"
+	AuditingReadyType                    ConditionType = "AuditingReady"
+	LDAPReadyType                        ConditionType = "LDAPReady"
+	ProjectSettingsReadyType             ConditionType = "ProjectSettingsReady"
+	ProjectCustomRolesReadyType          ConditionType = "ProjectCustomRolesReady"
+	ProjectTeamsReadyType                ConditionType = "ProjectTeamsReady"
 )
 
 // AtlasDeployment condition types
@@ -56,6 +58,11 @@ const (
 	ServerlessPrivateEndpointReadyType ConditionType = "ServerlessPrivateEndpointReady"
 	ManagedNamespacesReadyType         ConditionType = "ManagedNamespacesReady"
 	CustomZoneMappingReadyType         ConditionType = "CustomZoneMappingReady"
+	SearchIndexesReadyType             ConditionType = "SearchIndexesReady"
+	OnlineArchivesReadyType            ConditionType = "OnlineArchivesReady"
+	UpgradeInProgressType              ConditionType = "UpgradeInProgress"
+	TenantUpgradeInProgressType        ConditionType = "TenantUpgradeInProgress"
+	StalledType                        ConditionType = "Stalled"
 )
 
 // AtlasDatabaseUser condition types
@@ -63,6 +70,26 @@ const (
 	DatabaseUserReadyType ConditionType = "DatabaseUserReady"
 )
 
+// AtlasDataFederation condition types
+const (
+	DataFederationReadyType ConditionType = "DataFederationReady"
+)
+
+// AtlasTeam condition types
+const (
+	TeamReadyType ConditionType = "TeamReady"
+)
+
+// AtlasAPIKey condition types
+const (
+	APIKeyReadyType ConditionType = "APIKeyReady"
+)
+
+// AtlasFederatedAuth condition types
+const (
+	FederatedAuthReadyType ConditionType = "FederatedAuthReady"
+)
+
 // Generic condition type
 const (
 	ResourceVersionStatus ConditionType = "ResourceVersionIsValid"
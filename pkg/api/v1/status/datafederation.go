@@ -0,0 +1,38 @@
+package status
+
+// +k8s:deepcopy-gen=false
+
+// AtlasDataFederationStatusOption is the option that is applied to Atlas Data Federation Status
+type AtlasDataFederationStatusOption func(s *DataFederationStatus)
+
+func AtlasDataFederationStateOption(state string) AtlasDataFederationStatusOption {
+	return func(s *DataFederationStatus) {
+		s.State = state
+	}
+}
+
+func AtlasDataFederationHostnamesOption(hostnames []string) AtlasDataFederationStatusOption {
+	return func(s *DataFederationStatus) {
+		s.Hostnames = hostnames
+	}
+}
+
+func AtlasDataFederationConnectionStringOption(connectionString string) AtlasDataFederationStatusOption {
+	return func(s *DataFederationStatus) {
+		s.ConnectionString = connectionString
+	}
+}
+
+// DataFederationStatus defines the observed state of AtlasDataFederation.
+type DataFederationStatus struct {
+	Common `json:",inline"`
+
+	// State is the current state of the federated database instance in Atlas. Either ACTIVE or DELETED.
+	State string `json:"state,omitempty"`
+
+	// Hostnames is the list of hostnames assigned to the federated database instance.
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// ConnectionString is the standard connection string applications use to connect to the instance.
+	ConnectionString string `json:"connectionString,omitempty"`
+}
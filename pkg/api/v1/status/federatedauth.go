@@ -0,0 +1,48 @@
+package status
+
+// +k8s:deepcopy-gen=false
+
+// AtlasFederatedAuthStatusOption is the option that is applied to Atlas Federated Auth Status
+type AtlasFederatedAuthStatusOption func(s *AtlasFederatedAuthStatus)
+
+func AtlasFederatedAuthSettingsIDOption(id string) AtlasFederatedAuthStatusOption {
+	return func(s *AtlasFederatedAuthStatus) {
+		s.FederationSettingsID = id
+	}
+}
+
+func AtlasFederatedAuthOrgIDOption(orgID string) AtlasFederatedAuthStatusOption {
+	return func(s *AtlasFederatedAuthStatus) {
+		s.OrgID = orgID
+	}
+}
+
+func AtlasFederatedAuthRoleMappingsOption(roleMappings []FederatedRoleMappingStatus) AtlasFederatedAuthStatusOption {
+	return func(s *AtlasFederatedAuthStatus) {
+		s.RoleMappings = roleMappings
+	}
+}
+
+// AtlasFederatedAuthStatus defines the observed state of AtlasFederatedAuth.
+type AtlasFederatedAuthStatus struct {
+	Common `json:",inline"`
+
+	// FederationSettingsID is the unique identifier of the federation the organization belongs to.
+	FederationSettingsID string `json:"federationSettingsId,omitempty"`
+
+	// OrgID is the unique identifier of the organization the settings are applied to.
+	OrgID string `json:"orgId,omitempty"`
+
+	// RoleMappings are the role mappings managed by the operator. Only these are removed from Atlas once they are
+	// removed from the spec.
+	RoleMappings []FederatedRoleMappingStatus `json:"roleMappings,omitempty"`
+}
+
+// FederatedRoleMappingStatus is the role mapping in Atlas
+type FederatedRoleMappingStatus struct {
+	// ID is the unique identifier of the role mapping in Atlas.
+	ID string `json:"id"`
+
+	// ExternalGroupName is the name of the identity provider group.
+	ExternalGroupName string `json:"externalGroupName"`
+}
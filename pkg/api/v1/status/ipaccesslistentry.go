@@ -0,0 +1,40 @@
+package status
+
+import "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/common"
+
+// +k8s:deepcopy-gen=false
+
+// AtlasIPAccessListEntryStatusOption is the option that is applied to Atlas IP Access List Entry Status
+type AtlasIPAccessListEntryStatusOption func(s *IPAccessListEntryStatus)
+
+func AtlasIPAccessListEntryProjectIDOption(projectID string) AtlasIPAccessListEntryStatusOption {
+	return func(s *IPAccessListEntryStatus) {
+		s.ProjectID = projectID
+	}
+}
+
+func AtlasIPAccessListEntryStateOption(state string) AtlasIPAccessListEntryStatusOption {
+	return func(s *IPAccessListEntryStatus) {
+		s.EntryStatus = state
+	}
+}
+
+// IPAccessListEntryStatus defines the observed state of AtlasIPAccessListEntry.
+type IPAccessListEntryStatus struct {
+	Common `json:",inline"`
+
+	// ProjectID is the ID of the Atlas project the entry is added to.
+	ProjectID string `json:"projectId,omitempty"`
+
+	// EntryStatus is the status of the entry in Atlas: ACTIVE, PENDING or FAILED.
+	EntryStatus string `json:"entryStatus,omitempty"`
+}
+
+// ProjectIPAccessListEntry is the IP Access List entry of the project owned by the AtlasIPAccessListEntry resource.
+type ProjectIPAccessListEntry struct {
+	// Entry is the IP address, the CIDR block or the AWS security group of the entry.
+	Entry string `json:"entry"`
+
+	// Owner is the AtlasIPAccessListEntry resource the entry belongs to.
+	Owner common.ResourceRefNamespaced `json:"ownerRef"`
+}
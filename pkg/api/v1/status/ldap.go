@@ -0,0 +1,33 @@
+package status
+
+const (
+	LDAPVerificationPending = "PENDING"
+	LDAPVerificationSuccess = "SUCCESS"
+	LDAPVerificationFailed  = "FAILED"
+)
+
+// LDAPStatus is the state of the verification of the project LDAP configuration
+type LDAPStatus struct {
+	// RequestID is the identifier of the last LDAP configuration verification request
+	RequestID string `json:"requestId,omitempty"`
+
+	// VerificationStatus is the status of the verification request: PENDING, SUCCESS or FAILED
+	VerificationStatus string `json:"verificationStatus,omitempty"`
+
+	// FailedValidations lists the validations of the LDAP configuration that didn't succeed
+	// +optional
+	FailedValidations []string `json:"failedValidations,omitempty"`
+
+	// ConfigVersion identifies the LDAP server connection settings and the 'ResourceVersion' of the Secrets which
+	// were verified
+	ConfigVersion string `json:"configVersion,omitempty"`
+
+	// FailedAt is the time the last verification of the same configuration failed at. The verifications failed
+	// because the LDAP server couldn't be reached are requested again after a backoff growing with FailedAttempts.
+	// +optional
+	FailedAt string `json:"failedAt,omitempty"`
+
+	// FailedAttempts is the number of the verifications of the same configuration failed in a row
+	// +optional
+	FailedAttempts int `json:"failedAttempts,omitempty"`
+}
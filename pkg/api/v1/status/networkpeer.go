@@ -34,26 +34,32 @@ type AtlasNetworkPeer struct {
 	AtlasNetworkName string `json:"atlasNetworkName,omitempty"`
 	// ProjectID of Atlas container. Applicable only for GCP. It's needed to add network peer connection.
 	AtlasGCPProjectID string `json:"atlasGcpProjectId,omitempty"`
+	// Unique identifier of the Azure subscription of Atlas container. Applicable only for Azure. It's needed to grant the peering permissions.
+	AtlasAzureSubscriptionID string `json:"atlasAzureSubscriptionId,omitempty"`
+	// Name of the Azure VNet of Atlas container. Applicable only for Azure.
+	AtlasVNetName string `json:"atlasVnetName,omitempty"`
 	// ContainerID of Atlas network peer container.
 	ContainerID string `json:"containerId,omitempty"`
 }
 
 func NewNetworkPeerStatus(atlasPeer mongodbatlas.Peer, providerName provider.ProviderName, vpcName string, container mongodbatlas.Container) AtlasNetworkPeer {
 	return AtlasNetworkPeer{
-		ID:                atlasPeer.ID,
-		ProviderName:      providerName,
-		Region:            atlasPeer.AccepterRegionName,
-		StatusName:        atlasPeer.StatusName,
-		ErrorMessage:      atlasPeer.ErrorMessage,
-		ErrorState:        atlasPeer.ErrorState,
-		ErrorStateName:    atlasPeer.ErrorStateName,
-		ConnectionID:      atlasPeer.ConnectionID,
-		Status:            atlasPeer.Status,
-		VPC:               vpcName,
-		AtlasNetworkName:  container.NetworkName,
-		AtlasGCPProjectID: container.GCPProjectID,
-		ContainerID:       container.ID,
-		GCPProjectID:      atlasPeer.GCPProjectID,
+		ID:                       atlasPeer.ID,
+		ProviderName:             providerName,
+		Region:                   atlasPeer.AccepterRegionName,
+		StatusName:               atlasPeer.StatusName,
+		ErrorMessage:             atlasPeer.ErrorMessage,
+		ErrorState:               atlasPeer.ErrorState,
+		ErrorStateName:           atlasPeer.ErrorStateName,
+		ConnectionID:             atlasPeer.ConnectionID,
+		Status:                   atlasPeer.Status,
+		VPC:                      vpcName,
+		AtlasNetworkName:         container.NetworkName,
+		AtlasGCPProjectID:        container.GCPProjectID,
+		AtlasAzureSubscriptionID: container.AzureSubscriptionID,
+		AtlasVNetName:            container.VNetName,
+		ContainerID:              container.ID,
+		GCPProjectID:             atlasPeer.GCPProjectID,
 	}
 }
 
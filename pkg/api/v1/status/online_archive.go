@@ -0,0 +1,46 @@
+package status
+
+import (
+	"fmt"
+)
+
+const (
+	OnlineArchiveStatePending   = "PENDING"
+	OnlineArchiveStateArchiving = "ARCHIVING"
+	OnlineArchiveStateIdle      = "IDLE"
+	OnlineArchiveStateActive    = "ACTIVE"
+	OnlineArchiveStatePausing   = "PAUSING"
+	OnlineArchiveStatePaused    = "PAUSED"
+	OnlineArchiveStateOrphaned  = "ORPHANED"
+	OnlineArchiveStateDeleted   = "DELETED"
+	OnlineArchiveStateFailed    = "FAILED"
+)
+
+// OnlineArchive is the status of the Online Archive managed by the operator
+type OnlineArchive struct {
+	// Unique identifier of the Online Archive in Atlas
+	ID string `json:"id,omitempty"`
+
+	// Name of the database containing the archived collection
+	DBName string `json:"dbName"`
+
+	// Name of the archived collection
+	CollName string `json:"collName"`
+
+	// State of the Online Archive: PENDING, ARCHIVING, IDLE, PAUSING, PAUSED, ORPHANED or FAILED if the operator
+	// couldn't create or update the Online Archive
+	State string `json:"state,omitempty"`
+
+	// Details of the error if the Online Archive couldn't be created or updated
+	Message string `json:"message,omitempty"`
+}
+
+// Identifier is required to satisfy "Identifiable" interface
+func (s OnlineArchive) Identifier() interface{} {
+	return OnlineArchiveIdentifier(s.DBName, s.CollName)
+}
+
+// OnlineArchiveIdentifier returns the key identifying the Online Archive within the deployment
+func OnlineArchiveIdentifier(dbName, collName string) string {
+	return fmt.Sprintf("%s.%s", dbName, collName)
+}
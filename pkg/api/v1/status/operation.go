@@ -0,0 +1,10 @@
+package status
+
+// Operation is the long-running Atlas operation in progress for the resource
+type Operation struct {
+	// Kind of the operation, for example Creating or Updating.
+	Kind string `json:"kind"`
+
+	// StartedAt is the time in ISO 8601 format in UTC when the operator first saw the operation in progress.
+	StartedAt string `json:"startedAt"`
+}
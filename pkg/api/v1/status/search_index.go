@@ -0,0 +1,42 @@
+package status
+
+import (
+	"fmt"
+)
+
+const (
+	SearchIndexStatusSteady     = "STEADY"
+	SearchIndexStatusInProgress = "IN_PROGRESS"
+	SearchIndexStatusFailed     = "FAILED"
+)
+
+// SearchIndex is the status of the Atlas Search index managed by the operator
+type SearchIndex struct {
+	// Name of the index
+	Name string `json:"name"`
+
+	// Name of the database containing the indexed collection
+	Database string `json:"database"`
+
+	// Name of the indexed collection
+	CollectionName string `json:"collectionName"`
+
+	// Unique identifier of the index in Atlas
+	ID string `json:"id,omitempty"`
+
+	// Status of the index build: STEADY, IN_PROGRESS, FAILED or MIGRATING
+	Status string `json:"status,omitempty"`
+
+	// Details of the error if the index couldn't be created or updated
+	Message string `json:"message,omitempty"`
+}
+
+// Identifier is required to satisfy "Identifiable" interface
+func (s SearchIndex) Identifier() interface{} {
+	return SearchIndexIdentifier(s.Database, s.CollectionName, s.Name)
+}
+
+// SearchIndexIdentifier returns the key identifying the search index within the deployment
+func SearchIndexIdentifier(database, collectionName, name string) string {
+	return fmt.Sprintf("%s.%s/%s", database, collectionName, name)
+}
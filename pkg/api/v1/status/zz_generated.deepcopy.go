@@ -18,6 +18,22 @@ import (
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIKeyStatus) DeepCopyInto(out *APIKeyStatus) {
+	*out = *in
+	in.Common.DeepCopyInto(&out.Common)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeyStatus.
+func (in *APIKeyStatus) DeepCopy() *APIKeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(APIKeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AlertConfiguration) DeepCopyInto(out *AlertConfiguration) {
 	*out = *in
@@ -112,6 +128,51 @@ func (in *AtlasDeploymentStatus) DeepCopyInto(out *AtlasDeploymentStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SearchIndexes != nil {
+		in, out := &in.SearchIndexes, &out.SearchIndexes
+		*out = make([]SearchIndex, len(*in))
+		copy(*out, *in)
+	}
+	if in.OnlineArchives != nil {
+		in, out := &in.OnlineArchives, &out.OnlineArchives
+		*out = make([]OnlineArchive, len(*in))
+		copy(*out, *in)
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(DeploymentSchedule)
+		**out = **in
+	}
+	if in.MajorVersionUpgrade != nil {
+		in, out := &in.MajorVersionUpgrade, &out.MajorVersionUpgrade
+		*out = new(MajorVersionUpgrade)
+		**out = **in
+	}
+	if in.TestFailover != nil {
+		in, out := &in.TestFailover, &out.TestFailover
+		*out = new(TestFailover)
+		**out = **in
+	}
+	if in.OutageSimulation != nil {
+		in, out := &in.OutageSimulation, &out.OutageSimulation
+		*out = new(OutageSimulation)
+		**out = **in
+	}
+	if in.Operation != nil {
+		in, out := &in.Operation, &out.Operation
+		*out = new(Operation)
+		**out = **in
+	}
+	if in.Restore != nil {
+		in, out := &in.Restore, &out.Restore
+		*out = new(DeploymentRestore)
+		**out = **in
+	}
+	if in.TenantUpgrade != nil {
+		in, out := &in.TenantUpgrade, &out.TenantUpgrade
+		*out = new(TenantUpgrade)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasDeploymentStatus.
@@ -124,6 +185,43 @@ func (in *AtlasDeploymentStatus) DeepCopy() *AtlasDeploymentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasFederatedAuthStatus) DeepCopyInto(out *AtlasFederatedAuthStatus) {
+	*out = *in
+	in.Common.DeepCopyInto(&out.Common)
+	if in.RoleMappings != nil {
+		in, out := &in.RoleMappings, &out.RoleMappings
+		*out = make([]FederatedRoleMappingStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasFederatedAuthStatus.
+func (in *AtlasFederatedAuthStatus) DeepCopy() *AtlasFederatedAuthStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasFederatedAuthStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasNetworkContainerStatus) DeepCopyInto(out *AtlasNetworkContainerStatus) {
+	*out = *in
+	in.Common.DeepCopyInto(&out.Common)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasNetworkContainerStatus.
+func (in *AtlasNetworkContainerStatus) DeepCopy() *AtlasNetworkContainerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasNetworkContainerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AtlasNetworkPeer) DeepCopyInto(out *AtlasNetworkPeer) {
 	*out = *in
@@ -139,6 +237,45 @@ func (in *AtlasNetworkPeer) DeepCopy() *AtlasNetworkPeer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasNetworkPeeringStatus) DeepCopyInto(out *AtlasNetworkPeeringStatus) {
+	*out = *in
+	in.Common.DeepCopyInto(&out.Common)
+	out.AtlasNetworkPeer = in.AtlasNetworkPeer
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasNetworkPeeringStatus.
+func (in *AtlasNetworkPeeringStatus) DeepCopy() *AtlasNetworkPeeringStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasNetworkPeeringStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasPrivateEndpointStatus) DeepCopyInto(out *AtlasPrivateEndpointStatus) {
+	*out = *in
+	in.Common.DeepCopyInto(&out.Common)
+	in.PrivateEndpointService.DeepCopyInto(&out.PrivateEndpointService)
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]GCPEndpoint, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasPrivateEndpointStatus.
+func (in *AtlasPrivateEndpointStatus) DeepCopy() *AtlasPrivateEndpointStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasPrivateEndpointStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AtlasProjectStatus) DeepCopyInto(out *AtlasProjectStatus) {
 	*out = *in
@@ -148,6 +285,11 @@ func (in *AtlasProjectStatus) DeepCopyInto(out *AtlasProjectStatus) {
 		*out = make([]project.IPAccessList, len(*in))
 		copy(*out, *in)
 	}
+	if in.IPAccessListEntries != nil {
+		in, out := &in.IPAccessListEntries, &out.IPAccessListEntries
+		*out = make([]ProjectIPAccessListEntry, len(*in))
+		copy(*out, *in)
+	}
 	if in.PrivateEndpoints != nil {
 		in, out := &in.PrivateEndpoints, &out.PrivateEndpoints
 		*out = make([]ProjectPrivateEndpoint, len(*in))
@@ -194,6 +336,11 @@ func (in *AtlasProjectStatus) DeepCopyInto(out *AtlasProjectStatus) {
 		*out = new(Prometheus)
 		**out = **in
 	}
+	if in.LDAP != nil {
+		in, out := &in.LDAP, &out.LDAP
+		*out = new(LDAPStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasProjectStatus.
@@ -380,6 +527,57 @@ func (in *CustomZoneMapping) DeepCopy() *CustomZoneMapping {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataFederationStatus) DeepCopyInto(out *DataFederationStatus) {
+	*out = *in
+	in.Common.DeepCopyInto(&out.Common)
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataFederationStatus.
+func (in *DataFederationStatus) DeepCopy() *DataFederationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataFederationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentRestore) DeepCopyInto(out *DeploymentRestore) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentRestore.
+func (in *DeploymentRestore) DeepCopy() *DeploymentRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentSchedule) DeepCopyInto(out *DeploymentSchedule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentSchedule.
+func (in *DeploymentSchedule) DeepCopy() *DeploymentSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Endpoint) DeepCopyInto(out *Endpoint) {
 	*out = *in
@@ -410,6 +608,21 @@ func (in *FeatureUsage) DeepCopy() *FeatureUsage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FederatedRoleMappingStatus) DeepCopyInto(out *FederatedRoleMappingStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FederatedRoleMappingStatus.
+func (in *FederatedRoleMappingStatus) DeepCopy() *FederatedRoleMappingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FederatedRoleMappingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCPEndpoint) DeepCopyInto(out *GCPEndpoint) {
 	*out = *in
@@ -425,6 +638,57 @@ func (in *GCPEndpoint) DeepCopy() *GCPEndpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAccessListEntryStatus) DeepCopyInto(out *IPAccessListEntryStatus) {
+	*out = *in
+	in.Common.DeepCopyInto(&out.Common)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAccessListEntryStatus.
+func (in *IPAccessListEntryStatus) DeepCopy() *IPAccessListEntryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAccessListEntryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LDAPStatus) DeepCopyInto(out *LDAPStatus) {
+	*out = *in
+	if in.FailedValidations != nil {
+		in, out := &in.FailedValidations, &out.FailedValidations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPStatus.
+func (in *LDAPStatus) DeepCopy() *LDAPStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LDAPStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MajorVersionUpgrade) DeepCopyInto(out *MajorVersionUpgrade) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MajorVersionUpgrade.
+func (in *MajorVersionUpgrade) DeepCopy() *MajorVersionUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(MajorVersionUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedNamespace) DeepCopyInto(out *ManagedNamespace) {
 	*out = *in
@@ -520,6 +784,51 @@ func (in *Notification) DeepCopy() *Notification {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnlineArchive) DeepCopyInto(out *OnlineArchive) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnlineArchive.
+func (in *OnlineArchive) DeepCopy() *OnlineArchive {
+	if in == nil {
+		return nil
+	}
+	out := new(OnlineArchive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Operation) DeepCopyInto(out *Operation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Operation.
+func (in *Operation) DeepCopy() *Operation {
+	if in == nil {
+		return nil
+	}
+	out := new(Operation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutageSimulation) DeepCopyInto(out *OutageSimulation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutageSimulation.
+func (in *OutageSimulation) DeepCopy() *OutageSimulation {
+	if in == nil {
+		return nil
+	}
+	out := new(OutageSimulation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivateEndpoint) DeepCopyInto(out *PrivateEndpoint) {
 	*out = *in
@@ -540,6 +849,42 @@ func (in *PrivateEndpoint) DeepCopy() *PrivateEndpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateEndpointService) DeepCopyInto(out *PrivateEndpointService) {
+	*out = *in
+	if in.ServiceAttachmentNames != nil {
+		in, out := &in.ServiceAttachmentNames, &out.ServiceAttachmentNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateEndpointService.
+func (in *PrivateEndpointService) DeepCopy() *PrivateEndpointService {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateEndpointService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectIPAccessListEntry) DeepCopyInto(out *ProjectIPAccessListEntry) {
+	*out = *in
+	out.Owner = in.Owner
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectIPAccessListEntry.
+func (in *ProjectIPAccessListEntry) DeepCopy() *ProjectIPAccessListEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectIPAccessListEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProjectPrivateEndpoint) DeepCopyInto(out *ProjectPrivateEndpoint) {
 	*out = *in
@@ -611,6 +956,21 @@ func (in *ReplicaSet) DeepCopy() *ReplicaSet {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SearchIndex) DeepCopyInto(out *SearchIndex) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SearchIndex.
+func (in *SearchIndex) DeepCopy() *SearchIndex {
+	if in == nil {
+		return nil
+	}
+	out := new(SearchIndex)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerlessPrivateEndpoint) DeepCopyInto(out *ServerlessPrivateEndpoint) {
 	*out = *in
@@ -662,6 +1022,36 @@ func (in *TeamStatus) DeepCopy() *TeamStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantUpgrade) DeepCopyInto(out *TenantUpgrade) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantUpgrade.
+func (in *TenantUpgrade) DeepCopy() *TenantUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestFailover) DeepCopyInto(out *TestFailover) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestFailover.
+func (in *TestFailover) DeepCopy() *TestFailover {
+	if in == nil {
+		return nil
+	}
+	out := new(TestFailover)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Threshold) DeepCopyInto(out *Threshold) {
 	*out = *in
@@ -0,0 +1,22 @@
+package v1
+
+// DeploymentDeletionConfirmationAnnotation confirms the deletion of the deployment with termination protection
+// enabled. The value must be the name of the deployment in Atlas, otherwise the deletion of the resource is blocked.
+const DeploymentDeletionConfirmationAnnotation = "mongodb.com/atlas-deletion-confirmation"
+
+// IsTerminationProtected returns true if the termination protection is enabled in the spec of the deployment
+func (c *AtlasDeployment) IsTerminationProtected() bool {
+	var enabled *bool
+	switch {
+	case c.Spec.AdvancedDeploymentSpec != nil:
+		enabled = c.Spec.AdvancedDeploymentSpec.TerminationProtectionEnabled
+	case c.Spec.ServerlessSpec != nil:
+		enabled = c.Spec.ServerlessSpec.TerminationProtectionEnabled
+	}
+	return enabled != nil && *enabled
+}
+
+// DeletionConfirmed returns true if the deletion of the deployment is confirmed by the annotation
+func (c *AtlasDeployment) DeletionConfirmed() bool {
+	return c.Annotations[DeploymentDeletionConfirmationAnnotation] == c.GetDeploymentName()
+}
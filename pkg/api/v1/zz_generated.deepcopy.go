@@ -16,9 +16,52 @@ package v1
 import (
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/common"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIKeyAccessListEntry) DeepCopyInto(out *APIKeyAccessListEntry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeyAccessListEntry.
+func (in *APIKeyAccessListEntry) DeepCopy() *APIKeyAccessListEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(APIKeyAccessListEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIKeySpec) DeepCopyInto(out *APIKeySpec) {
+	*out = *in
+	out.Project = in.Project
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]APIKeyRole, len(*in))
+		copy(*out, *in)
+	}
+	if in.AccessList != nil {
+		in, out := &in.AccessList, &out.AccessList
+		*out = make([]APIKeyAccessListEntry, len(*in))
+		copy(*out, *in)
+	}
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeySpec.
+func (in *APIKeySpec) DeepCopy() *APIKeySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APIKeySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Action) DeepCopyInto(out *Action) {
 	*out = *in
@@ -110,6 +153,11 @@ func (in *AdvancedDeploymentSpec) DeepCopyInto(out *AdvancedDeploymentSpec) {
 			}
 		}
 	}
+	if in.TerminationProtectionEnabled != nil {
+		in, out := &in.TerminationProtectionEnabled, &out.TerminationProtectionEnabled
+		*out = new(bool)
+		**out = **in
+	}
 	if in.CustomZoneMapping != nil {
 		in, out := &in.CustomZoneMapping, &out.CustomZoneMapping
 		*out = make([]CustomZoneMapping, len(*in))
@@ -122,6 +170,13 @@ func (in *AdvancedDeploymentSpec) DeepCopyInto(out *AdvancedDeploymentSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SearchIndexes != nil {
+		in, out := &in.SearchIndexes, &out.SearchIndexes
+		*out = make([]SearchIndex, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedDeploymentSpec.
@@ -237,6 +292,65 @@ func (in *AlertConfiguration) DeepCopy() *AlertConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasAPIKey) DeepCopyInto(out *AtlasAPIKey) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasAPIKey.
+func (in *AtlasAPIKey) DeepCopy() *AtlasAPIKey {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasAPIKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasAPIKey) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasAPIKeyList) DeepCopyInto(out *AtlasAPIKeyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AtlasAPIKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasAPIKeyList.
+func (in *AtlasAPIKeyList) DeepCopy() *AtlasAPIKeyList {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasAPIKeyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasAPIKeyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AtlasBackupExportSpec) DeepCopyInto(out *AtlasBackupExportSpec) {
 	*out = *in
@@ -433,6 +547,65 @@ func (in *AtlasBackupScheduleSpec) DeepCopy() *AtlasBackupScheduleSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasDataFederation) DeepCopyInto(out *AtlasDataFederation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasDataFederation.
+func (in *AtlasDataFederation) DeepCopy() *AtlasDataFederation {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasDataFederation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasDataFederation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasDataFederationList) DeepCopyInto(out *AtlasDataFederationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AtlasDataFederation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasDataFederationList.
+func (in *AtlasDataFederationList) DeepCopy() *AtlasDataFederationList {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasDataFederationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasDataFederationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AtlasDatabaseUser) DeepCopyInto(out *AtlasDatabaseUser) {
 	*out = *in
@@ -516,6 +689,11 @@ func (in *AtlasDatabaseUserSpec) DeepCopyInto(out *AtlasDatabaseUserSpec) {
 		*out = new(common.ResourceRef)
 		**out = **in
 	}
+	if in.X509Certificate != nil {
+		in, out := &in.X509Certificate, &out.X509Certificate
+		*out = new(X509CertificateSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasDatabaseUserSpec.
@@ -612,6 +790,40 @@ func (in *AtlasDeploymentSpec) DeepCopyInto(out *AtlasDeploymentSpec) {
 		*out = new(ProcessArgs)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OnlineArchives != nil {
+		in, out := &in.OnlineArchives, &out.OnlineArchives
+		*out = make([]OnlineArchive, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(DeploymentSchedule)
+		**out = **in
+	}
+	if in.ScalingProfiles != nil {
+		in, out := &in.ScalingProfiles, &out.ScalingProfiles
+		*out = make([]ScalingProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MajorVersionUpgrade != nil {
+		in, out := &in.MajorVersionUpgrade, &out.MajorVersionUpgrade
+		*out = new(MajorVersionUpgrade)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(DeletionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestoreFrom != nil {
+		in, out := &in.RestoreFrom, &out.RestoreFrom
+		*out = new(RestoreFrom)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasDeploymentSpec.
@@ -625,7 +837,7 @@ func (in *AtlasDeploymentSpec) DeepCopy() *AtlasDeploymentSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AtlasProject) DeepCopyInto(out *AtlasProject) {
+func (in *AtlasFederatedAuth) DeepCopyInto(out *AtlasFederatedAuth) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -633,18 +845,18 @@ func (in *AtlasProject) DeepCopyInto(out *AtlasProject) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasProject.
-func (in *AtlasProject) DeepCopy() *AtlasProject {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasFederatedAuth.
+func (in *AtlasFederatedAuth) DeepCopy() *AtlasFederatedAuth {
 	if in == nil {
 		return nil
 	}
-	out := new(AtlasProject)
+	out := new(AtlasFederatedAuth)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AtlasProject) DeepCopyObject() runtime.Object {
+func (in *AtlasFederatedAuth) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -652,31 +864,31 @@ func (in *AtlasProject) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AtlasProjectList) DeepCopyInto(out *AtlasProjectList) {
+func (in *AtlasFederatedAuthList) DeepCopyInto(out *AtlasFederatedAuthList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]AtlasProject, len(*in))
+		*out = make([]AtlasFederatedAuth, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasProjectList.
-func (in *AtlasProjectList) DeepCopy() *AtlasProjectList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasFederatedAuthList.
+func (in *AtlasFederatedAuthList) DeepCopy() *AtlasFederatedAuthList {
 	if in == nil {
 		return nil
 	}
-	out := new(AtlasProjectList)
+	out := new(AtlasFederatedAuthList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AtlasProjectList) DeepCopyObject() runtime.Object {
+func (in *AtlasFederatedAuthList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -684,115 +896,80 @@ func (in *AtlasProjectList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AtlasProjectSpec) DeepCopyInto(out *AtlasProjectSpec) {
+func (in *AtlasFederatedAuthSpec) DeepCopyInto(out *AtlasFederatedAuthSpec) {
 	*out = *in
 	if in.ConnectionSecret != nil {
 		in, out := &in.ConnectionSecret, &out.ConnectionSecret
-		*out = new(common.ResourceRefNamespaced)
+		*out = new(common.ResourceRef)
 		**out = **in
 	}
-	if in.ProjectIPAccessList != nil {
-		in, out := &in.ProjectIPAccessList, &out.ProjectIPAccessList
-		*out = make([]project.IPAccessList, len(*in))
-		copy(*out, *in)
-	}
-	out.MaintenanceWindow = in.MaintenanceWindow
-	if in.PrivateEndpoints != nil {
-		in, out := &in.PrivateEndpoints, &out.PrivateEndpoints
-		*out = make([]PrivateEndpoint, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
 	}
-	if in.CloudProviderAccessRoles != nil {
-		in, out := &in.CloudProviderAccessRoles, &out.CloudProviderAccessRoles
-		*out = make([]CloudProviderAccessRole, len(*in))
+	if in.DataAccessIdentityProviderIDs != nil {
+		in, out := &in.DataAccessIdentityProviderIDs, &out.DataAccessIdentityProviderIDs
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.AlertConfigurations != nil {
-		in, out := &in.AlertConfigurations, &out.AlertConfigurations
-		*out = make([]AlertConfiguration, len(*in))
+	if in.IdentityProviders != nil {
+		in, out := &in.IdentityProviders, &out.IdentityProviders
+		*out = make([]FederatedIdentityProvider, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.NetworkPeers != nil {
-		in, out := &in.NetworkPeers, &out.NetworkPeers
-		*out = make([]NetworkPeer, len(*in))
+	if in.DomainAllowList != nil {
+		in, out := &in.DomainAllowList, &out.DomainAllowList
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.X509CertRef != nil {
-		in, out := &in.X509CertRef, &out.X509CertRef
-		*out = new(common.ResourceRefNamespaced)
-		**out = **in
-	}
-	if in.Integrations != nil {
-		in, out := &in.Integrations, &out.Integrations
-		*out = make([]project.Integration, len(*in))
+	if in.PostAuthRoleGrants != nil {
+		in, out := &in.PostAuthRoleGrants, &out.PostAuthRoleGrants
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.EncryptionAtRest != nil {
-		in, out := &in.EncryptionAtRest, &out.EncryptionAtRest
-		*out = new(EncryptionAtRest)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Auditing != nil {
-		in, out := &in.Auditing, &out.Auditing
-		*out = new(Auditing)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Settings != nil {
-		in, out := &in.Settings, &out.Settings
-		*out = new(ProjectSettings)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.CustomRoles != nil {
-		in, out := &in.CustomRoles, &out.CustomRoles
-		*out = make([]CustomRole, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Teams != nil {
-		in, out := &in.Teams, &out.Teams
-		*out = make([]Team, len(*in))
+	if in.RoleMappings != nil {
+		in, out := &in.RoleMappings, &out.RoleMappings
+		*out = make([]FederatedRoleMapping, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasProjectSpec.
-func (in *AtlasProjectSpec) DeepCopy() *AtlasProjectSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasFederatedAuthSpec.
+func (in *AtlasFederatedAuthSpec) DeepCopy() *AtlasFederatedAuthSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(AtlasProjectSpec)
+	out := new(AtlasFederatedAuthSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AtlasTeam) DeepCopyInto(out *AtlasTeam) {
+func (in *AtlasIPAccessListEntry) DeepCopyInto(out *AtlasIPAccessListEntry) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasTeam.
-func (in *AtlasTeam) DeepCopy() *AtlasTeam {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasIPAccessListEntry.
+func (in *AtlasIPAccessListEntry) DeepCopy() *AtlasIPAccessListEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(AtlasTeam)
+	out := new(AtlasIPAccessListEntry)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AtlasTeam) DeepCopyObject() runtime.Object {
+func (in *AtlasIPAccessListEntry) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -800,31 +977,31 @@ func (in *AtlasTeam) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AtlasTeamList) DeepCopyInto(out *AtlasTeamList) {
+func (in *AtlasIPAccessListEntryList) DeepCopyInto(out *AtlasIPAccessListEntryList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]AtlasTeam, len(*in))
+		*out = make([]AtlasIPAccessListEntry, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasTeamList.
-func (in *AtlasTeamList) DeepCopy() *AtlasTeamList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasIPAccessListEntryList.
+func (in *AtlasIPAccessListEntryList) DeepCopy() *AtlasIPAccessListEntryList {
 	if in == nil {
 		return nil
 	}
-	out := new(AtlasTeamList)
+	out := new(AtlasIPAccessListEntryList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AtlasTeamList) DeepCopyObject() runtime.Object {
+func (in *AtlasIPAccessListEntryList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -832,22 +1009,486 @@ func (in *AtlasTeamList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Auditing) DeepCopyInto(out *Auditing) {
+func (in *AtlasNetworkContainer) DeepCopyInto(out *AtlasNetworkContainer) {
 	*out = *in
-	if in.AuditAuthorizationSuccess != nil {
-		in, out := &in.AuditAuthorizationSuccess, &out.AuditAuthorizationSuccess
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Auditing.
-func (in *Auditing) DeepCopy() *Auditing {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasNetworkContainer.
+func (in *AtlasNetworkContainer) DeepCopy() *AtlasNetworkContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasNetworkContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasNetworkContainer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasNetworkContainerList) DeepCopyInto(out *AtlasNetworkContainerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AtlasNetworkContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasNetworkContainerList.
+func (in *AtlasNetworkContainerList) DeepCopy() *AtlasNetworkContainerList {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasNetworkContainerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasNetworkContainerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasNetworkContainerSpec) DeepCopyInto(out *AtlasNetworkContainerSpec) {
+	*out = *in
+	out.Project = in.Project
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasNetworkContainerSpec.
+func (in *AtlasNetworkContainerSpec) DeepCopy() *AtlasNetworkContainerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasNetworkContainerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasNetworkPeering) DeepCopyInto(out *AtlasNetworkPeering) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasNetworkPeering.
+func (in *AtlasNetworkPeering) DeepCopy() *AtlasNetworkPeering {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasNetworkPeering)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasNetworkPeering) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasNetworkPeeringList) DeepCopyInto(out *AtlasNetworkPeeringList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AtlasNetworkPeering, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasNetworkPeeringList.
+func (in *AtlasNetworkPeeringList) DeepCopy() *AtlasNetworkPeeringList {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasNetworkPeeringList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasNetworkPeeringList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasNetworkPeeringSpec) DeepCopyInto(out *AtlasNetworkPeeringSpec) {
+	*out = *in
+	out.Project = in.Project
+	if in.Container != nil {
+		in, out := &in.Container, &out.Container
+		*out = new(common.ResourceRefNamespaced)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasNetworkPeeringSpec.
+func (in *AtlasNetworkPeeringSpec) DeepCopy() *AtlasNetworkPeeringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasNetworkPeeringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasPrivateEndpoint) DeepCopyInto(out *AtlasPrivateEndpoint) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasPrivateEndpoint.
+func (in *AtlasPrivateEndpoint) DeepCopy() *AtlasPrivateEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasPrivateEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasPrivateEndpoint) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasPrivateEndpointList) DeepCopyInto(out *AtlasPrivateEndpointList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AtlasPrivateEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasPrivateEndpointList.
+func (in *AtlasPrivateEndpointList) DeepCopy() *AtlasPrivateEndpointList {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasPrivateEndpointList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasPrivateEndpointList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasPrivateEndpointSpec) DeepCopyInto(out *AtlasPrivateEndpointSpec) {
+	*out = *in
+	out.Project = in.Project
+	if in.Interface != nil {
+		in, out := &in.Interface, &out.Interface
+		*out = new(PrivateEndpointInterface)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasPrivateEndpointSpec.
+func (in *AtlasPrivateEndpointSpec) DeepCopy() *AtlasPrivateEndpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasPrivateEndpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasProject) DeepCopyInto(out *AtlasProject) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasProject.
+func (in *AtlasProject) DeepCopy() *AtlasProject {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasProject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasProject) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasProjectList) DeepCopyInto(out *AtlasProjectList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AtlasProject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasProjectList.
+func (in *AtlasProjectList) DeepCopy() *AtlasProjectList {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasProjectList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasProjectList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasProjectSpec) DeepCopyInto(out *AtlasProjectSpec) {
+	*out = *in
+	if in.ConnectionSecret != nil {
+		in, out := &in.ConnectionSecret, &out.ConnectionSecret
+		*out = new(common.ResourceRefNamespaced)
+		**out = **in
+	}
+	if in.ProjectIPAccessList != nil {
+		in, out := &in.ProjectIPAccessList, &out.ProjectIPAccessList
+		*out = make([]project.IPAccessList, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPAccessListSources != nil {
+		in, out := &in.IPAccessListSources, &out.IPAccessListSources
+		*out = make([]project.IPAccessListSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IPAccessListEntryNamespaces != nil {
+		in, out := &in.IPAccessListEntryNamespaces, &out.IPAccessListEntryNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.MaintenanceWindow = in.MaintenanceWindow
+	if in.PrivateEndpoints != nil {
+		in, out := &in.PrivateEndpoints, &out.PrivateEndpoints
+		*out = make([]PrivateEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RegionalizedPrivateEndpoints != nil {
+		in, out := &in.RegionalizedPrivateEndpoints, &out.RegionalizedPrivateEndpoints
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CloudProviderAccessRoles != nil {
+		in, out := &in.CloudProviderAccessRoles, &out.CloudProviderAccessRoles
+		*out = make([]CloudProviderAccessRole, len(*in))
+		copy(*out, *in)
+	}
+	if in.AlertConfigurations != nil {
+		in, out := &in.AlertConfigurations, &out.AlertConfigurations
+		*out = make([]AlertConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NetworkPeers != nil {
+		in, out := &in.NetworkPeers, &out.NetworkPeers
+		*out = make([]NetworkPeer, len(*in))
+		copy(*out, *in)
+	}
+	if in.X509CertRef != nil {
+		in, out := &in.X509CertRef, &out.X509CertRef
+		*out = new(common.ResourceRefNamespaced)
+		**out = **in
+	}
+	if in.Integrations != nil {
+		in, out := &in.Integrations, &out.Integrations
+		*out = make([]project.Integration, len(*in))
+		copy(*out, *in)
+	}
+	if in.EncryptionAtRest != nil {
+		in, out := &in.EncryptionAtRest, &out.EncryptionAtRest
+		*out = new(EncryptionAtRest)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Auditing != nil {
+		in, out := &in.Auditing, &out.Auditing
+		*out = new(Auditing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(ProjectSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CustomRoles != nil {
+		in, out := &in.CustomRoles, &out.CustomRoles
+		*out = make([]CustomRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]Team, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LDAP != nil {
+		in, out := &in.LDAP, &out.LDAP
+		*out = new(LDAPConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasProjectSpec.
+func (in *AtlasProjectSpec) DeepCopy() *AtlasProjectSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasProjectSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasTeam) DeepCopyInto(out *AtlasTeam) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasTeam.
+func (in *AtlasTeam) DeepCopy() *AtlasTeam {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasTeam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasTeam) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasTeamList) DeepCopyInto(out *AtlasTeamList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AtlasTeam, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasTeamList.
+func (in *AtlasTeamList) DeepCopy() *AtlasTeamList {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasTeamList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasTeamList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Auditing) DeepCopyInto(out *Auditing) {
+	*out = *in
+	if in.AuditAuthorizationSuccess != nil {
+		in, out := &in.AuditAuthorizationSuccess, &out.AuditAuthorizationSuccess
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Auditing.
+func (in *Auditing) DeepCopy() *Auditing {
 	if in == nil {
 		return nil
 	}
@@ -857,274 +1498,568 @@ func (in *Auditing) DeepCopy() *Auditing {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AutoScalingSpec) DeepCopyInto(out *AutoScalingSpec) {
+func (in *AutoScalingSpec) DeepCopyInto(out *AutoScalingSpec) {
+	*out = *in
+	if in.AutoIndexingEnabled != nil {
+		in, out := &in.AutoIndexingEnabled, &out.AutoIndexingEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DiskGBEnabled != nil {
+		in, out := &in.DiskGBEnabled, &out.DiskGBEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Compute != nil {
+		in, out := &in.Compute, &out.Compute
+		*out = new(ComputeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoScalingSpec.
+func (in *AutoScalingSpec) DeepCopy() *AutoScalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoScalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AwsKms) DeepCopyInto(out *AwsKms) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Valid != nil {
+		in, out := &in.Valid, &out.Valid
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsKms.
+func (in *AwsKms) DeepCopy() *AwsKms {
+	if in == nil {
+		return nil
+	}
+	out := new(AwsKms)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureKeyVault) DeepCopyInto(out *AzureKeyVault) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKeyVault.
+func (in *AzureKeyVault) DeepCopy() *AzureKeyVault {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureKeyVault)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BiConnector) DeepCopyInto(out *BiConnector) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiConnector.
+func (in *BiConnector) DeepCopy() *BiConnector {
+	if in == nil {
+		return nil
+	}
+	out := new(BiConnector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BiConnectorSpec) DeepCopyInto(out *BiConnectorSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiConnectorSpec.
+func (in *BiConnectorSpec) DeepCopy() *BiConnectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BiConnectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudProviderAccessRole) DeepCopyInto(out *CloudProviderAccessRole) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderAccessRole.
+func (in *CloudProviderAccessRole) DeepCopy() *CloudProviderAccessRole {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudProviderAccessRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComputeSpec) DeepCopyInto(out *ComputeSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ScaleDownEnabled != nil {
+		in, out := &in.ScaleDownEnabled, &out.ScaleDownEnabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComputeSpec.
+func (in *ComputeSpec) DeepCopy() *ComputeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComputeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionStrings) DeepCopyInto(out *ConnectionStrings) {
+	*out = *in
+	if in.PrivateEndpoint != nil {
+		in, out := &in.PrivateEndpoint, &out.PrivateEndpoint
+		*out = make([]PrivateEndpointSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AwsPrivateLink != nil {
+		in, out := &in.AwsPrivateLink, &out.AwsPrivateLink
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AwsPrivateLinkSrv != nil {
+		in, out := &in.AwsPrivateLinkSrv, &out.AwsPrivateLinkSrv
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionStrings.
+func (in *ConnectionStrings) DeepCopy() *ConnectionStrings {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionStrings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CopySetting) DeepCopyInto(out *CopySetting) {
+	*out = *in
+	if in.CloudProvider != nil {
+		in, out := &in.CloudProvider, &out.CloudProvider
+		*out = new(string)
+		**out = **in
+	}
+	if in.RegionName != nil {
+		in, out := &in.RegionName, &out.RegionName
+		*out = new(string)
+		**out = **in
+	}
+	if in.ReplicationSpecID != nil {
+		in, out := &in.ReplicationSpecID, &out.ReplicationSpecID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ShouldCopyOplogs != nil {
+		in, out := &in.ShouldCopyOplogs, &out.ShouldCopyOplogs
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Frequencies != nil {
+		in, out := &in.Frequencies, &out.Frequencies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CopySetting.
+func (in *CopySetting) DeepCopy() *CopySetting {
+	if in == nil {
+		return nil
+	}
+	out := new(CopySetting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomRole) DeepCopyInto(out *CustomRole) {
+	*out = *in
+	if in.InheritedRoles != nil {
+		in, out := &in.InheritedRoles, &out.InheritedRoles
+		*out = make([]Role, len(*in))
+		copy(*out, *in)
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]Action, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomRole.
+func (in *CustomRole) DeepCopy() *CustomRole {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomZoneMapping) DeepCopyInto(out *CustomZoneMapping) {
 	*out = *in
-	if in.AutoIndexingEnabled != nil {
-		in, out := &in.AutoIndexingEnabled, &out.AutoIndexingEnabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.DiskGBEnabled != nil {
-		in, out := &in.DiskGBEnabled, &out.DiskGBEnabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Compute != nil {
-		in, out := &in.Compute, &out.Compute
-		*out = new(ComputeSpec)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoScalingSpec.
-func (in *AutoScalingSpec) DeepCopy() *AutoScalingSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomZoneMapping.
+func (in *CustomZoneMapping) DeepCopy() *CustomZoneMapping {
 	if in == nil {
 		return nil
 	}
-	out := new(AutoScalingSpec)
+	out := new(CustomZoneMapping)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AwsKms) DeepCopyInto(out *AwsKms) {
+func (in *DataFederationAWSConfig) DeepCopyInto(out *DataFederationAWSConfig) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataFederationAWSConfig.
+func (in *DataFederationAWSConfig) DeepCopy() *DataFederationAWSConfig {
+	if in == nil {
+		return nil
 	}
-	if in.Valid != nil {
-		in, out := &in.Valid, &out.Valid
-		*out = new(bool)
+	out := new(DataFederationAWSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataFederationCloudProviderConfig) DeepCopyInto(out *DataFederationCloudProviderConfig) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(DataFederationAWSConfig)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsKms.
-func (in *AwsKms) DeepCopy() *AwsKms {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataFederationCloudProviderConfig.
+func (in *DataFederationCloudProviderConfig) DeepCopy() *DataFederationCloudProviderConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(AwsKms)
+	out := new(DataFederationCloudProviderConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AzureKeyVault) DeepCopyInto(out *AzureKeyVault) {
+func (in *DataFederationCollection) DeepCopyInto(out *DataFederationCollection) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
+	if in.DataSources != nil {
+		in, out := &in.DataSources, &out.DataSources
+		*out = make([]DataFederationDataSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKeyVault.
-func (in *AzureKeyVault) DeepCopy() *AzureKeyVault {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataFederationCollection.
+func (in *DataFederationCollection) DeepCopy() *DataFederationCollection {
 	if in == nil {
 		return nil
 	}
-	out := new(AzureKeyVault)
+	out := new(DataFederationCollection)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BiConnector) DeepCopyInto(out *BiConnector) {
+func (in *DataFederationDataProcessRegion) DeepCopyInto(out *DataFederationDataProcessRegion) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataFederationDataProcessRegion.
+func (in *DataFederationDataProcessRegion) DeepCopy() *DataFederationDataProcessRegion {
+	if in == nil {
+		return nil
+	}
+	out := new(DataFederationDataProcessRegion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataFederationDataSource) DeepCopyInto(out *DataFederationDataSource) {
+	*out = *in
+	if in.URLs != nil {
+		in, out := &in.URLs, &out.URLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowInsecure != nil {
+		in, out := &in.AllowInsecure, &out.AllowInsecure
 		*out = new(bool)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiConnector.
-func (in *BiConnector) DeepCopy() *BiConnector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataFederationDataSource.
+func (in *DataFederationDataSource) DeepCopy() *DataFederationDataSource {
 	if in == nil {
 		return nil
 	}
-	out := new(BiConnector)
+	out := new(DataFederationDataSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BiConnectorSpec) DeepCopyInto(out *BiConnectorSpec) {
+func (in *DataFederationDatabase) DeepCopyInto(out *DataFederationDatabase) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
+	if in.Collections != nil {
+		in, out := &in.Collections, &out.Collections
+		*out = make([]DataFederationCollection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Views != nil {
+		in, out := &in.Views, &out.Views
+		*out = make([]DataFederationView, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiConnectorSpec.
-func (in *BiConnectorSpec) DeepCopy() *BiConnectorSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataFederationDatabase.
+func (in *DataFederationDatabase) DeepCopy() *DataFederationDatabase {
 	if in == nil {
 		return nil
 	}
-	out := new(BiConnectorSpec)
+	out := new(DataFederationDatabase)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CloudProviderAccessRole) DeepCopyInto(out *CloudProviderAccessRole) {
+func (in *DataFederationReadPreference) DeepCopyInto(out *DataFederationReadPreference) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderAccessRole.
-func (in *CloudProviderAccessRole) DeepCopy() *CloudProviderAccessRole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataFederationReadPreference.
+func (in *DataFederationReadPreference) DeepCopy() *DataFederationReadPreference {
 	if in == nil {
 		return nil
 	}
-	out := new(CloudProviderAccessRole)
+	out := new(DataFederationReadPreference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComputeSpec) DeepCopyInto(out *ComputeSpec) {
+func (in *DataFederationSpec) DeepCopyInto(out *DataFederationSpec) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
+	out.Project = in.Project
+	if in.CloudProviderConfig != nil {
+		in, out := &in.CloudProviderConfig, &out.CloudProviderConfig
+		*out = new(DataFederationCloudProviderConfig)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.ScaleDownEnabled != nil {
-		in, out := &in.ScaleDownEnabled, &out.ScaleDownEnabled
-		*out = new(bool)
+	if in.DataProcessRegion != nil {
+		in, out := &in.DataProcessRegion, &out.DataProcessRegion
+		*out = new(DataFederationDataProcessRegion)
 		**out = **in
 	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(DataFederationStorage)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComputeSpec.
-func (in *ComputeSpec) DeepCopy() *ComputeSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataFederationSpec.
+func (in *DataFederationSpec) DeepCopy() *DataFederationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ComputeSpec)
+	out := new(DataFederationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConnectionStrings) DeepCopyInto(out *ConnectionStrings) {
+func (in *DataFederationStorage) DeepCopyInto(out *DataFederationStorage) {
 	*out = *in
-	if in.PrivateEndpoint != nil {
-		in, out := &in.PrivateEndpoint, &out.PrivateEndpoint
-		*out = make([]PrivateEndpointSpec, len(*in))
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]DataFederationDatabase, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.AwsPrivateLink != nil {
-		in, out := &in.AwsPrivateLink, &out.AwsPrivateLink
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.AwsPrivateLinkSrv != nil {
-		in, out := &in.AwsPrivateLinkSrv, &out.AwsPrivateLinkSrv
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.Stores != nil {
+		in, out := &in.Stores, &out.Stores
+		*out = make([]DataFederationStore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionStrings.
-func (in *ConnectionStrings) DeepCopy() *ConnectionStrings {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataFederationStorage.
+func (in *DataFederationStorage) DeepCopy() *DataFederationStorage {
 	if in == nil {
 		return nil
 	}
-	out := new(ConnectionStrings)
+	out := new(DataFederationStorage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CopySetting) DeepCopyInto(out *CopySetting) {
+func (in *DataFederationStore) DeepCopyInto(out *DataFederationStore) {
 	*out = *in
-	if in.CloudProvider != nil {
-		in, out := &in.CloudProvider, &out.CloudProvider
-		*out = new(string)
+	if in.ReadPreference != nil {
+		in, out := &in.ReadPreference, &out.ReadPreference
+		*out = new(DataFederationReadPreference)
 		**out = **in
 	}
-	if in.RegionName != nil {
-		in, out := &in.RegionName, &out.RegionName
-		*out = new(string)
+	if in.IncludeTags != nil {
+		in, out := &in.IncludeTags, &out.IncludeTags
+		*out = new(bool)
 		**out = **in
 	}
-	if in.ReplicationSpecID != nil {
-		in, out := &in.ReplicationSpecID, &out.ReplicationSpecID
-		*out = new(string)
-		**out = **in
+	if in.AdditionalStorageClasses != nil {
+		in, out := &in.AdditionalStorageClasses, &out.AdditionalStorageClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.ShouldCopyOplogs != nil {
-		in, out := &in.ShouldCopyOplogs, &out.ShouldCopyOplogs
+	if in.Public != nil {
+		in, out := &in.Public, &out.Public
 		*out = new(bool)
 		**out = **in
 	}
-	if in.Frequencies != nil {
-		in, out := &in.Frequencies, &out.Frequencies
+	if in.URLs != nil {
+		in, out := &in.URLs, &out.URLs
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AllowInsecure != nil {
+		in, out := &in.AllowInsecure, &out.AllowInsecure
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CopySetting.
-func (in *CopySetting) DeepCopy() *CopySetting {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataFederationStore.
+func (in *DataFederationStore) DeepCopy() *DataFederationStore {
 	if in == nil {
 		return nil
 	}
-	out := new(CopySetting)
+	out := new(DataFederationStore)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CustomRole) DeepCopyInto(out *CustomRole) {
+func (in *DataFederationView) DeepCopyInto(out *DataFederationView) {
 	*out = *in
-	if in.InheritedRoles != nil {
-		in, out := &in.InheritedRoles, &out.InheritedRoles
-		*out = make([]Role, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataFederationView.
+func (in *DataFederationView) DeepCopy() *DataFederationView {
+	if in == nil {
+		return nil
 	}
-	if in.Actions != nil {
-		in, out := &in.Actions, &out.Actions
-		*out = make([]Action, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out := new(DataFederationView)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeletionPolicy) DeepCopyInto(out *DeletionPolicy) {
+	*out = *in
+	if in.RetainBackups != nil {
+		in, out := &in.RetainBackups, &out.RetainBackups
+		*out = new(bool)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomRole.
-func (in *CustomRole) DeepCopy() *CustomRole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletionPolicy.
+func (in *DeletionPolicy) DeepCopy() *DeletionPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(CustomRole)
+	out := new(DeletionPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CustomZoneMapping) DeepCopyInto(out *CustomZoneMapping) {
+func (in *DeploymentSchedule) DeepCopyInto(out *DeploymentSchedule) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomZoneMapping.
-func (in *CustomZoneMapping) DeepCopy() *CustomZoneMapping {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentSchedule.
+func (in *DeploymentSchedule) DeepCopy() *DeploymentSchedule {
 	if in == nil {
 		return nil
 	}
-	out := new(CustomZoneMapping)
+	out := new(DeploymentSchedule)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1203,60 +2138,152 @@ func (in *DeploymentSpec) DeepCopy() *DeploymentSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DeploymentSpec)
+	out := new(DeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskGB) DeepCopyInto(out *DiskGB) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskGB.
+func (in *DiskGB) DeepCopy() *DiskGB {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskGB)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionAtRest) DeepCopyInto(out *EncryptionAtRest) {
+	*out = *in
+	in.AwsKms.DeepCopyInto(&out.AwsKms)
+	in.AzureKeyVault.DeepCopyInto(&out.AzureKeyVault)
+	in.GoogleCloudKms.DeepCopyInto(&out.GoogleCloudKms)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionAtRest.
+func (in *EncryptionAtRest) DeepCopy() *EncryptionAtRest {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionAtRest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointSpec) DeepCopyInto(out *EndpointSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointSpec.
+func (in *EndpointSpec) DeepCopy() *EndpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FederatedIdentityProvider) DeepCopyInto(out *FederatedIdentityProvider) {
+	*out = *in
+	if in.AssociatedDomains != nil {
+		in, out := &in.AssociatedDomains, &out.AssociatedDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SSODebugEnabled != nil {
+		in, out := &in.SSODebugEnabled, &out.SSODebugEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(FederatedOIDCSettings)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FederatedIdentityProvider.
+func (in *FederatedIdentityProvider) DeepCopy() *FederatedIdentityProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(FederatedIdentityProvider)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DiskGB) DeepCopyInto(out *DiskGB) {
+func (in *FederatedOIDCSettings) DeepCopyInto(out *FederatedOIDCSettings) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
+	if in.RequestedScopes != nil {
+		in, out := &in.RequestedScopes, &out.RequestedScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskGB.
-func (in *DiskGB) DeepCopy() *DiskGB {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FederatedOIDCSettings.
+func (in *FederatedOIDCSettings) DeepCopy() *FederatedOIDCSettings {
 	if in == nil {
 		return nil
 	}
-	out := new(DiskGB)
+	out := new(FederatedOIDCSettings)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EncryptionAtRest) DeepCopyInto(out *EncryptionAtRest) {
+func (in *FederatedRoleAssignment) DeepCopyInto(out *FederatedRoleAssignment) {
 	*out = *in
-	in.AwsKms.DeepCopyInto(&out.AwsKms)
-	in.AzureKeyVault.DeepCopyInto(&out.AzureKeyVault)
-	in.GoogleCloudKms.DeepCopyInto(&out.GoogleCloudKms)
+	if in.ProjectRef != nil {
+		in, out := &in.ProjectRef, &out.ProjectRef
+		*out = new(common.ResourceRefNamespaced)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionAtRest.
-func (in *EncryptionAtRest) DeepCopy() *EncryptionAtRest {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FederatedRoleAssignment.
+func (in *FederatedRoleAssignment) DeepCopy() *FederatedRoleAssignment {
 	if in == nil {
 		return nil
 	}
-	out := new(EncryptionAtRest)
+	out := new(FederatedRoleAssignment)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EndpointSpec) DeepCopyInto(out *EndpointSpec) {
+func (in *FederatedRoleMapping) DeepCopyInto(out *FederatedRoleMapping) {
 	*out = *in
+	if in.RoleAssignments != nil {
+		in, out := &in.RoleAssignments, &out.RoleAssignments
+		*out = make([]FederatedRoleAssignment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointSpec.
-func (in *EndpointSpec) DeepCopy() *EndpointSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FederatedRoleMapping.
+func (in *FederatedRoleMapping) DeepCopy() *FederatedRoleMapping {
 	if in == nil {
 		return nil
 	}
-	out := new(EndpointSpec)
+	out := new(FederatedRoleMapping)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1315,6 +2342,84 @@ func (in *GoogleCloudKms) DeepCopy() *GoogleCloudKms {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAccessListEntrySpec) DeepCopyInto(out *IPAccessListEntrySpec) {
+	*out = *in
+	out.Project = in.Project
+	out.IPAccessList = in.IPAccessList
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAccessListEntrySpec.
+func (in *IPAccessListEntrySpec) DeepCopy() *IPAccessListEntrySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAccessListEntrySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LDAPConfiguration) DeepCopyInto(out *LDAPConfiguration) {
+	*out = *in
+	out.BindPasswordSecretRef = in.BindPasswordSecretRef
+	if in.CACertificateSecretRef != nil {
+		in, out := &in.CACertificateSecretRef, &out.CACertificateSecretRef
+		*out = new(common.ResourceRefNamespaced)
+		**out = **in
+	}
+	if in.UserToDNMapping != nil {
+		in, out := &in.UserToDNMapping, &out.UserToDNMapping
+		*out = make([]LDAPUserToDNMapping, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPConfiguration.
+func (in *LDAPConfiguration) DeepCopy() *LDAPConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(LDAPConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LDAPUserToDNMapping) DeepCopyInto(out *LDAPUserToDNMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPUserToDNMapping.
+func (in *LDAPUserToDNMapping) DeepCopy() *LDAPUserToDNMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(LDAPUserToDNMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MajorVersionUpgrade) DeepCopyInto(out *MajorVersionUpgrade) {
+	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(UpgradeWindow)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MajorVersionUpgrade.
+func (in *MajorVersionUpgrade) DeepCopy() *MajorVersionUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(MajorVersionUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedNamespace) DeepCopyInto(out *ManagedNamespace) {
 	*out = *in
@@ -1425,6 +2530,57 @@ func (in *Notification) DeepCopy() *Notification {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnlineArchive) DeepCopyInto(out *OnlineArchive) {
+	*out = *in
+	out.Criteria = in.Criteria
+	if in.PartitionFields != nil {
+		in, out := &in.PartitionFields, &out.PartitionFields
+		*out = make([]OnlineArchivePartitionField, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnlineArchive.
+func (in *OnlineArchive) DeepCopy() *OnlineArchive {
+	if in == nil {
+		return nil
+	}
+	out := new(OnlineArchive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnlineArchiveCriteria) DeepCopyInto(out *OnlineArchiveCriteria) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnlineArchiveCriteria.
+func (in *OnlineArchiveCriteria) DeepCopy() *OnlineArchiveCriteria {
+	if in == nil {
+		return nil
+	}
+	out := new(OnlineArchiveCriteria)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnlineArchivePartitionField) DeepCopyInto(out *OnlineArchivePartitionField) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnlineArchivePartitionField.
+func (in *OnlineArchivePartitionField) DeepCopy() *OnlineArchivePartitionField {
+	if in == nil {
+		return nil
+	}
+	out := new(OnlineArchivePartitionField)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivateEndpoint) DeepCopyInto(out *PrivateEndpoint) {
 	*out = *in
@@ -1445,6 +2601,26 @@ func (in *PrivateEndpoint) DeepCopy() *PrivateEndpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateEndpointInterface) DeepCopyInto(out *PrivateEndpointInterface) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make(GCPEndpoints, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateEndpointInterface.
+func (in *PrivateEndpointInterface) DeepCopy() *PrivateEndpointInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateEndpointInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivateEndpointSpec) DeepCopyInto(out *PrivateEndpointSpec) {
 	*out = *in
@@ -1677,6 +2853,22 @@ func (in *Resource) DeepCopy() *Resource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreFrom) DeepCopyInto(out *RestoreFrom) {
+	*out = *in
+	out.DeploymentRef = in.DeploymentRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreFrom.
+func (in *RestoreFrom) DeepCopy() *RestoreFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Role) DeepCopyInto(out *Role) {
 	*out = *in
@@ -1707,6 +2899,58 @@ func (in *RoleSpec) DeepCopy() *RoleSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingProfile) DeepCopyInto(out *ScalingProfile) {
+	*out = *in
+	if in.RegionConfigs != nil {
+		in, out := &in.RegionConfigs, &out.RegionConfigs
+		*out = make([]ScalingProfileRegionConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingProfile.
+func (in *ScalingProfile) DeepCopy() *ScalingProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingProfileRegionConfig) DeepCopyInto(out *ScalingProfileRegionConfig) {
+	*out = *in
+	if in.ElectableSpecs != nil {
+		in, out := &in.ElectableSpecs, &out.ElectableSpecs
+		*out = new(Specs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AnalyticsSpecs != nil {
+		in, out := &in.AnalyticsSpecs, &out.AnalyticsSpecs
+		*out = new(Specs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Compute != nil {
+		in, out := &in.Compute, &out.Compute
+		*out = new(ComputeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingProfileRegionConfig.
+func (in *ScalingProfileRegionConfig) DeepCopy() *ScalingProfileRegionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingProfileRegionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScopeSpec) DeepCopyInto(out *ScopeSpec) {
 	*out = *in
@@ -1722,6 +2966,65 @@ func (in *ScopeSpec) DeepCopy() *ScopeSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SearchIndex) DeepCopyInto(out *SearchIndex) {
+	*out = *in
+	if in.Mappings != nil {
+		in, out := &in.Mappings, &out.Mappings
+		*out = new(SearchIndexMappings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Analyzers != nil {
+		in, out := &in.Analyzers, &out.Analyzers
+		*out = make([]apiextensionsv1.JSON, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Synonyms != nil {
+		in, out := &in.Synonyms, &out.Synonyms
+		*out = make([]apiextensionsv1.JSON, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefinitionRef != nil {
+		in, out := &in.DefinitionRef, &out.DefinitionRef
+		*out = new(common.ResourceRefNamespaced)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SearchIndex.
+func (in *SearchIndex) DeepCopy() *SearchIndex {
+	if in == nil {
+		return nil
+	}
+	out := new(SearchIndex)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SearchIndexMappings) DeepCopyInto(out *SearchIndexMappings) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SearchIndexMappings.
+func (in *SearchIndexMappings) DeepCopy() *SearchIndexMappings {
+	if in == nil {
+		return nil
+	}
+	out := new(SearchIndexMappings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerlessPrivateEndpoint) DeepCopyInto(out *ServerlessPrivateEndpoint) {
 	*out = *in
@@ -1745,6 +3048,11 @@ func (in *ServerlessSpec) DeepCopyInto(out *ServerlessSpec) {
 		*out = new(ProviderSettingsSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TerminationProtectionEnabled != nil {
+		in, out := &in.TerminationProtectionEnabled, &out.TerminationProtectionEnabled
+		*out = new(bool)
+		**out = **in
+	}
 	if in.PrivateEndpoints != nil {
 		in, out := &in.PrivateEndpoints, &out.PrivateEndpoints
 		*out = make([]ServerlessPrivateEndpoint, len(*in))
@@ -1816,6 +3124,11 @@ func (in *TeamSpec) DeepCopyInto(out *TeamSpec) {
 		*out = make([]TeamUser, len(*in))
 		copy(*out, *in)
 	}
+	if in.ConnectionSecret != nil {
+		in, out := &in.ConnectionSecret, &out.ConnectionSecret
+		*out = new(common.ResourceRef)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamSpec.
@@ -1842,3 +3155,38 @@ func (in *Threshold) DeepCopy() *Threshold {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeWindow) DeepCopyInto(out *UpgradeWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeWindow.
+func (in *UpgradeWindow) DeepCopy() *UpgradeWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *X509CertificateSpec) DeepCopyInto(out *X509CertificateSpec) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(common.ResourceRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new X509CertificateSpec.
+func (in *X509CertificateSpec) DeepCopy() *X509CertificateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(X509CertificateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
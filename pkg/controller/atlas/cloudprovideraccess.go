@@ -0,0 +1,141 @@
+package atlas
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+)
+
+const cloudProviderAccessBasePath = "api/atlas/v1.0/groups/%s/cloudProviderAccess"
+
+// CloudProviderAccessService is an interface for the Cloud Provider Access endpoints of the Atlas API.
+// The mongodbatlas client supports only the AWS IAM roles, so the Azure service principals and the GCP service
+// accounts are handled by the requests built on top of the generic client.
+type CloudProviderAccessService interface {
+	ListRoles(ctx context.Context, groupID string) (*CloudProviderAccessRoles, *mongodbatlas.Response, error)
+	CreateRole(ctx context.Context, groupID string, request *CloudProviderAccessRoleRequest) (*CloudProviderAccessRole, *mongodbatlas.Response, error)
+	AuthorizeRole(ctx context.Context, groupID, roleID string, request *CloudProviderAccessRoleRequest) (*CloudProviderAccessRole, *mongodbatlas.Response, error)
+	DeauthorizeRole(ctx context.Context, request *mongodbatlas.CloudProviderDeauthorizationRequest) (*mongodbatlas.Response, error)
+}
+
+type cloudProviderAccessService struct {
+	client *mongodbatlas.Client
+}
+
+// NewCloudProviderAccessService returns the CloudProviderAccessService working through the specified Atlas client.
+func NewCloudProviderAccessService(client *mongodbatlas.Client) CloudProviderAccessService {
+	return &cloudProviderAccessService{client: client}
+}
+
+// CloudProviderAccessRole is the AWS IAM role, the Azure service principal or the GCP service account as returned
+// by the Atlas API.
+type CloudProviderAccessRole struct {
+	mongodbatlas.AWSIAMRole
+	// ID identifies the Azure service principals instead of RoleID
+	ID                        string `json:"_id,omitempty"`
+	AtlasAzureAppID           string `json:"atlasAzureAppId,omitempty"`
+	ServicePrincipalID        string `json:"servicePrincipalId,omitempty"`
+	TenantID                  string `json:"tenantId,omitempty"`
+	GCPServiceAccountForAtlas string `json:"gcpServiceAccountForAtlas,omitempty"`
+	Status                    string `json:"status,omitempty"`
+}
+
+// GetRoleID returns the unique identifier of the role regardless of the provider.
+func (r CloudProviderAccessRole) GetRoleID() string {
+	if r.RoleID != "" {
+		return r.RoleID
+	}
+	return r.ID
+}
+
+// CloudProviderAccessRoles are all roles of the project grouped by the provider.
+type CloudProviderAccessRoles struct {
+	AWSIAMRoles            []CloudProviderAccessRole `json:"awsIamRoles,omitempty"`
+	AzureServicePrincipals []CloudProviderAccessRole `json:"azureServicePrincipals,omitempty"`
+	GCPServiceAccounts     []CloudProviderAccessRole `json:"gcpServiceAccounts,omitempty"`
+}
+
+// All returns the roles of all providers.
+func (r CloudProviderAccessRoles) All() []CloudProviderAccessRole {
+	result := make([]CloudProviderAccessRole, 0, len(r.AWSIAMRoles)+len(r.AzureServicePrincipals)+len(r.GCPServiceAccounts))
+	result = append(result, r.AWSIAMRoles...)
+	result = append(result, r.AzureServicePrincipals...)
+	return append(result, r.GCPServiceAccounts...)
+}
+
+// CloudProviderAccessRoleRequest creates or authorizes the role. Only the fields of the provider are sent.
+type CloudProviderAccessRoleRequest struct {
+	ProviderName       string `json:"providerName"`
+	IAMAssumedRoleARN  string `json:"iamAssumedRoleArn,omitempty"`
+	AtlasAzureAppID    string `json:"atlasAzureAppId,omitempty"`
+	ServicePrincipalID string `json:"servicePrincipalId,omitempty"`
+	TenantID           string `json:"tenantId,omitempty"`
+}
+
+func (s *cloudProviderAccessService) ListRoles(ctx context.Context, groupID string) (*CloudProviderAccessRoles, *mongodbatlas.Response, error) {
+	if groupID == "" {
+		return nil, nil, mongodbatlas.NewArgError("groupID", "must be set")
+	}
+	req, err := s.client.NewRequest(ctx, http.MethodGet, fmt.Sprintf(cloudProviderAccessBasePath, groupID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(CloudProviderAccessRoles)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root, resp, nil
+}
+
+func (s *cloudProviderAccessService) CreateRole(ctx context.Context, groupID string, request *CloudProviderAccessRoleRequest) (*CloudProviderAccessRole, *mongodbatlas.Response, error) {
+	if groupID == "" {
+		return nil, nil, mongodbatlas.NewArgError("groupID", "must be set")
+	}
+	if request == nil {
+		return nil, nil, mongodbatlas.NewArgError("request", "must be set")
+	}
+	req, err := s.client.NewRequest(ctx, http.MethodPost, fmt.Sprintf(cloudProviderAccessBasePath, groupID), request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(CloudProviderAccessRole)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root, resp, nil
+}
+
+func (s *cloudProviderAccessService) AuthorizeRole(ctx context.Context, groupID, roleID string, request *CloudProviderAccessRoleRequest) (*CloudProviderAccessRole, *mongodbatlas.Response, error) {
+	if groupID == "" {
+		return nil, nil, mongodbatlas.NewArgError("groupID", "must be set")
+	}
+	if roleID == "" {
+		return nil, nil, mongodbatlas.NewArgError("roleID", "must be set")
+	}
+	if request == nil {
+		return nil, nil, mongodbatlas.NewArgError("request", "must be set")
+	}
+	path := fmt.Sprintf(cloudProviderAccessBasePath, groupID) + "/" + url.PathEscape(roleID)
+	req, err := s.client.NewRequest(ctx, http.MethodPatch, path, request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(CloudProviderAccessRole)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root, resp, nil
+}
+
+func (s *cloudProviderAccessService) DeauthorizeRole(ctx context.Context, request *mongodbatlas.CloudProviderDeauthorizationRequest) (*mongodbatlas.Response, error) {
+	return s.client.CloudProviderAccess.DeauthorizeRole(ctx, request)
+}
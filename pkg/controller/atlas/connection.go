@@ -35,6 +35,15 @@ func ReadConnection(log *zap.SugaredLogger, kubeClient client.Client, operatorAP
 	return readAtlasConnectionFromSecret(kubeClient, operatorAPISecret)
 }
 
+// SecretData returns the connection in the format of the Atlas API credentials Secret
+func (c Connection) SecretData() map[string][]byte {
+	return map[string][]byte{
+		orgIDKey:      []byte(c.OrgID),
+		publicAPIKey:  []byte(c.PublicKey),
+		privateAPIKey: []byte(c.PrivateKey),
+	}
+}
+
 func readAtlasConnectionFromSecret(kubeClient client.Client, secretRef client.ObjectKey) (Connection, error) {
 	secret := &corev1.Secret{}
 	if err := kubeClient.Get(context.Background(), secretRef, secret); err != nil {
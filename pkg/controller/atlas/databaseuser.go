@@ -0,0 +1,90 @@
+package atlas
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+)
+
+const databaseUsersBasePath = "api/atlas/v1.0/groups/%s/databaseUsers"
+
+// DatabaseUsersService is an interface for the Database Users endpoints of the Atlas API.
+// The mongodbatlas client doesn't support the users authenticating with OIDC, so the requests are built on top of
+// the generic client.
+type DatabaseUsersService interface {
+	Get(ctx context.Context, databaseName, groupID, username string) (*DatabaseUser, *mongodbatlas.Response, error)
+	Create(ctx context.Context, groupID string, user *DatabaseUser) (*DatabaseUser, *mongodbatlas.Response, error)
+	Update(ctx context.Context, groupID, username string, user *DatabaseUser) (*DatabaseUser, *mongodbatlas.Response, error)
+}
+
+type databaseUsersService struct {
+	client *mongodbatlas.Client
+}
+
+// NewDatabaseUsersService returns the DatabaseUsersService working through the specified Atlas client.
+func NewDatabaseUsersService(client *mongodbatlas.Client) DatabaseUsersService {
+	return &databaseUsersService{client: client}
+}
+
+// DatabaseUser is the database user as returned by the Atlas API.
+type DatabaseUser struct {
+	mongodbatlas.DatabaseUser
+	OIDCAuthType string `json:"oidcAuthType,omitempty"`
+}
+
+func (s *databaseUsersService) Get(ctx context.Context, databaseName, groupID, username string) (*DatabaseUser, *mongodbatlas.Response, error) {
+	if databaseName == "" {
+		return nil, nil, mongodbatlas.NewArgError("databaseName", "must be set")
+	}
+	if groupID == "" {
+		return nil, nil, mongodbatlas.NewArgError("groupID", "must be set")
+	}
+	if username == "" {
+		return nil, nil, mongodbatlas.NewArgError("username", "must be set")
+	}
+	return s.do(ctx, http.MethodGet, databaseUserPath(groupID, databaseName, username), nil)
+}
+
+func (s *databaseUsersService) Create(ctx context.Context, groupID string, user *DatabaseUser) (*DatabaseUser, *mongodbatlas.Response, error) {
+	if groupID == "" {
+		return nil, nil, mongodbatlas.NewArgError("groupID", "must be set")
+	}
+	if user == nil {
+		return nil, nil, mongodbatlas.NewArgError("user", "must be set")
+	}
+	return s.do(ctx, http.MethodPost, fmt.Sprintf(databaseUsersBasePath, groupID), user)
+}
+
+func (s *databaseUsersService) Update(ctx context.Context, groupID, username string, user *DatabaseUser) (*DatabaseUser, *mongodbatlas.Response, error) {
+	if groupID == "" {
+		return nil, nil, mongodbatlas.NewArgError("groupID", "must be set")
+	}
+	if username == "" {
+		return nil, nil, mongodbatlas.NewArgError("username", "must be set")
+	}
+	if user == nil {
+		return nil, nil, mongodbatlas.NewArgError("user", "must be set")
+	}
+	return s.do(ctx, http.MethodPatch, databaseUserPath(groupID, user.DatabaseName, username), user)
+}
+
+func (s *databaseUsersService) do(ctx context.Context, method, path string, body interface{}) (*DatabaseUser, *mongodbatlas.Response, error) {
+	req, err := s.client.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(DatabaseUser)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root, resp, nil
+}
+
+func databaseUserPath(groupID, databaseName, username string) string {
+	return fmt.Sprintf(databaseUsersBasePath, groupID) + "/" + url.PathEscape(databaseName) + "/" + url.PathEscape(username)
+}
@@ -0,0 +1,266 @@
+package atlas
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	dataFederationBasePath = "api/atlas/v1.0/groups/%s/dataFederation"
+
+	// dataFederationTypeOnlineArchive is the type of the federated database instances Atlas creates to query the
+	// Online Archives of the clusters
+	dataFederationTypeOnlineArchive = "ONLINE_ARCHIVE"
+)
+
+// DataFederationService is an interface for the Data Federation endpoints of the Atlas API.
+// The mongodbatlas client only supports the legacy Data Lake endpoints which don't allow configuring Atlas and
+// HTTP stores, so the requests are built on top of the generic client.
+type DataFederationService interface {
+	List(ctx context.Context, groupID string) ([]DataFederationInstance, *mongodbatlas.Response, error)
+	ListOnlineArchiveInstances(ctx context.Context, groupID string) ([]DataFederationInstance, *mongodbatlas.Response, error)
+	Get(ctx context.Context, groupID, name string) (*DataFederationInstance, *mongodbatlas.Response, error)
+	Create(ctx context.Context, groupID string, instance *DataFederationInstance) (*DataFederationInstance, *mongodbatlas.Response, error)
+	Update(ctx context.Context, groupID, name string, instance *DataFederationInstance) (*DataFederationInstance, *mongodbatlas.Response, error)
+	Delete(ctx context.Context, groupID, name string) (*mongodbatlas.Response, error)
+}
+
+type dataFederationService struct {
+	client *mongodbatlas.Client
+}
+
+// NewDataFederationService returns the DataFederationService working through the specified Atlas client.
+func NewDataFederationService(client *mongodbatlas.Client) DataFederationService {
+	return &dataFederationService{client: client}
+}
+
+// DataFederationInstance is the federated database instance as returned by the Atlas API.
+type DataFederationInstance struct {
+	CloudProviderConfig *DataFederationCloudProviderConfig `json:"cloudProviderConfig,omitempty"`
+	DataProcessRegion   *DataFederationDataProcessRegion   `json:"dataProcessRegion,omitempty"`
+	GroupID             string                             `json:"groupId,omitempty"`
+	Hostnames           []string                           `json:"hostnames,omitempty"`
+	Name                string                             `json:"name,omitempty"`
+	State               string                             `json:"state,omitempty"`
+	Storage             *DataFederationStorage             `json:"storage,omitempty"`
+}
+
+type DataFederationCloudProviderConfig struct {
+	AWS *DataFederationAWSConfig `json:"aws,omitempty"`
+}
+
+type DataFederationAWSConfig struct {
+	ExternalID        string `json:"externalId,omitempty"`
+	IAMAssumedRoleARN string `json:"iamAssumedRoleARN,omitempty"`
+	IAMUserARN        string `json:"iamUserARN,omitempty"`
+	RoleID            string `json:"roleId,omitempty"`
+	TestS3Bucket      string `json:"testS3Bucket,omitempty"`
+}
+
+type DataFederationDataProcessRegion struct {
+	CloudProvider string `json:"cloudProvider,omitempty"`
+	Region        string `json:"region,omitempty"`
+}
+
+type DataFederationStorage struct {
+	Databases []DataFederationDatabase `json:"databases,omitempty"`
+	Stores    []DataFederationStore    `json:"stores,omitempty"`
+}
+
+type DataFederationStore struct {
+	Name                     string                        `json:"name,omitempty"`
+	Provider                 string                        `json:"provider,omitempty"`
+	ClusterName              string                        `json:"clusterName,omitempty"`
+	ProjectID                string                        `json:"projectId,omitempty"`
+	ReadPreference           *DataFederationReadPreference `json:"readPreference,omitempty"`
+	Bucket                   string                        `json:"bucket,omitempty"`
+	Region                   string                        `json:"region,omitempty"`
+	Prefix                   string                        `json:"prefix,omitempty"`
+	Delimiter                string                        `json:"delimiter,omitempty"`
+	IncludeTags              *bool                         `json:"includeTags,omitempty"`
+	AdditionalStorageClasses []string                      `json:"additionalStorageClasses,omitempty"`
+	Public                   *bool                         `json:"public,omitempty"`
+	URLs                     []string                      `json:"urls,omitempty"`
+	DefaultFormat            string                        `json:"defaultFormat,omitempty"`
+	AllowInsecure            *bool                         `json:"allowInsecure,omitempty"`
+}
+
+type DataFederationReadPreference struct {
+	Mode                string `json:"mode,omitempty"`
+	MaxStalenessSeconds int    `json:"maxStalenessSeconds,omitempty"`
+}
+
+type DataFederationDatabase struct {
+	Name                   string                     `json:"name,omitempty"`
+	Collections            []DataFederationCollection `json:"collections,omitempty"`
+	Views                  []DataFederationView       `json:"views,omitempty"`
+	MaxWildcardCollections int                        `json:"maxWildcardCollections,omitempty"`
+}
+
+type DataFederationCollection struct {
+	Name        string                     `json:"name,omitempty"`
+	DataSources []DataFederationDataSource `json:"dataSources,omitempty"`
+}
+
+type DataFederationDataSource struct {
+	StoreName           string   `json:"storeName,omitempty"`
+	Database            string   `json:"database,omitempty"`
+	Collection          string   `json:"collection,omitempty"`
+	CollectionRegex     string   `json:"collectionRegex,omitempty"`
+	Path                string   `json:"path,omitempty"`
+	URLs                []string `json:"urls,omitempty"`
+	DefaultFormat       string   `json:"defaultFormat,omitempty"`
+	AllowInsecure       *bool    `json:"allowInsecure,omitempty"`
+	ProvenanceFieldName string   `json:"provenanceFieldName,omitempty"`
+}
+
+type DataFederationView struct {
+	Name     string `json:"name,omitempty"`
+	Source   string `json:"source,omitempty"`
+	Pipeline string `json:"pipeline,omitempty"`
+}
+
+// OnlineArchiveConnectionString returns the connection string of the federated database instance querying the cluster
+// of the project together with its Online Archive or an empty string if there's no such instance.
+func OnlineArchiveConnectionString(instances []DataFederationInstance, projectID, clusterName string) string {
+	for _, instance := range instances {
+		if instance.Storage == nil {
+			continue
+		}
+		for _, store := range instance.Storage.Stores {
+			storeProjectID := store.ProjectID
+			if storeProjectID == "" {
+				storeProjectID = instance.GroupID
+			}
+			if store.ClusterName == clusterName && storeProjectID == projectID {
+				return instance.ConnectionString()
+			}
+		}
+	}
+	return ""
+}
+
+// ConnectionString returns the standard connection string for the federated database instance or an empty string
+// if Atlas hasn't assigned any hostnames yet.
+func (d DataFederationInstance) ConnectionString() string {
+	if len(d.Hostnames) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("mongodb://%s/?ssl=true&authSource=admin", strings.Join(d.Hostnames, ","))
+}
+
+func (s *dataFederationService) List(ctx context.Context, groupID string) ([]DataFederationInstance, *mongodbatlas.Response, error) {
+	if groupID == "" {
+		return nil, nil, mongodbatlas.NewArgError("groupID", "must be set")
+	}
+	return s.list(ctx, fmt.Sprintf(dataFederationBasePath, groupID))
+}
+
+// ListOnlineArchiveInstances returns the federated database instances Atlas creates for the Online Archives.
+func (s *dataFederationService) ListOnlineArchiveInstances(ctx context.Context, groupID string) ([]DataFederationInstance, *mongodbatlas.Response, error) {
+	if groupID == "" {
+		return nil, nil, mongodbatlas.NewArgError("groupID", "must be set")
+	}
+	return s.list(ctx, fmt.Sprintf(dataFederationBasePath, groupID)+"?type="+dataFederationTypeOnlineArchive)
+}
+
+func (s *dataFederationService) list(ctx context.Context, path string) ([]DataFederationInstance, *mongodbatlas.Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var root []DataFederationInstance
+	resp, err := s.client.Do(ctx, req, &root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root, resp, nil
+}
+
+func (s *dataFederationService) Get(ctx context.Context, groupID, name string) (*DataFederationInstance, *mongodbatlas.Response, error) {
+	if groupID == "" {
+		return nil, nil, mongodbatlas.NewArgError("groupID", "must be set")
+	}
+	if name == "" {
+		return nil, nil, mongodbatlas.NewArgError("name", "must be set")
+	}
+	req, err := s.client.NewRequest(ctx, http.MethodGet, dataFederationInstancePath(groupID, name), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(DataFederationInstance)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root, resp, nil
+}
+
+func (s *dataFederationService) Create(ctx context.Context, groupID string, instance *DataFederationInstance) (*DataFederationInstance, *mongodbatlas.Response, error) {
+	if groupID == "" {
+		return nil, nil, mongodbatlas.NewArgError("groupID", "must be set")
+	}
+	if instance == nil {
+		return nil, nil, mongodbatlas.NewArgError("instance", "must be set")
+	}
+	req, err := s.client.NewRequest(ctx, http.MethodPost, fmt.Sprintf(dataFederationBasePath, groupID), instance)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(DataFederationInstance)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root, resp, nil
+}
+
+func (s *dataFederationService) Update(ctx context.Context, groupID, name string, instance *DataFederationInstance) (*DataFederationInstance, *mongodbatlas.Response, error) {
+	if groupID == "" {
+		return nil, nil, mongodbatlas.NewArgError("groupID", "must be set")
+	}
+	if name == "" {
+		return nil, nil, mongodbatlas.NewArgError("name", "must be set")
+	}
+	if instance == nil {
+		return nil, nil, mongodbatlas.NewArgError("instance", "must be set")
+	}
+	req, err := s.client.NewRequest(ctx, http.MethodPatch, dataFederationInstancePath(groupID, name)+"?skipRoleValidation=false", instance)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(DataFederationInstance)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root, resp, nil
+}
+
+func (s *dataFederationService) Delete(ctx context.Context, groupID, name string) (*mongodbatlas.Response, error) {
+	if groupID == "" {
+		return nil, mongodbatlas.NewArgError("groupID", "must be set")
+	}
+	if name == "" {
+		return nil, mongodbatlas.NewArgError("name", "must be set")
+	}
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, dataFederationInstancePath(groupID, name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
+
+func dataFederationInstancePath(groupID, name string) string {
+	return fmt.Sprintf(dataFederationBasePath, groupID) + "/" + url.PathEscape(name)
+}
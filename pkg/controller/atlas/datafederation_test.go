@@ -0,0 +1,27 @@
+package atlas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnlineArchiveConnectionString(t *testing.T) {
+	instances := []DataFederationInstance{
+		{
+			GroupID:   "other-project",
+			Hostnames: []string{"other.a.query.mongodb.net"},
+			Storage:   &DataFederationStorage{Stores: []DataFederationStore{{ClusterName: "cluster", ProjectID: "other-project"}}},
+		},
+		{
+			GroupID:   "project",
+			Hostnames: []string{"archive.a.query.mongodb.net"},
+			Storage:   &DataFederationStorage{Stores: []DataFederationStore{{ClusterName: "cluster"}}},
+		},
+	}
+
+	assert.Equal(t, "mongodb://archive.a.query.mongodb.net/?ssl=true&authSource=admin", OnlineArchiveConnectionString(instances, "project", "cluster"))
+	assert.Equal(t, "mongodb://other.a.query.mongodb.net/?ssl=true&authSource=admin", OnlineArchiveConnectionString(instances, "other-project", "cluster"))
+	assert.Empty(t, OnlineArchiveConnectionString(instances, "project", "another-cluster"))
+	assert.Empty(t, OnlineArchiveConnectionString(instances, "third-project", "cluster"))
+}
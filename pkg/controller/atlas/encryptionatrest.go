@@ -0,0 +1,67 @@
+package atlas
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+)
+
+const encryptionAtRestBasePath = "api/atlas/v1.0/groups/%s/encryptionAtRest"
+
+// EncryptionAtRestService is an interface for updating the Encryption at Rest configuration of the project.
+// The mongodbatlas client doesn't support the Azure service principals and the GCP service accounts authorizing Atlas
+// to access the Key Vault and the KMS, so the request is built on top of the generic client.
+type EncryptionAtRestService interface {
+	Update(ctx context.Context, encryptionAtRest *EncryptionAtRest) (*EncryptionAtRest, *mongodbatlas.Response, error)
+}
+
+type encryptionAtRestService struct {
+	client *mongodbatlas.Client
+}
+
+// NewEncryptionAtRestService returns the EncryptionAtRestService working through the specified Atlas client.
+func NewEncryptionAtRestService(client *mongodbatlas.Client) EncryptionAtRestService {
+	return &encryptionAtRestService{client: client}
+}
+
+// EncryptionAtRest is the Encryption at Rest configuration of the project as accepted by the Atlas API.
+type EncryptionAtRest struct {
+	GroupID        string              `json:"groupId,omitempty"`
+	AwsKms         mongodbatlas.AwsKms `json:"awsKms,omitempty"`
+	AzureKeyVault  AzureKeyVault       `json:"azureKeyVault,omitempty"`
+	GoogleCloudKms GoogleCloudKms      `json:"googleCloudKms,omitempty"`
+}
+
+// AzureKeyVault is the Azure Key Vault configuration which may use the Azure service principal instead of the secret.
+type AzureKeyVault struct {
+	mongodbatlas.AzureKeyVault
+	RoleID string `json:"roleId,omitempty"`
+}
+
+// GoogleCloudKms is the GCP KMS configuration which may use the GCP service account instead of the key.
+type GoogleCloudKms struct {
+	mongodbatlas.GoogleCloudKms
+	RoleID string `json:"roleId,omitempty"`
+}
+
+func (s *encryptionAtRestService) Update(ctx context.Context, encryptionAtRest *EncryptionAtRest) (*EncryptionAtRest, *mongodbatlas.Response, error) {
+	if encryptionAtRest == nil {
+		return nil, nil, mongodbatlas.NewArgError("encryptionAtRest", "must be set")
+	}
+	if encryptionAtRest.GroupID == "" {
+		return nil, nil, mongodbatlas.NewArgError("groupID", "must be set")
+	}
+	req, err := s.client.NewRequest(ctx, http.MethodPatch, fmt.Sprintf(encryptionAtRestBasePath, encryptionAtRest.GroupID), encryptionAtRest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(EncryptionAtRest)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root, resp, nil
+}
@@ -0,0 +1,145 @@
+package atlas
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+)
+
+const federationSettingsBasePath = "api/atlas/v1.0/federationSettings/%s"
+
+// FederatedAuthService is an interface for the Federation Settings endpoints of the Atlas API.
+// The mongodbatlas client doesn't support the OIDC identity providers and the data access identity providers of the
+// connected organizations, so these requests are built on top of the generic client.
+type FederatedAuthService interface {
+	ListIdentityProviders(ctx context.Context, federationSettingsID string) ([]FederatedIdentityProvider, *mongodbatlas.Response, error)
+	UpdateIdentityProvider(ctx context.Context, federationSettingsID, idpID string, idp *FederatedIdentityProvider) (*FederatedIdentityProvider, *mongodbatlas.Response, error)
+	GetConnectedOrg(ctx context.Context, federationSettingsID, orgID string) (*FederatedConnectedOrg, *mongodbatlas.Response, error)
+	UpdateConnectedOrg(ctx context.Context, federationSettingsID, orgID string, org *FederatedConnectedOrg) (*FederatedConnectedOrg, *mongodbatlas.Response, error)
+}
+
+type federatedAuthService struct {
+	client *mongodbatlas.Client
+}
+
+// NewFederatedAuthService returns the FederatedAuthService working through the specified Atlas client.
+func NewFederatedAuthService(client *mongodbatlas.Client) FederatedAuthService {
+	return &federatedAuthService{client: client}
+}
+
+// FederatedIdentityProvider is the SAML or OIDC identity provider as returned by the Atlas API.
+type FederatedIdentityProvider struct {
+	mongodbatlas.FederatedSettingsIdentityProvider
+	ID                string   `json:"id,omitempty"`
+	Protocol          string   `json:"protocol,omitempty"`
+	Description       string   `json:"description,omitempty"`
+	Audience          string   `json:"audience,omitempty"`
+	ClientID          string   `json:"clientId,omitempty"`
+	AuthorizationType string   `json:"authorizationType,omitempty"`
+	GroupsClaim       string   `json:"groupsClaim,omitempty"`
+	UserClaim         string   `json:"userClaim,omitempty"`
+	RequestedScopes   []string `json:"requestedScopes,omitempty"`
+}
+
+// FederatedConnectedOrg is the configuration of the organization connected to the federation as returned by the
+// Atlas API.
+type FederatedConnectedOrg struct {
+	mongodbatlas.FederatedSettingsConnectedOrganization
+	DataAccessIdentityProviderIDs []string `json:"dataAccessIdentityProviderIds,omitempty"`
+}
+
+type federatedIdentityProviders struct {
+	Results []FederatedIdentityProvider `json:"results,omitempty"`
+}
+
+func (s *federatedAuthService) ListIdentityProviders(ctx context.Context, federationSettingsID string) ([]FederatedIdentityProvider, *mongodbatlas.Response, error) {
+	if federationSettingsID == "" {
+		return nil, nil, mongodbatlas.NewArgError("federationSettingsID", "must be set")
+	}
+	path := fmt.Sprintf(federationSettingsBasePath, federationSettingsID) + "/identityProviders?protocol=SAML,OIDC"
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(federatedIdentityProviders)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root.Results, resp, nil
+}
+
+func (s *federatedAuthService) UpdateIdentityProvider(ctx context.Context, federationSettingsID, idpID string, idp *FederatedIdentityProvider) (*FederatedIdentityProvider, *mongodbatlas.Response, error) {
+	if federationSettingsID == "" {
+		return nil, nil, mongodbatlas.NewArgError("federationSettingsID", "must be set")
+	}
+	if idpID == "" {
+		return nil, nil, mongodbatlas.NewArgError("idpID", "must be set")
+	}
+	if idp == nil {
+		return nil, nil, mongodbatlas.NewArgError("idp", "must be set")
+	}
+	path := fmt.Sprintf(federationSettingsBasePath, federationSettingsID) + "/identityProviders/" + url.PathEscape(idpID)
+	req, err := s.client.NewRequest(ctx, http.MethodPatch, path, idp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(FederatedIdentityProvider)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root, resp, nil
+}
+
+func (s *federatedAuthService) GetConnectedOrg(ctx context.Context, federationSettingsID, orgID string) (*FederatedConnectedOrg, *mongodbatlas.Response, error) {
+	if federationSettingsID == "" {
+		return nil, nil, mongodbatlas.NewArgError("federationSettingsID", "must be set")
+	}
+	if orgID == "" {
+		return nil, nil, mongodbatlas.NewArgError("orgID", "must be set")
+	}
+	req, err := s.client.NewRequest(ctx, http.MethodGet, connectedOrgPath(federationSettingsID, orgID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(FederatedConnectedOrg)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root, resp, nil
+}
+
+func (s *federatedAuthService) UpdateConnectedOrg(ctx context.Context, federationSettingsID, orgID string, org *FederatedConnectedOrg) (*FederatedConnectedOrg, *mongodbatlas.Response, error) {
+	if federationSettingsID == "" {
+		return nil, nil, mongodbatlas.NewArgError("federationSettingsID", "must be set")
+	}
+	if orgID == "" {
+		return nil, nil, mongodbatlas.NewArgError("orgID", "must be set")
+	}
+	if org == nil {
+		return nil, nil, mongodbatlas.NewArgError("org", "must be set")
+	}
+	req, err := s.client.NewRequest(ctx, http.MethodPatch, connectedOrgPath(federationSettingsID, orgID), org)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(FederatedConnectedOrg)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root, resp, nil
+}
+
+func connectedOrgPath(federationSettingsID, orgID string) string {
+	return fmt.Sprintf(federationSettingsBasePath, federationSettingsID) + "/connectedOrgConfigs/" + url.PathEscape(orgID)
+}
@@ -0,0 +1,349 @@
+package atlasapikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/connectionsecret"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/set"
+)
+
+// accessListEntry is the alias for the access list entry of the spec implementing the 'Identifiable' interface
+type accessListEntry mdbv1.APIKeyAccessListEntry
+
+func (e accessListEntry) Identifier() interface{} {
+	if e.CIDRBlock != "" {
+		return e.CIDRBlock
+	}
+	return ipToCIDR(e.IPAddress)
+}
+
+// atlasAccessListEntry is the alias for the Atlas access list entry implementing the 'Identifiable' interface.
+// Atlas returns the CIDR block for both the single IP addresses and the ranges.
+type atlasAccessListEntry mongodbatlas.AccessListAPIKey
+
+func (e atlasAccessListEntry) Identifier() interface{} {
+	if e.CidrBlock != "" {
+		return e.CidrBlock
+	}
+	return ipToCIDR(e.IPAddress)
+}
+
+// secretLabelKey marks the Secret written by the operator with the name of the AtlasAPIKey it belongs to. The Secrets
+// without it are never updated nor removed.
+const secretLabelKey = "atlas.mongodb.com/api-key"
+
+// ensureAPIKey creates the project API key in Atlas and writes it to the Secret. The API key is created again if it
+// was removed from Atlas, if the Secret doesn't exist anymore or if the rotation was requested. Otherwise, the
+// description, the roles and the access list of the API key are updated to match the spec.
+func (r *AtlasAPIKeyReconciler) ensureAPIKey(ctx *workflow.Context, projectID string, apiKey *mdbv1.AtlasAPIKey) workflow.Result {
+	current, err := getAPIKey(ctx, apiKey.Status.ID)
+	if err != nil {
+		return workflow.Terminate(workflow.APIKeyNotUpdatedInAtlas, err.Error())
+	}
+
+	secretExists, err := r.secretExists(apiKey)
+	if err != nil {
+		return workflow.Terminate(workflow.APIKeySecretNotCreated, err.Error())
+	}
+
+	if current == nil || !secretExists || apiKey.RotationRequest() != apiKey.Status.Rotation {
+		created, result := r.createAPIKey(ctx, projectID, apiKey)
+		if !result.IsOk() {
+			return result
+		}
+		if current != nil {
+			// The previous API key is removed only after the new one is written to the Secret
+			ctx.Log.Infow("API key rotated", "previousPublicKey", current.PublicKey, "publicKey", created.PublicKey)
+			if result = removePreviousAPIKey(ctx, current.ID); !result.IsOk() {
+				return result
+			}
+		}
+		current = created
+	} else if result := updateAPIKey(ctx, projectID, apiKey, current); !result.IsOk() {
+		return result
+	}
+
+	if apiKey.Status.PreviousID != "" && apiKey.Status.PreviousID != current.ID {
+		if result := removePreviousAPIKey(ctx, apiKey.Status.PreviousID); !result.IsOk() {
+			return result
+		}
+	}
+
+	return syncAccessList(ctx, apiKey, current.ID)
+}
+
+// removePreviousAPIKey removes the API key replaced by the rotation. The key is kept in the status until it's removed so
+// the live credentials don't leak if Atlas fails to remove it.
+func removePreviousAPIKey(ctx *workflow.Context, id string) workflow.Result {
+	if err := deleteAPIKey(ctx, id); err != nil {
+		ctx.EnsureStatusOption(status.AtlasAPIKeyPreviousIDOption(id))
+		return workflow.Terminate(workflow.APIKeyNotUpdatedInAtlas, fmt.Sprintf("failed to remove the previous API key %s from Atlas: %v", id, err))
+	}
+	ctx.EnsureStatusOption(status.AtlasAPIKeyPreviousIDOption(""))
+	return workflow.OK()
+}
+
+// getAPIKey returns the API key from Atlas or nil if it doesn't exist
+func getAPIKey(ctx *workflow.Context, id string) (*mongodbatlas.APIKey, error) {
+	if id == "" {
+		return nil, nil
+	}
+
+	apiKey, _, err := ctx.Client.APIKeys.Get(context.Background(), ctx.Connection.OrgID, id)
+	if err != nil {
+		var apiError *mongodbatlas.ErrorResponse
+		if errors.As(err, &apiError) && apiError.HTTPCode == http.StatusNotFound {
+			ctx.Log.Infof("API key %s doesn't exist in Atlas", id)
+			return nil, nil
+		}
+		return nil, err
+	}
+	return apiKey, nil
+}
+
+func (r *AtlasAPIKeyReconciler) createAPIKey(ctx *workflow.Context, projectID string, apiKey *mdbv1.AtlasAPIKey) (*mongodbatlas.APIKey, workflow.Result) {
+	created, _, err := ctx.Client.ProjectAPIKeys.Create(context.Background(), projectID, &mongodbatlas.APIKeyInput{
+		Desc:  apiKey.Spec.Description,
+		Roles: rolesToAtlas(apiKey.Spec.Roles),
+	})
+	if err != nil {
+		return nil, workflow.Terminate(workflow.APIKeyNotCreatedInAtlas, err.Error())
+	}
+	ctx.Log.Infow("API key created in Atlas", "publicKey", created.PublicKey)
+
+	if err = r.writeSecret(apiKey, ctx.Connection.OrgID, created); err != nil {
+		// The private key can't be read from Atlas again so the API key is of no use without the Secret
+		if deleteErr := deleteAPIKey(ctx, created.ID); deleteErr != nil {
+			ctx.Log.Errorw("Failed to remove the API key from Atlas", "publicKey", created.PublicKey, "error", deleteErr)
+		}
+		return nil, workflow.Terminate(workflow.APIKeySecretNotCreated, err.Error())
+	}
+
+	ctx.
+		EnsureStatusOption(status.AtlasAPIKeyIDOption(created.ID)).
+		EnsureStatusOption(status.AtlasAPIKeyPublicKeyOption(created.PublicKey)).
+		EnsureStatusOption(status.AtlasAPIKeyRotationOption(apiKey.RotationRequest()))
+
+	return created, workflow.OK()
+}
+
+func updateAPIKey(ctx *workflow.Context, projectID string, apiKey *mdbv1.AtlasAPIKey, current *mongodbatlas.APIKey) workflow.Result {
+	if current.Desc != apiKey.Spec.Description {
+		_, _, err := ctx.Client.APIKeys.Update(context.Background(), ctx.Connection.OrgID, current.ID, &mongodbatlas.APIKeyInput{Desc: apiKey.Spec.Description})
+		if err != nil {
+			return workflow.Terminate(workflow.APIKeyNotUpdatedInAtlas, err.Error())
+		}
+		ctx.Log.Debugw("API key description updated in Atlas", "publicKey", current.PublicKey)
+	}
+
+	roles := rolesToAtlas(apiKey.Spec.Roles)
+	if !rolesEqual(roles, projectRoles(current, projectID)) {
+		_, err := ctx.Client.ProjectAPIKeys.Assign(context.Background(), projectID, current.ID, &mongodbatlas.AssignAPIKey{Roles: roles})
+		if err != nil {
+			return workflow.Terminate(workflow.APIKeyNotUpdatedInAtlas, err.Error())
+		}
+		ctx.Log.Debugw("API key roles updated in Atlas", "publicKey", current.PublicKey, "roles", roles)
+	}
+
+	ctx.
+		EnsureStatusOption(status.AtlasAPIKeyIDOption(current.ID)).
+		EnsureStatusOption(status.AtlasAPIKeyPublicKeyOption(current.PublicKey))
+
+	return workflow.OK()
+}
+
+// syncAccessList makes the access list of the API key match the spec. All the entries not present in the spec are
+// removed as the API key is fully managed by the operator.
+func syncAccessList(ctx *workflow.Context, apiKey *mdbv1.AtlasAPIKey, id string) workflow.Result {
+	accessList, _, err := ctx.Client.AccessListAPIKeys.List(context.Background(), ctx.Connection.OrgID, id, nil)
+	if err != nil {
+		return workflow.Terminate(workflow.APIKeyAccessListNotReady, err.Error())
+	}
+
+	atlasEntries := make([]atlasAccessListEntry, 0, len(accessList.Results))
+	for _, entry := range accessList.Results {
+		if entry != nil {
+			atlasEntries = append(atlasEntries, atlasAccessListEntry(*entry))
+		}
+	}
+	specEntries := make([]accessListEntry, 0, len(apiKey.Spec.AccessList))
+	for _, entry := range apiKey.Spec.AccessList {
+		specEntries = append(specEntries, accessListEntry(entry))
+	}
+
+	for _, item := range set.Difference(atlasEntries, specEntries) {
+		entry := item.(atlasAccessListEntry).Identifier().(string)
+		if _, err = ctx.Client.AccessListAPIKeys.Delete(context.Background(), ctx.Connection.OrgID, id, url.PathEscape(entry)); err != nil {
+			return workflow.Terminate(workflow.APIKeyAccessListNotReady, fmt.Sprintf("Failed to remove %s from the access list: %v", entry, err))
+		}
+		ctx.Log.Debugw("Entry removed from the API key access list", "entry", entry)
+	}
+
+	toCreate := make([]*mongodbatlas.AccessListAPIKeysReq, 0)
+	for _, item := range set.Difference(specEntries, atlasEntries) {
+		entry := item.(accessListEntry)
+		toCreate = append(toCreate, &mongodbatlas.AccessListAPIKeysReq{IPAddress: entry.IPAddress, CidrBlock: entry.CIDRBlock})
+	}
+	if len(toCreate) > 0 {
+		if _, _, err = ctx.Client.AccessListAPIKeys.Create(context.Background(), ctx.Connection.OrgID, id, toCreate); err != nil {
+			return workflow.Terminate(workflow.APIKeyAccessListNotReady, fmt.Sprintf("Failed to update the access list: %v", err))
+		}
+		ctx.Log.Debugw("Entries added to the API key access list", "count", len(toCreate))
+	}
+
+	return workflow.OK()
+}
+
+// secretExists returns true if the Secret written by the operator exists. Another Secret with the same name is an error
+// as it's not overwritten.
+func (r *AtlasAPIKeyReconciler) secretExists(apiKey *mdbv1.AtlasAPIKey) (bool, error) {
+	secret := &corev1.Secret{}
+	if err := r.Client.Get(context.Background(), apiKey.SecretObjectKey(), secret); err != nil {
+		if apiErrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if !secretOwnedBy(secret, apiKey) {
+		return false, notOwnedSecretError(apiKey)
+	}
+	return true, nil
+}
+
+func secretOwnedBy(secret *corev1.Secret, apiKey *mdbv1.AtlasAPIKey) bool {
+	return secret.Labels[secretLabelKey] == apiKey.Name
+}
+
+func notOwnedSecretError(apiKey *mdbv1.AtlasAPIKey) error {
+	return fmt.Errorf("the Secret %s already exists and wasn't written by the operator for the AtlasAPIKey %s", apiKey.SecretObjectKey(), apiKey.Name)
+}
+
+// writeSecret creates or updates the Secret with the API key. The Secret has the same format as the operator connection
+// Secret so it can be referenced by the 'connectionSecretRef' of an AtlasProject.
+func (r *AtlasAPIKeyReconciler) writeSecret(apiKey *mdbv1.AtlasAPIKey, orgID string, created *mongodbatlas.APIKey) error {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:      apiKey.SecretObjectKey().Name,
+		Namespace: apiKey.SecretObjectKey().Namespace,
+	}}
+	getError := r.Client.Get(context.Background(), apiKey.SecretObjectKey(), secret)
+	if getError != nil && !apiErrors.IsNotFound(getError) {
+		return getError
+	}
+	if getError == nil && !secretOwnedBy(secret, apiKey) {
+		return notOwnedSecretError(apiKey)
+	}
+
+	if secret.Labels == nil {
+		secret.Labels = map[string]string{}
+	}
+	secret.Labels[connectionsecret.TypeLabelKey] = connectionsecret.CredLabelVal
+	secret.Labels[secretLabelKey] = apiKey.Name
+	secret.Data = atlas.Connection{OrgID: orgID, PublicKey: created.PublicKey, PrivateKey: created.PrivateKey}.SecretData()
+
+	if getError != nil {
+		return r.Client.Create(context.Background(), secret)
+	}
+	return r.Client.Update(context.Background(), secret)
+}
+
+// deleteAPIKeyFromAtlas removes the API key from Atlas together with the Secret containing it
+func (r *AtlasAPIKeyReconciler) deleteAPIKeyFromAtlas(ctx *workflow.Context, apiKey *mdbv1.AtlasAPIKey) error {
+	ctx.Log.Infow("-> Starting AtlasAPIKey deletion", "spec", apiKey.Spec)
+
+	for _, id := range []string{apiKey.Status.PreviousID, apiKey.Status.ID} {
+		if id == "" {
+			continue
+		}
+		if err := deleteAPIKey(ctx, id); err != nil {
+			return err
+		}
+		ctx.Log.Infow("API key removed from Atlas", "id", id)
+	}
+
+	// The Secret is only removed if it was written by the operator
+	secret := &corev1.Secret{}
+	if err := r.Client.Get(context.Background(), apiKey.SecretObjectKey(), secret); err != nil {
+		if apiErrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read the API key secret: %w", err)
+	}
+	if !secretOwnedBy(secret, apiKey) {
+		return nil
+	}
+	if err := r.Client.Delete(context.Background(), secret); err != nil && !apiErrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the API key secret: %w", err)
+	}
+	return nil
+}
+
+func deleteAPIKey(ctx *workflow.Context, id string) error {
+	_, err := ctx.Client.APIKeys.Delete(context.Background(), ctx.Connection.OrgID, id)
+	var apiError *mongodbatlas.ErrorResponse
+	if errors.As(err, &apiError) && apiError.HTTPCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// projectRoles returns the roles the API key is granted in the project
+func projectRoles(apiKey *mongodbatlas.APIKey, projectID string) []string {
+	roles := make([]string, 0, len(apiKey.Roles))
+	for _, role := range apiKey.Roles {
+		if role.GroupID == projectID {
+			roles = append(roles, role.RoleName)
+		}
+	}
+	return roles
+}
+
+func rolesToAtlas(roles []mdbv1.APIKeyRole) []string {
+	result := make([]string, 0, len(roles))
+	for _, role := range roles {
+		result = append(result, string(role))
+	}
+	return result
+}
+
+func rolesEqual(desired, current []string) bool {
+	if len(desired) != len(current) {
+		return false
+	}
+	desired = append([]string{}, desired...)
+	current = append([]string{}, current...)
+	sort.Strings(desired)
+	sort.Strings(current)
+	for i := range desired {
+		if desired[i] != current[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ipToCIDR returns the single address CIDR block of the IP address
+func ipToCIDR(ipAddress string) string {
+	ip := net.ParseIP(ipAddress)
+	if ip == nil {
+		return ipAddress
+	}
+	if ip.To4() != nil {
+		return ipAddress + "/32"
+	}
+	return ipAddress + "/128"
+}
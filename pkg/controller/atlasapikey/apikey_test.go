@@ -0,0 +1,145 @@
+package atlasapikey
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/connectionsecret"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/set"
+)
+
+func TestWriteSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	utilruntime.Must(mdbv1.AddToScheme(scheme))
+	r := &AtlasAPIKeyReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build()}
+	apiKey := mdbv1.NewAPIKey("ns", "key", "project", "CI key", "ci-key")
+
+	for _, created := range []*mongodbatlas.APIKey{
+		{ID: "id", PublicKey: "public", PrivateKey: "private"},
+		{ID: "rotated", PublicKey: "public-rotated", PrivateKey: "private-rotated"},
+	} {
+		require.NoError(t, r.writeSecret(apiKey, "org-id", created))
+
+		exists, err := r.secretExists(apiKey)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		secret := &corev1.Secret{}
+		require.NoError(t, r.Client.Get(context.Background(), apiKey.SecretObjectKey(), secret))
+		assert.Equal(t, connectionsecret.CredLabelVal, secret.Labels[connectionsecret.TypeLabelKey])
+		assert.Equal(t, "key", secret.Labels[secretLabelKey])
+		assert.Equal(t, atlas.Connection{OrgID: "org-id", PublicKey: created.PublicKey, PrivateKey: created.PrivateKey}.SecretData(), secret.Data)
+	}
+}
+
+func TestSecretNotWrittenByOperator(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	utilruntime.Must(mdbv1.AddToScheme(scheme))
+	apiKey := mdbv1.NewAPIKey("ns", "key", "project", "CI key", "ci-key")
+	apiKey.Status.ID = "id"
+	userSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ci-key", Namespace: "ns"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+	r := &AtlasAPIKeyReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(userSecret).Build()}
+
+	_, err := r.secretExists(apiKey)
+	assert.Error(t, err)
+	assert.Error(t, r.writeSecret(apiKey, "org-id", &mongodbatlas.APIKey{ID: "id", PublicKey: "public", PrivateKey: "private"}))
+
+	ctx := workflow.NewContext(zap.S(), []status.Condition{})
+	ctx.Client = mongodbatlas.Client{APIKeys: &apiKeysStub{}}
+	require.NoError(t, r.deleteAPIKeyFromAtlas(ctx, apiKey))
+
+	secret := &corev1.Secret{}
+	require.NoError(t, r.Client.Get(context.Background(), apiKey.SecretObjectKey(), secret))
+	assert.Equal(t, userSecret.Data, secret.Data)
+}
+
+// apiKeysStub records the removed API keys and fails to remove the ones in failing
+type apiKeysStub struct {
+	mongodbatlas.APIKeysService
+
+	failing map[string]bool
+	deleted []string
+}
+
+func (s *apiKeysStub) Delete(_ context.Context, _ string, id string) (*mongodbatlas.Response, error) {
+	if s.failing[id] {
+		return nil, errors.New("unavailable")
+	}
+	s.deleted = append(s.deleted, id)
+	return nil, nil
+}
+
+func TestRemovePreviousAPIKey(t *testing.T) {
+	stub := &apiKeysStub{failing: map[string]bool{"previous": true}}
+	ctx := workflow.NewContext(zap.S(), []status.Condition{})
+	ctx.Client = mongodbatlas.Client{APIKeys: stub}
+
+	apiKeyStatus := status.APIKeyStatus{}
+	assert.False(t, removePreviousAPIKey(ctx, "previous").IsOk())
+	for _, option := range ctx.StatusOptions() {
+		option.(status.AtlasAPIKeyStatusOption)(&apiKeyStatus)
+	}
+	assert.Equal(t, "previous", apiKeyStatus.PreviousID)
+
+	stub.failing = nil
+	ctx = workflow.NewContext(zap.S(), []status.Condition{})
+	ctx.Client = mongodbatlas.Client{APIKeys: stub}
+	assert.True(t, removePreviousAPIKey(ctx, "previous").IsOk())
+	for _, option := range ctx.StatusOptions() {
+		option.(status.AtlasAPIKeyStatusOption)(&apiKeyStatus)
+	}
+	assert.Empty(t, apiKeyStatus.PreviousID)
+	assert.Equal(t, []string{"previous"}, stub.deleted)
+}
+
+func TestRolesEqual(t *testing.T) {
+	apiKey := &mongodbatlas.APIKey{Roles: []mongodbatlas.AtlasRole{
+		{OrgID: "org-id", RoleName: "ORG_MEMBER"},
+		{GroupID: "project-id", RoleName: "GROUP_READ_ONLY"},
+		{GroupID: "project-id", RoleName: "GROUP_CLUSTER_MANAGER"},
+		{GroupID: "other-project", RoleName: "GROUP_OWNER"},
+	}}
+
+	current := projectRoles(apiKey, "project-id")
+	assert.Equal(t, []string{"GROUP_READ_ONLY", "GROUP_CLUSTER_MANAGER"}, current)
+	assert.True(t, rolesEqual([]string{"GROUP_CLUSTER_MANAGER", "GROUP_READ_ONLY"}, current))
+	assert.False(t, rolesEqual([]string{"GROUP_READ_ONLY"}, current))
+	assert.False(t, rolesEqual([]string{"GROUP_OWNER", "GROUP_READ_ONLY"}, current))
+}
+
+func TestAccessListEntryIdentifier(t *testing.T) {
+	spec := []accessListEntry{
+		{IPAddress: "10.0.0.1"},
+		{IPAddress: "2001:db8::1"},
+		{CIDRBlock: "192.168.0.0/16"},
+	}
+	atlasEntries := []atlasAccessListEntry{
+		{IPAddress: "10.0.0.1", CidrBlock: "10.0.0.1/32"},
+		{IPAddress: "2001:db8::1", CidrBlock: "2001:db8::1/128"},
+		{CidrBlock: "172.16.0.0/12"},
+	}
+
+	assert.Len(t, set.Intersection(spec, atlasEntries), 2)
+	assert.Equal(t, []set.Identifiable{accessListEntry{CIDRBlock: "192.168.0.0/16"}}, set.Difference(spec, atlasEntries))
+	assert.Equal(t, []set.Identifiable{atlasAccessListEntry{CidrBlock: "172.16.0.0/12"}}, set.Difference(atlasEntries, spec))
+}
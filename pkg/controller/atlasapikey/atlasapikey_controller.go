@@ -0,0 +1,197 @@
+/*
+Copyright 2023 MongoDB.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package atlasapikey
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/statushandler"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/validate"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/watch"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+)
+
+// AtlasAPIKeyReconciler reconciles an AtlasAPIKey object
+type AtlasAPIKeyReconciler struct {
+	watch.ResourceWatcher
+	Client           client.Client
+	Log              *zap.SugaredLogger
+	Scheme           *runtime.Scheme
+	AtlasDomain      string
+	GlobalAPISecret  client.ObjectKey
+	GlobalPredicates []predicate.Predicate
+	EventRecorder    record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasapikeys,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasapikeys/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasapikeys,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasapikeys/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",namespace=default,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",namespace=default,resources=events,verbs=create;patch
+
+func (r *AtlasAPIKeyReconciler) Reconcile(context context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.With("atlasapikey", req.NamespacedName)
+
+	apiKey := &mdbv1.AtlasAPIKey{}
+	result := customresource.PrepareResource(r.Client, req, apiKey, log)
+	if !result.IsOk() {
+		return result.ReconcileResult(), nil
+	}
+
+	if shouldSkip := customresource.ReconciliationShouldBeSkipped(apiKey); shouldSkip {
+		log.Infow(fmt.Sprintf("-> Skipping AtlasAPIKey reconciliation as annotation %s=%s", customresource.ReconciliationPolicyAnnotation, customresource.ReconciliationPolicySkip), "spec", apiKey.Spec)
+		if !apiKey.GetDeletionTimestamp().IsZero() {
+			if err := r.removeDeletionFinalizer(context, apiKey); err != nil {
+				result = workflow.Terminate(workflow.Internal, err.Error())
+				log.Errorw("failed to remove finalizer", "error", err)
+				return result.ReconcileResult(), nil
+			}
+		}
+		return workflow.OK().ReconcileResult(), nil
+	}
+
+	ctx := customresource.MarkReconciliationStarted(r.Client, apiKey, log)
+	log.Infow("-> Starting AtlasAPIKey reconciliation", "spec", apiKey.Spec, "status", apiKey.Status)
+	defer statushandler.Update(ctx, r.Client, r.EventRecorder, apiKey)
+
+	resourceVersionIsValid := customresource.ValidateResourceVersion(ctx, apiKey, r.Log)
+	if !resourceVersionIsValid.IsOk() {
+		r.Log.Debugf("api key validation result: %v", resourceVersionIsValid)
+		return resourceVersionIsValid.ReconcileResult(), nil
+	}
+
+	if err := validate.APIKey(apiKey); err != nil {
+		result := workflow.Terminate(workflow.APIKeyInvalidSpec, err.Error())
+		ctx.SetConditionFromResult(status.ValidationSucceeded, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.SetConditionTrue(status.ValidationSucceeded)
+
+	project := &mdbv1.AtlasProject{}
+	if err := r.Client.Get(context, apiKey.AtlasProjectObjectKey(), project); err != nil {
+		result := workflow.Terminate(workflow.Internal, err.Error())
+		ctx.SetConditionFromResult(status.APIKeyReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	connection, err := atlas.ReadConnection(log, r.Client, r.GlobalAPISecret, project.ConnectionSecretObjectKey())
+	if err != nil {
+		result := workflow.Terminate(workflow.AtlasCredentialsNotProvided, err.Error())
+		ctx.SetConditionFromResult(status.APIKeyReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.Connection = connection
+
+	atlasClient, err := atlas.Client(r.AtlasDomain, connection, log)
+	if err != nil {
+		result := workflow.Terminate(workflow.Internal, err.Error())
+		ctx.SetConditionFromResult(status.APIKeyReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.Client = atlasClient
+
+	if apiKey.GetDeletionTimestamp().IsZero() {
+		if !customresource.HaveFinalizer(apiKey, customresource.FinalizerLabel) {
+			customresource.SetFinalizer(apiKey, customresource.FinalizerLabel)
+			if err = r.Client.Update(context, apiKey); err != nil {
+				result = workflow.Terminate(workflow.Internal, err.Error())
+				log.Errorw("failed to add finalizer", "error", err)
+				return result.ReconcileResult(), nil
+			}
+		}
+	} else {
+		if !customresource.HaveFinalizer(apiKey, customresource.FinalizerLabel) {
+			return workflow.OK().ReconcileResult(), nil
+		}
+		if customresource.ResourceShouldBeLeftInAtlas(apiKey) {
+			log.Infof("Not removing the API key from Atlas as the '%s' annotation is set", customresource.ResourcePolicyAnnotation)
+		} else if err = r.deleteAPIKeyFromAtlas(ctx, apiKey); err != nil {
+			log.Errorf("failed to remove the API key from Atlas: %s", err)
+			result = workflow.Terminate(workflow.Internal, err.Error())
+			ctx.SetConditionFromResult(status.APIKeyReadyType, result)
+			return result.ReconcileResult(), nil
+		}
+		if err = r.removeDeletionFinalizer(context, apiKey); err != nil {
+			result = workflow.Terminate(workflow.Internal, err.Error())
+			log.Errorw("failed to remove finalizer", "error", err)
+			return result.ReconcileResult(), nil
+		}
+		return workflow.OK().ReconcileResult(), nil
+	}
+
+	if result = r.ensureAPIKey(ctx, project.ID(), apiKey); !result.IsOk() {
+		ctx.SetConditionFromResult(status.APIKeyReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	ctx.SetConditionTrue(status.APIKeyReadyType)
+	ctx.SetConditionTrue(status.ReadyType)
+	return workflow.OK().ReconcileResult(), nil
+}
+
+func (r *AtlasAPIKeyReconciler) removeDeletionFinalizer(ctx context.Context, apiKey *mdbv1.AtlasAPIKey) error {
+	err := r.Client.Get(ctx, kube.ObjectKeyFromObject(apiKey), apiKey)
+	if err != nil {
+		return fmt.Errorf("cannot get AtlasAPIKey while removing finalizer: %w", err)
+	}
+
+	customresource.UnsetFinalizer(apiKey, customresource.FinalizerLabel)
+	if err = r.Client.Update(ctx, apiKey); err != nil {
+		return fmt.Errorf("failed to remove deletion finalizer from %s: %w", apiKey.Name, err)
+	}
+	return nil
+}
+
+func (r *AtlasAPIKeyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c, err := controller.New("AtlasAPIKey", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource AtlasAPIKey
+	err = c.Watch(&source.Kind{Type: &mdbv1.AtlasAPIKey{}}, &handler.EnqueueRequestForObject{}, r.GlobalPredicates...)
+	if err != nil {
+		return err
+	}
+
+	// The rotation is requested by changing the annotation which doesn't bump the generation of the resource
+	err = c.Watch(&source.Kind{Type: &mdbv1.AtlasAPIKey{}}, &handler.EnqueueRequestForObject{}, watch.AnnotationChanged(mdbv1.APIKeyRotationAnnotation))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -190,6 +190,12 @@ func (r AtlasDatabaseUserReconciler) Delete(e event.DeleteEvent) error {
 	// We ignore the error as it will be printed by the function
 	_ = connectionsecret.RemoveStaleSecretsByUserName(r.Client, project.ID(), dbUser.Spec.Username, *dbUser, log)
 
+	if dbUser.HasManagedCertificate() {
+		if err := removeX509Certificate(r.Client, *dbUser); err != nil {
+			log.Errorf("Failed to remove the X.509 certificate Secret: %s", err)
+		}
+	}
+
 	return nil
 }
 
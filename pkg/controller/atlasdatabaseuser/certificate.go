@@ -0,0 +1,150 @@
+package atlasdatabaseuser
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/timeutil"
+)
+
+const (
+	certificateTypeLabelKey = "atlas.mongodb.com/type"
+	certificateLabelVal     = "x509-certificate"
+)
+
+// ensureX509Certificate makes sure the Secret keeps a valid Atlas-managed certificate of the user. The certificate is
+// requested from Atlas if it's missing, was issued for another username or is getting close to the expiration.
+func ensureX509Certificate(ctx *workflow.Context, k8sClient client.Client, projectID string, dbUser mdbv1.AtlasDatabaseUser) workflow.Result {
+	if !dbUser.HasManagedCertificate() {
+		if dbUser.Status.CertificateExpiration != "" {
+			if err := removeX509Certificate(k8sClient, dbUser); err != nil {
+				return workflow.Terminate(workflow.Internal, err.Error())
+			}
+			ctx.EnsureStatusOption(status.AtlasDatabaseUserCertificateExpirationOption(""))
+		}
+		return workflow.OK()
+	}
+
+	secret := &corev1.Secret{}
+	err := k8sClient.Get(context.Background(), dbUser.X509CertificateSecretObjectKey(), secret)
+	if err != nil && !apiErrors.IsNotFound(err) {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+	if err == nil {
+		cert, parseErr := parseCertificate(secret.Data[corev1.TLSCertKey])
+		if parseErr == nil && !certificateNeedsRenewal(cert, dbUser.Spec.Username, time.Now()) {
+			ctx.EnsureStatusOption(status.AtlasDatabaseUserCertificateExpirationOption(timeutil.FormatISO8601(cert.NotAfter)))
+			return workflow.OK()
+		}
+	}
+
+	ctx.Log.Infow("Requesting X.509 certificate for the database user from Atlas", "username", dbUser.Spec.Username)
+	userCertificate, _, err := ctx.Client.X509AuthDBUsers.CreateUserCertificate(context.Background(), projectID, dbUser.Spec.Username, dbUser.X509CertificateMonthsUntilExpiration())
+	if err != nil {
+		return workflow.Terminate(workflow.DatabaseUserCertificateNotCreated, err.Error())
+	}
+	certPEM, keyPEM, err := splitCertificatePEM([]byte(userCertificate.Certificate))
+	if err != nil {
+		return workflow.Terminate(workflow.DatabaseUserCertificateNotCreated, err.Error())
+	}
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return workflow.Terminate(workflow.DatabaseUserCertificateNotCreated, err.Error())
+	}
+
+	if err = saveX509Certificate(k8sClient, dbUser, certPEM, keyPEM); err != nil {
+		return workflow.Terminate(workflow.DatabaseUserCertificateNotCreated, err.Error())
+	}
+	ctx.EnsureStatusOption(status.AtlasDatabaseUserCertificateExpirationOption(timeutil.FormatISO8601(cert.NotAfter)))
+
+	return workflow.OK()
+}
+
+// certificateNeedsRenewal returns true if the certificate doesn't belong to the user or less than a third of its
+// validity period remains
+func certificateNeedsRenewal(cert *x509.Certificate, username string, now time.Time) bool {
+	if cert.Subject.CommonName != username {
+		return true
+	}
+	renewAfter := cert.NotAfter.Add(-cert.NotAfter.Sub(cert.NotBefore) / 3)
+	return now.After(renewAfter)
+}
+
+func saveX509Certificate(k8sClient client.Client, dbUser mdbv1.AtlasDatabaseUser, certPEM, keyPEM []byte) error {
+	key := dbUser.X509CertificateSecretObjectKey()
+	secret := &corev1.Secret{}
+	err := k8sClient.Get(context.Background(), key, secret)
+	if err != nil && !apiErrors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+
+	if exists && secret.Type != corev1.SecretTypeTLS {
+		return fmt.Errorf("secret %s already exists and is not of %s type", key.Name, corev1.SecretTypeTLS)
+	}
+
+	secret.ObjectMeta = metav1.ObjectMeta{
+		Name:            key.Name,
+		Namespace:       key.Namespace,
+		ResourceVersion: secret.ResourceVersion,
+		Labels:          map[string]string{certificateTypeLabelKey: certificateLabelVal},
+	}
+	secret.Type = corev1.SecretTypeTLS
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey:       certPEM,
+		corev1.TLSPrivateKeyKey: keyPEM,
+	}
+
+	if exists {
+		return k8sClient.Update(context.Background(), secret)
+	}
+	return k8sClient.Create(context.Background(), secret)
+}
+
+// removeX509Certificate removes the Secret with the Atlas-managed certificate unless it was created by someone else
+func removeX509Certificate(k8sClient client.Client, dbUser mdbv1.AtlasDatabaseUser) error {
+	secret := &corev1.Secret{}
+	if err := k8sClient.Get(context.Background(), dbUser.X509CertificateSecretObjectKey(), secret); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if secret.Labels[certificateTypeLabelKey] != certificateLabelVal {
+		return nil
+	}
+	return client.IgnoreNotFound(k8sClient.Delete(context.Background(), secret))
+}
+
+// splitCertificatePEM separates the certificate and the private key which Atlas returns as a single PEM
+func splitCertificatePEM(data []byte) ([]byte, []byte, error) {
+	var certPEM, keyPEM []byte
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type == "CERTIFICATE" {
+			certPEM = append(certPEM, pem.EncodeToMemory(block)...)
+		} else {
+			keyPEM = append(keyPEM, pem.EncodeToMemory(block)...)
+		}
+	}
+	if len(certPEM) == 0 || len(keyPEM) == 0 {
+		return nil, nil, errors.New("the X.509 certificate returned by Atlas must contain both the certificate and the private key")
+	}
+	return certPEM, keyPEM, nil
+}
+
+func parseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
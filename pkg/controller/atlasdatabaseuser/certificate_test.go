@@ -0,0 +1,111 @@
+package atlasdatabaseuser
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+)
+
+func TestCertificateNeedsRenewal(t *testing.T) {
+	notBefore := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	cert := &x509.Certificate{
+		Subject:   pkix.Name{CommonName: "app"},
+		NotBefore: notBefore,
+		NotAfter:  notBefore.AddDate(0, 0, 90),
+	}
+
+	t.Run("Fresh certificate is kept", func(t *testing.T) {
+		assert.False(t, certificateNeedsRenewal(cert, "app", notBefore.AddDate(0, 0, 30)))
+	})
+	t.Run("Certificate close to expiration is renewed", func(t *testing.T) {
+		assert.True(t, certificateNeedsRenewal(cert, "app", notBefore.AddDate(0, 0, 61)))
+	})
+	t.Run("Certificate of another user is renewed", func(t *testing.T) {
+		assert.True(t, certificateNeedsRenewal(cert, "other-app", notBefore.AddDate(0, 0, 1)))
+	})
+}
+
+func TestSplitCertificatePEM(t *testing.T) {
+	t.Run("Certificate and private key are separated", func(t *testing.T) {
+		certPEM, keyPEM := generateCertificate(t, "app")
+
+		cert, key, err := splitCertificatePEM(append(append([]byte{}, keyPEM...), certPEM...))
+		require.NoError(t, err)
+		assert.Equal(t, certPEM, cert)
+		assert.Equal(t, keyPEM, key)
+
+		parsed, err := parseCertificate(cert)
+		require.NoError(t, err)
+		assert.Equal(t, "app", parsed.Subject.CommonName)
+	})
+	t.Run("Private key is required", func(t *testing.T) {
+		certPEM, _ := generateCertificate(t, "app")
+
+		_, _, err := splitCertificatePEM(certPEM)
+		assert.Error(t, err)
+	})
+}
+
+func TestSaveX509Certificate(t *testing.T) {
+	dbUser := mdbv1.DefaultDBUser("ns", "app", "project")
+	dbUser.Spec.X509Type = mdbv1.X509TypeManaged
+	certPEM, keyPEM := generateCertificate(t, "app")
+
+	t.Run("Secret is created", func(t *testing.T) {
+		k8sClient := fake.NewClientBuilder().Build()
+		require.NoError(t, saveX509Certificate(k8sClient, *dbUser, certPEM, keyPEM))
+
+		secret := &corev1.Secret{}
+		require.NoError(t, k8sClient.Get(context.Background(), dbUser.X509CertificateSecretObjectKey(), secret))
+		assert.Equal(t, "app-x509-certificate", secret.Name)
+		assert.Equal(t, corev1.SecretTypeTLS, secret.Type)
+		assert.Equal(t, certPEM, secret.Data[corev1.TLSCertKey])
+		assert.Equal(t, keyPEM, secret.Data[corev1.TLSPrivateKeyKey])
+
+		require.NoError(t, removeX509Certificate(k8sClient, *dbUser))
+		assert.Error(t, k8sClient.Get(context.Background(), dbUser.X509CertificateSecretObjectKey(), secret))
+	})
+	t.Run("Foreign Secret is not overwritten", func(t *testing.T) {
+		foreign := &corev1.Secret{}
+		foreign.Name = "app-x509-certificate"
+		foreign.Namespace = "ns"
+		k8sClient := fake.NewClientBuilder().WithObjects(foreign).Build()
+
+		assert.Error(t, saveX509Certificate(k8sClient, *dbUser, certPEM, keyPEM))
+		require.NoError(t, removeX509Certificate(k8sClient, *dbUser))
+		assert.NoError(t, k8sClient.Get(context.Background(), dbUser.X509CertificateSecretObjectKey(), &corev1.Secret{}))
+	})
+}
+
+func generateCertificate(t *testing.T, commonName string) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().AddDate(0, 3, 0),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
@@ -28,6 +28,8 @@ func (r *AtlasDatabaseUserReconciler) ensureDatabaseUser(ctx *workflow.Context,
 	if err != nil {
 		return workflow.Terminate(workflow.Internal, err.Error())
 	}
+	// The OIDC authentication type is not known to the Atlas client, so it's sent on top of it
+	desiredUser := &atlas.DatabaseUser{DatabaseUser: *apiUser, OIDCAuthType: dbUser.Spec.OIDCAuthType}
 
 	if result := checkUserExpired(ctx.Log, r.Client, project.ID(), dbUser); !result.IsOk() {
 		return result
@@ -37,7 +39,7 @@ func (r *AtlasDatabaseUserReconciler) ensureDatabaseUser(ctx *workflow.Context,
 		return workflow.Terminate(workflow.DatabaseUserInvalidSpec, err.Error())
 	}
 
-	if result := performUpdateInAtlas(ctx, r.Client, project, dbUser, apiUser); !result.IsOk() {
+	if result := performUpdateInAtlas(ctx, r.Client, project, dbUser, desiredUser); !result.IsOk() {
 		return result
 	}
 
@@ -45,6 +47,10 @@ func (r *AtlasDatabaseUserReconciler) ensureDatabaseUser(ctx *workflow.Context,
 		return result
 	}
 
+	if result := ensureX509Certificate(ctx, r.Client, project.ID(), dbUser); !result.IsOk() {
+		return result
+	}
+
 	if result := connectionsecret.CreateOrUpdateConnectionSecrets(ctx, r.Client, r.EventRecorder, project, dbUser); !result.IsOk() {
 		return result
 	}
@@ -97,7 +103,7 @@ func checkUserExpired(log *zap.SugaredLogger, k8sClient client.Client, projectID
 	return workflow.OK()
 }
 
-func performUpdateInAtlas(ctx *workflow.Context, k8sClient client.Client, project mdbv1.AtlasProject, dbUser mdbv1.AtlasDatabaseUser, apiUser *mongodbatlas.DatabaseUser) workflow.Result {
+func performUpdateInAtlas(ctx *workflow.Context, k8sClient client.Client, project mdbv1.AtlasProject, dbUser mdbv1.AtlasDatabaseUser, apiUser *atlas.DatabaseUser) workflow.Result {
 	log := ctx.Log
 
 	secret := &corev1.Secret{}
@@ -112,13 +118,15 @@ func performUpdateInAtlas(ctx *workflow.Context, k8sClient client.Client, projec
 
 	retryAfterUpdate := workflow.InProgress(workflow.DatabaseUserDeploymentAppliedChanges, "Clusters are scheduled to handle database users updates")
 
+	service := atlas.NewDatabaseUsersService(&ctx.Client)
+
 	// Try to find the user
-	u, _, err := ctx.Client.DatabaseUsers.Get(context.Background(), dbUser.Spec.DatabaseName, project.ID(), dbUser.Spec.Username)
+	u, _, err := service.Get(context.Background(), dbUser.Spec.DatabaseName, project.ID(), dbUser.Spec.Username)
 	if err != nil {
 		var apiError *mongodbatlas.ErrorResponse
 		if errors.As(err, &apiError) && apiError.ErrorCode == atlas.UsernameNotFound {
 			log.Debugw("User doesn't exist. Create new user", "apiUser", apiUser)
-			if _, _, err = ctx.Client.DatabaseUsers.Create(context.Background(), project.ID(), apiUser); err != nil {
+			if _, _, err = service.Create(context.Background(), project.ID(), apiUser); err != nil {
 				return workflow.Terminate(workflow.DatabaseUserNotCreatedInAtlas, err.Error())
 			}
 			ctx.EnsureStatusOption(status.AtlasDatabaseUserPasswordVersion(currentPasswordResourceVersion))
@@ -133,7 +141,7 @@ func performUpdateInAtlas(ctx *workflow.Context, k8sClient client.Client, projec
 	if shouldUpdate, err := shouldUpdate(ctx.Log, u, dbUser, currentPasswordResourceVersion); err != nil {
 		return workflow.Terminate(workflow.Internal, err.Error())
 	} else if shouldUpdate {
-		_, _, err = ctx.Client.DatabaseUsers.Update(context.Background(), project.ID(), dbUser.Spec.Username, apiUser)
+		_, _, err = service.Update(context.Background(), project.ID(), dbUser.Spec.Username, apiUser)
 		if err != nil {
 			return workflow.Terminate(workflow.DatabaseUserNotUpdatedInAtlas, err.Error())
 		}
@@ -218,7 +226,7 @@ func filterScopeDeployments(user mdbv1.AtlasDatabaseUser, allDeploymentsInProjec
 	return deploymentsToCheck
 }
 
-func shouldUpdate(log *zap.SugaredLogger, atlasSpec *mongodbatlas.DatabaseUser, operatorDBUser mdbv1.AtlasDatabaseUser, currentPasswordResourceVersion string) (bool, error) {
+func shouldUpdate(log *zap.SugaredLogger, atlasSpec *atlas.DatabaseUser, operatorDBUser mdbv1.AtlasDatabaseUser, currentPasswordResourceVersion string) (bool, error) {
 	matches, err := userMatchesSpec(log, atlasSpec, operatorDBUser.Spec)
 	if err != nil {
 		return false, err
@@ -235,8 +243,8 @@ func shouldUpdate(log *zap.SugaredLogger, atlasSpec *mongodbatlas.DatabaseUser,
 }
 
 // TODO move to a separate utils (reuse from deployments)
-func userMatchesSpec(log *zap.SugaredLogger, atlasSpec *mongodbatlas.DatabaseUser, operatorSpec mdbv1.AtlasDatabaseUserSpec) (bool, error) {
-	userMerged := mongodbatlas.DatabaseUser{}
+func userMatchesSpec(log *zap.SugaredLogger, atlasSpec *atlas.DatabaseUser, operatorSpec mdbv1.AtlasDatabaseUserSpec) (bool, error) {
+	userMerged := atlas.DatabaseUser{}
 	if err := compat.JSONCopy(&userMerged, atlasSpec); err != nil {
 		return false, err
 	}
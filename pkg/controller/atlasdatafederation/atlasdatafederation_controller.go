@@ -0,0 +1,240 @@
+/*
+Copyright 2023 MongoDB.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package atlasdatafederation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/connectionsecret"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/statushandler"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/validate"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/watch"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+)
+
+// AtlasDataFederationReconciler reconciles an AtlasDataFederation object
+type AtlasDataFederationReconciler struct {
+	watch.ResourceWatcher
+	Client           client.Client
+	Log              *zap.SugaredLogger
+	Scheme           *runtime.Scheme
+	AtlasDomain      string
+	GlobalAPISecret  client.ObjectKey
+	GlobalPredicates []predicate.Predicate
+	EventRecorder    record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasdatafederations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasdatafederations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasdatafederations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasdatafederations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",namespace=default,resources=events,verbs=create;patch
+
+func (r *AtlasDataFederationReconciler) Reconcile(context context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.With("atlasdatafederation", req.NamespacedName)
+
+	dataFederation := &mdbv1.AtlasDataFederation{}
+	result := customresource.PrepareResource(r.Client, req, dataFederation, log)
+	if !result.IsOk() {
+		return result.ReconcileResult(), nil
+	}
+
+	if shouldSkip := customresource.ReconciliationShouldBeSkipped(dataFederation); shouldSkip {
+		log.Infow(fmt.Sprintf("-> Skipping AtlasDataFederation reconciliation as annotation %s=%s", customresource.ReconciliationPolicyAnnotation, customresource.ReconciliationPolicySkip), "spec", dataFederation.Spec)
+		if !dataFederation.GetDeletionTimestamp().IsZero() {
+			if err := r.removeDeletionFinalizer(context, dataFederation); err != nil {
+				result = workflow.Terminate(workflow.Internal, err.Error())
+				log.Errorw("failed to remove finalizer", "error", err)
+				return result.ReconcileResult(), nil
+			}
+		}
+		return workflow.OK().ReconcileResult(), nil
+	}
+
+	ctx := customresource.MarkReconciliationStarted(r.Client, dataFederation, log)
+	log.Infow("-> Starting AtlasDataFederation reconciliation", "spec", dataFederation.Spec, "status", dataFederation.Status)
+	defer statushandler.Update(ctx, r.Client, r.EventRecorder, dataFederation)
+
+	resourceVersionIsValid := customresource.ValidateResourceVersion(ctx, dataFederation, r.Log)
+	if !resourceVersionIsValid.IsOk() {
+		r.Log.Debugf("data federation validation result: %v", resourceVersionIsValid)
+		return resourceVersionIsValid.ReconcileResult(), nil
+	}
+
+	if err := validate.DataFederation(dataFederation); err != nil {
+		result := workflow.Terminate(workflow.DataFederationInvalidSpec, err.Error())
+		ctx.SetConditionFromResult(status.ValidationSucceeded, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.SetConditionTrue(status.ValidationSucceeded)
+
+	project := &mdbv1.AtlasProject{}
+	if err := r.Client.Get(context, dataFederation.AtlasProjectObjectKey(), project); err != nil {
+		result := workflow.Terminate(workflow.Internal, err.Error())
+		ctx.SetConditionFromResult(status.DataFederationReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	connection, err := atlas.ReadConnection(log, r.Client, r.GlobalAPISecret, project.ConnectionSecretObjectKey())
+	if err != nil {
+		result := workflow.Terminate(workflow.AtlasCredentialsNotProvided, err.Error())
+		ctx.SetConditionFromResult(status.DataFederationReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.Connection = connection
+
+	atlasClient, err := atlas.Client(r.AtlasDomain, connection, log)
+	if err != nil {
+		result := workflow.Terminate(workflow.Internal, err.Error())
+		ctx.SetConditionFromResult(status.DataFederationReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.Client = atlasClient
+
+	if dataFederation.GetDeletionTimestamp().IsZero() {
+		if !customresource.HaveFinalizer(dataFederation, customresource.FinalizerLabel) {
+			customresource.SetFinalizer(dataFederation, customresource.FinalizerLabel)
+			if err = r.Client.Update(context, dataFederation); err != nil {
+				result = workflow.Terminate(workflow.Internal, err.Error())
+				log.Errorw("failed to add finalizer", "error", err)
+				return result.ReconcileResult(), nil
+			}
+		}
+	} else {
+		if !customresource.HaveFinalizer(dataFederation, customresource.FinalizerLabel) {
+			return workflow.OK().ReconcileResult(), nil
+		}
+		if customresource.ResourceShouldBeLeftInAtlas(dataFederation) {
+			log.Infof("Not removing the federated database instance from Atlas as the '%s' annotation is set", customresource.ResourcePolicyAnnotation)
+		} else if err = r.deleteDataFederationFromAtlas(context, project, dataFederation, atlasClient, log); err != nil {
+			log.Errorf("failed to remove the federated database instance from Atlas: %s", err)
+			result = workflow.Terminate(workflow.Internal, err.Error())
+			ctx.SetConditionFromResult(status.DataFederationReadyType, result)
+			return result.ReconcileResult(), nil
+		}
+		if err = r.removeDeletionFinalizer(context, dataFederation); err != nil {
+			result = workflow.Terminate(workflow.Internal, err.Error())
+			log.Errorw("failed to remove finalizer", "error", err)
+			return result.ReconcileResult(), nil
+		}
+		return workflow.OK().ReconcileResult(), nil
+	}
+
+	instance, result := ensureDataFederation(ctx, project, dataFederation)
+	if instance != nil {
+		ctx.
+			EnsureStatusOption(status.AtlasDataFederationStateOption(instance.State)).
+			EnsureStatusOption(status.AtlasDataFederationHostnamesOption(instance.Hostnames)).
+			EnsureStatusOption(status.AtlasDataFederationConnectionStringOption(instance.ConnectionString()))
+	}
+	if !result.IsOk() {
+		ctx.SetConditionFromResult(status.DataFederationReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	if result = r.ensureConnectionSecrets(ctx, project, dataFederation, instance); !result.IsOk() {
+		ctx.SetConditionFromResult(status.DataFederationReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	ctx.SetConditionTrue(status.DataFederationReadyType)
+	ctx.SetConditionTrue(status.ReadyType)
+	return workflow.OK().ReconcileResult(), nil
+}
+
+func (r *AtlasDataFederationReconciler) deleteDataFederationFromAtlas(
+	ctx context.Context,
+	project *mdbv1.AtlasProject,
+	dataFederation *mdbv1.AtlasDataFederation,
+	atlasClient mongodbatlas.Client,
+	log *zap.SugaredLogger,
+) error {
+	log.Infow("-> Starting AtlasDataFederation deletion", "spec", dataFederation.Spec)
+
+	// We always remove the connection secrets even if the instance is not removed from Atlas
+	secrets, err := connectionsecret.ListByDeploymentName(r.Client, "", project.ID(), dataFederation.Spec.Name)
+	if err != nil {
+		return fmt.Errorf("failed to find connection secrets for the federated database instance: %w", err)
+	}
+	for i := range secrets {
+		if err = r.Client.Delete(ctx, &secrets[i]); err != nil && !k8serrors.IsNotFound(err) {
+			log.Errorw("Failed to delete secret", "secretName", secrets[i].Name, "error", err)
+		}
+	}
+
+	_, err = atlas.NewDataFederationService(&atlasClient).Delete(ctx, project.ID(), dataFederation.Spec.Name)
+	var apiError *mongodbatlas.ErrorResponse
+	if errors.As(err, &apiError) && apiError.HTTPCode == http.StatusNotFound {
+		log.Info("Federated database instance doesn't exist or is already deleted")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Infow("Federated database instance removed from Atlas", "projectID", project.ID(), "name", dataFederation.Spec.Name)
+	return nil
+}
+
+func (r *AtlasDataFederationReconciler) removeDeletionFinalizer(ctx context.Context, dataFederation *mdbv1.AtlasDataFederation) error {
+	err := r.Client.Get(ctx, kube.ObjectKeyFromObject(dataFederation), dataFederation)
+	if err != nil {
+		return fmt.Errorf("cannot get AtlasDataFederation while removing finalizer: %w", err)
+	}
+
+	customresource.UnsetFinalizer(dataFederation, customresource.FinalizerLabel)
+	if err = r.Client.Update(ctx, dataFederation); err != nil {
+		return fmt.Errorf("failed to remove deletion finalizer from %s: %w", dataFederation.Spec.Name, err)
+	}
+	return nil
+}
+
+func (r *AtlasDataFederationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c, err := controller.New("AtlasDataFederation", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource AtlasDataFederation
+	err = c.Watch(&source.Kind{Type: &mdbv1.AtlasDataFederation{}}, &handler.EnqueueRequestForObject{}, r.GlobalPredicates...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
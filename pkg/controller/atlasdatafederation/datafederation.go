@@ -0,0 +1,197 @@
+package atlasdatafederation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.mongodb.org/atlas/mongodbatlas"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/connectionsecret"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/compat"
+)
+
+// ensureDataFederation creates the federated database instance in Atlas or updates it if the spec has changed
+func ensureDataFederation(ctx *workflow.Context, project *mdbv1.AtlasProject, dataFederation *mdbv1.AtlasDataFederation) (*atlas.DataFederationInstance, workflow.Result) {
+	service := atlas.NewDataFederationService(&ctx.Client)
+
+	desired, err := dataFederationToAtlas(project, dataFederation)
+	if err != nil {
+		return nil, workflow.Terminate(workflow.DataFederationInvalidSpec, err.Error())
+	}
+
+	instance, _, err := service.Get(context.Background(), project.ID(), dataFederation.Spec.Name)
+	if err != nil {
+		var apiError *mongodbatlas.ErrorResponse
+		if !errors.As(err, &apiError) || apiError.HTTPCode != http.StatusNotFound {
+			return nil, workflow.Terminate(workflow.DataFederationNotCreatedInAtlas, err.Error())
+		}
+
+		ctx.Log.Infof("Federated database instance %s doesn't exist in Atlas - creating", dataFederation.Spec.Name)
+		instance, _, err = service.Create(context.Background(), project.ID(), desired)
+		if err != nil {
+			return nil, workflow.Terminate(workflow.DataFederationNotCreatedInAtlas, err.Error())
+		}
+		return instance, workflow.OK()
+	}
+
+	if dataFederationEqual(desired, instance) {
+		return instance, workflow.OK()
+	}
+
+	ctx.Log.Infow("Federated database instance differs from the spec - updating", "name", dataFederation.Spec.Name)
+	instance, _, err = service.Update(context.Background(), project.ID(), dataFederation.Spec.Name, desired)
+	if err != nil {
+		return nil, workflow.Terminate(workflow.DataFederationNotUpdatedInAtlas, err.Error())
+	}
+	return instance, workflow.OK()
+}
+
+// dataFederationToAtlas converts the spec into the Atlas representation. The store of the 'atlas' provider defaults to
+// the project of the instance and the AWS IAM role ARN is resolved into the cloud provider access role of the project.
+func dataFederationToAtlas(project *mdbv1.AtlasProject, dataFederation *mdbv1.AtlasDataFederation) (*atlas.DataFederationInstance, error) {
+	result := &atlas.DataFederationInstance{Name: dataFederation.Spec.Name}
+
+	if dataFederation.Spec.DataProcessRegion != nil {
+		result.DataProcessRegion = &atlas.DataFederationDataProcessRegion{}
+		if err := compat.JSONCopy(result.DataProcessRegion, dataFederation.Spec.DataProcessRegion); err != nil {
+			return nil, err
+		}
+	}
+
+	if dataFederation.Spec.Storage != nil {
+		result.Storage = &atlas.DataFederationStorage{}
+		if err := compat.JSONCopy(result.Storage, dataFederation.Spec.Storage); err != nil {
+			return nil, err
+		}
+		for i, store := range result.Storage.Stores {
+			if store.Provider == string(mdbv1.DataFederationStoreAtlas) && store.ProjectID == "" {
+				result.Storage.Stores[i].ProjectID = project.ID()
+			}
+		}
+	}
+
+	if dataFederation.Spec.CloudProviderConfig != nil && dataFederation.Spec.CloudProviderConfig.AWS != nil {
+		aws := dataFederation.Spec.CloudProviderConfig.AWS
+		roleID := aws.RoleID
+		if roleID == "" {
+			var err error
+			if roleID, err = resolveRoleID(project, aws.IamAssumedRoleArn); err != nil {
+				return nil, err
+			}
+		}
+		result.CloudProviderConfig = &atlas.DataFederationCloudProviderConfig{
+			AWS: &atlas.DataFederationAWSConfig{
+				RoleID:       roleID,
+				TestS3Bucket: aws.TestS3Bucket,
+			},
+		}
+	}
+
+	return result, nil
+}
+
+func resolveRoleID(project *mdbv1.AtlasProject, iamAssumedRoleArn string) (string, error) {
+	for _, role := range project.Status.CloudProviderAccessRoles {
+		if role.IamAssumedRoleArn != iamAssumedRoleArn {
+			continue
+		}
+		if role.Status != status.StatusReady || role.RoleID == "" {
+			return "", fmt.Errorf("cloud provider access role %s is not ready in the project %s", iamAssumedRoleArn, project.Name)
+		}
+		return role.RoleID, nil
+	}
+	return "", fmt.Errorf("cloud provider access role %s is not configured in the project %s", iamAssumedRoleArn, project.Name)
+}
+
+// dataFederationEqual compares only the configuration managed by the operator, read-only fields returned by Atlas
+// (e.g. external ID of the AWS role) are ignored.
+func dataFederationEqual(desired, current *atlas.DataFederationInstance) bool {
+	if !cmp.Equal(desired.Storage, current.Storage, cmpopts.EquateEmpty()) {
+		return false
+	}
+	if desired.DataProcessRegion != nil && !cmp.Equal(desired.DataProcessRegion, current.DataProcessRegion) {
+		return false
+	}
+	if desired.CloudProviderConfig == nil || desired.CloudProviderConfig.AWS == nil {
+		return true
+	}
+	if current.CloudProviderConfig == nil || current.CloudProviderConfig.AWS == nil {
+		return false
+	}
+	return desired.CloudProviderConfig.AWS.RoleID == current.CloudProviderConfig.AWS.RoleID &&
+		desired.CloudProviderConfig.AWS.TestS3Bucket == current.CloudProviderConfig.AWS.TestS3Bucket
+}
+
+// ensureConnectionSecrets creates the connection secrets for the ready database users having the federated database
+// instance in their 'DATA_LAKE' scopes.
+func (r *AtlasDataFederationReconciler) ensureConnectionSecrets(ctx *workflow.Context, project *mdbv1.AtlasProject, dataFederation *mdbv1.AtlasDataFederation, instance *atlas.DataFederationInstance) workflow.Result {
+	connURL := instance.ConnectionString()
+	if connURL == "" {
+		return workflow.InProgress(workflow.DataFederationNotCreatedInAtlas, "Waiting for the federated database instance hostnames")
+	}
+
+	databaseUsers := mdbv1.AtlasDatabaseUserList{}
+	if err := r.Client.List(context.Background(), &databaseUsers); err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+
+	secrets := make([]string, 0)
+	for i := range databaseUsers.Items {
+		dbUser := databaseUsers.Items[i]
+		if !dbUserBelongsToProject(&dbUser, project) {
+			continue
+		}
+		if !connectionsecret.HasAccess(dbUser, mdbv1.DataLakeScopeType, dataFederation.Spec.Name) {
+			continue
+		}
+		if !dbUserIsReady(&dbUser) {
+			ctx.Log.Debugw("AtlasDatabaseUser not ready - not creating connection secret", "user.name", dbUser.Name)
+			continue
+		}
+
+		password, err := dbUser.ReadPassword(r.Client)
+		if err != nil {
+			return workflow.Terminate(workflow.DataFederationNotCreatedInAtlas, err.Error())
+		}
+		data := connectionsecret.ConnectionData{
+			DBUserName: dbUser.Spec.Username,
+			Password:   password,
+			ConnURL:    connURL,
+		}
+		secretName, err := connectionsecret.Ensure(r.Client, dbUser.Namespace, project.Spec.Name, project.ID(), dataFederation.Spec.Name, data)
+		if err != nil {
+			return workflow.Terminate(workflow.DataFederationNotCreatedInAtlas, err.Error())
+		}
+		secrets = append(secrets, secretName)
+	}
+
+	if len(secrets) > 0 {
+		r.EventRecorder.Eventf(dataFederation, "Normal", connectionsecret.ConnectionSecretsEnsuredEvent, "Connection Secrets were created/updated: %s", strings.Join(secrets, ", "))
+	}
+
+	return workflow.OK()
+}
+
+func dbUserIsReady(dbUser *mdbv1.AtlasDatabaseUser) bool {
+	for _, c := range dbUser.Status.Conditions {
+		if c.Type == status.ReadyType && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func dbUserBelongsToProject(dbUser *mdbv1.AtlasDatabaseUser, project *mdbv1.AtlasProject) bool {
+	return dbUser.AtlasProjectObjectKey() == client.ObjectKeyFromObject(project)
+}
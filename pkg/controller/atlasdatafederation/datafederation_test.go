@@ -0,0 +1,110 @@
+package atlasdatafederation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+)
+
+func testProject() *mdbv1.AtlasProject {
+	project := mdbv1.DefaultProject("ns", "secret")
+	project.Status.ID = "project-id"
+	project.Status.CloudProviderAccessRoles = []status.CloudProviderAccessRole{
+		{ProviderName: "AWS", IamAssumedRoleArn: "arn:aws:iam::123:role/ready", RoleID: "role-id", Status: status.StatusReady},
+		{ProviderName: "AWS", IamAssumedRoleArn: "arn:aws:iam::123:role/created", Status: status.StatusCreated},
+	}
+	return project
+}
+
+func TestDataFederationToAtlas(t *testing.T) {
+	t.Run("atlas store defaults to the project", func(t *testing.T) {
+		dataFederation := mdbv1.NewDataFederationInstance("ns", "df", "df-name", "project").
+			WithStore(mdbv1.DataFederationStore{Name: "local", Provider: mdbv1.DataFederationStoreAtlas, ClusterName: "Cluster0"}).
+			WithStore(mdbv1.DataFederationStore{Name: "remote", Provider: mdbv1.DataFederationStoreAtlas, ClusterName: "Cluster1", ProjectID: "other"}).
+			WithStore(mdbv1.DataFederationStore{Name: "http", Provider: mdbv1.DataFederationStoreHTTP, URLs: []string{"https://example.com/data.json"}})
+
+		result, err := dataFederationToAtlas(testProject(), dataFederation)
+		require.NoError(t, err)
+		assert.Equal(t, "df-name", result.Name)
+		assert.Equal(t, "project-id", result.Storage.Stores[0].ProjectID)
+		assert.Equal(t, "other", result.Storage.Stores[1].ProjectID)
+		assert.Empty(t, result.Storage.Stores[2].ProjectID)
+		assert.Equal(t, []string{"https://example.com/data.json"}, result.Storage.Stores[2].URLs)
+	})
+	t.Run("role arn is resolved to the role id", func(t *testing.T) {
+		dataFederation := mdbv1.NewDataFederationInstance("ns", "df", "df-name", "project")
+		dataFederation.Spec.CloudProviderConfig = &mdbv1.DataFederationCloudProviderConfig{
+			AWS: &mdbv1.DataFederationAWSConfig{IamAssumedRoleArn: "arn:aws:iam::123:role/ready", TestS3Bucket: "bucket"},
+		}
+
+		result, err := dataFederationToAtlas(testProject(), dataFederation)
+		require.NoError(t, err)
+		assert.Equal(t, &atlas.DataFederationAWSConfig{RoleID: "role-id", TestS3Bucket: "bucket"}, result.CloudProviderConfig.AWS)
+	})
+	t.Run("role is not ready", func(t *testing.T) {
+		dataFederation := mdbv1.NewDataFederationInstance("ns", "df", "df-name", "project")
+		dataFederation.Spec.CloudProviderConfig = &mdbv1.DataFederationCloudProviderConfig{
+			AWS: &mdbv1.DataFederationAWSConfig{IamAssumedRoleArn: "arn:aws:iam::123:role/created", TestS3Bucket: "bucket"},
+		}
+
+		_, err := dataFederationToAtlas(testProject(), dataFederation)
+		assert.Error(t, err)
+	})
+	t.Run("role is not configured in the project", func(t *testing.T) {
+		dataFederation := mdbv1.NewDataFederationInstance("ns", "df", "df-name", "project")
+		dataFederation.Spec.CloudProviderConfig = &mdbv1.DataFederationCloudProviderConfig{
+			AWS: &mdbv1.DataFederationAWSConfig{IamAssumedRoleArn: "arn:aws:iam::123:role/unknown", TestS3Bucket: "bucket"},
+		}
+
+		_, err := dataFederationToAtlas(testProject(), dataFederation)
+		assert.Error(t, err)
+	})
+}
+
+func TestDataFederationEqual(t *testing.T) {
+	desired := &atlas.DataFederationInstance{
+		Name: "df",
+		Storage: &atlas.DataFederationStorage{
+			Stores: []atlas.DataFederationStore{{Name: "local", Provider: "atlas", ClusterName: "Cluster0", ProjectID: "project-id"}},
+		},
+		CloudProviderConfig: &atlas.DataFederationCloudProviderConfig{
+			AWS: &atlas.DataFederationAWSConfig{RoleID: "role-id", TestS3Bucket: "bucket"},
+		},
+	}
+
+	t.Run("read-only fields are ignored", func(t *testing.T) {
+		current := &atlas.DataFederationInstance{
+			Name:      "df",
+			State:     "ACTIVE",
+			Hostnames: []string{"df.a.query.mongodb.net"},
+			Storage: &atlas.DataFederationStorage{
+				Databases: []atlas.DataFederationDatabase{},
+				Stores:    []atlas.DataFederationStore{{Name: "local", Provider: "atlas", ClusterName: "Cluster0", ProjectID: "project-id"}},
+			},
+			DataProcessRegion: &atlas.DataFederationDataProcessRegion{CloudProvider: "AWS", Region: "VIRGINIA_USA"},
+			CloudProviderConfig: &atlas.DataFederationCloudProviderConfig{
+				AWS: &atlas.DataFederationAWSConfig{RoleID: "role-id", TestS3Bucket: "bucket", ExternalID: "external"},
+			},
+		}
+		assert.True(t, dataFederationEqual(desired, current))
+	})
+	t.Run("store has changed", func(t *testing.T) {
+		current := &atlas.DataFederationInstance{
+			Name: "df",
+			Storage: &atlas.DataFederationStorage{
+				Stores: []atlas.DataFederationStore{{Name: "local", Provider: "atlas", ClusterName: "Cluster1", ProjectID: "project-id"}},
+			},
+			CloudProviderConfig: desired.CloudProviderConfig,
+		}
+		assert.False(t, dataFederationEqual(desired, current))
+	})
+	t.Run("cloud provider config is missing", func(t *testing.T) {
+		current := &atlas.DataFederationInstance{Name: "df", Storage: desired.Storage}
+		assert.False(t, dataFederationEqual(desired, current))
+	})
+}
@@ -18,26 +18,50 @@ import (
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/connectionsecret"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/compat"
-	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/stringutil"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
 )
 
 const FreeTier = "M0"
 
-func (r *AtlasDeploymentReconciler) ensureAdvancedDeploymentState(ctx *workflow.Context, project *mdbv1.AtlasProject, deployment *mdbv1.AtlasDeployment) (*mongodbatlas.AdvancedCluster, workflow.Result) {
-	advancedDeploymentSpec := deployment.Spec.AdvancedDeploymentSpec
-
-	advancedDeployment, resp, err := ctx.Client.AdvancedClusters.Get(context.Background(), project.Status.ID, advancedDeploymentSpec.Name)
+// fetchAdvancedDeployment returns the advanced deployment from Atlas once per reconciliation, the steps preparing the
+// update and the handler share it. No deployment is returned if it doesn't exist in Atlas or is already deleted.
+func fetchAdvancedDeployment(ctx *workflow.Context, projectID string, deployment *mdbv1.AtlasDeployment) (*mongodbatlas.AdvancedCluster, workflow.Result) {
+	if deployment.Spec.AdvancedDeploymentSpec == nil {
+		return nil, workflow.OK()
+	}
 
+	advancedDeployment, resp, err := ctx.Client.AdvancedClusters.Get(context.Background(), projectID, deployment.Spec.AdvancedDeploymentSpec.Name)
 	if err != nil {
 		if resp == nil {
-			return advancedDeployment, workflow.Terminate(workflow.Internal, err.Error())
+			return nil, workflow.Terminate(workflow.Internal, err.Error())
 		}
 
 		if resp.StatusCode != http.StatusNotFound {
-			return advancedDeployment, workflow.Terminate(workflow.DeploymentNotCreatedInAtlas, err.Error())
+			return nil, workflow.Terminate(workflow.DeploymentNotCreatedInAtlas, err.Error())
 		}
 
+		return nil, workflow.OK()
+	}
+
+	if advancedDeployment.StateName == status.StateDELETED {
+		return nil, workflow.OK()
+	}
+
+	return advancedDeployment, workflow.OK()
+}
+
+// ensureAdvancedDeploymentState creates the advanced deployment if it doesn't exist in Atlas or updates the current one
+// fetched by fetchAdvancedDeployment
+func (r *AtlasDeploymentReconciler) ensureAdvancedDeploymentState(ctx *workflow.Context, project *mdbv1.AtlasProject, deployment *mdbv1.AtlasDeployment, advancedDeployment *mongodbatlas.AdvancedCluster) (*mongodbatlas.AdvancedCluster, workflow.Result) {
+	advancedDeploymentSpec := deployment.Spec.AdvancedDeploymentSpec
+
+	// the deployment with the same name might still be being deleted in Atlas after the resource was recreated
+	if advancedDeployment != nil && advancedDeployment.StateName == status.StateDELETING {
+		return advancedDeployment, deploymentStateResult(advancedDeployment.StateName)
+	}
+
+	if advancedDeployment == nil {
+		var err error
 		advancedDeployment, err = advancedDeploymentSpec.ToAtlas()
 		if err != nil {
 			return advancedDeployment, workflow.Terminate(workflow.Internal, err.Error())
@@ -48,6 +72,10 @@ func (r *AtlasDeploymentReconciler) ensureAdvancedDeploymentState(ctx *workflow.
 		if err != nil {
 			return advancedDeployment, workflow.Terminate(workflow.DeploymentNotCreatedInAtlas, err.Error())
 		}
+		if deployment.Spec.RestoreFrom != nil {
+			// only the deployment created here is restored once it's IDLE
+			ctx.EnsureStatusOption(status.AtlasDeploymentRestoreOption(&status.DeploymentRestore{Phase: status.RestorePending}))
+		}
 	}
 
 	result := EnsureCustomZoneMapping(ctx, project.ID(), deployment.Spec.AdvancedDeploymentSpec.CustomZoneMapping, advancedDeployment.Name)
@@ -61,19 +89,35 @@ func (r *AtlasDeploymentReconciler) ensureAdvancedDeploymentState(ctx *workflow.
 	}
 
 	switch advancedDeployment.StateName {
-	case "IDLE":
-		return advancedDeploymentIdle(ctx, project, deployment, advancedDeployment)
-
-	case "CREATING":
-		return advancedDeployment, workflow.InProgress(workflow.DeploymentCreating, "deployment is provisioning")
+	case status.StateIDLE:
+		// the restored data must not race with the other changes of the deployment
+		if result = r.ensureRestore(ctx, project, deployment); !result.IsOk() {
+			return advancedDeployment, result
+		}
 
-	case "UPDATING", "REPAIRING":
-		return advancedDeployment, workflow.InProgress(workflow.DeploymentUpdating, "deployment is updating")
+		return advancedDeploymentIdle(ctx, project, deployment, advancedDeployment)
 
-	// TODO: add "DELETING", "DELETED", handle 404 on delete
+	default:
+		return advancedDeployment, deploymentStateResult(advancedDeployment.StateName)
+	}
+}
 
+// deploymentStateResult returns the result of the reconciliation of the deployment which can't be updated in its
+// current state in Atlas
+func deploymentStateResult(stateName string) workflow.Result {
+	switch stateName {
+	case status.StateCREATING:
+		return workflow.InProgress(workflow.DeploymentCreating, "deployment is provisioning")
+	case status.StateUPDATING:
+		return workflow.InProgress(workflow.DeploymentUpdating, "deployment is updating")
+	case status.StateREPAIRING:
+		return workflow.InProgress(workflow.DeploymentRepairing, "deployment is being repaired by Atlas")
+	case status.StateDELETING:
+		return workflow.InProgress(workflow.DeploymentDeleting, "deployment with the same name is being deleted in Atlas, it will be recreated once deleted")
+	case status.StateDELETED:
+		return workflow.InProgress(workflow.DeploymentDeleted, "deployment was deleted in Atlas, it will be recreated")
 	default:
-		return advancedDeployment, workflow.Terminate(workflow.Internal, fmt.Sprintf("unknown deployment state %q", advancedDeployment.StateName))
+		return workflow.InProgress(workflow.DeploymentStateUnknown, fmt.Sprintf("waiting for the deployment to leave the unknown state %q", stateName))
 	}
 }
 
@@ -256,6 +300,9 @@ func MergedAdvancedDeployment(atlasDeploymentAsAtlas mongodbatlas.AdvancedCluste
 		return
 	}
 
+	// search indexes are managed through the Atlas Search API and are not a part of the deployment
+	mergedDeployment.SearchIndexes = nil
+
 	for i, replicationSpec := range atlasDeployment.ReplicationSpecs {
 		for k, v := range replicationSpec.RegionConfigs {
 			// the response does not return backing provider names in some situations.
@@ -394,7 +441,7 @@ func isInstanceSizeTheSame(currentDeployment *mongodbatlas.AdvancedCluster, desi
 	return desiredInstanceSize == currentDeployment.ReplicationSpecs[0].RegionConfigs[0].ElectableSpecs.InstanceSize
 }
 
-func (r *AtlasDeploymentReconciler) ensureConnectionSecrets(ctx *workflow.Context, project *mdbv1.AtlasProject, name string, connectionStrings *mongodbatlas.ConnectionStrings, deploymentResource *mdbv1.AtlasDeployment) workflow.Result {
+func (r *AtlasDeploymentReconciler) ensureConnectionSecrets(ctx *workflow.Context, project *mdbv1.AtlasProject, name string, connectionStrings *mongodbatlas.ConnectionStrings, onlineArchiveConnURL string, deploymentResource *mdbv1.AtlasDeployment) workflow.Result {
 	databaseUsers := mdbv1.AtlasDatabaseUserList{}
 	err := r.Client.List(context.TODO(), &databaseUsers, &client.ListOptions{})
 	if err != nil {
@@ -422,25 +469,16 @@ func (r *AtlasDeploymentReconciler) ensureConnectionSecrets(ctx *workflow.Contex
 			continue
 		}
 
-		scopes := dbUser.GetScopes(mdbv1.DeploymentScopeType)
-		if len(scopes) != 0 && !stringutil.Contains(scopes, name) {
+		if !connectionsecret.HasAccess(dbUser, mdbv1.DeploymentScopeType, name) {
 			continue
 		}
 
-		password, err := dbUser.ReadPassword(r.Client)
+		data, err := connectionsecret.NewConnectionData(r.Client, dbUser, connectionStrings, onlineArchiveConnURL)
 		if err != nil {
 			return workflow.Terminate(workflow.DeploymentConnectionSecretsNotCreated, err.Error())
 		}
 
-		data := connectionsecret.ConnectionData{
-			DBUserName: dbUser.Spec.Username,
-			Password:   password,
-			ConnURL:    connectionStrings.Standard,
-			SrvConnURL: connectionStrings.StandardSrv,
-		}
-		connectionsecret.FillPrivateConnStrings(connectionStrings, &data)
-
-		ctx.Log.Debugw("Creating a connection Secret", "data", data)
+		ctx.Log.Debugw("Creating a connection Secret", "user", dbUser.Spec.Username)
 
 		secretName, err := connectionsecret.Ensure(r.Client, dbUser.Namespace, project.Spec.Name, project.ID(), name, data)
 		if err != nil {
@@ -1,19 +1,29 @@
 package atlasdeployment
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
 	"reflect"
 	"testing"
 
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/common"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/connectionsecret"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.mongodb.org/atlas/mongodbatlas"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -48,6 +58,17 @@ func TestMergedAdvancedDeployment(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, atlasRegionConfig.BackingProviderName, merged.ReplicationSpecs[0].RegionConfigs[0].BackingProviderName)
 	})
+
+	t.Run("Test merging clusters removes search indexes", func(t *testing.T) {
+		advancedCluster := mdbv1.DefaultAwsAdvancedDeployment("default", "my-project")
+		advancedCluster.Spec.AdvancedDeploymentSpec.SearchIndexes = []mdbv1.SearchIndex{
+			{Name: "default", Database: "db", CollectionName: "col", Mappings: &mdbv1.SearchIndexMappings{Dynamic: true}},
+		}
+
+		merged, _, err := MergedAdvancedDeployment(*defaultAtlas, *advancedCluster.Spec.AdvancedDeploymentSpec)
+		assert.NoError(t, err)
+		assert.Empty(t, merged.SearchIndexes)
+	})
 }
 
 func TestAdvancedDeploymentsEqual(t *testing.T) {
@@ -920,3 +941,189 @@ func TestDbUserBelongsToProjects(t *testing.T) {
 		assert.True(t, dbUserBelongsToProject(dbUser, project))
 	})
 }
+
+func TestDeploymentStateResult(t *testing.T) {
+	tests := []struct {
+		stateName      string
+		expectedReason workflow.ConditionReason
+	}{
+		{stateName: status.StateCREATING, expectedReason: workflow.DeploymentCreating},
+		{stateName: status.StateUPDATING, expectedReason: workflow.DeploymentUpdating},
+		{stateName: status.StateREPAIRING, expectedReason: workflow.DeploymentRepairing},
+		{stateName: status.StateDELETING, expectedReason: workflow.DeploymentDeleting},
+		{stateName: status.StateDELETED, expectedReason: workflow.DeploymentDeleted},
+		{stateName: "MIGRATING", expectedReason: workflow.DeploymentStateUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.stateName, func(t *testing.T) {
+			result := deploymentStateResult(tt.stateName)
+			assert.False(t, result.IsOk())
+			assert.Equal(t, workflow.InProgress(tt.expectedReason, result.GetMessage()), result)
+		})
+	}
+}
+
+// advancedDeploymentStub returns the deployment in the given state and records the deployments created
+type advancedDeploymentStub struct {
+	mongodbatlas.AdvancedClustersService
+
+	stateName string
+	notFound  bool
+	created   int
+}
+
+func (s *advancedDeploymentStub) Get(_ context.Context, _, name string) (*mongodbatlas.AdvancedCluster, *mongodbatlas.Response, error) {
+	if s.notFound {
+		return nil, &mongodbatlas.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, &mongodbatlas.ErrorResponse{ErrorCode: "CLUSTER_NOT_FOUND"}
+	}
+	return &mongodbatlas.AdvancedCluster{Name: name, StateName: s.stateName}, nil, nil
+}
+
+func (s *advancedDeploymentStub) Create(_ context.Context, _ string, cluster *mongodbatlas.AdvancedCluster) (*mongodbatlas.AdvancedCluster, *mongodbatlas.Response, error) {
+	s.created++
+	s.notFound, s.stateName = false, status.StateCREATING
+	return &mongodbatlas.AdvancedCluster{Name: cluster.Name, StateName: status.StateCREATING}, nil, nil
+}
+
+// globalClustersStub returns the deployment without the managed namespaces and custom zone mappings
+type globalClustersStub struct {
+	mongodbatlas.GlobalClustersService
+}
+
+func (s *globalClustersStub) Get(context.Context, string, string) (*mongodbatlas.GlobalCluster, *mongodbatlas.Response, error) {
+	return &mongodbatlas.GlobalCluster{}, nil, nil
+}
+
+func TestEnsureAdvancedDeploymentState(t *testing.T) {
+	tests := []struct {
+		name            string
+		stub            *advancedDeploymentStub
+		expectedReason  workflow.ConditionReason
+		expectedCreated int
+	}{
+		{name: "Deployment is created if it doesn't exist", stub: &advancedDeploymentStub{notFound: true}, expectedReason: workflow.DeploymentCreating, expectedCreated: 1},
+		{name: "Deployment is recreated once deleted", stub: &advancedDeploymentStub{stateName: status.StateDELETED}, expectedReason: workflow.DeploymentCreating, expectedCreated: 1},
+		{name: "Waiting for the deletion of the previous deployment", stub: &advancedDeploymentStub{stateName: status.StateDELETING}, expectedReason: workflow.DeploymentDeleting},
+		{name: "Waiting for the repair", stub: &advancedDeploymentStub{stateName: status.StateREPAIRING}, expectedReason: workflow.DeploymentRepairing},
+		{name: "Waiting in the unknown state", stub: &advancedDeploymentStub{stateName: "MIGRATING"}, expectedReason: workflow.DeploymentStateUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := workflow.NewContext(zap.S(), []status.Condition{})
+			ctx.Client = mongodbatlas.Client{AdvancedClusters: tt.stub, GlobalClusters: &globalClustersStub{}}
+			project := mdbv1.DefaultProject("ns", "connection")
+			project.Status.ID = "project-id"
+			deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+
+			current, result := fetchAdvancedDeployment(ctx, project.ID(), deployment)
+			assert.True(t, result.IsOk())
+			_, result = (&AtlasDeploymentReconciler{}).ensureAdvancedDeploymentState(ctx, project, deployment, current)
+			assert.Equal(t, workflow.InProgress(tt.expectedReason, result.GetMessage()), result)
+			assert.Equal(t, tt.expectedCreated, tt.stub.created)
+		})
+	}
+}
+
+func TestNewDeploymentWithRestoreFromIsMarkedForRestore(t *testing.T) {
+	ctx := workflow.NewContext(zap.S(), []status.Condition{})
+	ctx.Client = mongodbatlas.Client{AdvancedClusters: &advancedDeploymentStub{notFound: true}, GlobalClusters: &globalClustersStub{}}
+	project := mdbv1.DefaultProject("ns", "connection")
+	project.Status.ID = "project-id"
+	deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+	deployment.Spec.RestoreFrom = &mdbv1.RestoreFrom{DeploymentRef: common.ResourceRefNamespaced{Name: "source"}}
+
+	current, result := fetchAdvancedDeployment(ctx, project.ID(), deployment)
+	require.True(t, result.IsOk())
+	_, result = (&AtlasDeploymentReconciler{}).ensureAdvancedDeploymentState(ctx, project, deployment, current)
+
+	assert.Equal(t, workflow.DeploymentCreating, result.GetReason())
+	applyStatusOptions(ctx, deployment)
+	assert.Equal(t, &status.DeploymentRestore{Phase: status.RestorePending}, deployment.Status.Restore)
+}
+
+func TestTerminationProtectionIsSentToAtlas(t *testing.T) {
+	tests := []struct {
+		name  string
+		atlas *bool
+		spec  *bool
+	}{
+		{name: "Termination protection is enabled", atlas: toptr.MakePtr(false), spec: toptr.MakePtr(true)},
+		{name: "Termination protection is disabled", atlas: toptr.MakePtr(true), spec: toptr.MakePtr(false)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atlasDeployment := makeDefaultAtlasSpec()
+			fillInSpecs(atlasDeployment.ReplicationSpecs[0].RegionConfigs[0], "M10", "AWS")
+			atlasDeployment.TerminationProtectionEnabled = tt.atlas
+			deployment := mdbv1.DefaultAwsAdvancedDeployment("default", "my-project")
+			deployment.Spec.AdvancedDeploymentSpec.TerminationProtectionEnabled = tt.spec
+
+			merged, atlas, err := MergedAdvancedDeployment(*atlasDeployment, *deployment.Spec.AdvancedDeploymentSpec)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.atlas, atlas.TerminationProtectionEnabled)
+			assert.Equal(t, tt.spec, merged.TerminationProtectionEnabled)
+
+			request, err := merged.ToAtlas()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.spec, request.TerminationProtectionEnabled)
+		})
+	}
+}
+
+func TestEnsureConnectionSecrets(t *testing.T) {
+	project := mdbv1.DefaultProject("ns", "connection")
+	project.Status.ID = "project-id"
+	deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+	connectionStrings := &mongodbatlas.ConnectionStrings{
+		Standard:    "mongodb://cluster0-shard-00-00.example.mongodb.net:27017/?ssl=true",
+		StandardSrv: "mongodb+srv://cluster0.example.mongodb.net",
+	}
+	newReadyUser := func(username string) *mdbv1.AtlasDatabaseUser {
+		dbUser := mdbv1.DefaultDBUser("ns", "user", project.Name)
+		dbUser.Spec.Username = username
+		dbUser.Spec.PasswordSecret = nil
+		dbUser.Status.Conditions = []status.Condition{{Type: status.ReadyType, Status: corev1.ConditionTrue}}
+		return dbUser
+	}
+	ensureSecret := func(t *testing.T, objects ...client.Object) corev1.Secret {
+		scheme := runtime.NewScheme()
+		utilruntime.Must(corev1.AddToScheme(scheme))
+		utilruntime.Must(mdbv1.AddToScheme(scheme))
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+		reconciler := &AtlasDeploymentReconciler{Client: k8sClient, EventRecorder: record.NewFakeRecorder(10)}
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+
+		result := reconciler.ensureConnectionSecrets(ctx, project, deployment.GetDeploymentName(), connectionStrings, "", deployment)
+		require.True(t, result.IsOk())
+
+		secrets, err := connectionsecret.ListByUserName(k8sClient, "ns", project.ID(), objects[0].(*mdbv1.AtlasDatabaseUser).Spec.Username)
+		require.NoError(t, err)
+		require.Len(t, secrets, 1)
+		return secrets[0]
+	}
+
+	t.Run("AWS IAM user connects with the IAM credentials of the client", func(t *testing.T) {
+		dbUser := newReadyUser("arn:aws:iam::123456789012:role/app")
+		dbUser.Spec.AWSIAMType = mdbv1.AWSIAMTypeRole
+
+		secret := ensureSecret(t, dbUser)
+
+		assert.Equal(t, "mongodb+srv://cluster0.example.mongodb.net?authMechanism=MONGODB-AWS&authSource=%24external", string(secret.Data["connectionStringStandardSrv"]))
+		assert.Equal(t, "mongodb://cluster0-shard-00-00.example.mongodb.net:27017/?authMechanism=MONGODB-AWS&authSource=%24external&ssl=true", string(secret.Data["connectionStringStandard"]))
+	})
+	t.Run("Atlas-managed X.509 user keeps the certificate", func(t *testing.T) {
+		dbUser := newReadyUser("CN=app")
+		dbUser.Spec.X509Type = mdbv1.X509TypeManaged
+		certificate := &corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{Name: dbUser.X509CertificateSecretObjectKey().Name, Namespace: "ns"},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{corev1.TLSCertKey: []byte("certificate"), corev1.TLSPrivateKeyKey: []byte("key")},
+		}
+
+		secret := ensureSecret(t, dbUser, certificate)
+
+		assert.Equal(t, "mongodb+srv://cluster0.example.mongodb.net?authMechanism=MONGODB-X509&authSource=%24external", string(secret.Data["connectionStringStandardSrv"]))
+		assert.Equal(t, []byte("certificate"), secret.Data[corev1.TLSCertKey])
+		assert.Equal(t, []byte("key"), secret.Data[corev1.TLSPrivateKeyKey])
+	})
+}
@@ -20,7 +20,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 
@@ -42,11 +44,13 @@ import (
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/connectionsecret"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/stalled"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/statushandler"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/validate"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/watch"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
 )
 
 // AtlasDeploymentReconciler reconciles an AtlasDeployment object
@@ -59,6 +63,9 @@ type AtlasDeploymentReconciler struct {
 	GlobalAPISecret  client.ObjectKey
 	GlobalPredicates []predicate.Predicate
 	EventRecorder    record.EventRecorder
+
+	// StalledOperationTimeouts are the timeouts of the long-running Atlas operations by the kinds of operations
+	StalledOperationTimeouts stalled.Timeouts
 }
 
 // +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasdeployments,verbs=get;list;watch;create;update;patch;delete
@@ -66,6 +73,8 @@ type AtlasDeploymentReconciler struct {
 // +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasdeployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasdeployments/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups="",namespace=default,resources=configmaps,verbs=get;list;watch;create;update
 
 // +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasbackupschedules,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasbackupschedules/status,verbs=get;update;patch
@@ -164,7 +173,22 @@ func (r *AtlasDeploymentReconciler) Reconcile(context context.Context, req ctrl.
 			if customresource.ResourceShouldBeLeftInAtlas(deployment) {
 				log.Infof("Not removing Atlas Deployment from Atlas as the '%s' annotation is set", customresource.ResourcePolicyAnnotation)
 			} else {
-				if err = r.deleteDeploymentFromAtlas(context, project, deployment, atlasClient, log); err != nil {
+				protected, err := terminationProtectedInAtlas(context, atlasClient, project.ID(), deployment)
+				if err != nil {
+					result = workflow.Terminate(workflow.Internal, err.Error())
+					ctx.SetConditionFromResult(status.DeploymentReadyType, result)
+					return result.ReconcileResult(), nil
+				}
+				if result = verifyDeletionConfirmed(deployment, protected); !result.IsOk() {
+					log.Infow("Deletion of the deployment with termination protection is not confirmed", "annotation", mdbv1.DeploymentDeletionConfirmationAnnotation)
+					ctx.SetConditionFromResult(status.DeploymentReadyType, result)
+					return result.ReconcileResult(), nil
+				}
+				if result = r.ensureFinalSnapshot(ctx, project, deployment); !result.IsOk() {
+					ctx.SetConditionFromResult(status.DeploymentReadyType, result)
+					return result.ReconcileResult(), nil
+				}
+				if err = r.deleteDeploymentFromAtlas(context, project, deployment, protected, atlasClient, log); err != nil {
 					log.Errorf("failed to remove deployment from Atlas: %s", err)
 					result = workflow.Terminate(workflow.Internal, err.Error())
 					ctx.SetConditionFromResult(status.DeploymentReadyType, result)
@@ -177,6 +201,7 @@ func (r *AtlasDeploymentReconciler) Reconcile(context context.Context, req ctrl.
 				log.Errorw("failed to remove finalizer", "error", err)
 				return result.ReconcileResult(), nil
 			}
+			r.operationTracker().Finish(ctx, deployment, deployment.Status.Operation)
 		} else {
 			return result.ReconcileResult(), nil
 		}
@@ -191,20 +216,91 @@ func (r *AtlasDeploymentReconciler) Reconcile(context context.Context, req ctrl.
 		deployment.Spec.DeploymentSpec = nil
 	}
 
-	handleDeployment := r.selectDeploymentHandler(deployment)
-	if result, _ := handleDeployment(ctx, project, deployment, req); !result.IsOk() {
+	now := time.Now()
+	var nextOutageSimulationCheck time.Time
+	if !deployment.IsServerless() {
+		nextOutageSimulationCheck = r.checkOutageSimulation(ctx, project.ID(), deployment, now)
+	}
+
+	nextScheduledTransition, result := applySchedule(ctx, deployment, now)
+	if !result.IsOk() {
+		ctx.SetConditionFromResult(status.DeploymentReadyType, result)
+		return requeueBy(result, nextOutageSimulationCheck), nil
+	}
+
+	scalingProfile, nextScalingProfileTransition, result := applyScalingProfile(deployment, now)
+	if !result.IsOk() {
 		ctx.SetConditionFromResult(status.DeploymentReadyType, result)
-		return result.ReconcileResult(), nil
+		return requeueBy(result, nextOutageSimulationCheck), nil
 	}
 
+	current, result := fetchAdvancedDeployment(ctx, project.ID(), deployment)
+	if !result.IsOk() {
+		r.trackOperation(ctx, deployment, result, now)
+		ctx.SetConditionFromResult(status.DeploymentReadyType, result)
+		return requeueBy(result, nextOutageSimulationCheck), nil
+	}
+
+	startedUpgrade, nextUpgradeWindow, result := ensureMajorVersionUpgrade(ctx, project, deployment, current, now)
+	if !result.IsOk() {
+		r.trackOperation(ctx, deployment, result, now)
+		ctx.SetConditionFromResult(status.DeploymentReadyType, result)
+		return requeueBy(result, nextOutageSimulationCheck), nil
+	}
+
+	if result := r.ensureTenantUpgrade(ctx, project, deployment, current, now); !result.IsOk() {
+		r.trackOperation(ctx, deployment, result, now)
+		ctx.SetConditionFromResult(status.DeploymentReadyType, result)
+		return requeueBy(result, nextOutageSimulationCheck), nil
+	}
+
+	handleDeployment := r.selectDeploymentHandler(deployment, current)
+	result, _ = handleDeployment(ctx, project, deployment, req)
+	if startedUpgrade != nil && result.GetReason() == workflow.DeploymentNotUpdatedInAtlas {
+		failMajorVersionUpgrade(ctx, deployment, startedUpgrade, result.GetMessage())
+	}
+	r.trackOperation(ctx, deployment, result, now)
+	if !result.IsOk() {
+		ctx.SetConditionFromResult(status.DeploymentReadyType, result)
+		return requeueBy(result, nextOutageSimulationCheck), nil
+	}
+	// the profile is recorded only once the deployment is in sync with it
+	ctx.EnsureStatusOption(status.AtlasDeploymentScalingProfileOption(scalingProfile))
+
 	if !deployment.IsServerless() {
 		if result := r.handleAdvancedOptions(ctx, project, deployment); !result.IsOk() {
 			ctx.SetConditionFromResult(status.DeploymentReadyType, result)
-			return result.ReconcileResult(), nil
+			return requeueBy(result, nextOutageSimulationCheck), nil
 		}
 	}
 
-	return workflow.OK().ReconcileResult(), nil
+	// the search indexes don't keep the deployment from being ready, they are only checked again while not ready
+	searchIndexesResult := workflow.OK()
+	var nextChaosExperimentCheck time.Time
+	if !deployment.IsServerless() {
+		searchIndexesResult = r.ensureSearchIndexes(ctx, project.ID(), deployment, deployment.GetDeploymentName())
+		nextChaosExperimentCheck = r.ensureChaosExperiments(ctx, project.ID(), deployment, now)
+	}
+
+	nextTransition := earliest(nextScheduledTransition, nextScalingProfileTransition, nextUpgradeWindow, nextChaosExperimentCheck)
+	return requeueBy(searchIndexesResult, nextTransition, nextOutageSimulationCheck), nil
+}
+
+// requeueBy returns the reconcile result of the result requeued not later than at the earliest of the given times
+func requeueBy(result workflow.Result, times ...time.Time) ctrl.Result {
+	reconcileResult := result.ReconcileResult()
+	next := earliest(times...)
+	if next.IsZero() {
+		return reconcileResult
+	}
+	retry := time.Until(next)
+	if retry <= 0 {
+		return ctrl.Result{Requeue: true}
+	}
+	if reconcileResult.RequeueAfter == 0 || retry < reconcileResult.RequeueAfter {
+		reconcileResult.RequeueAfter = retry
+	}
+	return reconcileResult
 }
 
 func (r *AtlasDeploymentReconciler) verifyNonTenantCase(deployment *mdbv1.AtlasDeployment) {
@@ -247,16 +343,20 @@ func modifyProviderSettings(pSettings *mdbv1.ProviderSettingsSpec, deploymentTyp
 	}
 }
 
-func (r *AtlasDeploymentReconciler) selectDeploymentHandler(deployment *mdbv1.AtlasDeployment) deploymentHandlerFunc {
+// selectDeploymentHandler returns the handler of the deployment. The advanced deployment handler reuses the current
+// deployment fetched from Atlas by the reconciliation.
+func (r *AtlasDeploymentReconciler) selectDeploymentHandler(deployment *mdbv1.AtlasDeployment, current *mongodbatlas.AdvancedCluster) deploymentHandlerFunc {
 	if deployment.IsServerless() {
 		return r.handleServerlessInstance
 	}
-	return r.handleAdvancedDeployment
+	return func(ctx *workflow.Context, project *mdbv1.AtlasProject, deployment *mdbv1.AtlasDeployment, req reconcile.Request) (workflow.Result, error) {
+		return r.handleAdvancedDeployment(ctx, project, deployment, current, req)
+	}
 }
 
 // handleAdvancedDeployment ensures the state of the deployment using the Advanced Deployment API
-func (r *AtlasDeploymentReconciler) handleAdvancedDeployment(ctx *workflow.Context, project *mdbv1.AtlasProject, deployment *mdbv1.AtlasDeployment, req reconcile.Request) (workflow.Result, error) {
-	c, result := r.ensureAdvancedDeploymentState(ctx, project, deployment)
+func (r *AtlasDeploymentReconciler) handleAdvancedDeployment(ctx *workflow.Context, project *mdbv1.AtlasProject, deployment *mdbv1.AtlasDeployment, current *mongodbatlas.AdvancedCluster, req reconcile.Request) (workflow.Result, error) {
+	c, result := r.ensureAdvancedDeploymentState(ctx, project, deployment, current)
 	if c != nil && c.StateName != "" {
 		ctx.EnsureStatusOption(status.AtlasDeploymentStateNameOption(c.StateName))
 	}
@@ -295,14 +395,27 @@ func (r *AtlasDeploymentReconciler) handleAdvancedDeployment(ctx *workflow.Conte
 		return result, nil
 	}
 
-	if csResult := r.ensureConnectionSecrets(ctx, project, c.Name, c.ConnectionStrings, deployment); !csResult.IsOk() {
+	if result := ensureOnlineArchives(ctx, project.ID(), deployment, c.Name); !result.IsOk() {
+		return result, nil
+	}
+
+	onlineArchiveConnURL := ""
+	if len(deployment.Spec.OnlineArchives) > 0 {
+		var err error
+		if onlineArchiveConnURL, err = connectionsecret.OnlineArchiveConnURL(ctx, project.ID(), c.Name); err != nil {
+			return workflow.Terminate(workflow.OnlineArchivesNotReady, err.Error()), nil
+		}
+	}
+
+	if csResult := r.ensureConnectionSecrets(ctx, project, c.Name, c.ConnectionStrings, onlineArchiveConnURL, deployment); !csResult.IsOk() {
 		return csResult, nil
 	}
 
 	ctx.
 		SetConditionTrue(status.DeploymentReadyType).
 		EnsureStatusOption(status.AtlasDeploymentMongoDBVersionOption(c.MongoDBVersion)).
-		EnsureStatusOption(status.AtlasDeploymentConnectionStringsOption(c.ConnectionStrings))
+		EnsureStatusOption(status.AtlasDeploymentConnectionStringsOption(c.ConnectionStrings)).
+		EnsureStatusOption(status.AtlasDeploymentOnlineArchiveConnectionStringOption(onlineArchiveConnURL))
 
 	ctx.SetConditionTrue(status.ReadyType)
 	return result, nil
@@ -325,7 +438,7 @@ func (r *AtlasDeploymentReconciler) ensureConnectionSecretsAndSetStatusOptions(c
 		return result, nil
 	}
 
-	if csResult := r.ensureConnectionSecrets(ctx, project, d.Name, d.ConnectionStrings, deployment); !csResult.IsOk() {
+	if csResult := r.ensureConnectionSecrets(ctx, project, d.Name, d.ConnectionStrings, "", deployment); !csResult.IsOk() {
 		return csResult, nil
 	}
 
@@ -389,6 +502,20 @@ func (r *AtlasDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	// The schedule, scaling profile, chaos experiment and deletion confirmation annotations don't bump the generation
+	err = c.Watch(&source.Kind{Type: &mdbv1.AtlasDeployment{}}, &handler.EnqueueRequestForObject{},
+		predicate.Or(
+			watch.AnnotationChanged(mdbv1.DeploymentKeepRunningUntilAnnotation),
+			watch.AnnotationChanged(mdbv1.DeploymentScalingProfileAnnotation),
+			watch.AnnotationChanged(mdbv1.TestFailoverAnnotation),
+			watch.AnnotationChanged(mdbv1.SimulateOutageAnnotation),
+			watch.AnnotationChanged(mdbv1.SimulateOutageDurationAnnotation),
+			watch.AnnotationChanged(mdbv1.DeploymentDeletionConfirmationAnnotation),
+		))
+	if err != nil {
+		return err
+	}
+
 	// Watch for Backup schedules
 	err = c.Watch(&source.Kind{Type: &mdbv1.AtlasBackupSchedule{}}, watch.NewBackupScheduleHandler(r.WatchedResources))
 	if err != nil {
@@ -433,6 +560,7 @@ func (r *AtlasDeploymentReconciler) deleteDeploymentFromAtlas(
 	ctx context.Context,
 	project *mdbv1.AtlasProject,
 	deployment *mdbv1.AtlasDeployment,
+	terminationProtected bool,
 	atlasClient mongodbatlas.Client,
 	log *zap.SugaredLogger,
 ) error {
@@ -443,9 +571,19 @@ func (r *AtlasDeploymentReconciler) deleteDeploymentFromAtlas(
 		return err
 	}
 
+	if terminationProtected {
+		// Atlas rejects the deletion until the termination protection is disabled
+		if err = disableTerminationProtection(ctx, atlasClient, project.ID(), deployment); err != nil {
+			log.Errorw("Cannot disable the termination protection of the Atlas deployment", "error", err)
+			return err
+		}
+	}
+
 	deleteDeploymentFunc := atlasClient.AdvancedClusters.Delete
 	if deployment.IsServerless() {
 		deleteDeploymentFunc = atlasClient.ServerlessInstances.Delete
+	} else if deployment.Spec.DeletionPolicy.RetainsBackups() {
+		deleteDeploymentFunc = deleteAdvancedDeploymentRetainingBackups(&atlasClient)
 	}
 
 	_, err = deleteDeploymentFunc(ctx, project.Status.ID, deployment.GetDeploymentName())
@@ -464,6 +602,63 @@ func (r *AtlasDeploymentReconciler) deleteDeploymentFromAtlas(
 	return nil
 }
 
+// verifyDeletionConfirmed blocks the deletion of the deployment with termination protection enabled in the spec or
+// in Atlas until it's confirmed by the annotation
+func verifyDeletionConfirmed(deployment *mdbv1.AtlasDeployment, protectedInAtlas bool) workflow.Result {
+	if !(deployment.IsTerminationProtected() || protectedInAtlas) || deployment.DeletionConfirmed() {
+		return workflow.OK()
+	}
+	return workflow.Terminate(workflow.DeploymentDeletionBlocked,
+		fmt.Sprintf("deployment %s has termination protection enabled, set the %s annotation to the name of the deployment to confirm the deletion or disable the termination protection",
+			deployment.GetDeploymentName(), mdbv1.DeploymentDeletionConfirmationAnnotation)).
+		WithoutRetry()
+}
+
+// terminationProtectedInAtlas returns true if the termination protection of the deployment is enabled in Atlas. The
+// deployment which doesn't exist in Atlas isn't protected.
+func terminationProtectedInAtlas(ctx context.Context, atlasClient mongodbatlas.Client, projectID string, deployment *mdbv1.AtlasDeployment) (bool, error) {
+	var enabled *bool
+	var resp *mongodbatlas.Response
+	var err error
+	if deployment.IsServerless() {
+		var instance *mongodbatlas.Cluster
+		if instance, resp, err = atlasClient.ServerlessInstances.Get(ctx, projectID, deployment.GetDeploymentName()); err == nil {
+			enabled = instance.TerminationProtectionEnabled
+		}
+	} else {
+		var cluster *mongodbatlas.AdvancedCluster
+		if cluster, resp, err = atlasClient.AdvancedClusters.Get(ctx, projectID, deployment.GetDeploymentName()); err == nil {
+			enabled = cluster.TerminationProtectionEnabled
+		}
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return boolValue(enabled), nil
+}
+
+func disableTerminationProtection(ctx context.Context, atlasClient mongodbatlas.Client, projectID string, deployment *mdbv1.AtlasDeployment) error {
+	if deployment.IsServerless() {
+		current, _, err := atlasClient.ServerlessInstances.Get(ctx, projectID, deployment.GetDeploymentName())
+		if err != nil {
+			return err
+		}
+		_, _, err = atlasClient.ServerlessInstances.Update(ctx, projectID, deployment.GetDeploymentName(), &mongodbatlas.ServerlessUpdateRequestParams{
+			ServerlessBackupOptions:      current.ServerlessBackupOptions,
+			TerminationProtectionEnabled: toptr.MakePtr(false),
+		})
+		return err
+	}
+
+	_, _, err := atlasClient.AdvancedClusters.Update(ctx, projectID, deployment.GetDeploymentName(), &mongodbatlas.AdvancedCluster{
+		TerminationProtectionEnabled: toptr.MakePtr(false),
+	})
+	return err
+}
+
 func (r *AtlasDeploymentReconciler) removeDeletionFinalizer(context context.Context, deployment *mdbv1.AtlasDeployment) error {
 	err := r.Client.Get(context, kube.ObjectKeyFromObject(deployment), deployment)
 	if err != nil {
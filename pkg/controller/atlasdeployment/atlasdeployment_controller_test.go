@@ -0,0 +1,78 @@
+package atlasdeployment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+func TestVerifyDeletionConfirmed(t *testing.T) {
+	t.Run("Deployment without termination protection", func(t *testing.T) {
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+		assert.True(t, verifyDeletionConfirmed(deployment, false).IsOk())
+	})
+	t.Run("Protected deployment without confirmation", func(t *testing.T) {
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+		deployment.Spec.AdvancedDeploymentSpec.TerminationProtectionEnabled = toptr.MakePtr(true)
+		deployment.Annotations = map[string]string{mdbv1.DeploymentDeletionConfirmationAnnotation: "another-deployment"}
+
+		result := verifyDeletionConfirmed(deployment, false)
+		assert.False(t, result.IsOk())
+		assert.Equal(t, workflow.Terminate(workflow.DeploymentDeletionBlocked, result.GetMessage()).WithoutRetry(), result)
+	})
+	t.Run("Protected deployment with confirmation", func(t *testing.T) {
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+		deployment.Spec.AdvancedDeploymentSpec.TerminationProtectionEnabled = toptr.MakePtr(true)
+		deployment.Annotations = map[string]string{mdbv1.DeploymentDeletionConfirmationAnnotation: deployment.GetDeploymentName()}
+
+		assert.True(t, verifyDeletionConfirmed(deployment, false).IsOk())
+	})
+	t.Run("Protected serverless instance without confirmation", func(t *testing.T) {
+		deployment := mdbv1.NewDefaultAWSServerlessInstance("ns", "project")
+		deployment.Spec.ServerlessSpec.TerminationProtectionEnabled = toptr.MakePtr(true)
+
+		assert.False(t, verifyDeletionConfirmed(deployment, false).IsOk())
+	})
+	t.Run("Deployment protected in Atlas only", func(t *testing.T) {
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+
+		assert.False(t, verifyDeletionConfirmed(deployment, true).IsOk())
+
+		deployment.Annotations = map[string]string{mdbv1.DeploymentDeletionConfirmationAnnotation: deployment.GetDeploymentName()}
+		assert.True(t, verifyDeletionConfirmed(deployment, true).IsOk())
+	})
+}
+
+func TestTerminationProtectedInAtlas(t *testing.T) {
+	t.Run("Advanced deployment", func(t *testing.T) {
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+		client := mongodbatlas.Client{AdvancedClusters: &advancedClustersStub{cluster: &mongodbatlas.AdvancedCluster{TerminationProtectionEnabled: toptr.MakePtr(true)}}}
+
+		protected, err := terminationProtectedInAtlas(context.Background(), client, "project-id", deployment)
+		require.NoError(t, err)
+		assert.True(t, protected)
+	})
+	t.Run("Serverless instance", func(t *testing.T) {
+		deployment := mdbv1.NewDefaultAWSServerlessInstance("ns", "project")
+		client := mongodbatlas.Client{ServerlessInstances: &serverlessInstancesStub{terminationProtectionEnabled: toptr.MakePtr(true)}}
+
+		protected, err := terminationProtectedInAtlas(context.Background(), client, "project-id", deployment)
+		require.NoError(t, err)
+		assert.True(t, protected)
+	})
+	t.Run("Deployment which doesn't exist in Atlas", func(t *testing.T) {
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+		client := mongodbatlas.Client{AdvancedClusters: &advancedDeploymentStub{notFound: true}}
+
+		protected, err := terminationProtectedInAtlas(context.Background(), client, "project-id", deployment)
+		require.NoError(t, err)
+		assert.False(t, protected)
+	})
+}
@@ -0,0 +1,309 @@
+package atlasdeployment
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/timeutil"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+const (
+	defaultOutageSimulationDuration = 15 * time.Minute
+
+	// testFailoverSettleTime is the time after the request Atlas takes to start the test failover. Until then the
+	// deployment can still be reported as IDLE.
+	testFailoverSettleTime = time.Minute
+
+	outageSimulationSimulating        = "SIMULATING"
+	outageSimulationRecoveryRequested = "RECOVERY_REQUESTED"
+)
+
+// ensureChaosExperiments triggers the test failover and the outage simulation requested by the annotations and
+// tracks the test failover until it completes. The running outage simulation is tracked by checkOutageSimulation. It
+// returns the time the experiments are checked next or the zero time if none is running.
+func (r *AtlasDeploymentReconciler) ensureChaosExperiments(ctx *workflow.Context, projectID string, deployment *mdbv1.AtlasDeployment, now time.Time) time.Time {
+	return earliest(
+		r.ensureTestFailover(ctx, projectID, deployment, now),
+		r.ensureOutageSimulation(ctx, projectID, deployment, now),
+	)
+}
+
+func (r *AtlasDeploymentReconciler) ensureTestFailover(ctx *workflow.Context, projectID string, deployment *mdbv1.AtlasDeployment, now time.Time) time.Time {
+	nonce := deployment.Annotations[mdbv1.TestFailoverAnnotation]
+	if nonce == "" {
+		ctx.EnsureStatusOption(status.AtlasDeploymentTestFailoverOption(nil))
+		return time.Time{}
+	}
+
+	current := deployment.Status.TestFailover
+	if current != nil && current.Nonce == nonce {
+		if current.Phase != status.TestFailoverRequested {
+			return time.Time{}
+		}
+		cluster, _, err := ctx.Client.AdvancedClusters.Get(context.Background(), projectID, deployment.GetDeploymentName())
+		if err != nil {
+			ctx.Log.Errorw("Failed to get the deployment to check the test failover", "nonce", nonce, "error", err)
+			return now.Add(workflow.DefaultRetry)
+		}
+		if checkAt, completed := testFailoverCompletion(current, cluster.StateName, now); !completed {
+			return checkAt
+		}
+		ctx.Log.Infow("Test failover completed", "nonce", nonce)
+		ctx.EnsureStatusOption(status.AtlasDeploymentTestFailoverOption(&status.TestFailover{
+			Nonce:       nonce,
+			Phase:       status.TestFailoverCompleted,
+			RequestedAt: current.RequestedAt,
+		}))
+		r.EventRecorder.Eventf(deployment, "Normal", "TestFailoverCompleted", "Test failover %s completed", nonce)
+		return time.Time{}
+	}
+
+	if _, err := ctx.Client.AdvancedClusters.TestFailover(context.Background(), projectID, deployment.GetDeploymentName()); err != nil {
+		ctx.Log.Errorw("Failed to trigger the test failover", "nonce", nonce, "error", err)
+		ctx.EnsureStatusOption(status.AtlasDeploymentTestFailoverOption(&status.TestFailover{
+			Nonce:   nonce,
+			Phase:   status.TestFailoverFailed,
+			Message: err.Error(),
+		}))
+		r.EventRecorder.Eventf(deployment, "Warning", "TestFailoverFailed", "Test failover %s failed: %s", nonce, err)
+		return time.Time{}
+	}
+
+	ctx.Log.Infow("Test failover requested", "nonce", nonce)
+	ctx.EnsureStatusOption(status.AtlasDeploymentTestFailoverOption(&status.TestFailover{
+		Nonce:       nonce,
+		Phase:       status.TestFailoverRequested,
+		RequestedAt: timeutil.FormatISO8601(now.UTC()),
+	}))
+	r.EventRecorder.Eventf(deployment, "Normal", "TestFailoverRequested", "Test failover %s requested", nonce)
+	return now.Add(testFailoverSettleTime)
+}
+
+// testFailoverCompletion returns whether the requested test failover is completed at the moment now and when it's
+// checked next otherwise. The test failover is completed once the deployment in the given state is IDLE again after
+// the settle time.
+func testFailoverCompletion(testFailover *status.TestFailover, stateName string, now time.Time) (time.Time, bool) {
+	if requestedAt, err := timeutil.ParseISO8601(testFailover.RequestedAt); err == nil {
+		if settledAt := requestedAt.Add(testFailoverSettleTime); now.Before(settledAt) {
+			return settledAt, false
+		}
+	}
+	if stateName != status.StateIDLE {
+		return now.Add(workflow.DefaultRetry), false
+	}
+	return time.Time{}, true
+}
+
+// checkOutageSimulation tracks the running outage simulation. It's called before the deployment is reconciled so the
+// simulation is ended in time even if the deployment isn't ready. It returns the time the simulation is checked next
+// or the zero time if none is running.
+func (r *AtlasDeploymentReconciler) checkOutageSimulation(ctx *workflow.Context, projectID string, deployment *mdbv1.AtlasDeployment, now time.Time) time.Time {
+	current := deployment.Status.OutageSimulation
+	if current == nil || !outageSimulationRunning(current) {
+		return time.Time{}
+	}
+	requested := deployment.Annotations[mdbv1.SimulateOutageAnnotation] == current.Regions
+	return r.trackOutageSimulation(ctx, projectID, deployment, current, requested, now)
+}
+
+func (r *AtlasDeploymentReconciler) ensureOutageSimulation(ctx *workflow.Context, projectID string, deployment *mdbv1.AtlasDeployment, now time.Time) time.Time {
+	regions := deployment.Annotations[mdbv1.SimulateOutageAnnotation]
+	current := deployment.Status.OutageSimulation
+
+	if current != nil && outageSimulationRunning(current) {
+		// already tracked by checkOutageSimulation
+		return time.Time{}
+	}
+	if regions == "" {
+		ctx.EnsureStatusOption(status.AtlasDeploymentOutageSimulationOption(nil))
+		return time.Time{}
+	}
+	if current != nil && current.Regions == regions {
+		return time.Time{}
+	}
+	return r.startOutageSimulation(ctx, projectID, deployment, regions, now)
+}
+
+func (r *AtlasDeploymentReconciler) startOutageSimulation(ctx *workflow.Context, projectID string, deployment *mdbv1.AtlasDeployment, regions string, now time.Time) time.Time {
+	failed := func(err error) time.Time {
+		ctx.Log.Errorw("Failed to start the outage simulation", "regions", regions, "error", err)
+		ctx.EnsureStatusOption(status.AtlasDeploymentOutageSimulationOption(&status.OutageSimulation{
+			Regions: regions,
+			State:   status.OutageSimulationFailed,
+			Message: err.Error(),
+		}))
+		r.EventRecorder.Eventf(deployment, "Warning", "OutageSimulationFailed", "Outage simulation of %s failed: %s", regions, err)
+		return time.Time{}
+	}
+
+	filters, err := outageFilters(deployment, regions)
+	if err != nil {
+		return failed(err)
+	}
+	duration, err := outageSimulationDuration(deployment)
+	if err != nil {
+		return failed(err)
+	}
+
+	simulation, _, err := ctx.Client.ClusterOutageSimulation.StartOutageSimulation(context.Background(), projectID, deployment.GetDeploymentName(),
+		&mongodbatlas.ClusterOutageSimulationRequest{OutageFilters: filters})
+	if err != nil {
+		return failed(err)
+	}
+
+	ctx.Log.Infow("Outage simulation started", "regions", regions, "duration", duration)
+	ctx.EnsureStatusOption(status.AtlasDeploymentOutageSimulationOption(&status.OutageSimulation{
+		Regions:   regions,
+		ID:        stringValue(simulation.ID),
+		State:     stringValue(simulation.State),
+		StartedAt: timeutil.FormatISO8601(now.UTC()),
+		EndsAt:    timeutil.FormatISO8601(now.Add(duration).UTC()),
+	}))
+	r.EventRecorder.Eventf(deployment, "Normal", "OutageSimulationStarted", "Outage simulation of %s started for %s", regions, duration)
+	return now.Add(workflow.DefaultRetry)
+}
+
+// trackOutageSimulation refreshes the state of the running simulation and ends it once its duration is over or the
+// annotation requesting it is changed. The end of the simulation follows the duration annotation, so changing it
+// shortens or extends the simulation in progress. The simulation in progress is checked next at the time it ends, the
+// annotation changes trigger the reconciliation on their own.
+func (r *AtlasDeploymentReconciler) trackOutageSimulation(ctx *workflow.Context, projectID string, deployment *mdbv1.AtlasDeployment, current *status.OutageSimulation, requested bool, now time.Time) time.Time {
+	name := deployment.GetDeploymentName()
+	updated := *current
+	updated.EndsAt = outageSimulationEndsAt(ctx, deployment, current)
+
+	simulation, resp, err := ctx.Client.ClusterOutageSimulation.GetOutageSimulation(context.Background(), projectID, name)
+	switch {
+	case err != nil && resp != nil && resp.StatusCode == http.StatusNotFound:
+		updated.State = status.OutageSimulationComplete
+	case err != nil:
+		ctx.Log.Errorw("Failed to get the outage simulation", "error", err)
+		return now.Add(workflow.DefaultRetry)
+	default:
+		updated.State = stringValue(simulation.State)
+	}
+
+	if updated.State == status.OutageSimulationComplete {
+		ctx.Log.Infow("Outage simulation completed", "regions", current.Regions)
+		ctx.EnsureStatusOption(status.AtlasDeploymentOutageSimulationOption(&updated))
+		r.EventRecorder.Eventf(deployment, "Normal", "OutageSimulationCompleted", "Outage simulation of %s completed", current.Regions)
+		return time.Time{}
+	}
+
+	if updated.State == outageSimulationSimulating && (!requested || outageSimulationOver(&updated, now)) {
+		if _, _, err = ctx.Client.ClusterOutageSimulation.EndOutageSimulation(context.Background(), projectID, name); err != nil {
+			ctx.Log.Errorw("Failed to end the outage simulation", "error", err)
+			ctx.EnsureStatusOption(status.AtlasDeploymentOutageSimulationOption(&updated))
+			return now.Add(workflow.DefaultRetry)
+		}
+		ctx.Log.Infow("Ending the outage simulation", "regions", current.Regions)
+		updated.State = outageSimulationRecoveryRequested
+		r.EventRecorder.Eventf(deployment, "Normal", "OutageSimulationEnding", "Ending the outage simulation of %s", current.Regions)
+	}
+
+	ctx.EnsureStatusOption(status.AtlasDeploymentOutageSimulationOption(&updated))
+	if updated.State == outageSimulationSimulating {
+		if endsAt, err := timeutil.ParseISO8601(updated.EndsAt); err == nil {
+			return endsAt
+		}
+	}
+	return now.Add(workflow.DefaultRetry)
+}
+
+func outageSimulationRunning(simulation *status.OutageSimulation) bool {
+	return simulation.State != "" && simulation.State != status.OutageSimulationComplete && simulation.State != status.OutageSimulationFailed
+}
+
+func outageSimulationOver(simulation *status.OutageSimulation, now time.Time) bool {
+	endsAt, err := timeutil.ParseISO8601(simulation.EndsAt)
+	return err != nil || !now.Before(endsAt)
+}
+
+// outageSimulationEndsAt returns the end of the simulation by the current duration annotation. The end recorded when
+// the simulation started is kept if the annotation is invalid.
+func outageSimulationEndsAt(ctx *workflow.Context, deployment *mdbv1.AtlasDeployment, simulation *status.OutageSimulation) string {
+	startedAt, err := timeutil.ParseISO8601(simulation.StartedAt)
+	if err != nil {
+		return simulation.EndsAt
+	}
+	duration, err := outageSimulationDuration(deployment)
+	if err != nil {
+		ctx.Log.Warnw("Keeping the end of the outage simulation", "endsAt", simulation.EndsAt, "error", err)
+		return simulation.EndsAt
+	}
+	return timeutil.FormatISO8601(startedAt.Add(duration).UTC())
+}
+
+func outageSimulationDuration(deployment *mdbv1.AtlasDeployment) (time.Duration, error) {
+	value, ok := deployment.Annotations[mdbv1.SimulateOutageDurationAnnotation]
+	if !ok {
+		return defaultOutageSimulationDuration, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid %s annotation, expected positive duration: %q", mdbv1.SimulateOutageDurationAnnotation, value)
+	}
+	return duration, nil
+}
+
+// outageFilters parses the regions from the annotation. The provider of the region without the prefix is looked up in
+// the region configs of the deployment.
+func outageFilters(deployment *mdbv1.AtlasDeployment, regions string) ([]mongodbatlas.ClusterOutageSimulationOutageFilter, error) {
+	var filters []mongodbatlas.ClusterOutageSimulationOutageFilter
+	for _, region := range strings.Split(regions, ",") {
+		region = strings.TrimSpace(region)
+		if region == "" {
+			continue
+		}
+		provider, regionName, found := strings.Cut(region, ":")
+		if !found {
+			regionName = region
+			provider = regionProvider(deployment, regionName)
+			if provider == "" {
+				return nil, fmt.Errorf("region %s is not configured for the deployment, prefix it with the provider", regionName)
+			}
+		}
+		filters = append(filters, mongodbatlas.ClusterOutageSimulationOutageFilter{
+			CloudProvider: toptr.MakePtr(provider),
+			RegionName:    toptr.MakePtr(regionName),
+			Type:          toptr.MakePtr("REGION"),
+		})
+	}
+	if len(filters) == 0 {
+		return nil, fmt.Errorf("no regions in the %s annotation", mdbv1.SimulateOutageAnnotation)
+	}
+	return filters, nil
+}
+
+func regionProvider(deployment *mdbv1.AtlasDeployment, regionName string) string {
+	if deployment.Spec.AdvancedDeploymentSpec == nil {
+		return ""
+	}
+	for _, replicationSpec := range deployment.Spec.AdvancedDeploymentSpec.ReplicationSpecs {
+		if replicationSpec == nil {
+			continue
+		}
+		for _, regionConfig := range replicationSpec.RegionConfigs {
+			if regionConfig != nil && regionConfig.RegionName == regionName {
+				return regionConfig.ProviderName
+			}
+		}
+	}
+	return ""
+}
+
+func stringValue(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
@@ -0,0 +1,241 @@
+package atlasdeployment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/timeutil"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+// testFailoverStub records the test failovers and returns the given deployment
+type testFailoverStub struct {
+	advancedClustersStub
+
+	failovers int
+}
+
+func (s *testFailoverStub) TestFailover(context.Context, string, string) (*mongodbatlas.Response, error) {
+	s.failovers++
+	return nil, nil
+}
+
+// outageSimulationStub returns the simulation in the given state and records the simulations ended
+type outageSimulationStub struct {
+	mongodbatlas.ClusterOutageSimulationService
+
+	state    string
+	notFound bool
+	ended    int
+}
+
+func (s *outageSimulationStub) GetOutageSimulation(context.Context, string, string) (*mongodbatlas.ClusterOutageSimulation, *mongodbatlas.Response, error) {
+	if s.notFound {
+		return nil, &mongodbatlas.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found")
+	}
+	return &mongodbatlas.ClusterOutageSimulation{State: toptr.MakePtr(s.state)}, nil, nil
+}
+
+func (s *outageSimulationStub) EndOutageSimulation(context.Context, string, string) (*mongodbatlas.ClusterOutageSimulation, *mongodbatlas.Response, error) {
+	s.ended++
+	return &mongodbatlas.ClusterOutageSimulation{State: toptr.MakePtr(outageSimulationRecoveryRequested)}, nil, nil
+}
+
+func TestOutageFilters(t *testing.T) {
+	deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+
+	t.Run("Provider is taken from the region configs or the prefix", func(t *testing.T) {
+		filters, err := outageFilters(deployment, "US_EAST_1, GCP:CENTRAL_US")
+		require.NoError(t, err)
+		assert.Equal(t, []mongodbatlas.ClusterOutageSimulationOutageFilter{
+			{CloudProvider: toptr.MakePtr("AWS"), RegionName: toptr.MakePtr("US_EAST_1"), Type: toptr.MakePtr("REGION")},
+			{CloudProvider: toptr.MakePtr("GCP"), RegionName: toptr.MakePtr("CENTRAL_US"), Type: toptr.MakePtr("REGION")},
+		}, filters)
+	})
+	t.Run("Unknown region without the provider", func(t *testing.T) {
+		_, err := outageFilters(deployment, "EU_WEST_1")
+		assert.Error(t, err)
+	})
+	t.Run("No regions", func(t *testing.T) {
+		_, err := outageFilters(deployment, " , ")
+		assert.Error(t, err)
+	})
+}
+
+func TestOutageSimulationDuration(t *testing.T) {
+	deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+
+	duration, err := outageSimulationDuration(deployment)
+	require.NoError(t, err)
+	assert.Equal(t, defaultOutageSimulationDuration, duration)
+
+	deployment.Annotations = map[string]string{mdbv1.SimulateOutageDurationAnnotation: "30m"}
+	duration, err = outageSimulationDuration(deployment)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, duration)
+
+	deployment.Annotations[mdbv1.SimulateOutageDurationAnnotation] = "-5m"
+	_, err = outageSimulationDuration(deployment)
+	assert.Error(t, err)
+}
+
+func TestTestFailoverCompletion(t *testing.T) {
+	testFailover := &status.TestFailover{Nonce: "1", Phase: status.TestFailoverRequested, RequestedAt: "2023-06-09T12:00:00Z"}
+
+	checkAt, completed := testFailoverCompletion(testFailover, status.StateIDLE, time.Date(2023, 6, 9, 12, 0, 30, 0, time.UTC))
+	assert.False(t, completed)
+	assert.Equal(t, time.Date(2023, 6, 9, 12, 1, 0, 0, time.UTC), checkAt)
+
+	checkAt, completed = testFailoverCompletion(testFailover, status.StateREPAIRING, time.Date(2023, 6, 9, 12, 1, 0, 0, time.UTC))
+	assert.False(t, completed)
+	assert.Equal(t, time.Date(2023, 6, 9, 12, 1, 10, 0, time.UTC), checkAt)
+
+	_, completed = testFailoverCompletion(testFailover, status.StateIDLE, time.Date(2023, 6, 9, 12, 1, 0, 0, time.UTC))
+	assert.True(t, completed)
+}
+
+func TestEnsureTestFailover(t *testing.T) {
+	now := time.Date(2023, 6, 9, 12, 0, 0, 0, time.UTC)
+	reconciler := &AtlasDeploymentReconciler{EventRecorder: record.NewFakeRecorder(10)}
+	deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+	deployment.Annotations = map[string]string{mdbv1.TestFailoverAnnotation: "1"}
+	stub := &testFailoverStub{}
+	reconcile := func(stateName string, now time.Time) time.Time {
+		stub.cluster = &mongodbatlas.AdvancedCluster{Name: "test-deployment-advanced", StateName: stateName}
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		ctx.Client = mongodbatlas.Client{AdvancedClusters: stub}
+		next := reconciler.ensureTestFailover(ctx, "project-id", deployment, now)
+		applyStatusOptions(ctx, deployment)
+		return next
+	}
+
+	assert.Equal(t, now.Add(testFailoverSettleTime), reconcile(status.StateIDLE, now))
+	assert.Equal(t, 1, stub.failovers)
+	require.NotNil(t, deployment.Status.TestFailover)
+	assert.Equal(t, status.TestFailoverRequested, deployment.Status.TestFailover.Phase)
+
+	// Atlas takes a moment to start the failover
+	assert.Equal(t, now.Add(testFailoverSettleTime), reconcile(status.StateIDLE, now.Add(30*time.Second)))
+	assert.Equal(t, status.TestFailoverRequested, deployment.Status.TestFailover.Phase)
+
+	later := now.Add(2 * time.Minute)
+	assert.Equal(t, later.Add(workflow.DefaultRetry), reconcile(status.StateREPAIRING, later))
+	assert.Equal(t, status.TestFailoverRequested, deployment.Status.TestFailover.Phase)
+
+	assert.True(t, reconcile(status.StateIDLE, later).IsZero())
+	assert.Equal(t, status.TestFailoverCompleted, deployment.Status.TestFailover.Phase)
+
+	// the test failover is triggered once per nonce
+	assert.True(t, reconcile(status.StateIDLE, later).IsZero())
+	assert.Equal(t, 1, stub.failovers)
+}
+
+func TestCheckOutageSimulation(t *testing.T) {
+	now := time.Date(2023, 6, 9, 12, 0, 0, 0, time.UTC)
+	endsAt := now.Add(10 * time.Minute)
+	reconciler := &AtlasDeploymentReconciler{EventRecorder: record.NewFakeRecorder(10)}
+	newDeployment := func(regions, state string) *mdbv1.AtlasDeployment {
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+		deployment.Annotations = map[string]string{mdbv1.SimulateOutageAnnotation: regions}
+		deployment.Status.OutageSimulation = &status.OutageSimulation{
+			Regions:   "US_EAST_1",
+			State:     state,
+			StartedAt: timeutil.FormatISO8601(now.Add(-5 * time.Minute)),
+			EndsAt:    timeutil.FormatISO8601(endsAt),
+		}
+		return deployment
+	}
+	check := func(deployment *mdbv1.AtlasDeployment, stub *outageSimulationStub, now time.Time) time.Time {
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		ctx.Client = mongodbatlas.Client{ClusterOutageSimulation: stub}
+		next := reconciler.checkOutageSimulation(ctx, "project-id", deployment, now)
+		applyStatusOptions(ctx, deployment)
+		return next
+	}
+
+	t.Run("Simulation in progress is checked next when it ends", func(t *testing.T) {
+		deployment := newDeployment("US_EAST_1", outageSimulationSimulating)
+		stub := &outageSimulationStub{state: outageSimulationSimulating}
+
+		assert.Equal(t, endsAt, check(deployment, stub, now))
+		assert.Zero(t, stub.ended)
+		assert.Equal(t, outageSimulationSimulating, deployment.Status.OutageSimulation.State)
+	})
+	t.Run("Simulation is ended once its duration is over", func(t *testing.T) {
+		deployment := newDeployment("US_EAST_1", outageSimulationSimulating)
+		stub := &outageSimulationStub{state: outageSimulationSimulating}
+
+		assert.Equal(t, endsAt.Add(workflow.DefaultRetry), check(deployment, stub, endsAt))
+		assert.Equal(t, 1, stub.ended)
+		assert.Equal(t, outageSimulationRecoveryRequested, deployment.Status.OutageSimulation.State)
+	})
+	t.Run("Simulation is ended by the changed duration", func(t *testing.T) {
+		deployment := newDeployment("US_EAST_1", outageSimulationSimulating)
+		deployment.Annotations[mdbv1.SimulateOutageDurationAnnotation] = "5m"
+		stub := &outageSimulationStub{state: outageSimulationSimulating}
+
+		assert.Equal(t, now.Add(workflow.DefaultRetry), check(deployment, stub, now))
+		assert.Equal(t, 1, stub.ended)
+		assert.Equal(t, timeutil.FormatISO8601(now), deployment.Status.OutageSimulation.EndsAt)
+	})
+	t.Run("Simulation is extended by the changed duration", func(t *testing.T) {
+		deployment := newDeployment("US_EAST_1", outageSimulationSimulating)
+		deployment.Annotations[mdbv1.SimulateOutageDurationAnnotation] = "1h"
+		stub := &outageSimulationStub{state: outageSimulationSimulating}
+
+		assert.Equal(t, now.Add(55*time.Minute), check(deployment, stub, endsAt))
+		assert.Zero(t, stub.ended)
+	})
+	t.Run("Simulation is ended once the annotation is removed", func(t *testing.T) {
+		deployment := newDeployment("", outageSimulationSimulating)
+		stub := &outageSimulationStub{state: outageSimulationSimulating}
+
+		assert.Equal(t, now.Add(workflow.DefaultRetry), check(deployment, stub, now))
+		assert.Equal(t, 1, stub.ended)
+	})
+	t.Run("Simulation is completed once Atlas doesn't find it", func(t *testing.T) {
+		deployment := newDeployment("US_EAST_1", outageSimulationRecoveryRequested)
+
+		assert.True(t, check(deployment, &outageSimulationStub{notFound: true}, endsAt).IsZero())
+		assert.Equal(t, status.OutageSimulationComplete, deployment.Status.OutageSimulation.State)
+	})
+	t.Run("Finished simulation isn't checked", func(t *testing.T) {
+		deployment := newDeployment("US_EAST_1", status.OutageSimulationComplete)
+
+		assert.True(t, check(deployment, nil, now).IsZero())
+	})
+}
+
+func TestRequeueBy(t *testing.T) {
+	assert.Equal(t, ctrl.Result{RequeueAfter: workflow.DefaultRetry}, requeueBy(workflow.Terminate(workflow.Internal, "error"), time.Time{}))
+	assert.Equal(t, ctrl.Result{}, requeueBy(workflow.OK()))
+
+	result := requeueBy(workflow.OK(), time.Now().Add(time.Hour), time.Now().Add(time.Minute))
+	assert.InDelta(t, time.Minute, result.RequeueAfter, float64(time.Second))
+
+	result = requeueBy(workflow.Terminate(workflow.Internal, "error").WithoutRetry(), time.Now().Add(time.Hour))
+	assert.InDelta(t, time.Hour, result.RequeueAfter, float64(time.Second))
+
+	result = requeueBy(workflow.Terminate(workflow.Internal, "error"), time.Now().Add(time.Hour))
+	assert.Equal(t, workflow.DefaultRetry, result.RequeueAfter)
+}
+
+func TestOutageSimulationRunning(t *testing.T) {
+	assert.True(t, outageSimulationRunning(&status.OutageSimulation{State: "STARTING"}))
+	assert.True(t, outageSimulationRunning(&status.OutageSimulation{State: outageSimulationSimulating}))
+	assert.False(t, outageSimulationRunning(&status.OutageSimulation{State: status.OutageSimulationComplete}))
+	assert.False(t, outageSimulationRunning(&status.OutageSimulation{State: status.OutageSimulationFailed}))
+}
@@ -0,0 +1,140 @@
+package atlasdeployment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+)
+
+const (
+	defaultFinalSnapshotRetentionDays = 30
+
+	FinalSnapshotTakenEvent = "FinalSnapshotTaken"
+
+	// finalSnapshotLabelKey marks the ConfigMaps the operator records the final snapshots in. The value is the name
+	// of the deployment resource.
+	finalSnapshotLabelKey = "atlas.mongodb.com/final-snapshot"
+)
+
+// ensureFinalSnapshot takes the on-demand snapshot before the deployment is deleted and waits for it to complete. The
+// completed snapshot is recorded in the ConfigMap which outlives the deployment resource.
+func (r *AtlasDeploymentReconciler) ensureFinalSnapshot(ctx *workflow.Context, project *mdbv1.AtlasProject, deployment *mdbv1.AtlasDeployment) workflow.Result {
+	policy := deployment.Spec.DeletionPolicy
+	if policy == nil || !policy.SnapshotBeforeDelete || deployment.IsServerless() {
+		return workflow.OK()
+	}
+
+	name := deployment.GetDeploymentName()
+	snapshotID := deployment.Status.FinalSnapshotID
+	if snapshotID == "" {
+		retentionDays := policy.SnapshotRetentionDays
+		if retentionDays == 0 {
+			retentionDays = defaultFinalSnapshotRetentionDays
+		}
+		snapshot, _, err := ctx.Client.CloudProviderSnapshots.Create(context.Background(),
+			&mongodbatlas.SnapshotReqPathParameters{GroupID: project.ID(), ClusterName: name},
+			&mongodbatlas.CloudProviderSnapshot{
+				RetentionInDays: retentionDays,
+				Description:     "Before the deletion of the deployment",
+			})
+		if err != nil {
+			var apiError *mongodbatlas.ErrorResponse
+			if errors.As(err, &apiError) && apiError.ErrorCode == atlas.ClusterNotFound {
+				ctx.Log.Info("Deployment doesn't exist in Atlas, no snapshot is taken before the deletion")
+				return workflow.OK()
+			}
+			return workflow.Terminate(workflow.DeploymentFinalSnapshotFailed, err.Error())
+		}
+		ctx.Log.Infow("Taking the snapshot before the deletion of the deployment", "snapshotID", snapshot.ID)
+		ctx.EnsureStatusOption(status.AtlasDeploymentFinalSnapshotOption(snapshot.ID))
+		return workflow.InProgress(workflow.DeploymentFinalSnapshotInProgress, "Taking the snapshot before the deletion")
+	}
+
+	snapshot, _, err := ctx.Client.CloudProviderSnapshots.GetOneCloudProviderSnapshot(context.Background(),
+		&mongodbatlas.SnapshotReqPathParameters{GroupID: project.ID(), ClusterName: name, SnapshotID: snapshotID})
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+	switch snapshot.Status {
+	case "completed":
+		if result := r.recordFinalSnapshot(project, deployment, snapshot); !result.IsOk() {
+			return result
+		}
+		r.EventRecorder.Eventf(deployment, "Normal", FinalSnapshotTakenEvent, "Snapshot %s of the deployment %s was taken before the deletion", snapshotID, name)
+		return workflow.OK()
+	case "failed":
+		return workflow.Terminate(workflow.DeploymentFinalSnapshotFailed,
+			fmt.Sprintf("the snapshot %s taken before the deletion failed, disable snapshotBeforeDelete to delete the deployment without it", snapshotID))
+	default:
+		return workflow.InProgress(workflow.DeploymentFinalSnapshotInProgress, "Taking the snapshot before the deletion")
+	}
+}
+
+// recordFinalSnapshot creates or updates the ConfigMap with the details of the snapshot taken before the deletion. The
+// ConfigMap isn't owned by the deployment resource so it's kept after the resource is deleted. The existing ConfigMap
+// without the operator label is never overwritten.
+func (r *AtlasDeploymentReconciler) recordFinalSnapshot(project *mdbv1.AtlasProject, deployment *mdbv1.AtlasDeployment, snapshot *mongodbatlas.CloudProviderSnapshot) workflow.Result {
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name:      finalSnapshotConfigMapName(deployment),
+		Namespace: deployment.Namespace,
+	}}
+	getError := r.Client.Get(context.Background(), kube.ObjectKeyFromObject(configMap), configMap)
+	if getError != nil && !apiErrors.IsNotFound(getError) {
+		return workflow.Terminate(workflow.Internal, fmt.Sprintf("failed to record the snapshot taken before the deletion: %s", getError))
+	}
+	if getError == nil && configMap.Labels[finalSnapshotLabelKey] != deployment.Name {
+		return workflow.Terminate(workflow.DeploymentFinalSnapshotNotRecorded,
+			fmt.Sprintf("ConfigMap %s/%s exists and isn't managed by the operator, remove it to record the snapshot %s taken before the deletion", configMap.Namespace, configMap.Name, snapshot.ID))
+	}
+
+	configMap.Data = map[string]string{
+		"projectID":      project.ID(),
+		"deploymentName": deployment.GetDeploymentName(),
+		"snapshotID":     snapshot.ID,
+		"createdAt":      snapshot.CreatedAt,
+		"expiresAt":      snapshot.ExpiresAt,
+		"retainBackups":  strconv.FormatBool(deployment.Spec.DeletionPolicy.RetainsBackups()),
+	}
+	var err error
+	if getError != nil {
+		configMap.Labels = map[string]string{finalSnapshotLabelKey: deployment.Name}
+		err = r.Client.Create(context.Background(), configMap)
+	} else {
+		err = r.Client.Update(context.Background(), configMap)
+	}
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, fmt.Sprintf("failed to record the snapshot taken before the deletion: %s", err))
+	}
+	return workflow.OK()
+}
+
+func finalSnapshotConfigMapName(deployment *mdbv1.AtlasDeployment) string {
+	return fmt.Sprintf("%s-final-snapshot", deployment.Name)
+}
+
+// deleteAdvancedDeploymentRetainingBackups deletes the advanced deployment keeping its snapshots in Atlas. The client
+// doesn't support the retainBackups parameter so the request is sent directly.
+func deleteAdvancedDeploymentRetainingBackups(atlasClient *mongodbatlas.Client) func(context.Context, string, string) (*mongodbatlas.Response, error) {
+	return func(ctx context.Context, groupID, clusterName string) (*mongodbatlas.Response, error) {
+		path := fmt.Sprintf("api/atlas/v1.5/groups/%s/clusters/%s?retainBackups=true", groupID, url.PathEscape(clusterName))
+		req, err := atlasClient.NewRequest(ctx, http.MethodDelete, path, nil)
+		if err != nil {
+			return nil, err
+		}
+		return atlasClient.Do(ctx, req, nil)
+	}
+}
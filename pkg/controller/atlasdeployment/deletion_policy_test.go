@@ -0,0 +1,154 @@
+package atlasdeployment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+)
+
+// cloudProviderSnapshotsStub creates the snapshot and returns it in the given status
+type cloudProviderSnapshotsStub struct {
+	mongodbatlas.CloudProviderSnapshotsService
+
+	status  string
+	created []*mongodbatlas.CloudProviderSnapshot
+}
+
+func (s *cloudProviderSnapshotsStub) Create(_ context.Context, _ *mongodbatlas.SnapshotReqPathParameters, snapshot *mongodbatlas.CloudProviderSnapshot) (*mongodbatlas.CloudProviderSnapshot, *mongodbatlas.Response, error) {
+	s.created = append(s.created, snapshot)
+	return &mongodbatlas.CloudProviderSnapshot{ID: "snapshot-id", Status: "queued"}, nil, nil
+}
+
+func (s *cloudProviderSnapshotsStub) GetOneCloudProviderSnapshot(_ context.Context, params *mongodbatlas.SnapshotReqPathParameters) (*mongodbatlas.CloudProviderSnapshot, *mongodbatlas.Response, error) {
+	return &mongodbatlas.CloudProviderSnapshot{ID: params.SnapshotID, Status: s.status, CreatedAt: "2023-06-09T12:00:00Z"}, nil, nil
+}
+
+func TestEnsureFinalSnapshot(t *testing.T) {
+	newReconciler := func(objects ...client.Object) *AtlasDeploymentReconciler {
+		scheme := runtime.NewScheme()
+		utilruntime.Must(corev1.AddToScheme(scheme))
+		return &AtlasDeploymentReconciler{
+			Client:        fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+			EventRecorder: record.NewFakeRecorder(10),
+		}
+	}
+	project := mdbv1.DefaultProject("ns", "connection")
+	project.Status.ID = "project-id"
+	newDeployment := func(snapshotID string) *mdbv1.AtlasDeployment {
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+		deployment.Spec.DeletionPolicy = &mdbv1.DeletionPolicy{SnapshotBeforeDelete: true}
+		deployment.Status.FinalSnapshotID = snapshotID
+		return deployment
+	}
+
+	t.Run("Snapshot is requested", func(t *testing.T) {
+		stub := &cloudProviderSnapshotsStub{}
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		ctx.Client = mongodbatlas.Client{CloudProviderSnapshots: stub}
+
+		result := newReconciler().ensureFinalSnapshot(ctx, project, newDeployment(""))
+		assert.Equal(t, workflow.InProgress(workflow.DeploymentFinalSnapshotInProgress, "Taking the snapshot before the deletion"), result)
+		require.Len(t, stub.created, 1)
+		assert.Equal(t, defaultFinalSnapshotRetentionDays, stub.created[0].RetentionInDays)
+
+		deploymentStatus := status.AtlasDeploymentStatus{}
+		for _, option := range ctx.StatusOptions() {
+			option.(status.AtlasDeploymentStatusOption)(&deploymentStatus)
+		}
+		assert.Equal(t, "snapshot-id", deploymentStatus.FinalSnapshotID)
+	})
+	t.Run("Deletion waits for the snapshot", func(t *testing.T) {
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		ctx.Client = mongodbatlas.Client{CloudProviderSnapshots: &cloudProviderSnapshotsStub{status: "inProgress"}}
+
+		result := newReconciler().ensureFinalSnapshot(ctx, project, newDeployment("snapshot-id"))
+		assert.False(t, result.IsOk())
+	})
+	t.Run("Completed snapshot is recorded", func(t *testing.T) {
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		ctx.Client = mongodbatlas.Client{CloudProviderSnapshots: &cloudProviderSnapshotsStub{status: "completed"}}
+		r := newReconciler()
+		deployment := newDeployment("snapshot-id")
+
+		result := r.ensureFinalSnapshot(ctx, project, deployment)
+		require.True(t, result.IsOk())
+
+		configMap := &corev1.ConfigMap{}
+		require.NoError(t, r.Client.Get(context.Background(), kube.ObjectKey("ns", finalSnapshotConfigMapName(deployment)), configMap))
+		assert.Equal(t, map[string]string{
+			"projectID":      "project-id",
+			"deploymentName": deployment.GetDeploymentName(),
+			"snapshotID":     "snapshot-id",
+			"createdAt":      "2023-06-09T12:00:00Z",
+			"expiresAt":      "",
+			"retainBackups":  "true",
+		}, configMap.Data)
+		assert.Equal(t, deployment.Name, configMap.Labels[finalSnapshotLabelKey])
+		assert.Contains(t, <-r.EventRecorder.(*record.FakeRecorder).Events, "Normal FinalSnapshotTaken Snapshot snapshot-id")
+	})
+	t.Run("ConfigMap recorded by the operator is updated", func(t *testing.T) {
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		ctx.Client = mongodbatlas.Client{CloudProviderSnapshots: &cloudProviderSnapshotsStub{status: "completed"}}
+		deployment := newDeployment("snapshot-id")
+		r := newReconciler(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      finalSnapshotConfigMapName(deployment),
+				Namespace: "ns",
+				Labels:    map[string]string{finalSnapshotLabelKey: deployment.Name},
+			},
+			Data: map[string]string{"snapshotID": "previous-snapshot-id"},
+		})
+
+		result := r.ensureFinalSnapshot(ctx, project, deployment)
+		require.True(t, result.IsOk())
+
+		configMap := &corev1.ConfigMap{}
+		require.NoError(t, r.Client.Get(context.Background(), kube.ObjectKey("ns", finalSnapshotConfigMapName(deployment)), configMap))
+		assert.Equal(t, "snapshot-id", configMap.Data["snapshotID"])
+	})
+	t.Run("ConfigMap not managed by the operator isn't overwritten", func(t *testing.T) {
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		ctx.Client = mongodbatlas.Client{CloudProviderSnapshots: &cloudProviderSnapshotsStub{status: "completed"}}
+		deployment := newDeployment("snapshot-id")
+		r := newReconciler(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: finalSnapshotConfigMapName(deployment), Namespace: "ns"},
+			Data:       map[string]string{"key": "value"},
+		})
+
+		result := r.ensureFinalSnapshot(ctx, project, deployment)
+		assert.Equal(t, workflow.Terminate(workflow.DeploymentFinalSnapshotNotRecorded, result.GetMessage()), result)
+
+		configMap := &corev1.ConfigMap{}
+		require.NoError(t, r.Client.Get(context.Background(), kube.ObjectKey("ns", finalSnapshotConfigMapName(deployment)), configMap))
+		assert.Equal(t, map[string]string{"key": "value"}, configMap.Data)
+	})
+	t.Run("Failed snapshot blocks the deletion", func(t *testing.T) {
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		ctx.Client = mongodbatlas.Client{CloudProviderSnapshots: &cloudProviderSnapshotsStub{status: "failed"}}
+
+		result := newReconciler().ensureFinalSnapshot(ctx, project, newDeployment("snapshot-id"))
+		assert.Equal(t, workflow.Terminate(workflow.DeploymentFinalSnapshotFailed, result.GetMessage()), result)
+	})
+	t.Run("No snapshot without the policy", func(t *testing.T) {
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+
+		result := newReconciler().ensureFinalSnapshot(ctx, project, mdbv1.DefaultAwsAdvancedDeployment("ns", "project"))
+		assert.True(t, result.IsOk())
+	})
+}
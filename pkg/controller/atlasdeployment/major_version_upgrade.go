@@ -0,0 +1,256 @@
+package atlasdeployment
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/timeutil"
+)
+
+const defaultUpgradeSnapshotRetentionDays = 7
+
+// knownMajorVersions are the MongoDB major versions in the order the deployments are upgraded through
+var knownMajorVersions = []string{"3.6", "4.0", "4.2", "4.4", "5.0", "6.0", "7.0"}
+
+// ensureMajorVersionUpgrade runs the pre-flight checks of the MongoDB major version upgrade. Until the upgrade can
+// start, the major version of the advanced deployment is held back at the version running in Atlas so the other
+// changes are still applied. The current deployment is nil if it doesn't exist in Atlas yet. It returns the upgrade if
+// it is started by this reconciliation and the time the upgrade window opens if the upgrade waits for it.
+func ensureMajorVersionUpgrade(ctx *workflow.Context, project *mdbv1.AtlasProject, deployment *mdbv1.AtlasDeployment, current *mongodbatlas.AdvancedCluster, now time.Time) (*status.MajorVersionUpgrade, time.Time, workflow.Result) {
+	advancedDeploymentSpec := deployment.Spec.AdvancedDeploymentSpec
+	if deployment.Spec.MajorVersionUpgrade == nil || advancedDeploymentSpec == nil || advancedDeploymentSpec.MongoDBMajorVersion == "" {
+		finishMajorVersionUpgrade(ctx)
+		return nil, time.Time{}, workflow.OK()
+	}
+
+	if current == nil {
+		// the new deployment is created with the major version from the spec
+		finishMajorVersionUpgrade(ctx)
+		return nil, time.Time{}, workflow.OK()
+	}
+
+	target := advancedDeploymentSpec.MongoDBMajorVersion
+	upgrade := deployment.Status.MajorVersionUpgrade
+	if upgrade == nil || upgrade.TargetVersion != target {
+		upgrade = &status.MajorVersionUpgrade{TargetVersion: target}
+	}
+
+	if upgrade.StartedAt != "" {
+		ctx.EnsureStatusOption(status.AtlasDeploymentMongoDBVersionOption(current.MongoDBVersion))
+		if current.StateName != status.StateIDLE {
+			ctx.EnsureCondition(status.Condition{
+				Type:    status.UpgradeInProgressType,
+				Status:  corev1.ConditionTrue,
+				Reason:  string(workflow.MajorVersionUpgrading),
+				Message: fmt.Sprintf("Upgrading from %s to %s", current.MongoDBVersion, target),
+			})
+			return nil, time.Time{}, workflow.OK()
+		}
+		if !runsMajorVersion(current.MongoDBVersion, target) {
+			advancedDeploymentSpec.MongoDBMajorVersion = current.MongoDBMajorVersion
+			failMajorVersionUpgrade(ctx, deployment, upgrade,
+				fmt.Sprintf("the deployment is IDLE running MongoDB %s instead of %s", current.MongoDBVersion, target))
+			return nil, time.Time{}, workflow.OK()
+		}
+		ctx.Log.Infow("MongoDB major version upgrade finished", "version", current.MongoDBVersion)
+		finishMajorVersionUpgrade(ctx)
+		return nil, time.Time{}, workflow.OK()
+	}
+
+	if current.MongoDBMajorVersion == target {
+		finishMajorVersionUpgrade(ctx)
+		return nil, time.Time{}, workflow.OK()
+	}
+
+	if upgrade.Failure != "" {
+		if upgrade.FailedGeneration == deployment.Generation {
+			advancedDeploymentSpec.MongoDBMajorVersion = current.MongoDBMajorVersion
+			ctx.SetConditionFromResult(status.UpgradeInProgressType, workflow.Terminate(workflow.MajorVersionUpgradeFailed, upgrade.Failure))
+			return nil, time.Time{}, workflow.OK()
+		}
+		// the spec has changed since the failure so the upgrade is attempted again
+		upgrade = &status.MajorVersionUpgrade{TargetVersion: target}
+	}
+
+	if err := validateUpgradePath(current.MongoDBMajorVersion, target); err != nil {
+		result := workflow.Terminate(workflow.MajorVersionUpgradeInvalid, err.Error())
+		ctx.SetConditionFromResult(status.UpgradeInProgressType, result)
+		return nil, time.Time{}, result
+	}
+
+	advancedDeploymentSpec.MongoDBMajorVersion = current.MongoDBMajorVersion
+
+	if current.StateName != status.StateIDLE {
+		ctx.SetConditionFromResult(status.UpgradeInProgressType,
+			workflow.InProgress(workflow.MajorVersionUpgradePending, fmt.Sprintf("Upgrade to %s is waiting for the deployment to become IDLE", target)))
+		return nil, time.Time{}, workflow.OK()
+	}
+
+	// once the snapshot is taken the upgrade doesn't wait for the window again
+	if upgrade.SnapshotID == "" {
+		window, location, err := upgradeWindow(deployment.Spec.MajorVersionUpgrade, project.Spec.MaintenanceWindow)
+		if err != nil {
+			result := workflow.Terminate(workflow.MajorVersionUpgradeInvalid, fmt.Sprintf("invalid upgrade window: %s", err))
+			ctx.SetConditionFromResult(status.UpgradeInProgressType, result)
+			return nil, time.Time{}, result
+		}
+		if window != nil {
+			if active, opensAt := window.activeAt(now.In(location)); !active {
+				ctx.SetConditionFromResult(status.UpgradeInProgressType,
+					workflow.InProgress(workflow.MajorVersionUpgradePending, fmt.Sprintf("Upgrade to %s is waiting for the upgrade window opening at %s", target, timeutil.FormatISO8601(opensAt.UTC()))))
+				return nil, opensAt, workflow.OK()
+			}
+		}
+	}
+
+	if deployment.Spec.MajorVersionUpgrade.SnapshotBeforeUpgrade {
+		if result := ensureUpgradeSnapshot(ctx, project.ID(), deployment, upgrade); !result.IsOk() {
+			ctx.SetConditionFromResult(status.UpgradeInProgressType, result)
+			return nil, time.Time{}, result
+		}
+	}
+
+	ctx.Log.Infow("Starting MongoDB major version upgrade", "from", current.MongoDBMajorVersion, "to", target)
+	advancedDeploymentSpec.MongoDBMajorVersion = target
+	upgrade.StartedAt = timeutil.FormatISO8601(now.UTC())
+	ctx.EnsureStatusOption(status.AtlasDeploymentMajorVersionUpgradeOption(upgrade))
+	ctx.EnsureCondition(status.Condition{
+		Type:    status.UpgradeInProgressType,
+		Status:  corev1.ConditionTrue,
+		Reason:  string(workflow.MajorVersionUpgrading),
+		Message: fmt.Sprintf("Upgrading from %s to %s", current.MongoDBVersion, target),
+	})
+	return upgrade, time.Time{}, workflow.OK()
+}
+
+// ensureUpgradeSnapshot takes the on-demand snapshot before the upgrade and waits for it to complete
+func ensureUpgradeSnapshot(ctx *workflow.Context, projectID string, deployment *mdbv1.AtlasDeployment, upgrade *status.MajorVersionUpgrade) workflow.Result {
+	name := deployment.Spec.AdvancedDeploymentSpec.Name
+	if upgrade.SnapshotID == "" {
+		retentionDays := deployment.Spec.MajorVersionUpgrade.SnapshotRetentionDays
+		if retentionDays == 0 {
+			retentionDays = defaultUpgradeSnapshotRetentionDays
+		}
+		snapshot, _, err := ctx.Client.CloudProviderSnapshots.Create(context.Background(),
+			&mongodbatlas.SnapshotReqPathParameters{GroupID: projectID, ClusterName: name},
+			&mongodbatlas.CloudProviderSnapshot{
+				RetentionInDays: retentionDays,
+				Description:     fmt.Sprintf("Before the upgrade to MongoDB %s", upgrade.TargetVersion),
+			})
+		if err != nil {
+			return workflow.Terminate(workflow.MajorVersionUpgradeSnapshotFailed, err.Error())
+		}
+		ctx.Log.Infow("Taking the snapshot before the MongoDB major version upgrade", "snapshotID", snapshot.ID)
+		upgrade.SnapshotID = snapshot.ID
+		ctx.EnsureStatusOption(status.AtlasDeploymentMajorVersionUpgradeOption(upgrade))
+		return workflow.InProgress(workflow.MajorVersionUpgradeSnapshotInProgress, "Taking the snapshot before the upgrade")
+	}
+
+	snapshot, _, err := ctx.Client.CloudProviderSnapshots.GetOneCloudProviderSnapshot(context.Background(),
+		&mongodbatlas.SnapshotReqPathParameters{GroupID: projectID, ClusterName: name, SnapshotID: upgrade.SnapshotID})
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+	switch snapshot.Status {
+	case "completed":
+		return workflow.OK()
+	case "failed":
+		return workflow.Terminate(workflow.MajorVersionUpgradeSnapshotFailed,
+			fmt.Sprintf("the snapshot %s taken before the upgrade failed, disable snapshotBeforeUpgrade to upgrade without it", upgrade.SnapshotID))
+	default:
+		return workflow.InProgress(workflow.MajorVersionUpgradeSnapshotInProgress, "Taking the snapshot before the upgrade")
+	}
+}
+
+// failMajorVersionUpgrade records the failed upgrade. It isn't attempted again until the spec of the deployment changes.
+func failMajorVersionUpgrade(ctx *workflow.Context, deployment *mdbv1.AtlasDeployment, upgrade *status.MajorVersionUpgrade, message string) {
+	ctx.Log.Errorw("MongoDB major version upgrade failed", "version", upgrade.TargetVersion, "reason", message)
+	upgrade.StartedAt = ""
+	upgrade.Failure = message
+	upgrade.FailedGeneration = deployment.Generation
+	ctx.EnsureStatusOption(status.AtlasDeploymentMajorVersionUpgradeOption(upgrade))
+	ctx.SetConditionFromResult(status.UpgradeInProgressType, workflow.Terminate(workflow.MajorVersionUpgradeFailed, message))
+}
+
+func finishMajorVersionUpgrade(ctx *workflow.Context) {
+	ctx.UnsetCondition(status.UpgradeInProgressType)
+	ctx.EnsureStatusOption(status.AtlasDeploymentMajorVersionUpgradeOption(nil))
+}
+
+// upgradeWindow returns the window from the upgrade configuration or the hour starting at the maintenance window of
+// the project. No window is returned if neither is configured.
+func upgradeWindow(upgrade *mdbv1.MajorVersionUpgrade, maintenanceWindow project.MaintenanceWindow) (*cronWindow, *time.Location, error) {
+	if upgrade.Window != nil {
+		return parseCronWindow(upgrade.Window.Start, upgrade.Window.End, upgrade.Window.TimeZone)
+	}
+	if maintenanceWindow.DayOfWeek == 0 {
+		return nil, time.UTC, nil
+	}
+
+	// Atlas counts the days of week from 1 for Sunday while cron counts them from 0
+	startDay := maintenanceWindow.DayOfWeek - 1
+	endHour, endDay := maintenanceWindow.HourOfDay+1, startDay
+	if endHour == 24 {
+		endHour, endDay = 0, (startDay+1)%7
+	}
+	return parseCronWindow(
+		fmt.Sprintf("0 %d * * %d", maintenanceWindow.HourOfDay, startDay),
+		fmt.Sprintf("0 %d * * %d", endHour, endDay),
+		"",
+	)
+}
+
+// validateUpgradePath allows upgrading only to the next major version
+func validateUpgradePath(current, target string) error {
+	currentMajor, currentMinor, err := parseMajorVersion(current)
+	if err != nil {
+		return err
+	}
+	targetMajor, targetMinor, err := parseMajorVersion(target)
+	if err != nil {
+		return err
+	}
+	if targetMajor < currentMajor || (targetMajor == currentMajor && targetMinor < currentMinor) {
+		return fmt.Errorf("downgrading MongoDB from %s to %s is not supported", current, target)
+	}
+
+	currentIdx := slices.Index(knownMajorVersions, current)
+	targetIdx := slices.Index(knownMajorVersions, target)
+	if currentIdx >= 0 && targetIdx >= 0 {
+		if targetIdx != currentIdx+1 {
+			return fmt.Errorf("upgrading MongoDB from %s to %s skips major versions, upgrade to %s first", current, target, knownMajorVersions[currentIdx+1])
+		}
+		return nil
+	}
+
+	// the versions released after the known ones
+	if targetMajor != currentMajor+1 || targetMinor != 0 {
+		return fmt.Errorf("upgrading MongoDB from %s to %s is not supported, upgrade to %d.0 first", current, target, currentMajor+1)
+	}
+	return nil
+}
+
+func parseMajorVersion(version string) (int, int, error) {
+	majorValue, minorValue, found := strings.Cut(version, ".")
+	major, majorErr := strconv.Atoi(majorValue)
+	minor, minorErr := strconv.Atoi(minorValue)
+	if !found || majorErr != nil || minorErr != nil {
+		return 0, 0, fmt.Errorf("invalid MongoDB major version %q", version)
+	}
+	return major, minor, nil
+}
+
+func runsMajorVersion(version, majorVersion string) bool {
+	return version == majorVersion || strings.HasPrefix(version, majorVersion+".")
+}
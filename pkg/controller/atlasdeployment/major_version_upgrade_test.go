@@ -0,0 +1,202 @@
+package atlasdeployment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/timeutil"
+)
+
+func TestValidateUpgradePath(t *testing.T) {
+	tests := []struct {
+		current, target string
+		valid           bool
+	}{
+		{current: "4.4", target: "5.0", valid: true},
+		{current: "5.0", target: "6.0", valid: true},
+		{current: "4.2", target: "4.4", valid: true},
+		{current: "7.0", target: "8.0", valid: true},
+		{current: "5.0", target: "7.0", valid: false},
+		{current: "6.0", target: "5.0", valid: false},
+		{current: "7.0", target: "9.0", valid: false},
+		{current: "6.0", target: "latest", valid: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.current+" to "+tt.target, func(t *testing.T) {
+			err := validateUpgradePath(tt.current, tt.target)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+
+	assert.EqualError(t, validateUpgradePath("5.0", "7.0"), "upgrading MongoDB from 5.0 to 7.0 skips major versions, upgrade to 6.0 first")
+}
+
+func TestUpgradeWindow(t *testing.T) {
+	// 2023-06-10 is Saturday
+	saturday := func(hour, minute int) time.Time {
+		return time.Date(2023, 6, 10, hour, minute, 0, 0, time.UTC)
+	}
+
+	t.Run("Maintenance window of the project", func(t *testing.T) {
+		window, location, err := upgradeWindow(&mdbv1.MajorVersionUpgrade{}, project.NewMaintenanceWindow().WithDay(7).WithHour(23))
+		require.NoError(t, err)
+		require.NotNil(t, window)
+
+		active, next := window.activeAt(saturday(23, 30).In(location))
+		assert.True(t, active)
+		assert.Equal(t, time.Date(2023, 6, 11, 0, 0, 0, 0, time.UTC), next)
+
+		active, next = window.activeAt(saturday(12, 0).In(location))
+		assert.False(t, active)
+		assert.Equal(t, saturday(23, 0), next)
+	})
+	t.Run("Explicit window takes precedence", func(t *testing.T) {
+		upgrade := &mdbv1.MajorVersionUpgrade{Window: &mdbv1.UpgradeWindow{Start: "0 10 * * sat", End: "0 14 * * sat"}}
+		window, location, err := upgradeWindow(upgrade, project.NewMaintenanceWindow().WithDay(7).WithHour(23))
+		require.NoError(t, err)
+
+		active, _ := window.activeAt(saturday(12, 0).In(location))
+		assert.True(t, active)
+	})
+	t.Run("No window", func(t *testing.T) {
+		window, _, err := upgradeWindow(&mdbv1.MajorVersionUpgrade{}, project.NewMaintenanceWindow())
+		require.NoError(t, err)
+		assert.Nil(t, window)
+	})
+}
+
+func TestRunsMajorVersion(t *testing.T) {
+	assert.True(t, runsMajorVersion("6.0.8", "6.0"))
+	assert.True(t, runsMajorVersion("6.0", "6.0"))
+	assert.False(t, runsMajorVersion("5.0.19", "6.0"))
+	assert.False(t, runsMajorVersion("6.0.8", "6.1"))
+}
+
+func TestEnsureMajorVersionUpgrade(t *testing.T) {
+	now := time.Date(2023, 6, 9, 12, 0, 0, 0, time.UTC)
+	project := mdbv1.DefaultProject("ns", "connection")
+	project.Status.ID = "project-id"
+	newDeployment := func() *mdbv1.AtlasDeployment {
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+		deployment.Spec.AdvancedDeploymentSpec.MongoDBMajorVersion = "6.0"
+		deployment.Spec.MajorVersionUpgrade = &mdbv1.MajorVersionUpgrade{SnapshotBeforeUpgrade: true}
+		return deployment
+	}
+	atlasDeployment := func(stateName, version string) *mongodbatlas.AdvancedCluster {
+		return &mongodbatlas.AdvancedCluster{
+			Name:                "test-deployment-advanced",
+			StateName:           stateName,
+			MongoDBMajorVersion: version[:3],
+			MongoDBVersion:      version,
+		}
+	}
+	reconcile := func(deployment *mdbv1.AtlasDeployment, cluster *mongodbatlas.AdvancedCluster, snapshots *cloudProviderSnapshotsStub) (*workflow.Context, *status.MajorVersionUpgrade, workflow.Result) {
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		ctx.Client = mongodbatlas.Client{CloudProviderSnapshots: snapshots}
+		started, _, result := ensureMajorVersionUpgrade(ctx, project, deployment, cluster, now)
+		applyStatusOptions(ctx, deployment)
+		return ctx, started, result
+	}
+	// startUpgrade takes the snapshot and starts the upgrade of the deployment running 5.0
+	startUpgrade := func(t *testing.T, deployment *mdbv1.AtlasDeployment) {
+		snapshots := &cloudProviderSnapshotsStub{status: "queued"}
+		_, started, result := reconcile(deployment, atlasDeployment(status.StateIDLE, "5.0.19"), snapshots)
+		assert.Equal(t, workflow.MajorVersionUpgradeSnapshotInProgress, result.GetReason())
+		assert.Nil(t, started)
+		assert.Equal(t, "5.0", deployment.Spec.AdvancedDeploymentSpec.MongoDBMajorVersion)
+		require.Len(t, snapshots.created, 1)
+		require.NotNil(t, deployment.Status.MajorVersionUpgrade)
+		assert.Equal(t, "snapshot-id", deployment.Status.MajorVersionUpgrade.SnapshotID)
+
+		deployment.Spec.AdvancedDeploymentSpec.MongoDBMajorVersion = "6.0"
+		snapshots.status = "completed"
+		ctx, started, result := reconcile(deployment, atlasDeployment(status.StateIDLE, "5.0.19"), snapshots)
+		assert.True(t, result.IsOk())
+		require.NotNil(t, started)
+		assert.Equal(t, "6.0", deployment.Spec.AdvancedDeploymentSpec.MongoDBMajorVersion)
+		assert.Equal(t, timeutil.FormatISO8601(now), deployment.Status.MajorVersionUpgrade.StartedAt)
+		condition, found := ctx.GetCondition(status.UpgradeInProgressType)
+		require.True(t, found)
+		assert.Equal(t, string(workflow.MajorVersionUpgrading), condition.Reason)
+
+		ctx, _, result = reconcile(deployment, atlasDeployment(status.StateUPDATING, "5.0.19"), snapshots)
+		assert.True(t, result.IsOk())
+		condition, _ = ctx.GetCondition(status.UpgradeInProgressType)
+		assert.Equal(t, string(workflow.MajorVersionUpgrading), condition.Reason)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	}
+
+	t.Run("Upgrade finishes once the deployment runs the target version", func(t *testing.T) {
+		deployment := newDeployment()
+		startUpgrade(t, deployment)
+
+		ctx, _, result := reconcile(deployment, atlasDeployment(status.StateIDLE, "6.0.8"), &cloudProviderSnapshotsStub{})
+		assert.True(t, result.IsOk())
+		assert.Nil(t, deployment.Status.MajorVersionUpgrade)
+		assert.Equal(t, "6.0.8", deployment.Status.MongoDBVersion)
+		_, found := ctx.GetCondition(status.UpgradeInProgressType)
+		assert.False(t, found)
+	})
+	t.Run("Upgrade fails if the deployment is IDLE on the previous version", func(t *testing.T) {
+		deployment := newDeployment()
+		deployment.Generation = 2
+		startUpgrade(t, deployment)
+
+		ctx, _, result := reconcile(deployment, atlasDeployment(status.StateIDLE, "5.0.19"), &cloudProviderSnapshotsStub{})
+		assert.True(t, result.IsOk())
+		assert.Equal(t, "5.0", deployment.Spec.AdvancedDeploymentSpec.MongoDBMajorVersion)
+		require.NotNil(t, deployment.Status.MajorVersionUpgrade)
+		assert.Empty(t, deployment.Status.MajorVersionUpgrade.StartedAt)
+		assert.Equal(t, "the deployment is IDLE running MongoDB 5.0.19 instead of 6.0", deployment.Status.MajorVersionUpgrade.Failure)
+		assert.Equal(t, int64(2), deployment.Status.MajorVersionUpgrade.FailedGeneration)
+		assert.Equal(t, "5.0.19", deployment.Status.MongoDBVersion)
+		condition, found := ctx.GetCondition(status.UpgradeInProgressType)
+		require.True(t, found)
+		assert.Equal(t, string(workflow.MajorVersionUpgradeFailed), condition.Reason)
+		assert.Equal(t, corev1.ConditionFalse, condition.Status)
+
+		// the failed upgrade isn't attempted again until the spec changes
+		deployment.Spec.AdvancedDeploymentSpec.MongoDBMajorVersion = "6.0"
+		snapshots := &cloudProviderSnapshotsStub{}
+		ctx, started, result := reconcile(deployment, atlasDeployment(status.StateIDLE, "5.0.19"), snapshots)
+		assert.True(t, result.IsOk())
+		assert.Nil(t, started)
+		assert.Empty(t, snapshots.created)
+		assert.Equal(t, "5.0", deployment.Spec.AdvancedDeploymentSpec.MongoDBMajorVersion)
+		condition, _ = ctx.GetCondition(status.UpgradeInProgressType)
+		assert.Equal(t, string(workflow.MajorVersionUpgradeFailed), condition.Reason)
+
+		deployment.Spec.AdvancedDeploymentSpec.MongoDBMajorVersion = "6.0"
+		deployment.Generation = 3
+		_, _, result = reconcile(deployment, atlasDeployment(status.StateIDLE, "5.0.19"), snapshots)
+		assert.Equal(t, workflow.MajorVersionUpgradeSnapshotInProgress, result.GetReason())
+		assert.Len(t, snapshots.created, 1)
+		assert.Empty(t, deployment.Status.MajorVersionUpgrade.Failure)
+	})
+	t.Run("Upgrade fails if the deployment isn't updated in Atlas", func(t *testing.T) {
+		deployment := newDeployment()
+		startUpgrade(t, deployment)
+
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		failMajorVersionUpgrade(ctx, deployment, deployment.Status.MajorVersionUpgrade, "UNEXPECTED_ERROR")
+		applyStatusOptions(ctx, deployment)
+		assert.Empty(t, deployment.Status.MajorVersionUpgrade.StartedAt)
+		assert.Equal(t, "UNEXPECTED_ERROR", deployment.Status.MajorVersionUpgrade.Failure)
+		condition, _ := ctx.GetCondition(status.UpgradeInProgressType)
+		assert.Equal(t, string(workflow.MajorVersionUpgradeFailed), condition.Reason)
+	})
+}
@@ -0,0 +1,268 @@
+package atlasdeployment
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/set"
+)
+
+// atlasOnlineArchive is the alias for the Atlas Online Archive implementing the 'Identifiable' interface
+type atlasOnlineArchive mongodbatlas.OnlineArchive
+
+func (a atlasOnlineArchive) Identifier() interface{} {
+	return status.OnlineArchiveIdentifier(a.DBName, a.CollName)
+}
+
+// ensureOnlineArchives creates, updates, pauses and removes the Online Archives of the deployment.
+// Only the Online Archives created by the operator (the ones listed in the status) are removed from Atlas, the ones
+// created outside the operator are left intact.
+func ensureOnlineArchives(ctx *workflow.Context, projectID string, deployment *mdbv1.AtlasDeployment, deploymentName string) workflow.Result {
+	onlineArchives := deployment.Spec.OnlineArchives
+	if len(onlineArchives) == 0 && len(deployment.Status.OnlineArchives) == 0 {
+		ctx.UnsetCondition(status.OnlineArchivesReadyType)
+		return workflow.OK()
+	}
+
+	result := syncOnlineArchives(ctx, projectID, deployment, deploymentName)
+	if !result.IsOk() {
+		ctx.SetConditionFromResult(status.OnlineArchivesReadyType, result)
+		return result
+	}
+
+	if len(onlineArchives) == 0 {
+		ctx.UnsetCondition(status.OnlineArchivesReadyType)
+	} else {
+		ctx.SetConditionTrue(status.OnlineArchivesReadyType)
+	}
+	return result
+}
+
+func syncOnlineArchives(ctx *workflow.Context, projectID string, deployment *mdbv1.AtlasDeployment, deploymentName string) workflow.Result {
+	onlineArchives := deployment.Spec.OnlineArchives
+
+	atlasArchives, err := listOnlineArchives(ctx, projectID, deploymentName)
+	if err != nil {
+		return workflow.Terminate(workflow.OnlineArchivesFailed, fmt.Sprintf("Failed to list online archives: %v", err))
+	}
+
+	changed := make([]string, 0)
+	for _, pair := range set.Intersection(onlineArchives, atlasArchives) {
+		archive := pair[0].(mdbv1.OnlineArchive)
+		current := pair[1].(atlasOnlineArchive)
+		if !onlineArchivesImmutableEqual(archive.ToAtlas(), (*mongodbatlas.OnlineArchive)(&current)) {
+			changed = append(changed, archive.Identifier().(string))
+		}
+	}
+	if len(changed) > 0 {
+		result := workflow.Terminate(workflow.OnlineArchivesInvalid,
+			fmt.Sprintf("the partition fields and the collection type of the online archives %s can't be changed, remove the archives from the spec and add them back once deleted to recreate them", strings.Join(changed, ", "))).WithoutRetry()
+		ctx.SetConditionFromResult(status.ValidationSucceeded, result)
+		return result
+	}
+
+	ownedArchives := make([]atlasOnlineArchive, 0, len(deployment.Status.OnlineArchives))
+	for _, pair := range set.Intersection(atlasArchives, deployment.Status.OnlineArchives) {
+		ownedArchives = append(ownedArchives, pair[0].(atlasOnlineArchive))
+	}
+	for _, item := range set.Difference(ownedArchives, onlineArchives) {
+		archive := item.(atlasOnlineArchive)
+		if _, err = ctx.Client.OnlineArchives.Delete(context.Background(), projectID, deploymentName, archive.ID); err != nil {
+			return workflow.Terminate(workflow.OnlineArchivesFailed, fmt.Sprintf("Failed to delete online archive %s: %v", archive.Identifier(), err))
+		}
+		ctx.Log.Debugw("Online archive removed from Atlas", "archive", archive.Identifier())
+	}
+
+	statuses := map[interface{}]status.OnlineArchive{}
+	for _, item := range set.Difference(onlineArchives, atlasArchives) {
+		archive := item.(mdbv1.OnlineArchive)
+		statuses[archive.Identifier()] = createOnlineArchive(ctx, projectID, deploymentName, &archive)
+	}
+	for _, pair := range set.Intersection(onlineArchives, atlasArchives) {
+		archive := pair[0].(mdbv1.OnlineArchive)
+		current := pair[1].(atlasOnlineArchive)
+		statuses[archive.Identifier()] = updateOnlineArchive(ctx, projectID, deploymentName, &archive, &current)
+	}
+
+	archiveStatuses := make([]status.OnlineArchive, 0, len(onlineArchives))
+	for _, archive := range onlineArchives {
+		archiveStatuses = append(archiveStatuses, statuses[archive.Identifier()])
+	}
+	ctx.EnsureStatusOption(status.AtlasDeploymentOnlineArchivesOption(archiveStatuses))
+
+	return checkOnlineArchivesStatus(archiveStatuses)
+}
+
+// listOnlineArchives returns the Online Archives of the deployment skipping the deleted ones
+func listOnlineArchives(ctx *workflow.Context, projectID, deploymentName string) ([]atlasOnlineArchive, error) {
+	result := make([]atlasOnlineArchive, 0)
+	listOptions := &mongodbatlas.ListOptions{PageNum: 1, ItemsPerPage: 500}
+	for {
+		archives, _, err := ctx.Client.OnlineArchives.List(context.Background(), projectID, deploymentName, listOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, archive := range archives.Results {
+			if archive != nil && archive.State != status.OnlineArchiveStateDeleted {
+				result = append(result, atlasOnlineArchive(*archive))
+			}
+		}
+		if len(archives.Results) < listOptions.ItemsPerPage {
+			return result, nil
+		}
+		listOptions.PageNum++
+	}
+}
+
+func createOnlineArchive(ctx *workflow.Context, projectID, deploymentName string, archive *mdbv1.OnlineArchive) status.OnlineArchive {
+	created, _, err := ctx.Client.OnlineArchives.Create(context.Background(), projectID, deploymentName, archive.ToAtlas())
+	if err != nil {
+		return newFailedOnlineArchiveStatus(archive, "", err)
+	}
+	ctx.Log.Debugw("Online archive created in Atlas", "archive", archive.Identifier())
+
+	return newOnlineArchiveStatus(created)
+}
+
+func updateOnlineArchive(ctx *workflow.Context, projectID, deploymentName string, archive *mdbv1.OnlineArchive, current *atlasOnlineArchive) status.OnlineArchive {
+	desired := archive.ToAtlas()
+	if onlineArchivesEqual(desired, (*mongodbatlas.OnlineArchive)(current)) {
+		return newOnlineArchiveStatus((*mongodbatlas.OnlineArchive)(current))
+	}
+
+	// Only the criteria and the paused flag can be changed after the Online Archive is created
+	update := &mongodbatlas.OnlineArchive{
+		Criteria: desired.Criteria,
+		Paused:   desired.Paused,
+	}
+	updated, _, err := ctx.Client.OnlineArchives.Update(context.Background(), projectID, deploymentName, current.ID, update)
+	if err != nil {
+		return newFailedOnlineArchiveStatus(archive, current.ID, err)
+	}
+	ctx.Log.Debugw("Online archive updated in Atlas", "archive", archive.Identifier())
+
+	return newOnlineArchiveStatus(updated)
+}
+
+// onlineArchivesEqual compares the mutable configuration of the Online Archives. The date format is only compared if
+// it's specified as Atlas always returns the default one.
+func onlineArchivesEqual(desired, current *mongodbatlas.OnlineArchive) bool {
+	if boolValue(desired.Paused) != boolValue(current.Paused) {
+		return false
+	}
+	if current.Criteria == nil {
+		return false
+	}
+
+	if desired.Criteria.Type != current.Criteria.Type ||
+		desired.Criteria.DateField != current.Criteria.DateField ||
+		desired.Criteria.Query != current.Criteria.Query {
+		return false
+	}
+	if desired.Criteria.DateFormat != "" && desired.Criteria.DateFormat != current.Criteria.DateFormat {
+		return false
+	}
+	return floatValue(desired.Criteria.ExpireAfterDays) == floatValue(current.Criteria.ExpireAfterDays)
+}
+
+// onlineArchivesImmutableEqual compares the configuration of the Online Archives that can't be changed after they
+// are created. Atlas appends the date field of the criteria to the partition fields unless it's listed already and
+// always returns the default collection type, so both are only compared if they are specified.
+func onlineArchivesImmutableEqual(desired, current *mongodbatlas.OnlineArchive) bool {
+	if desired.CollectionType != "" && current.CollectionType != "" && desired.CollectionType != current.CollectionType {
+		return false
+	}
+
+	desiredFields := partitionFieldNames(desired.PartitionFields)
+	currentFields := partitionFieldNames(current.PartitionFields)
+	if current.Criteria != nil && current.Criteria.DateField != "" && len(currentFields) == len(desiredFields)+1 &&
+		currentFields[len(currentFields)-1] == current.Criteria.DateField {
+		currentFields = currentFields[:len(currentFields)-1]
+	}
+	if len(desiredFields) != len(currentFields) {
+		return false
+	}
+	for i := range desiredFields {
+		if desiredFields[i] != currentFields[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// partitionFieldNames returns the names of the partition fields in their order
+func partitionFieldNames(fields []*mongodbatlas.PartitionFields) []string {
+	sorted := make([]*mongodbatlas.PartitionFields, 0, len(fields))
+	for _, field := range fields {
+		if field != nil {
+			sorted = append(sorted, field)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return floatValue(sorted[i].Order) < floatValue(sorted[j].Order)
+	})
+	names := make([]string, 0, len(sorted))
+	for _, field := range sorted {
+		names = append(names, field.FieldName)
+	}
+	return names
+}
+
+func checkOnlineArchivesStatus(onlineArchives []status.OnlineArchive) workflow.Result {
+	failed := make([]string, 0)
+	inProgress := false
+	for _, archive := range onlineArchives {
+		switch archive.State {
+		case status.OnlineArchiveStateActive, status.OnlineArchiveStateArchiving, status.OnlineArchiveStateIdle, status.OnlineArchiveStatePaused:
+		case status.OnlineArchiveStateFailed, status.OnlineArchiveStateOrphaned:
+			failed = append(failed, archive.Identifier().(string))
+		default:
+			inProgress = true
+		}
+	}
+
+	if len(failed) > 0 {
+		return workflow.Terminate(workflow.OnlineArchivesFailed, fmt.Sprintf("Online archives failed: %s", strings.Join(failed, ", ")))
+	}
+	if inProgress {
+		return workflow.InProgress(workflow.OnlineArchivesNotReady, "Online archives are being configured")
+	}
+	return workflow.OK()
+}
+
+func newOnlineArchiveStatus(archive *mongodbatlas.OnlineArchive) status.OnlineArchive {
+	return status.OnlineArchive{
+		ID:       archive.ID,
+		DBName:   archive.DBName,
+		CollName: archive.CollName,
+		State:    archive.State,
+	}
+}
+
+func newFailedOnlineArchiveStatus(archive *mdbv1.OnlineArchive, id string, err error) status.OnlineArchive {
+	return status.OnlineArchive{
+		ID:       id,
+		DBName:   archive.DBName,
+		CollName: archive.CollName,
+		State:    status.OnlineArchiveStateFailed,
+		Message:  err.Error(),
+	}
+}
+
+func boolValue(v *bool) bool {
+	return v != nil && *v
+}
+
+func floatValue(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
@@ -0,0 +1,168 @@
+package atlasdeployment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+func TestOnlineArchivesEqual(t *testing.T) {
+	archive := mdbv1.OnlineArchive{
+		DBName:   "db",
+		CollName: "col",
+		Criteria: mdbv1.OnlineArchiveCriteria{Type: mdbv1.OnlineArchiveCriteriaDate, DateField: "created", ExpireAfterDays: 30},
+		PartitionFields: []mdbv1.OnlineArchivePartitionField{
+			{FieldName: "region", Order: 0},
+		},
+	}
+	current := func() *mongodbatlas.OnlineArchive {
+		return &mongodbatlas.OnlineArchive{
+			ID:       "id",
+			DBName:   "db",
+			CollName: "col",
+			Criteria: &mongodbatlas.OnlineArchiveCriteria{Type: "DATE", DateField: "created", DateFormat: "ISODATE", ExpireAfterDays: toptr.MakePtr(30.0)},
+			PartitionFields: []*mongodbatlas.PartitionFields{
+				{FieldName: "region", FieldType: "string", Order: toptr.MakePtr(0.0)},
+				{FieldName: "created", FieldType: "date", Order: toptr.MakePtr(1.0)},
+			},
+			Paused: toptr.MakePtr(false),
+			State:  status.OnlineArchiveStateActive,
+		}
+	}
+
+	t.Run("default and read-only fields are ignored", func(t *testing.T) {
+		assert.True(t, onlineArchivesEqual(archive.ToAtlas(), current()))
+	})
+	t.Run("expiration has changed", func(t *testing.T) {
+		changed := archive
+		changed.Criteria.ExpireAfterDays = 60
+		assert.False(t, onlineArchivesEqual(changed.ToAtlas(), current()))
+	})
+	t.Run("archive is paused", func(t *testing.T) {
+		paused := archive
+		paused.Paused = true
+		assert.False(t, onlineArchivesEqual(paused.ToAtlas(), current()))
+	})
+}
+
+func TestOnlineArchivesImmutableEqual(t *testing.T) {
+	archive := mdbv1.OnlineArchive{
+		DBName:   "db",
+		CollName: "col",
+		Criteria: mdbv1.OnlineArchiveCriteria{Type: mdbv1.OnlineArchiveCriteriaDate, DateField: "created", ExpireAfterDays: 30},
+		PartitionFields: []mdbv1.OnlineArchivePartitionField{
+			{FieldName: "region", Order: 0},
+		},
+	}
+	current := &mongodbatlas.OnlineArchive{
+		CollectionType: "STANDARD",
+		Criteria:       &mongodbatlas.OnlineArchiveCriteria{Type: "DATE", DateField: "created"},
+		PartitionFields: []*mongodbatlas.PartitionFields{
+			{FieldName: "created", FieldType: "date", Order: toptr.MakePtr(1.0)},
+			{FieldName: "region", FieldType: "string", Order: toptr.MakePtr(0.0)},
+		},
+	}
+
+	t.Run("date field appended by Atlas and default collection type are ignored", func(t *testing.T) {
+		assert.True(t, onlineArchivesImmutableEqual(archive.ToAtlas(), current))
+	})
+	t.Run("partition fields have changed", func(t *testing.T) {
+		changed := archive
+		changed.PartitionFields = []mdbv1.OnlineArchivePartitionField{{FieldName: "country", Order: 0}}
+		assert.False(t, onlineArchivesImmutableEqual(changed.ToAtlas(), current))
+	})
+	t.Run("partition fields are reordered", func(t *testing.T) {
+		changed := archive
+		changed.PartitionFields = []mdbv1.OnlineArchivePartitionField{{FieldName: "created", Order: 0}, {FieldName: "region", Order: 1}}
+		assert.False(t, onlineArchivesImmutableEqual(changed.ToAtlas(), current))
+	})
+	t.Run("collection type has changed", func(t *testing.T) {
+		changed := archive
+		changed.CollectionType = "TIMESERIES"
+		assert.False(t, onlineArchivesImmutableEqual(changed.ToAtlas(), current))
+	})
+}
+
+type onlineArchivesStub struct {
+	mongodbatlas.OnlineArchiveService
+	archives []*mongodbatlas.OnlineArchive
+	updated  bool
+}
+
+func (s *onlineArchivesStub) List(_ context.Context, _, _ string, _ *mongodbatlas.ListOptions) (*mongodbatlas.OnlineArchives, *mongodbatlas.Response, error) {
+	return &mongodbatlas.OnlineArchives{Results: s.archives, TotalCount: len(s.archives)}, nil, nil
+}
+
+func (s *onlineArchivesStub) Update(_ context.Context, _, _, _ string, archive *mongodbatlas.OnlineArchive) (*mongodbatlas.OnlineArchive, *mongodbatlas.Response, error) {
+	s.updated = true
+	return archive, nil, nil
+}
+
+func TestEnsureOnlineArchivesRejectsImmutableChanges(t *testing.T) {
+	deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "my-project")
+	deployment.Spec.OnlineArchives = []mdbv1.OnlineArchive{{
+		DBName:          "db",
+		CollName:        "col",
+		Criteria:        mdbv1.OnlineArchiveCriteria{Type: mdbv1.OnlineArchiveCriteriaDate, DateField: "created", ExpireAfterDays: 60},
+		PartitionFields: []mdbv1.OnlineArchivePartitionField{{FieldName: "country", Order: 0}},
+	}}
+	deployment.Status.OnlineArchives = []status.OnlineArchive{{ID: "id", DBName: "db", CollName: "col", State: status.OnlineArchiveStateActive}}
+	stub := &onlineArchivesStub{archives: []*mongodbatlas.OnlineArchive{{
+		ID:       "id",
+		DBName:   "db",
+		CollName: "col",
+		Criteria: &mongodbatlas.OnlineArchiveCriteria{Type: "DATE", DateField: "created", ExpireAfterDays: toptr.MakePtr(30.0)},
+		PartitionFields: []*mongodbatlas.PartitionFields{
+			{FieldName: "region", Order: toptr.MakePtr(0.0)},
+			{FieldName: "created", Order: toptr.MakePtr(1.0)},
+		},
+		State: status.OnlineArchiveStateActive,
+	}}}
+	ctx := workflow.NewContext(zap.S(), []status.Condition{})
+	ctx.Client = mongodbatlas.Client{OnlineArchives: stub}
+
+	result := ensureOnlineArchives(ctx, "project-id", deployment, deployment.GetDeploymentName())
+
+	assert.Equal(t, workflow.OnlineArchivesInvalid, result.GetReason())
+	assert.Contains(t, result.GetMessage(), "db.col")
+	assert.False(t, stub.updated)
+	condition, found := ctx.GetCondition(status.ValidationSucceeded)
+	require.True(t, found)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	condition, found = ctx.GetCondition(status.OnlineArchivesReadyType)
+	require.True(t, found)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+}
+
+func TestCheckOnlineArchivesStatus(t *testing.T) {
+	t.Run("all archives are ready", func(t *testing.T) {
+		result := checkOnlineArchivesStatus([]status.OnlineArchive{
+			{DBName: "db", CollName: "first", State: status.OnlineArchiveStateActive},
+			{DBName: "db", CollName: "second", State: status.OnlineArchiveStatePaused},
+		})
+		assert.True(t, result.IsOk())
+	})
+	t.Run("archive is pending", func(t *testing.T) {
+		result := checkOnlineArchivesStatus([]status.OnlineArchive{
+			{DBName: "db", CollName: "first", State: status.OnlineArchiveStatePending},
+		})
+		assert.Equal(t, workflow.InProgress(workflow.OnlineArchivesNotReady, "Online archives are being configured"), result)
+	})
+	t.Run("archive has failed", func(t *testing.T) {
+		result := checkOnlineArchivesStatus([]status.OnlineArchive{
+			{DBName: "db", CollName: "first", State: status.OnlineArchiveStateFailed},
+			{DBName: "db", CollName: "second", State: status.OnlineArchiveStateOrphaned},
+		})
+		assert.Equal(t, workflow.Terminate(workflow.OnlineArchivesFailed, "Online archives failed: db.first, db.second"), result)
+	})
+}
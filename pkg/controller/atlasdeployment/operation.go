@@ -0,0 +1,62 @@
+package atlasdeployment
+
+import (
+	"time"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/stalled"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+)
+
+// longRunningOperations are the kinds of the long-running Atlas operations by the reasons of the results reporting
+// them in progress
+var longRunningOperations = map[workflow.ConditionReason]string{
+	workflow.DeploymentCreating:                    "Creating",
+	workflow.DeploymentUpdating:                    "Updating",
+	workflow.DeploymentRepairing:                   "Repairing",
+	workflow.DeploymentDeleting:                    "Deleting",
+	workflow.MajorVersionUpgradeSnapshotInProgress: "UpgradeSnapshot",
+	workflow.DeploymentRestoring:                   "Restoring",
+	workflow.TenantUpgrading:                       "TenantUpgrade",
+}
+
+// defaultOperationTimeouts are the timeouts of the operations taking much longer than workflow.DefaultTimeout
+// normally. They apply unless the timeouts configured for the reconciler override them.
+var defaultOperationTimeouts = stalled.Timeouts{
+	"Restoring":     6 * time.Hour,
+	"TenantUpgrade": time.Hour,
+}
+
+func (r *AtlasDeploymentReconciler) operationTracker() stalled.Tracker {
+	timeouts := stalled.Timeouts{}
+	for kind, timeout := range defaultOperationTimeouts {
+		timeouts[kind] = timeout
+	}
+	for kind, timeout := range r.StalledOperationTimeouts {
+		timeouts[kind] = timeout
+	}
+	return stalled.Tracker{
+		ResourceKind:  "AtlasDeployment",
+		Timeouts:      timeouts,
+		EventRecorder: r.EventRecorder,
+	}
+}
+
+// trackOperation records the long-running operation reported by the result of the reconciliation. The operation is
+// finished once the reconciliation succeeds. Errors don't change the operation in progress.
+func (r *AtlasDeploymentReconciler) trackOperation(ctx *workflow.Context, deployment *mdbv1.AtlasDeployment, result workflow.Result, now time.Time) {
+	tracker := r.operationTracker()
+	if result.IsOk() {
+		tracker.Finish(ctx, deployment, deployment.Status.Operation)
+		ctx.EnsureStatusOption(status.AtlasDeploymentOperationOption(nil))
+		return
+	}
+
+	kind, ok := longRunningOperations[result.GetReason()]
+	if !ok {
+		return
+	}
+	operation := tracker.Track(ctx, deployment, deployment.Status.Operation, kind, now)
+	ctx.EnsureStatusOption(status.AtlasDeploymentOperationOption(operation))
+}
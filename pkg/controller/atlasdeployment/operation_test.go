@@ -0,0 +1,59 @@
+package atlasdeployment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"k8s.io/client-go/tools/record"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/stalled"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+)
+
+func TestTrackOperationDefaultTimeouts(t *testing.T) {
+	startedAt := time.Date(2023, 6, 9, 12, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		name     string
+		result   workflow.Result
+		notAfter time.Duration
+		after    time.Duration
+	}{
+		{name: "Restoring", result: workflow.InProgress(workflow.DeploymentRestoring, ""), notAfter: 5 * time.Hour, after: 7 * time.Hour},
+		{name: "TenantUpgrade", result: workflow.InProgress(workflow.TenantUpgrading, ""), notAfter: 50 * time.Minute, after: 70 * time.Minute},
+	} {
+		t.Run(tt.name+" is stalled after its default timeout", func(t *testing.T) {
+			reconciler := &AtlasDeploymentReconciler{EventRecorder: record.NewFakeRecorder(10)}
+			deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "operation-test")
+			deployment.Status.Operation = &status.Operation{Kind: tt.name, StartedAt: "2023-06-09T12:00:00Z"}
+
+			ctx := workflow.NewContext(zap.S(), []status.Condition{})
+			reconciler.trackOperation(ctx, deployment, tt.result, startedAt.Add(tt.notAfter))
+			_, found := ctx.GetCondition(status.StalledType)
+			assert.False(t, found)
+
+			ctx = workflow.NewContext(zap.S(), []status.Condition{})
+			reconciler.trackOperation(ctx, deployment, tt.result, startedAt.Add(tt.after))
+			_, found = ctx.GetCondition(status.StalledType)
+			assert.True(t, found)
+		})
+	}
+
+	t.Run("Configured timeout overrides the default one", func(t *testing.T) {
+		reconciler := &AtlasDeploymentReconciler{
+			EventRecorder:            record.NewFakeRecorder(10),
+			StalledOperationTimeouts: stalled.Timeouts{"Restoring": time.Hour},
+		}
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "operation-test")
+		deployment.Status.Operation = &status.Operation{Kind: "Restoring", StartedAt: "2023-06-09T12:00:00Z"}
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+
+		reconciler.trackOperation(ctx, deployment, workflow.InProgress(workflow.DeploymentRestoring, ""), startedAt.Add(2*time.Hour))
+		_, found := ctx.GetCondition(status.StalledType)
+		assert.True(t, found)
+	})
+}
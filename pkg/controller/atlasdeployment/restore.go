@@ -0,0 +1,185 @@
+package atlasdeployment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+)
+
+// ensureRestore restores the backup of the source deployment into the IDLE deployment. Only the deployment created by
+// the operator with restoreFrom set is restored, the creation records the pending restore in the status. Without it
+// the existing restore job into the deployment is tracked if Atlas has one, otherwise restoreFrom is rejected so the
+// data of the existing deployment is never replaced.
+func (r *AtlasDeploymentReconciler) ensureRestore(ctx *workflow.Context, project *mdbv1.AtlasProject, deployment *mdbv1.AtlasDeployment) workflow.Result {
+	restore := deployment.Status.Restore
+	if deployment.Spec.RestoreFrom == nil || (restore != nil && restore.Phase == status.RestoreCompleted) {
+		return workflow.OK()
+	}
+	if restore != nil && restore.Phase == status.RestoreFailed {
+		return workflow.Terminate(workflow.DeploymentRestoreFailed,
+			fmt.Sprintf("%s, remove restoreFrom to use the deployment without the restored data", restore.Message)).WithoutRetry()
+	}
+
+	if restore == nil || restore.JobID == "" {
+		return r.startRestore(ctx, project, deployment, restore != nil && restore.Phase == status.RestorePending)
+	}
+
+	job, _, err := ctx.Client.CloudProviderSnapshotRestoreJobs.Get(context.Background(), &mongodbatlas.SnapshotReqPathParameters{
+		GroupID:     restore.SourceProjectID,
+		ClusterName: restore.SourceDeployment,
+		JobID:       restore.JobID,
+	})
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+	return r.trackRestoreJob(ctx, deployment, restore, job)
+}
+
+// trackRestoreJob records the state of the restore job in the status of the deployment
+func (r *AtlasDeploymentReconciler) trackRestoreJob(ctx *workflow.Context, deployment *mdbv1.AtlasDeployment, restore *status.DeploymentRestore, job *mongodbatlas.CloudProviderSnapshotRestoreJob) workflow.Result {
+	updated := *restore
+	switch {
+	case boolValue(job.Failed) || job.Cancelled || job.Expired:
+		updated.Phase = status.RestoreFailed
+		updated.Message = fmt.Sprintf("restore job %s failed", restore.JobID)
+		ctx.EnsureStatusOption(status.AtlasDeploymentRestoreOption(&updated))
+		r.EventRecorder.Eventf(deployment, "Warning", "RestoreFailed", "Restore of the backup of %s failed", restore.SourceDeployment)
+		return workflow.Terminate(workflow.DeploymentRestoreFailed, updated.Message).WithoutRetry()
+	case job.FinishedAt != "":
+		ctx.Log.Infow("Restore of the backup completed", "jobID", restore.JobID)
+		updated.Phase = status.RestoreCompleted
+		ctx.EnsureStatusOption(status.AtlasDeploymentRestoreOption(&updated))
+		r.EventRecorder.Eventf(deployment, "Normal", "RestoreCompleted", "Restore of the backup of %s completed", restore.SourceDeployment)
+		return workflow.OK()
+	default:
+		ctx.EnsureStatusOption(status.AtlasDeploymentRestoreOption(&updated))
+		return workflow.InProgress(workflow.DeploymentRestoring, fmt.Sprintf("Restoring the backup of %s", restore.SourceDeployment))
+	}
+}
+
+// startRestore starts the restore of the deployment created with restoreFrom or adopts the restore job Atlas already has
+func (r *AtlasDeploymentReconciler) startRestore(ctx *workflow.Context, project *mdbv1.AtlasProject, deployment *mdbv1.AtlasDeployment, pending bool) workflow.Result {
+	source := &mdbv1.AtlasDeployment{}
+	if err := r.Client.Get(context.Background(), *deployment.Spec.RestoreFrom.DeploymentRef.GetObject(deployment.Namespace), source); err != nil {
+		return workflow.Terminate(workflow.DeploymentRestoreFailed, fmt.Sprintf("failed to read the source deployment: %s", err))
+	}
+	sourceProject := &mdbv1.AtlasProject{}
+	if err := r.Client.Get(context.Background(), source.AtlasProjectObjectKey(), sourceProject); err != nil {
+		return workflow.Terminate(workflow.DeploymentRestoreFailed, fmt.Sprintf("failed to read the project of the source deployment: %s", err))
+	}
+	if sourceProject.ID() == "" {
+		return workflow.InProgress(workflow.DeploymentRestoring, "Waiting for the project of the source deployment to be created")
+	}
+
+	sourceName := source.GetDeploymentName()
+	existing, err := existingRestoreJob(ctx, sourceProject.ID(), sourceName, project.ID(), deployment.GetDeploymentName())
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+	if existing != nil {
+		ctx.Log.Infow("Found the restore job of the deployment in Atlas", "source", sourceName, "jobID", existing.ID)
+		return r.trackRestoreJob(ctx, deployment, &status.DeploymentRestore{
+			Phase:            status.RestoreInProgress,
+			SourceProjectID:  sourceProject.ID(),
+			SourceDeployment: sourceName,
+			SnapshotID:       existing.SnapshotID,
+			JobID:            existing.ID,
+		}, existing)
+	}
+
+	if !pending {
+		result := workflow.Terminate(workflow.DeploymentRestoreInvalid,
+			"restoreFrom is only supported for the new deployments created by the operator, remove it to keep the data of the existing deployment").WithoutRetry()
+		ctx.SetConditionFromResult(status.ValidationSucceeded, result)
+		return result
+	}
+
+	job, err := restoreJob(ctx, deployment.Spec.RestoreFrom, sourceProject.ID(), sourceName)
+	if err != nil {
+		return workflow.Terminate(workflow.DeploymentRestoreFailed, err.Error())
+	}
+	job.TargetGroupID = project.ID()
+	job.TargetClusterName = deployment.GetDeploymentName()
+
+	job, _, err = ctx.Client.CloudProviderSnapshotRestoreJobs.Create(context.Background(),
+		&mongodbatlas.SnapshotReqPathParameters{GroupID: sourceProject.ID(), ClusterName: sourceName}, job)
+	if err != nil {
+		return workflow.Terminate(workflow.DeploymentRestoreFailed, err.Error())
+	}
+
+	ctx.Log.Infow("Restoring the backup of the source deployment", "source", sourceName, "snapshotID", job.SnapshotID, "jobID", job.ID)
+	ctx.EnsureStatusOption(status.AtlasDeploymentRestoreOption(&status.DeploymentRestore{
+		Phase:            status.RestoreInProgress,
+		SourceProjectID:  sourceProject.ID(),
+		SourceDeployment: sourceName,
+		SnapshotID:       job.SnapshotID,
+		JobID:            job.ID,
+	}))
+	r.EventRecorder.Eventf(deployment, "Normal", "RestoreStarted", "Restoring the backup of %s", sourceName)
+	return workflow.InProgress(workflow.DeploymentRestoring, fmt.Sprintf("Restoring the backup of %s", sourceName))
+}
+
+// existingRestoreJob returns the latest restore job of the source deployment into the target deployment in Atlas
+func existingRestoreJob(ctx *workflow.Context, sourceProjectID, sourceName, targetProjectID, targetName string) (*mongodbatlas.CloudProviderSnapshotRestoreJob, error) {
+	var latest *mongodbatlas.CloudProviderSnapshotRestoreJob
+	listOptions := &mongodbatlas.ListOptions{PageNum: 1, ItemsPerPage: 500}
+	for {
+		jobs, _, err := ctx.Client.CloudProviderSnapshotRestoreJobs.List(context.Background(),
+			&mongodbatlas.SnapshotReqPathParameters{GroupID: sourceProjectID, ClusterName: sourceName}, listOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the restore jobs of the source deployment: %w", err)
+		}
+		for _, job := range jobs.Results {
+			// the ISO 8601 times in UTC are ordered as strings
+			if job != nil && job.TargetGroupID == targetProjectID && job.TargetClusterName == targetName && (latest == nil || job.CreatedAt > latest.CreatedAt) {
+				latest = job
+			}
+		}
+		if len(jobs.Results) < listOptions.ItemsPerPage {
+			return latest, nil
+		}
+		listOptions.PageNum++
+	}
+}
+
+// restoreJob returns the restore job of the snapshot selected by the spec
+func restoreJob(ctx *workflow.Context, restoreFrom *mdbv1.RestoreFrom, sourceProjectID, sourceName string) (*mongodbatlas.CloudProviderSnapshotRestoreJob, error) {
+	switch {
+	case restoreFrom.SnapshotID != "":
+		return &mongodbatlas.CloudProviderSnapshotRestoreJob{DeliveryType: "automated", SnapshotID: restoreFrom.SnapshotID}, nil
+	case restoreFrom.PointInTime != "":
+		pointInTime, err := time.Parse(time.RFC3339, restoreFrom.PointInTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid point in time: %w", err)
+		}
+		return &mongodbatlas.CloudProviderSnapshotRestoreJob{DeliveryType: "pointInTime", PointInTimeUTCSeconds: pointInTime.Unix()}, nil
+	}
+
+	snapshots, _, err := ctx.Client.CloudProviderSnapshots.GetAllCloudProviderSnapshots(context.Background(),
+		&mongodbatlas.SnapshotReqPathParameters{GroupID: sourceProjectID, ClusterName: sourceName}, &mongodbatlas.ListOptions{ItemsPerPage: 500})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the snapshots of the source deployment: %w", err)
+	}
+	snapshot := latestCompletedSnapshot(snapshots.Results)
+	if snapshot == nil {
+		return nil, fmt.Errorf("the source deployment %s has no completed snapshots", sourceName)
+	}
+	return &mongodbatlas.CloudProviderSnapshotRestoreJob{DeliveryType: "automated", SnapshotID: snapshot.ID}, nil
+}
+
+func latestCompletedSnapshot(snapshots []*mongodbatlas.CloudProviderSnapshot) *mongodbatlas.CloudProviderSnapshot {
+	var latest *mongodbatlas.CloudProviderSnapshot
+	for _, snapshot := range snapshots {
+		// the ISO 8601 times in UTC are ordered as strings
+		if snapshot != nil && snapshot.Status == "completed" && (latest == nil || snapshot.CreatedAt > latest.CreatedAt) {
+			latest = snapshot
+		}
+	}
+	return latest
+}
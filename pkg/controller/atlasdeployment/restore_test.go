@@ -0,0 +1,283 @@
+package atlasdeployment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+// restoreJobsStub creates the restore jobs, returns the given job on get and lists the existing jobs
+type restoreJobsStub struct {
+	mongodbatlas.CloudProviderSnapshotRestoreJobsService
+
+	job      *mongodbatlas.CloudProviderSnapshotRestoreJob
+	existing []*mongodbatlas.CloudProviderSnapshotRestoreJob
+	params   []*mongodbatlas.SnapshotReqPathParameters
+	created  []*mongodbatlas.CloudProviderSnapshotRestoreJob
+}
+
+func (s *restoreJobsStub) List(_ context.Context, _ *mongodbatlas.SnapshotReqPathParameters, listOptions *mongodbatlas.ListOptions) (*mongodbatlas.CloudProviderSnapshotRestoreJobs, *mongodbatlas.Response, error) {
+	start := (listOptions.PageNum - 1) * listOptions.ItemsPerPage
+	end := start + listOptions.ItemsPerPage
+	if start > len(s.existing) {
+		start = len(s.existing)
+	}
+	if end > len(s.existing) {
+		end = len(s.existing)
+	}
+	return &mongodbatlas.CloudProviderSnapshotRestoreJobs{Results: s.existing[start:end], TotalCount: len(s.existing)}, nil, nil
+}
+
+func (s *restoreJobsStub) Create(_ context.Context, params *mongodbatlas.SnapshotReqPathParameters, job *mongodbatlas.CloudProviderSnapshotRestoreJob) (*mongodbatlas.CloudProviderSnapshotRestoreJob, *mongodbatlas.Response, error) {
+	s.params = append(s.params, params)
+	s.created = append(s.created, job)
+	created := *job
+	created.ID = "job-id"
+	return &created, nil, nil
+}
+
+func (s *restoreJobsStub) Get(_ context.Context, params *mongodbatlas.SnapshotReqPathParameters) (*mongodbatlas.CloudProviderSnapshotRestoreJob, *mongodbatlas.Response, error) {
+	s.params = append(s.params, params)
+	return s.job, nil, nil
+}
+
+// snapshotListStub lists the given snapshots
+type snapshotListStub struct {
+	mongodbatlas.CloudProviderSnapshotsService
+
+	snapshots []*mongodbatlas.CloudProviderSnapshot
+}
+
+func (s *snapshotListStub) GetAllCloudProviderSnapshots(context.Context, *mongodbatlas.SnapshotReqPathParameters, *mongodbatlas.ListOptions) (*mongodbatlas.CloudProviderSnapshots, *mongodbatlas.Response, error) {
+	return &mongodbatlas.CloudProviderSnapshots{Results: s.snapshots, TotalCount: len(s.snapshots)}, nil, nil
+}
+
+func TestEnsureRestore(t *testing.T) {
+	sourceProject := mdbv1.NewProject("ns", "source-project", "source-project")
+	sourceProject.Status.ID = "source-project-id"
+	source := mdbv1.NewAwsAdvancedDeployment("ns", "source", "source-deployment").WithProjectName("source-project")
+
+	newReconciler := func() *AtlasDeploymentReconciler {
+		scheme := runtime.NewScheme()
+		utilruntime.Must(mdbv1.AddToScheme(scheme))
+		return &AtlasDeploymentReconciler{
+			Client:        fake.NewClientBuilder().WithScheme(scheme).WithObjects(sourceProject, source).Build(),
+			EventRecorder: record.NewFakeRecorder(10),
+		}
+	}
+	project := mdbv1.DefaultProject("ns", "connection")
+	project.Status.ID = "project-id"
+	newDeployment := func(restoreFrom *mdbv1.RestoreFrom, restore *status.DeploymentRestore) *mdbv1.AtlasDeployment {
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+		deployment.Spec.RestoreFrom = restoreFrom
+		deployment.Status.Restore = restore
+		return deployment
+	}
+	sourceRef := common.ResourceRefNamespaced{Name: "source"}
+	pending := &status.DeploymentRestore{Phase: status.RestorePending}
+
+	t.Run("Nothing is restored without restoreFrom", func(t *testing.T) {
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		deployment := newDeployment(nil, nil)
+
+		assert.True(t, newReconciler().ensureRestore(ctx, project, deployment).IsOk())
+	})
+
+	t.Run("Restore job found in Atlas is tracked instead of starting another one", func(t *testing.T) {
+		jobs := &restoreJobsStub{existing: []*mongodbatlas.CloudProviderSnapshotRestoreJob{
+			{ID: "another-job-id", TargetGroupID: "project-id", TargetClusterName: "another-deployment", CreatedAt: "2023-06-09T14:00:00Z"},
+			{ID: "previous-job-id", TargetGroupID: "project-id", TargetClusterName: "test-deployment-advanced", CreatedAt: "2023-06-08T12:00:00Z", Failed: toptr.MakePtr(true)},
+			{ID: "job-id", TargetGroupID: "project-id", TargetClusterName: "test-deployment-advanced", SnapshotID: "latest", CreatedAt: "2023-06-09T12:00:00Z"},
+		}}
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		ctx.Client = mongodbatlas.Client{CloudProviderSnapshotRestoreJobs: jobs}
+		deployment := newDeployment(&mdbv1.RestoreFrom{DeploymentRef: sourceRef}, nil)
+
+		result := newReconciler().ensureRestore(ctx, project, deployment)
+
+		assert.Equal(t, workflow.DeploymentRestoring, result.GetReason())
+		assert.Empty(t, jobs.created)
+		applyStatusOptions(ctx, deployment)
+		assert.Equal(t, &status.DeploymentRestore{
+			Phase:            status.RestoreInProgress,
+			SourceProjectID:  "source-project-id",
+			SourceDeployment: "source-deployment",
+			SnapshotID:       "latest",
+			JobID:            "job-id",
+		}, deployment.Status.Restore)
+	})
+
+	t.Run("Finished restore job found in Atlas completes the restore", func(t *testing.T) {
+		jobs := &restoreJobsStub{existing: []*mongodbatlas.CloudProviderSnapshotRestoreJob{
+			{ID: "job-id", TargetGroupID: "project-id", TargetClusterName: "test-deployment-advanced", FinishedAt: "2023-06-09T13:00:00Z"},
+		}}
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		ctx.Client = mongodbatlas.Client{CloudProviderSnapshotRestoreJobs: jobs}
+		deployment := newDeployment(&mdbv1.RestoreFrom{DeploymentRef: sourceRef}, nil)
+
+		assert.True(t, newReconciler().ensureRestore(ctx, project, deployment).IsOk())
+		assert.Empty(t, jobs.created)
+		applyStatusOptions(ctx, deployment)
+		assert.Equal(t, status.RestoreCompleted, deployment.Status.Restore.Phase)
+	})
+
+	t.Run("Restore jobs found in Atlas are listed through all the pages", func(t *testing.T) {
+		existing := make([]*mongodbatlas.CloudProviderSnapshotRestoreJob, 0, 501)
+		for i := 0; i < 500; i++ {
+			existing = append(existing, &mongodbatlas.CloudProviderSnapshotRestoreJob{ID: "another-job-id", TargetGroupID: "project-id", TargetClusterName: "another-deployment"})
+		}
+		existing = append(existing, &mongodbatlas.CloudProviderSnapshotRestoreJob{ID: "job-id", TargetGroupID: "project-id", TargetClusterName: "test-deployment-advanced"})
+		jobs := &restoreJobsStub{existing: existing}
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		ctx.Client = mongodbatlas.Client{CloudProviderSnapshotRestoreJobs: jobs}
+		deployment := newDeployment(&mdbv1.RestoreFrom{DeploymentRef: sourceRef}, pending)
+
+		assert.Equal(t, workflow.DeploymentRestoring, newReconciler().ensureRestore(ctx, project, deployment).GetReason())
+		assert.Empty(t, jobs.created)
+		applyStatusOptions(ctx, deployment)
+		assert.Equal(t, "job-id", deployment.Status.Restore.JobID)
+	})
+
+	t.Run("Existing deployment is not restored", func(t *testing.T) {
+		jobs := &restoreJobsStub{}
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		ctx.Client = mongodbatlas.Client{CloudProviderSnapshotRestoreJobs: jobs}
+		deployment := newDeployment(&mdbv1.RestoreFrom{DeploymentRef: sourceRef}, nil)
+
+		result := newReconciler().ensureRestore(ctx, project, deployment)
+
+		assert.Equal(t, workflow.Terminate(workflow.DeploymentRestoreInvalid, result.GetMessage()).WithoutRetry(), result)
+		assert.Empty(t, jobs.created)
+		condition, found := ctx.GetCondition(status.ValidationSucceeded)
+		require.True(t, found)
+		assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	})
+
+	t.Run("Latest completed snapshot is restored", func(t *testing.T) {
+		jobs := &restoreJobsStub{}
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		ctx.Client = mongodbatlas.Client{
+			CloudProviderSnapshotRestoreJobs: jobs,
+			CloudProviderSnapshots: &snapshotListStub{snapshots: []*mongodbatlas.CloudProviderSnapshot{
+				{ID: "old", Status: "completed", CreatedAt: "2023-06-08T12:00:00Z"},
+				{ID: "latest", Status: "completed", CreatedAt: "2023-06-09T12:00:00Z"},
+				{ID: "queued", Status: "queued", CreatedAt: "2023-06-10T12:00:00Z"},
+			}},
+		}
+		deployment := newDeployment(&mdbv1.RestoreFrom{DeploymentRef: sourceRef}, pending)
+
+		result := newReconciler().ensureRestore(ctx, project, deployment)
+
+		assert.False(t, result.IsOk())
+		assert.Equal(t, workflow.DeploymentRestoring, result.GetReason())
+		require.Len(t, jobs.created, 1)
+		assert.Equal(t, &mongodbatlas.SnapshotReqPathParameters{GroupID: "source-project-id", ClusterName: "source-deployment"}, jobs.params[0])
+		assert.Equal(t, &mongodbatlas.CloudProviderSnapshotRestoreJob{
+			DeliveryType:      "automated",
+			SnapshotID:        "latest",
+			TargetGroupID:     "project-id",
+			TargetClusterName: "test-deployment-advanced",
+		}, jobs.created[0])
+		applyStatusOptions(ctx, deployment)
+		assert.Equal(t, &status.DeploymentRestore{
+			Phase:            status.RestoreInProgress,
+			SourceProjectID:  "source-project-id",
+			SourceDeployment: "source-deployment",
+			SnapshotID:       "latest",
+			JobID:            "job-id",
+		}, deployment.Status.Restore)
+	})
+
+	t.Run("Point in time is restored", func(t *testing.T) {
+		jobs := &restoreJobsStub{}
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		ctx.Client = mongodbatlas.Client{CloudProviderSnapshotRestoreJobs: jobs}
+		deployment := newDeployment(&mdbv1.RestoreFrom{DeploymentRef: sourceRef, PointInTime: "2023-06-09T12:00:00Z"}, pending)
+
+		assert.False(t, newReconciler().ensureRestore(ctx, project, deployment).IsOk())
+		require.Len(t, jobs.created, 1)
+		assert.Equal(t, "pointInTime", jobs.created[0].DeliveryType)
+		assert.Equal(t, int64(1686312000), jobs.created[0].PointInTimeUTCSeconds)
+	})
+
+	t.Run("Source deployment without snapshots fails the restore", func(t *testing.T) {
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		ctx.Client = mongodbatlas.Client{CloudProviderSnapshotRestoreJobs: &restoreJobsStub{}, CloudProviderSnapshots: &snapshotListStub{}}
+		deployment := newDeployment(&mdbv1.RestoreFrom{DeploymentRef: sourceRef}, pending)
+
+		result := newReconciler().ensureRestore(ctx, project, deployment)
+
+		assert.False(t, result.IsOk())
+		assert.Equal(t, workflow.DeploymentRestoreFailed, result.GetReason())
+	})
+
+	running := &status.DeploymentRestore{
+		Phase:            status.RestoreInProgress,
+		SourceProjectID:  "source-project-id",
+		SourceDeployment: "source-deployment",
+		SnapshotID:       "latest",
+		JobID:            "job-id",
+	}
+
+	t.Run("Running restore is in progress", func(t *testing.T) {
+		jobs := &restoreJobsStub{job: &mongodbatlas.CloudProviderSnapshotRestoreJob{ID: "job-id"}}
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		ctx.Client = mongodbatlas.Client{CloudProviderSnapshotRestoreJobs: jobs}
+		deployment := newDeployment(&mdbv1.RestoreFrom{DeploymentRef: sourceRef}, running)
+
+		result := newReconciler().ensureRestore(ctx, project, deployment)
+
+		assert.Equal(t, workflow.DeploymentRestoring, result.GetReason())
+		assert.Equal(t, &mongodbatlas.SnapshotReqPathParameters{GroupID: "source-project-id", ClusterName: "source-deployment", JobID: "job-id"}, jobs.params[0])
+	})
+
+	t.Run("Finished restore is completed", func(t *testing.T) {
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		ctx.Client = mongodbatlas.Client{CloudProviderSnapshotRestoreJobs: &restoreJobsStub{
+			job: &mongodbatlas.CloudProviderSnapshotRestoreJob{ID: "job-id", FinishedAt: "2023-06-09T13:00:00Z"},
+		}}
+		deployment := newDeployment(&mdbv1.RestoreFrom{DeploymentRef: sourceRef}, running)
+
+		assert.True(t, newReconciler().ensureRestore(ctx, project, deployment).IsOk())
+		applyStatusOptions(ctx, deployment)
+		assert.Equal(t, status.RestoreCompleted, deployment.Status.Restore.Phase)
+	})
+
+	t.Run("Failed restore is not retried", func(t *testing.T) {
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		ctx.Client = mongodbatlas.Client{CloudProviderSnapshotRestoreJobs: &restoreJobsStub{
+			job: &mongodbatlas.CloudProviderSnapshotRestoreJob{ID: "job-id", Failed: toptr.MakePtr(true)},
+		}}
+		deployment := newDeployment(&mdbv1.RestoreFrom{DeploymentRef: sourceRef}, running)
+
+		result := newReconciler().ensureRestore(ctx, project, deployment)
+
+		assert.Equal(t, workflow.DeploymentRestoreFailed, result.GetReason())
+		applyStatusOptions(ctx, deployment)
+		assert.Equal(t, status.RestoreFailed, deployment.Status.Restore.Phase)
+
+		ctx = workflow.NewContext(zap.S(), []status.Condition{})
+		assert.Equal(t, workflow.DeploymentRestoreFailed, newReconciler().ensureRestore(ctx, project, deployment).GetReason())
+	})
+}
+
+func applyStatusOptions(ctx *workflow.Context, deployment *mdbv1.AtlasDeployment) {
+	for _, option := range ctx.StatusOptions() {
+		option.(status.AtlasDeploymentStatusOption)(&deployment.Status)
+	}
+}
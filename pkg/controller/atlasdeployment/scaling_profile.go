@@ -0,0 +1,122 @@
+package atlasdeployment
+
+import (
+	"fmt"
+	"time"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/compat"
+)
+
+// applyScalingProfile overrides the region configs of the advanced deployment with the active scaling profile. The
+// changes reach Atlas through the regular update of the idle deployment, so the profiles never change the deployment
+// which is not IDLE. It returns the name of the active profile and the time the profiles change next.
+func applyScalingProfile(deployment *mdbv1.AtlasDeployment, now time.Time) (string, time.Time, workflow.Result) {
+	if len(deployment.Spec.ScalingProfiles) == 0 || deployment.Spec.AdvancedDeploymentSpec == nil {
+		return "", time.Time{}, workflow.OK()
+	}
+
+	profile, nextTransition, err := activeScalingProfile(deployment, now)
+	if err != nil {
+		return "", time.Time{}, workflow.Terminate(workflow.DeploymentScalingProfileInvalid, err.Error())
+	}
+	if profile == nil {
+		return "", nextTransition, workflow.OK()
+	}
+
+	if err = overrideRegionConfigs(deployment.Spec.AdvancedDeploymentSpec, profile); err != nil {
+		return "", time.Time{}, workflow.Terminate(workflow.Internal, err.Error())
+	}
+	return profile.Name, nextTransition, workflow.OK()
+}
+
+// activeScalingProfile returns the profile activated by the annotation or the first profile active according to its
+// schedule. It also returns the time any of the profiles changes next or the zero time if the profile is activated by
+// the annotation.
+func activeScalingProfile(deployment *mdbv1.AtlasDeployment, now time.Time) (*mdbv1.ScalingProfile, time.Time, error) {
+	var active *mdbv1.ScalingProfile
+	var nextTransition time.Time
+	for i := range deployment.Spec.ScalingProfiles {
+		profile := &deployment.Spec.ScalingProfiles[i]
+		if profile.Start == "" && profile.End == "" {
+			continue
+		}
+
+		window, location, err := parseCronWindow(profile.Start, profile.End, profile.TimeZone)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("invalid scaling profile %s: %w", profile.Name, err)
+		}
+		isActive, next := window.activeAt(now.In(location))
+		if isActive && active == nil {
+			active = profile
+		}
+		nextTransition = earliest(nextTransition, next)
+	}
+
+	if name, ok := deployment.Annotations[mdbv1.DeploymentScalingProfileAnnotation]; ok {
+		for i := range deployment.Spec.ScalingProfiles {
+			if deployment.Spec.ScalingProfiles[i].Name == name {
+				return &deployment.Spec.ScalingProfiles[i], time.Time{}, nil
+			}
+		}
+		return nil, time.Time{}, fmt.Errorf("the scaling profile %s specified in the %s annotation doesn't exist", name, mdbv1.DeploymentScalingProfileAnnotation)
+	}
+
+	return active, nextTransition, nil
+}
+
+func overrideRegionConfigs(advancedDeploymentSpec *mdbv1.AdvancedDeploymentSpec, profile *mdbv1.ScalingProfile) error {
+	for _, replicationSpec := range advancedDeploymentSpec.ReplicationSpecs {
+		if replicationSpec == nil {
+			continue
+		}
+		for _, regionConfig := range replicationSpec.RegionConfigs {
+			if regionConfig == nil {
+				continue
+			}
+			for _, override := range profile.RegionConfigs {
+				if override.ProviderName != regionConfig.ProviderName || override.RegionName != regionConfig.RegionName {
+					continue
+				}
+				if err := overrideRegionConfig(regionConfig, override); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func overrideRegionConfig(regionConfig *mdbv1.AdvancedRegionConfig, override mdbv1.ScalingProfileRegionConfig) error {
+	if override.ElectableSpecs != nil {
+		if regionConfig.ElectableSpecs == nil {
+			regionConfig.ElectableSpecs = &mdbv1.Specs{}
+		}
+		if err := compat.JSONCopy(regionConfig.ElectableSpecs, override.ElectableSpecs); err != nil {
+			return err
+		}
+	}
+
+	if override.AnalyticsSpecs != nil {
+		if regionConfig.AnalyticsSpecs == nil {
+			regionConfig.AnalyticsSpecs = &mdbv1.Specs{}
+		}
+		if err := compat.JSONCopy(regionConfig.AnalyticsSpecs, override.AnalyticsSpecs); err != nil {
+			return err
+		}
+	}
+
+	if override.Compute != nil {
+		if regionConfig.AutoScaling == nil {
+			regionConfig.AutoScaling = &mdbv1.AdvancedAutoScalingSpec{}
+		}
+		if regionConfig.AutoScaling.Compute == nil {
+			regionConfig.AutoScaling.Compute = &mdbv1.ComputeSpec{}
+		}
+		if err := compat.JSONCopy(regionConfig.AutoScaling.Compute, override.Compute); err != nil {
+			return err
+		}
+	}
+	return nil
+}
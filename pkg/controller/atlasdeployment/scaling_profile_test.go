@@ -0,0 +1,91 @@
+package atlasdeployment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+func TestApplyScalingProfile(t *testing.T) {
+	newDeployment := func() *mdbv1.AtlasDeployment {
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+		deployment.Spec.ScalingProfiles = []mdbv1.ScalingProfile{
+			{
+				Name:  "month-end",
+				Start: "0 0 28 * *",
+				End:   "0 0 1 * *",
+				RegionConfigs: []mdbv1.ScalingProfileRegionConfig{
+					{
+						ProviderName:   "AWS",
+						RegionName:     "US_EAST_1",
+						ElectableSpecs: &mdbv1.Specs{InstanceSize: "M60"},
+						AnalyticsSpecs: &mdbv1.Specs{InstanceSize: "M40", NodeCount: toptr.MakePtr(1)},
+						Compute:        &mdbv1.ComputeSpec{MaxInstanceSize: "M80"},
+					},
+					{
+						ProviderName:   "AWS",
+						RegionName:     "EU_WEST_1",
+						ElectableSpecs: &mdbv1.Specs{InstanceSize: "M80"},
+					},
+				},
+			},
+			{
+				Name: "load-test",
+				RegionConfigs: []mdbv1.ScalingProfileRegionConfig{
+					{ProviderName: "AWS", RegionName: "US_EAST_1", ElectableSpecs: &mdbv1.Specs{InstanceSize: "M200"}},
+				},
+			},
+		}
+		return deployment
+	}
+	regionConfig := func(deployment *mdbv1.AtlasDeployment) *mdbv1.AdvancedRegionConfig {
+		return deployment.Spec.AdvancedDeploymentSpec.ReplicationSpecs[0].RegionConfigs[0]
+	}
+
+	t.Run("Profile is active within its schedule", func(t *testing.T) {
+		deployment := newDeployment()
+
+		profile, nextTransition, result := applyScalingProfile(deployment, time.Date(2023, 6, 29, 12, 0, 0, 0, time.UTC))
+		require.True(t, result.IsOk())
+		assert.Equal(t, "month-end", profile)
+		assert.Equal(t, time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC), nextTransition)
+
+		config := regionConfig(deployment)
+		assert.Equal(t, &mdbv1.Specs{InstanceSize: "M60", NodeCount: toptr.MakePtr(3)}, config.ElectableSpecs)
+		assert.Equal(t, &mdbv1.Specs{InstanceSize: "M40", NodeCount: toptr.MakePtr(1)}, config.AnalyticsSpecs)
+		assert.Equal(t, &mdbv1.AdvancedAutoScalingSpec{Compute: &mdbv1.ComputeSpec{MaxInstanceSize: "M80"}}, config.AutoScaling)
+	})
+	t.Run("No profile is active outside of the schedules", func(t *testing.T) {
+		deployment := newDeployment()
+
+		profile, nextTransition, result := applyScalingProfile(deployment, time.Date(2023, 6, 12, 12, 0, 0, 0, time.UTC))
+		require.True(t, result.IsOk())
+		assert.Empty(t, profile)
+		assert.Equal(t, time.Date(2023, 6, 28, 0, 0, 0, 0, time.UTC), nextTransition)
+		assert.Equal(t, mdbv1.DefaultAwsAdvancedDeployment("ns", "project").Spec.AdvancedDeploymentSpec, deployment.Spec.AdvancedDeploymentSpec)
+	})
+	t.Run("Profile is activated by the annotation", func(t *testing.T) {
+		deployment := newDeployment()
+		deployment.Annotations = map[string]string{mdbv1.DeploymentScalingProfileAnnotation: "load-test"}
+
+		profile, nextTransition, result := applyScalingProfile(deployment, time.Date(2023, 6, 29, 12, 0, 0, 0, time.UTC))
+		require.True(t, result.IsOk())
+		assert.Equal(t, "load-test", profile)
+		assert.True(t, nextTransition.IsZero())
+		assert.Equal(t, "M200", regionConfig(deployment).ElectableSpecs.InstanceSize)
+	})
+	t.Run("Unknown profile in the annotation", func(t *testing.T) {
+		deployment := newDeployment()
+		deployment.Annotations = map[string]string{mdbv1.DeploymentScalingProfileAnnotation: "unknown"}
+
+		_, _, result := applyScalingProfile(deployment, time.Now())
+		assert.False(t, result.IsOk())
+		assert.Equal(t, workflow.Terminate(workflow.DeploymentScalingProfileInvalid, "").WithMessage(result.GetMessage()), result)
+	})
+}
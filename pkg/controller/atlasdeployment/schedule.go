@@ -0,0 +1,112 @@
+package atlasdeployment
+
+import (
+	"fmt"
+	"time"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/cron"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/timeutil"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+// cronWindow is the recurring period of time which starts and ends on the cron schedules
+type cronWindow struct {
+	start *cron.Schedule
+	end   *cron.Schedule
+}
+
+// applySchedule sets the paused flag of the advanced deployment to the value required by the schedule at the moment
+// now, so the regular pause request is sent to Atlas when it changes. It returns the time of the next transition or
+// the zero time if there is no schedule.
+func applySchedule(ctx *workflow.Context, deployment *mdbv1.AtlasDeployment, now time.Time) (time.Time, workflow.Result) {
+	if deployment.Spec.Schedule == nil || deployment.Spec.AdvancedDeploymentSpec == nil {
+		ctx.EnsureStatusOption(status.AtlasDeploymentScheduleOption(nil))
+		return time.Time{}, workflow.OK()
+	}
+
+	paused, nextTransition, err := scheduledPauseState(deployment, now)
+	if err != nil {
+		return time.Time{}, workflow.Terminate(workflow.DeploymentScheduleInvalid, err.Error())
+	}
+	deployment.Spec.AdvancedDeploymentSpec.Paused = toptr.MakePtr(paused)
+
+	scheduleStatus := &status.DeploymentSchedule{Paused: paused}
+	if !nextTransition.IsZero() {
+		scheduleStatus.NextTransition = timeutil.FormatISO8601(nextTransition.UTC())
+	}
+	ctx.EnsureStatusOption(status.AtlasDeploymentScheduleOption(scheduleStatus))
+	return nextTransition, workflow.OK()
+}
+
+// scheduledPauseState returns whether the deployment must be paused at the moment now and when it changes next taking
+// the keep running override into account
+func scheduledPauseState(deployment *mdbv1.AtlasDeployment, now time.Time) (bool, time.Time, error) {
+	schedule := deployment.Spec.Schedule
+	window, location, err := parseCronWindow(schedule.Pause, schedule.Resume, schedule.TimeZone)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid schedule: %w", err)
+	}
+	now = now.In(location)
+
+	if value, ok := deployment.Annotations[mdbv1.DeploymentKeepRunningUntilAnnotation]; ok {
+		keepRunningUntil, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return false, time.Time{}, fmt.Errorf("invalid %s annotation, expected RFC 3339 time: %w", mdbv1.DeploymentKeepRunningUntilAnnotation, err)
+		}
+		if now.Before(keepRunningUntil) {
+			pausedAfterwards, nextTransition := window.activeAt(keepRunningUntil.In(location))
+			if pausedAfterwards {
+				return false, keepRunningUntil, nil
+			}
+			return false, nextTransition, nil
+		}
+	}
+
+	paused, nextTransition := window.activeAt(now)
+	return paused, nextTransition, nil
+}
+
+func parseCronWindow(start, end, timeZone string) (*cronWindow, *time.Location, error) {
+	startSchedule, err := cron.Parse(start)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid start: %w", err)
+	}
+	endSchedule, err := cron.Parse(end)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid end: %w", err)
+	}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid time zone: %w", err)
+	}
+	return &cronWindow{start: startSchedule, end: endSchedule}, location, nil
+}
+
+// activeAt returns whether the window is active at the moment t and when it changes next. The window is active if its
+// next event is the end.
+func (w *cronWindow) activeAt(t time.Time) (bool, time.Time) {
+	nextStart := w.start.Next(t)
+	nextEnd := w.end.Next(t)
+	switch {
+	case nextEnd.IsZero():
+		return false, nextStart
+	case nextStart.IsZero() || nextEnd.Before(nextStart):
+		return true, nextEnd
+	default:
+		return false, nextStart
+	}
+}
+
+// earliest returns the earliest of the non-zero times or the zero time if there are none
+func earliest(times ...time.Time) time.Time {
+	var result time.Time
+	for _, t := range times {
+		if !t.IsZero() && (result.IsZero() || t.Before(result)) {
+			result = t
+		}
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+package atlasdeployment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+func TestScheduledPauseState(t *testing.T) {
+	newDeployment := func() *mdbv1.AtlasDeployment {
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+		deployment.Spec.Schedule = &mdbv1.DeploymentSchedule{Pause: "0 20 * * mon-fri", Resume: "0 8 * * mon-fri", TimeZone: "Europe/Berlin"}
+		return deployment
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	// 2023-06-09 is Friday
+	friday := func(hour, minute int) time.Time {
+		return time.Date(2023, 6, 9, hour, minute, 0, 0, berlin)
+	}
+	monday := time.Date(2023, 6, 12, 8, 0, 0, 0, berlin)
+
+	tests := []struct {
+		name                   string
+		now                    time.Time
+		keepRunningUntil       string
+		expectedPaused         bool
+		expectedNextTransition time.Time
+	}{
+		{name: "Running during the working hours", now: friday(12, 0), expectedPaused: false, expectedNextTransition: friday(20, 0)},
+		{name: "Paused at the pause time", now: friday(20, 0), expectedPaused: true, expectedNextTransition: monday},
+		{name: "Paused over the weekend", now: time.Date(2023, 6, 10, 12, 0, 0, 0, berlin), expectedPaused: true, expectedNextTransition: monday},
+		{name: "Time zone is taken into account", now: friday(18, 30).UTC(), expectedPaused: false, expectedNextTransition: friday(20, 0)},
+		{name: "Kept running by the override", now: friday(21, 0), keepRunningUntil: "2023-06-09T23:00:00+02:00", expectedPaused: false, expectedNextTransition: friday(23, 0)},
+		{name: "Override ending while running", now: friday(7, 0), keepRunningUntil: "2023-06-09T09:00:00+02:00", expectedPaused: false, expectedNextTransition: friday(20, 0)},
+		{name: "Expired override", now: friday(21, 0), keepRunningUntil: "2023-06-09T20:30:00+02:00", expectedPaused: true, expectedNextTransition: monday},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := newDeployment()
+			if tt.keepRunningUntil != "" {
+				deployment.Annotations = map[string]string{mdbv1.DeploymentKeepRunningUntilAnnotation: tt.keepRunningUntil}
+			}
+
+			paused, nextTransition, err := scheduledPauseState(deployment, tt.now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPaused, paused)
+			assert.True(t, tt.expectedNextTransition.Equal(nextTransition), "expected %s, got %s", tt.expectedNextTransition, nextTransition)
+		})
+	}
+
+	t.Run("Invalid override", func(t *testing.T) {
+		deployment := newDeployment()
+		deployment.Annotations = map[string]string{mdbv1.DeploymentKeepRunningUntilAnnotation: "tomorrow"}
+
+		_, _, err := scheduledPauseState(deployment, friday(12, 0))
+		assert.Error(t, err)
+	})
+}
+
+func TestApplySchedule(t *testing.T) {
+	t.Run("Paused flag is set from the schedule", func(t *testing.T) {
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+		deployment.Spec.Schedule = &mdbv1.DeploymentSchedule{Pause: "0 20 * * *", Resume: "0 8 * * *"}
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+
+		nextTransition, result := applySchedule(ctx, deployment, time.Date(2023, 6, 9, 22, 0, 0, 0, time.UTC))
+		assert.True(t, result.IsOk())
+		assert.Equal(t, time.Date(2023, 6, 10, 8, 0, 0, 0, time.UTC), nextTransition)
+		assert.Equal(t, toptr.MakePtr(true), deployment.Spec.AdvancedDeploymentSpec.Paused)
+
+		deploymentStatus := status.AtlasDeploymentStatus{}
+		for _, option := range ctx.StatusOptions() {
+			option.(status.AtlasDeploymentStatusOption)(&deploymentStatus)
+		}
+		assert.Equal(t, &status.DeploymentSchedule{Paused: true, NextTransition: "2023-06-10T08:00:00Z"}, deploymentStatus.Schedule)
+	})
+	t.Run("No schedule", func(t *testing.T) {
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+
+		nextTransition, result := applySchedule(ctx, deployment, time.Now())
+		assert.True(t, result.IsOk())
+		assert.True(t, nextTransition.IsZero())
+		assert.Nil(t, deployment.Spec.AdvancedDeploymentSpec.Paused)
+	})
+}
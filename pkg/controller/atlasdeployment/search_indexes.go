@@ -0,0 +1,237 @@
+package atlasdeployment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.mongodb.org/atlas/mongodbatlas"
+	corev1 "k8s.io/api/core/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/set"
+)
+
+// atlasSearchIndex is the alias for the Atlas search index implementing the 'Identifiable' interface
+type atlasSearchIndex mongodbatlas.SearchIndex
+
+func (i atlasSearchIndex) Identifier() interface{} {
+	return status.SearchIndexIdentifier(i.Database, i.CollectionName, i.Name)
+}
+
+// ensureSearchIndexes creates, updates and removes the Atlas Search indexes of the deployment.
+// Only the indexes created by the operator (the ones listed in the status) are removed from Atlas, the indexes
+// created outside the operator are left intact. The progress and the failures of the indexes are reported only by the
+// SearchIndexesReady condition, they don't affect the readiness of the deployment. The returned result tells when the
+// indexes are checked again.
+func (r *AtlasDeploymentReconciler) ensureSearchIndexes(ctx *workflow.Context, projectID string, deployment *mdbv1.AtlasDeployment, deploymentName string) workflow.Result {
+	searchIndexes := deployment.Spec.AdvancedDeploymentSpec.SearchIndexes
+	if len(searchIndexes) == 0 && len(deployment.Status.SearchIndexes) == 0 {
+		ctx.UnsetCondition(status.SearchIndexesReadyType)
+		return workflow.OK()
+	}
+
+	result := r.syncSearchIndexes(ctx, projectID, deployment, deploymentName)
+	if !result.IsOk() {
+		ctx.SetConditionFromResult(status.SearchIndexesReadyType, result)
+		return result
+	}
+
+	if len(searchIndexes) == 0 {
+		ctx.UnsetCondition(status.SearchIndexesReadyType)
+	} else {
+		ctx.SetConditionTrue(status.SearchIndexesReadyType)
+	}
+	return result
+}
+
+func (r *AtlasDeploymentReconciler) syncSearchIndexes(ctx *workflow.Context, projectID string, deployment *mdbv1.AtlasDeployment, deploymentName string) workflow.Result {
+	searchIndexes := deployment.Spec.AdvancedDeploymentSpec.SearchIndexes
+
+	atlasIndexes, err := listSearchIndexes(ctx, projectID, deploymentName, searchIndexes, deployment.Status.SearchIndexes)
+	if err != nil {
+		return workflow.Terminate(workflow.SearchIndexesFailed, fmt.Sprintf("Failed to list search indexes: %v", err))
+	}
+
+	ownedIndexes := make([]atlasSearchIndex, 0, len(deployment.Status.SearchIndexes))
+	for _, pair := range set.Intersection(atlasIndexes, deployment.Status.SearchIndexes) {
+		ownedIndexes = append(ownedIndexes, pair[0].(atlasSearchIndex))
+	}
+	for _, item := range set.Difference(ownedIndexes, searchIndexes) {
+		index := item.(atlasSearchIndex)
+		if _, err = ctx.Client.Search.DeleteIndex(context.Background(), projectID, deploymentName, index.IndexID); err != nil {
+			return workflow.Terminate(workflow.SearchIndexesFailed, fmt.Sprintf("Failed to delete search index %s: %v", index.Identifier(), err))
+		}
+		ctx.Log.Debugw("Search index removed from Atlas", "index", index.Identifier())
+	}
+
+	statuses := map[interface{}]status.SearchIndex{}
+	for _, item := range set.Difference(searchIndexes, atlasIndexes) {
+		index := item.(mdbv1.SearchIndex)
+		statuses[index.Identifier()] = r.createSearchIndex(ctx, projectID, deploymentName, deployment.Namespace, &index)
+	}
+	for _, pair := range set.Intersection(searchIndexes, atlasIndexes) {
+		index := pair[0].(mdbv1.SearchIndex)
+		current := pair[1].(atlasSearchIndex)
+		statuses[index.Identifier()] = r.updateSearchIndex(ctx, projectID, deploymentName, deployment.Namespace, &index, &current)
+	}
+
+	indexStatuses := make([]status.SearchIndex, 0, len(searchIndexes))
+	for _, index := range searchIndexes {
+		indexStatuses = append(indexStatuses, statuses[index.Identifier()])
+	}
+	ctx.EnsureStatusOption(status.AtlasDeploymentSearchIndexesOption(indexStatuses))
+
+	return checkSearchIndexesStatus(indexStatuses)
+}
+
+// listSearchIndexes returns the Atlas search indexes for all the collections either configured in the spec or
+// managed by the operator previously. Atlas doesn't allow listing the indexes for the whole deployment.
+func listSearchIndexes(ctx *workflow.Context, projectID, deploymentName string, searchIndexes []mdbv1.SearchIndex, statuses []status.SearchIndex) ([]atlasSearchIndex, error) {
+	type namespace struct{ database, collection string }
+	namespaces := make([]namespace, 0, len(searchIndexes)+len(statuses))
+	seen := map[namespace]bool{}
+	for _, index := range searchIndexes {
+		ns := namespace{index.Database, index.CollectionName}
+		if !seen[ns] {
+			seen[ns] = true
+			namespaces = append(namespaces, ns)
+		}
+	}
+	for _, index := range statuses {
+		ns := namespace{index.Database, index.CollectionName}
+		if !seen[ns] {
+			seen[ns] = true
+			namespaces = append(namespaces, ns)
+		}
+	}
+
+	result := make([]atlasSearchIndex, 0)
+	for _, ns := range namespaces {
+		indexes, _, err := ctx.Client.Search.ListIndexes(context.Background(), projectID, deploymentName, ns.database, ns.collection, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, index := range indexes {
+			if index != nil {
+				result = append(result, atlasSearchIndex(*index))
+			}
+		}
+	}
+	return result, nil
+}
+
+func (r *AtlasDeploymentReconciler) createSearchIndex(ctx *workflow.Context, projectID, deploymentName, namespace string, index *mdbv1.SearchIndex) status.SearchIndex {
+	desired, err := r.searchIndexToAtlas(index, namespace)
+	if err != nil {
+		return newFailedSearchIndexStatus(index, "", err)
+	}
+
+	created, _, err := ctx.Client.Search.CreateIndex(context.Background(), projectID, deploymentName, desired)
+	if err != nil {
+		return newFailedSearchIndexStatus(index, "", err)
+	}
+	ctx.Log.Debugw("Search index created in Atlas", "index", index.Identifier())
+
+	return newSearchIndexStatus(created)
+}
+
+func (r *AtlasDeploymentReconciler) updateSearchIndex(ctx *workflow.Context, projectID, deploymentName, namespace string, index *mdbv1.SearchIndex, current *atlasSearchIndex) status.SearchIndex {
+	desired, err := r.searchIndexToAtlas(index, namespace)
+	if err != nil {
+		return newFailedSearchIndexStatus(index, current.IndexID, err)
+	}
+
+	if searchIndexesEqual(desired, (*mongodbatlas.SearchIndex)(current)) {
+		return newSearchIndexStatus((*mongodbatlas.SearchIndex)(current))
+	}
+
+	updated, _, err := ctx.Client.Search.UpdateIndex(context.Background(), projectID, deploymentName, current.IndexID, desired)
+	if err != nil {
+		return newFailedSearchIndexStatus(index, current.IndexID, err)
+	}
+	ctx.Log.Debugw("Search index updated in Atlas", "index", index.Identifier())
+
+	return newSearchIndexStatus(updated)
+}
+
+// searchIndexToAtlas returns the Atlas search index with the definition either specified inline or read from the
+// referenced ConfigMap
+func (r *AtlasDeploymentReconciler) searchIndexToAtlas(index *mdbv1.SearchIndex, namespace string) (*mongodbatlas.SearchIndex, error) {
+	if index.DefinitionRef == nil {
+		return index.ToAtlas()
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.Client.Get(context.Background(), *index.DefinitionRef.GetObject(namespace), configMap); err != nil {
+		return nil, fmt.Errorf("failed to read the definition of the search index %s: %w", index.Identifier(), err)
+	}
+	definition, ok := configMap.Data[mdbv1.SearchIndexDefinitionKey]
+	if !ok {
+		return nil, fmt.Errorf("configmap %s is invalid: it doesn't contain '%s' field", configMap.Name, mdbv1.SearchIndexDefinitionKey)
+	}
+
+	return index.SearchIndexFromDefinition(definition)
+}
+
+// searchIndexesEqual compares the definition of the search indexes. The analyzers are only compared if they are
+// specified as Atlas doesn't always return the default ones.
+func searchIndexesEqual(desired, current *mongodbatlas.SearchIndex) bool {
+	if desired.Analyzer != "" && desired.Analyzer != current.Analyzer {
+		return false
+	}
+	if desired.SearchAnalyzer != "" && desired.SearchAnalyzer != current.SearchAnalyzer {
+		return false
+	}
+
+	return cmp.Equal(desired.Mappings, current.Mappings, cmpopts.EquateEmpty()) &&
+		cmp.Equal(desired.Analyzers, current.Analyzers, cmpopts.EquateEmpty()) &&
+		cmp.Equal(desired.Synonyms, current.Synonyms, cmpopts.EquateEmpty())
+}
+
+func checkSearchIndexesStatus(searchIndexes []status.SearchIndex) workflow.Result {
+	failed := make([]string, 0)
+	inProgress := false
+	for _, index := range searchIndexes {
+		switch index.Status {
+		case status.SearchIndexStatusSteady:
+		case status.SearchIndexStatusFailed:
+			failed = append(failed, index.Identifier().(string))
+		default:
+			inProgress = true
+		}
+	}
+
+	if len(failed) > 0 {
+		return workflow.Terminate(workflow.SearchIndexesFailed, fmt.Sprintf("Search indexes failed: %s", strings.Join(failed, ", ")))
+	}
+	if inProgress {
+		return workflow.InProgress(workflow.SearchIndexesNotReady, "Search indexes are being built")
+	}
+	return workflow.OK()
+}
+
+func newSearchIndexStatus(index *mongodbatlas.SearchIndex) status.SearchIndex {
+	return status.SearchIndex{
+		Name:           index.Name,
+		Database:       index.Database,
+		CollectionName: index.CollectionName,
+		ID:             index.IndexID,
+		Status:         index.Status,
+	}
+}
+
+func newFailedSearchIndexStatus(index *mdbv1.SearchIndex, id string, err error) status.SearchIndex {
+	return status.SearchIndex{
+		Name:           index.Name,
+		Database:       index.Database,
+		CollectionName: index.CollectionName,
+		ID:             id,
+		Status:         status.SearchIndexStatusFailed,
+		Message:        err.Error(),
+	}
+}
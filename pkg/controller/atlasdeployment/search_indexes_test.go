@@ -0,0 +1,171 @@
+package atlasdeployment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+)
+
+func TestSearchIndexToAtlas(t *testing.T) {
+	t.Run("inline definition", func(t *testing.T) {
+		index := mdbv1.SearchIndex{
+			Name:           "default",
+			Database:       "db",
+			CollectionName: "col",
+			Analyzer:       "lucene.english",
+			Mappings: &mdbv1.SearchIndexMappings{
+				Fields: &apiextensionsv1.JSON{Raw: []byte(`{"title":{"type":"string"}}`)},
+			},
+			Analyzers: []apiextensionsv1.JSON{{Raw: []byte(`{"name":"custom","tokenizer":{"type":"standard"}}`)}},
+		}
+
+		result, err := index.ToAtlas()
+		require.NoError(t, err)
+		assert.Equal(t, "default", result.Name)
+		assert.Equal(t, "db", result.Database)
+		assert.Equal(t, "col", result.CollectionName)
+		assert.Equal(t, "lucene.english", result.Analyzer)
+		assert.Equal(t, map[string]interface{}{"title": map[string]interface{}{"type": "string"}}, *result.Mappings.Fields)
+		assert.Equal(t, []map[string]interface{}{{"name": "custom", "tokenizer": map[string]interface{}{"type": "standard"}}}, result.Analyzers)
+	})
+	t.Run("definition from the config map", func(t *testing.T) {
+		index := mdbv1.SearchIndex{Name: "default", Database: "db", CollectionName: "col"}
+
+		result, err := index.SearchIndexFromDefinition(`{"name":"other","indexID":"id","mappings":{"dynamic":true},"searchAnalyzer":"lucene.simple"}`)
+		require.NoError(t, err)
+		assert.Equal(t, &mongodbatlas.SearchIndex{
+			Name:           "default",
+			Database:       "db",
+			CollectionName: "col",
+			SearchAnalyzer: "lucene.simple",
+			Mappings:       &mongodbatlas.IndexMapping{Dynamic: true},
+		}, result)
+	})
+	t.Run("invalid definition in the config map", func(t *testing.T) {
+		index := mdbv1.SearchIndex{Name: "default", Database: "db", CollectionName: "col"}
+
+		_, err := index.SearchIndexFromDefinition(`{"mappings":`)
+		assert.Error(t, err)
+	})
+}
+
+func TestSearchIndexesEqual(t *testing.T) {
+	fields := map[string]interface{}{"title": map[string]interface{}{"type": "string"}}
+	desired := &mongodbatlas.SearchIndex{
+		Name:     "default",
+		Mappings: &mongodbatlas.IndexMapping{Fields: &fields},
+	}
+
+	t.Run("read-only fields are ignored", func(t *testing.T) {
+		current := &mongodbatlas.SearchIndex{
+			Name:     "default",
+			IndexID:  "id",
+			Status:   status.SearchIndexStatusSteady,
+			Analyzer: "lucene.standard",
+			Mappings: &mongodbatlas.IndexMapping{Fields: &map[string]interface{}{"title": map[string]interface{}{"type": "string"}}},
+			Synonyms: []map[string]interface{}{},
+		}
+		assert.True(t, searchIndexesEqual(desired, current))
+	})
+	t.Run("mappings have changed", func(t *testing.T) {
+		current := &mongodbatlas.SearchIndex{
+			Name:     "default",
+			Mappings: &mongodbatlas.IndexMapping{Dynamic: true},
+		}
+		assert.False(t, searchIndexesEqual(desired, current))
+	})
+	t.Run("analyzer has changed", func(t *testing.T) {
+		withAnalyzer := *desired
+		withAnalyzer.Analyzer = "lucene.english"
+		current := &mongodbatlas.SearchIndex{
+			Name:     "default",
+			Analyzer: "lucene.standard",
+			Mappings: desired.Mappings,
+		}
+		assert.False(t, searchIndexesEqual(&withAnalyzer, current))
+	})
+}
+
+func TestCheckSearchIndexesStatus(t *testing.T) {
+	t.Run("all indexes are steady", func(t *testing.T) {
+		result := checkSearchIndexesStatus([]status.SearchIndex{
+			{Name: "first", Status: status.SearchIndexStatusSteady},
+			{Name: "second", Status: status.SearchIndexStatusSteady},
+		})
+		assert.True(t, result.IsOk())
+	})
+	t.Run("index is being built", func(t *testing.T) {
+		result := checkSearchIndexesStatus([]status.SearchIndex{
+			{Name: "first", Status: status.SearchIndexStatusSteady},
+			{Name: "second", Status: status.SearchIndexStatusInProgress},
+		})
+		assert.Equal(t, workflow.InProgress(workflow.SearchIndexesNotReady, "Search indexes are being built"), result)
+	})
+	t.Run("index has failed", func(t *testing.T) {
+		result := checkSearchIndexesStatus([]status.SearchIndex{
+			{Name: "first", Database: "db", CollectionName: "col", Status: status.SearchIndexStatusFailed},
+			{Name: "second", Status: status.SearchIndexStatusInProgress},
+		})
+		assert.Equal(t, workflow.Terminate(workflow.SearchIndexesFailed, "Search indexes failed: db.col/first"), result)
+	})
+}
+
+type searchStub struct {
+	mongodbatlas.SearchService
+	createdStatus string
+}
+
+func (s *searchStub) ListIndexes(_ context.Context, _, _, _, _ string, _ *mongodbatlas.ListOptions) ([]*mongodbatlas.SearchIndex, *mongodbatlas.Response, error) {
+	return nil, nil, nil
+}
+
+func (s *searchStub) CreateIndex(_ context.Context, _, _ string, index *mongodbatlas.SearchIndex) (*mongodbatlas.SearchIndex, *mongodbatlas.Response, error) {
+	created := *index
+	created.IndexID = "index-id"
+	created.Status = s.createdStatus
+	return &created, nil, nil
+}
+
+func TestEnsureSearchIndexesOnlyReportsOnItsCondition(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		createdStatus string
+		reason        workflow.ConditionReason
+	}{
+		{name: "index is being built", createdStatus: status.SearchIndexStatusInProgress, reason: workflow.SearchIndexesNotReady},
+		{name: "index has failed", createdStatus: status.SearchIndexStatusFailed, reason: workflow.SearchIndexesFailed},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := mdbv1.DefaultAwsAdvancedDeployment("default", "my-project")
+			deployment.Spec.AdvancedDeploymentSpec.SearchIndexes = []mdbv1.SearchIndex{{
+				Name:           "default",
+				Database:       "db",
+				CollectionName: "col",
+				Mappings:       &mdbv1.SearchIndexMappings{Dynamic: true},
+			}}
+			ctx := workflow.NewContext(zap.S(), []status.Condition{})
+			ctx.Client = mongodbatlas.Client{Search: &searchStub{createdStatus: tt.createdStatus}}
+			reconciler := &AtlasDeploymentReconciler{}
+
+			result := reconciler.ensureSearchIndexes(ctx, "project-id", deployment, deployment.GetDeploymentName())
+
+			assert.Equal(t, tt.reason, result.GetReason())
+			assert.True(t, requeueBy(result).RequeueAfter > 0)
+			condition, found := ctx.GetCondition(status.SearchIndexesReadyType)
+			require.True(t, found)
+			assert.Equal(t, corev1.ConditionFalse, condition.Status)
+			_, found = ctx.GetCondition(status.DeploymentReadyType)
+			assert.False(t, found)
+		})
+	}
+}
@@ -2,7 +2,6 @@ package atlasdeployment
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
@@ -23,7 +22,14 @@ func ensureServerlessInstanceState(ctx *workflow.Context, project *mdbv1.AtlasPr
 		if resp.StatusCode != http.StatusNotFound {
 			return atlasDeployment, workflow.Terminate(workflow.DeploymentNotCreatedInAtlas, err.Error())
 		}
+	}
+
+	// the instance with the same name might still be being deleted in Atlas after the resource was recreated
+	if err == nil && atlasDeployment.StateName == status.StateDELETING {
+		return atlasDeployment, deploymentStateResult(atlasDeployment.StateName)
+	}
 
+	if err != nil || atlasDeployment.StateName == status.StateDELETED {
 		ctx.Log.Infof("Serverless Instance %s doesn't exist in Atlas - creating", serverlessSpec.Name)
 		atlasDeployment, _, err = ctx.Client.ServerlessInstances.Create(context.Background(), project.Status.ID, &mongodbatlas.ServerlessCreateRequestParams{
 			Name: serverlessSpec.Name,
@@ -32,6 +38,7 @@ func ensureServerlessInstanceState(ctx *workflow.Context, project *mdbv1.AtlasPr
 				ProviderName:        string(serverlessSpec.ProviderSettings.ProviderName),
 				RegionName:          serverlessSpec.ProviderSettings.RegionName,
 			},
+			TerminationProtectionEnabled: serverlessSpec.TerminationProtectionEnabled,
 		})
 		if err != nil {
 			return atlasDeployment, workflow.Terminate(workflow.DeploymentNotCreatedInAtlas, err.Error())
@@ -40,17 +47,31 @@ func ensureServerlessInstanceState(ctx *workflow.Context, project *mdbv1.AtlasPr
 
 	switch atlasDeployment.StateName {
 	case status.StateIDLE:
+		if result := ensureServerlessTerminationProtection(ctx, project.ID(), serverlessSpec, atlasDeployment); !result.IsOk() {
+			return atlasDeployment, result
+		}
 		result := ensureServerlessPrivateEndpoints(ctx, project.ID(), serverlessSpec, atlasDeployment.Name)
 		return atlasDeployment, result
-	case status.StateCREATING:
-		return atlasDeployment, workflow.InProgress(workflow.DeploymentCreating, "deployment is provisioning")
 
-	case status.StateUPDATING, status.StateREPAIRING:
-		return atlasDeployment, workflow.InProgress(workflow.DeploymentUpdating, "deployment is updating")
+	default:
+		return atlasDeployment, deploymentStateResult(atlasDeployment.StateName)
+	}
+}
 
-	// TODO: add "DELETING", "DELETED", handle 404 on delete
+// ensureServerlessTerminationProtection updates the termination protection of the serverless instance if it differs
+// from the spec
+func ensureServerlessTerminationProtection(ctx *workflow.Context, projectID string, serverlessSpec *mdbv1.ServerlessSpec, atlasDeployment *mongodbatlas.Cluster) workflow.Result {
+	if serverlessSpec.TerminationProtectionEnabled == nil || boolValue(atlasDeployment.TerminationProtectionEnabled) == *serverlessSpec.TerminationProtectionEnabled {
+		return workflow.OK()
+	}
 
-	default:
-		return atlasDeployment, workflow.Terminate(workflow.Internal, fmt.Sprintf("unknown deployment state %q", atlasDeployment.StateName))
+	ctx.Log.Infow("Updating the termination protection of the Serverless Instance", "enabled", *serverlessSpec.TerminationProtectionEnabled)
+	_, _, err := ctx.Client.ServerlessInstances.Update(context.Background(), projectID, atlasDeployment.Name, &mongodbatlas.ServerlessUpdateRequestParams{
+		ServerlessBackupOptions:      atlasDeployment.ServerlessBackupOptions,
+		TerminationProtectionEnabled: serverlessSpec.TerminationProtectionEnabled,
+	})
+	if err != nil {
+		return workflow.Terminate(workflow.DeploymentNotUpdatedInAtlas, err.Error())
 	}
+	return workflow.OK()
 }
@@ -0,0 +1,104 @@
+package atlasdeployment
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+// serverlessInstancesStub returns the instance in the given state and records the instances created
+type serverlessInstancesStub struct {
+	mongodbatlas.ServerlessInstancesService
+
+	stateName                    string
+	notFound                     bool
+	terminationProtectionEnabled *bool
+	created                      int
+	updates                      []*mongodbatlas.ServerlessUpdateRequestParams
+}
+
+func (s *serverlessInstancesStub) Get(_ context.Context, _, name string) (*mongodbatlas.Cluster, *mongodbatlas.Response, error) {
+	if s.notFound {
+		return nil, &mongodbatlas.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, &mongodbatlas.ErrorResponse{ErrorCode: "SERVERLESS_INSTANCE_NOT_FOUND"}
+	}
+	return &mongodbatlas.Cluster{Name: name, StateName: s.stateName, TerminationProtectionEnabled: s.terminationProtectionEnabled}, nil, nil
+}
+
+func (s *serverlessInstancesStub) Update(_ context.Context, _, name string, params *mongodbatlas.ServerlessUpdateRequestParams) (*mongodbatlas.Cluster, *mongodbatlas.Response, error) {
+	s.updates = append(s.updates, params)
+	return &mongodbatlas.Cluster{Name: name, StateName: s.stateName, TerminationProtectionEnabled: params.TerminationProtectionEnabled}, nil, nil
+}
+
+func (s *serverlessInstancesStub) Create(_ context.Context, _ string, params *mongodbatlas.ServerlessCreateRequestParams) (*mongodbatlas.Cluster, *mongodbatlas.Response, error) {
+	s.created++
+	return &mongodbatlas.Cluster{Name: params.Name, StateName: status.StateCREATING}, nil, nil
+}
+
+func TestEnsureServerlessInstanceState(t *testing.T) {
+	tests := []struct {
+		name            string
+		stub            *serverlessInstancesStub
+		expectedReason  workflow.ConditionReason
+		expectedCreated int
+	}{
+		{name: "Instance is created if it doesn't exist", stub: &serverlessInstancesStub{notFound: true}, expectedReason: workflow.DeploymentCreating, expectedCreated: 1},
+		{name: "Instance is recreated once deleted", stub: &serverlessInstancesStub{stateName: status.StateDELETED}, expectedReason: workflow.DeploymentCreating, expectedCreated: 1},
+		{name: "Waiting for the deletion of the previous instance", stub: &serverlessInstancesStub{stateName: status.StateDELETING}, expectedReason: workflow.DeploymentDeleting},
+		{name: "Waiting for the repair", stub: &serverlessInstancesStub{stateName: status.StateREPAIRING}, expectedReason: workflow.DeploymentRepairing},
+		{name: "Waiting in the unknown state", stub: &serverlessInstancesStub{stateName: "MIGRATING"}, expectedReason: workflow.DeploymentStateUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := workflow.NewContext(zap.S(), []status.Condition{})
+			ctx.Client = mongodbatlas.Client{ServerlessInstances: tt.stub}
+			project := mdbv1.DefaultProject("ns", "connection")
+			project.Status.ID = "project-id"
+			deployment := mdbv1.NewDefaultAWSServerlessInstance("ns", "project")
+
+			_, result := ensureServerlessInstanceState(ctx, project, deployment.Spec.ServerlessSpec)
+			assert.Equal(t, workflow.InProgress(tt.expectedReason, result.GetMessage()), result)
+			assert.Equal(t, tt.expectedCreated, tt.stub.created)
+		})
+	}
+}
+
+func TestEnsureServerlessTerminationProtection(t *testing.T) {
+	tests := []struct {
+		name            string
+		spec            *bool
+		atlas           *bool
+		expectedUpdates int
+	}{
+		{name: "Protection is enabled", spec: toptr.MakePtr(true), atlas: toptr.MakePtr(false), expectedUpdates: 1},
+		{name: "Protection is disabled", spec: toptr.MakePtr(false), atlas: toptr.MakePtr(true), expectedUpdates: 1},
+		{name: "Protection is in sync", spec: toptr.MakePtr(true), atlas: toptr.MakePtr(true)},
+		{name: "Protection is not managed", atlas: toptr.MakePtr(true)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &serverlessInstancesStub{stateName: status.StateIDLE, terminationProtectionEnabled: tt.atlas}
+			ctx := workflow.NewContext(zap.S(), []status.Condition{})
+			ctx.Client = mongodbatlas.Client{ServerlessInstances: stub}
+			deployment := mdbv1.NewDefaultAWSServerlessInstance("ns", "project")
+			deployment.Spec.ServerlessSpec.TerminationProtectionEnabled = tt.spec
+
+			result := ensureServerlessTerminationProtection(ctx, "project-id", deployment.Spec.ServerlessSpec,
+				&mongodbatlas.Cluster{Name: deployment.GetDeploymentName(), TerminationProtectionEnabled: tt.atlas})
+			assert.True(t, result.IsOk())
+			require.Len(t, stub.updates, tt.expectedUpdates)
+			if tt.expectedUpdates > 0 {
+				assert.Equal(t, tt.spec, stub.updates[0].TerminationProtectionEnabled)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package atlasdeployment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+	corev1 "k8s.io/api/core/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/timeutil"
+)
+
+// ensureTenantUpgrade upgrades the shared tier (M0/M2/M5) deployment to the dedicated tier requested by the spec.
+// Atlas rejects such changes in the regular update, so they go through the tenant upgrade API. The upgrade can't be
+// reverted, the changes of the dedicated deployment back to the shared tier are rejected. The current deployment is
+// nil if it doesn't exist in Atlas yet.
+func (r *AtlasDeploymentReconciler) ensureTenantUpgrade(ctx *workflow.Context, project *mdbv1.AtlasProject, deployment *mdbv1.AtlasDeployment, current *mongodbatlas.AdvancedCluster, now time.Time) workflow.Result {
+	advancedDeploymentSpec := deployment.Spec.AdvancedDeploymentSpec
+	if advancedDeploymentSpec == nil {
+		finishTenantUpgrade(ctx)
+		return workflow.OK()
+	}
+	target := tenantRegionConfig(advancedDeploymentSpec.ReplicationSpecs)
+	if target == nil {
+		finishTenantUpgrade(ctx)
+		return workflow.OK()
+	}
+	targetTenant := isTenantRegionConfig(target)
+	if !targetTenant && target.ProviderName == string(provider.ProviderTenant) {
+		// The spec still describing the dedicated size with the TENANT provider and the shared tier backing provider
+		// is rewritten in memory only: the dedicated deployment runs directly in the cloud provider which backed the
+		// shared tier one, so both the upgrade request below and the regular update of this reconciliation send that
+		// provider to Atlas. The resource itself keeps the spec as the user wrote it.
+		target.ProviderName = target.BackingProviderName
+		target.BackingProviderName = ""
+	}
+
+	if current == nil {
+		// the new deployment is created in the tier from the spec
+		finishTenantUpgrade(ctx)
+		return workflow.OK()
+	}
+	currentSpec, err := AdvancedDeploymentFromAtlas(*current)
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+	source := tenantRegionConfig(currentSpec.ReplicationSpecs)
+	if source == nil {
+		finishTenantUpgrade(ctx)
+		return workflow.OK()
+	}
+
+	sourceTenant := isTenantRegionConfig(source)
+	upgrade := deployment.Status.TenantUpgrade
+
+	if targetTenant && (!sourceTenant || upgrade != nil) {
+		result := workflow.Terminate(workflow.TenantDowngradeInvalid,
+			fmt.Sprintf("downgrading the dedicated deployment to the shared tier %s is not supported, create a new deployment instead", instanceSize(target))).WithoutRetry()
+		ctx.SetConditionFromResult(status.ValidationSucceeded, result)
+		return result
+	}
+
+	if upgrade != nil {
+		if !sourceTenant && current.StateName == status.StateIDLE {
+			ctx.Log.Infow("Upgrade to the dedicated tier finished", "instanceSize", instanceSize(source))
+			r.EventRecorder.Eventf(deployment, "Normal", "TenantUpgradeCompleted", "Upgrade from %s to %s completed", upgrade.From, upgrade.To)
+			finishTenantUpgrade(ctx)
+			return workflow.OK()
+		}
+		return tenantUpgrading(ctx, upgrade)
+	}
+
+	if !sourceTenant || targetTenant {
+		finishTenantUpgrade(ctx)
+		return workflow.OK()
+	}
+
+	if current.StateName != status.StateIDLE {
+		return deploymentStateResult(current.StateName)
+	}
+
+	upgrade = &status.TenantUpgrade{
+		From:      instanceSize(source),
+		To:        instanceSize(target),
+		StartedAt: timeutil.FormatISO8601(now.UTC()),
+	}
+	_, _, err = ctx.Client.Clusters.Upgrade(context.Background(), project.ID(), &mongodbatlas.Cluster{
+		Name: advancedDeploymentSpec.Name,
+		ProviderSettings: &mongodbatlas.ProviderSettings{
+			ProviderName:     target.ProviderName,
+			InstanceSizeName: upgrade.To,
+			RegionName:       target.RegionName,
+		},
+	})
+	if err != nil {
+		result := workflow.Terminate(workflow.TenantUpgradeFailed, err.Error())
+		ctx.SetConditionFromResult(status.TenantUpgradeInProgressType, result)
+		return result
+	}
+
+	ctx.Log.Infow("Upgrading to the dedicated tier", "from", upgrade.From, "to", upgrade.To)
+	r.EventRecorder.Eventf(deployment, "Normal", "TenantUpgradeStarted", "Upgrading from %s to %s", upgrade.From, upgrade.To)
+	ctx.EnsureStatusOption(status.AtlasDeploymentTenantUpgradeOption(upgrade))
+	return tenantUpgrading(ctx, upgrade)
+}
+
+// tenantUpgrading keeps the other changes of the deployment until the upgrade is finished
+func tenantUpgrading(ctx *workflow.Context, upgrade *status.TenantUpgrade) workflow.Result {
+	message := fmt.Sprintf("Upgrading from %s to %s", upgrade.From, upgrade.To)
+	ctx.EnsureCondition(status.Condition{
+		Type:    status.TenantUpgradeInProgressType,
+		Status:  corev1.ConditionTrue,
+		Reason:  string(workflow.TenantUpgrading),
+		Message: message,
+	})
+	return workflow.InProgress(workflow.TenantUpgrading, message)
+}
+
+func finishTenantUpgrade(ctx *workflow.Context) {
+	ctx.UnsetCondition(status.TenantUpgradeInProgressType)
+	ctx.EnsureStatusOption(status.AtlasDeploymentTenantUpgradeOption(nil))
+}
+
+// tenantRegionConfig returns the only region config of the single replica set. The shared tier deployments can't have
+// more and such deployments are neither upgraded nor downgraded.
+func tenantRegionConfig(replicationSpecs []*mdbv1.AdvancedReplicationSpec) *mdbv1.AdvancedRegionConfig {
+	if len(replicationSpecs) != 1 || replicationSpecs[0] == nil || len(replicationSpecs[0].RegionConfigs) != 1 {
+		return nil
+	}
+	return replicationSpecs[0].RegionConfigs[0]
+}
+
+func isTenantRegionConfig(regionConfig *mdbv1.AdvancedRegionConfig) bool {
+	switch instanceSize(regionConfig) {
+	case "M0", "M2", "M5":
+		return true
+	}
+	return false
+}
+
+func instanceSize(regionConfig *mdbv1.AdvancedRegionConfig) string {
+	if regionConfig.ElectableSpecs == nil {
+		return ""
+	}
+	return regionConfig.ElectableSpecs.InstanceSize
+}
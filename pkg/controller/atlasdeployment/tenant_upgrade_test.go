@@ -0,0 +1,167 @@
+package atlasdeployment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	"k8s.io/client-go/tools/record"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+)
+
+// advancedClustersStub returns the given deployment
+type advancedClustersStub struct {
+	mongodbatlas.AdvancedClustersService
+
+	cluster *mongodbatlas.AdvancedCluster
+}
+
+func (s *advancedClustersStub) Get(context.Context, string, string) (*mongodbatlas.AdvancedCluster, *mongodbatlas.Response, error) {
+	return s.cluster, nil, nil
+}
+
+// tenantUpgradesStub records the tenant upgrade requests
+type tenantUpgradesStub struct {
+	mongodbatlas.ClustersService
+
+	upgrades []*mongodbatlas.Cluster
+}
+
+func (s *tenantUpgradesStub) Upgrade(_ context.Context, _ string, upgradeRequest *mongodbatlas.Cluster) (*mongodbatlas.Cluster, *mongodbatlas.Response, error) {
+	s.upgrades = append(s.upgrades, upgradeRequest)
+	return upgradeRequest, nil, nil
+}
+
+func TestEnsureTenantUpgrade(t *testing.T) {
+	now := time.Date(2023, 6, 9, 12, 0, 0, 0, time.UTC)
+	reconciler := &AtlasDeploymentReconciler{EventRecorder: record.NewFakeRecorder(10)}
+	project := mdbv1.DefaultProject("ns", "connection")
+	project.Status.ID = "project-id"
+	atlasDeployment := func(providerName, backingProviderName, instanceSize, stateName string) *mongodbatlas.AdvancedCluster {
+		return &mongodbatlas.AdvancedCluster{
+			Name:      "test-deployment-advanced",
+			StateName: stateName,
+			ReplicationSpecs: []*mongodbatlas.AdvancedReplicationSpec{{
+				RegionConfigs: []*mongodbatlas.AdvancedRegionConfig{{
+					ProviderName:        providerName,
+					BackingProviderName: backingProviderName,
+					RegionName:          "US_EAST_1",
+					ElectableSpecs:      &mongodbatlas.Specs{InstanceSize: instanceSize},
+				}},
+			}},
+		}
+	}
+	newContext := func(upgrades *tenantUpgradesStub) *workflow.Context {
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		ctx.Client = mongodbatlas.Client{Clusters: upgrades}
+		return ctx
+	}
+
+	t.Run("Shared tier deployment is upgraded", func(t *testing.T) {
+		upgrades := &tenantUpgradesStub{}
+		current := atlasDeployment("TENANT", "AWS", "M5", status.StateIDLE)
+		ctx := newContext(upgrades)
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+
+		result := reconciler.ensureTenantUpgrade(ctx, project, deployment, current, now)
+
+		assert.Equal(t, workflow.TenantUpgrading, result.GetReason())
+		require.Len(t, upgrades.upgrades, 1)
+		assert.Equal(t, &mongodbatlas.Cluster{
+			Name:             "test-deployment-advanced",
+			ProviderSettings: &mongodbatlas.ProviderSettings{ProviderName: "AWS", InstanceSizeName: "M10", RegionName: "US_EAST_1"},
+		}, upgrades.upgrades[0])
+		applyStatusOptions(ctx, deployment)
+		assert.Equal(t, &status.TenantUpgrade{From: "M5", To: "M10", StartedAt: "2023-06-09T12:00:00Z"}, deployment.Status.TenantUpgrade)
+		_, found := ctx.GetCondition(status.TenantUpgradeInProgressType)
+		assert.True(t, found)
+	})
+
+	t.Run("Backing provider of the shared tier spec is upgraded to", func(t *testing.T) {
+		upgrades := &tenantUpgradesStub{}
+		current := atlasDeployment("TENANT", "GCP", "M2", status.StateIDLE)
+		ctx := newContext(upgrades)
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+		regionConfig := deployment.Spec.AdvancedDeploymentSpec.ReplicationSpecs[0].RegionConfigs[0]
+		regionConfig.ProviderName = "TENANT"
+		regionConfig.BackingProviderName = "GCP"
+
+		reconciler.ensureTenantUpgrade(ctx, project, deployment, current, now)
+
+		require.Len(t, upgrades.upgrades, 1)
+		assert.Equal(t, "GCP", upgrades.upgrades[0].ProviderSettings.ProviderName)
+		assert.Equal(t, "GCP", regionConfig.ProviderName)
+		assert.Empty(t, regionConfig.BackingProviderName)
+	})
+
+	t.Run("Upgrade in progress isn't requested again", func(t *testing.T) {
+		upgrades := &tenantUpgradesStub{}
+		current := atlasDeployment("TENANT", "AWS", "M5", status.StateUPDATING)
+		ctx := newContext(upgrades)
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+		deployment.Status.TenantUpgrade = &status.TenantUpgrade{From: "M5", To: "M10"}
+
+		result := reconciler.ensureTenantUpgrade(ctx, project, deployment, current, now)
+
+		assert.Equal(t, workflow.TenantUpgrading, result.GetReason())
+		assert.Empty(t, upgrades.upgrades)
+	})
+
+	t.Run("Finished upgrade is completed", func(t *testing.T) {
+		current := atlasDeployment("AWS", "", "M10", status.StateIDLE)
+		ctx := newContext(&tenantUpgradesStub{})
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+		deployment.Status.TenantUpgrade = &status.TenantUpgrade{From: "M5", To: "M10"}
+
+		assert.True(t, reconciler.ensureTenantUpgrade(ctx, project, deployment, current, now).IsOk())
+		applyStatusOptions(ctx, deployment)
+		assert.Nil(t, deployment.Status.TenantUpgrade)
+		_, found := ctx.GetCondition(status.TenantUpgradeInProgressType)
+		assert.False(t, found)
+	})
+
+	t.Run("Downgrade to the shared tier is rejected", func(t *testing.T) {
+		upgrades := &tenantUpgradesStub{}
+		current := atlasDeployment("AWS", "", "M10", status.StateIDLE)
+		ctx := newContext(upgrades)
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+		deployment.Spec.AdvancedDeploymentSpec.ReplicationSpecs[0].RegionConfigs[0].ElectableSpecs.InstanceSize = "M5"
+
+		result := reconciler.ensureTenantUpgrade(ctx, project, deployment, current, now)
+
+		assert.Equal(t, workflow.TenantDowngradeInvalid, result.GetReason())
+		assert.Empty(t, upgrades.upgrades)
+		condition, found := ctx.GetCondition(status.ValidationSucceeded)
+		require.True(t, found)
+		assert.Equal(t, string(workflow.TenantDowngradeInvalid), condition.Reason)
+	})
+
+	t.Run("Dedicated deployments are updated as usual", func(t *testing.T) {
+		upgrades := &tenantUpgradesStub{}
+		current := atlasDeployment("AWS", "", "M10", status.StateIDLE)
+		ctx := newContext(upgrades)
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+		deployment.Spec.AdvancedDeploymentSpec.ReplicationSpecs[0].RegionConfigs[0].ElectableSpecs.InstanceSize = "M20"
+
+		assert.True(t, reconciler.ensureTenantUpgrade(ctx, project, deployment, current, now).IsOk())
+		assert.Empty(t, upgrades.upgrades)
+	})
+	t.Run("New deployment is created in the tier from the spec", func(t *testing.T) {
+		upgrades := &tenantUpgradesStub{}
+		ctx := newContext(upgrades)
+		deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "project")
+		deployment.Status.TenantUpgrade = &status.TenantUpgrade{From: "M5", To: "M10"}
+
+		assert.True(t, reconciler.ensureTenantUpgrade(ctx, project, deployment, nil, now).IsOk())
+		assert.Empty(t, upgrades.upgrades)
+		applyStatusOptions(ctx, deployment)
+		assert.Nil(t, deployment.Status.TenantUpgrade)
+	})
+}
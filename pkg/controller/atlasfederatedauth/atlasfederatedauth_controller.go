@@ -0,0 +1,184 @@
+/*
+Copyright 2023 MongoDB.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package atlasfederatedauth
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/statushandler"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/validate"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/watch"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+)
+
+// AtlasFederatedAuthReconciler reconciles an AtlasFederatedAuth object
+type AtlasFederatedAuthReconciler struct {
+	watch.ResourceWatcher
+	Client           client.Client
+	Log              *zap.SugaredLogger
+	Scheme           *runtime.Scheme
+	AtlasDomain      string
+	GlobalAPISecret  client.ObjectKey
+	GlobalPredicates []predicate.Predicate
+	EventRecorder    record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasfederatedauths,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasfederatedauths/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasfederatedauths,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasfederatedauths/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasprojects,verbs=get;list;watch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasprojects,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",namespace=default,resources=events,verbs=create;patch
+
+func (r *AtlasFederatedAuthReconciler) Reconcile(context context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.With("atlasfederatedauth", req.NamespacedName)
+
+	fedAuth := &mdbv1.AtlasFederatedAuth{}
+	result := customresource.PrepareResource(r.Client, req, fedAuth, log)
+	if !result.IsOk() {
+		return result.ReconcileResult(), nil
+	}
+
+	if shouldSkip := customresource.ReconciliationShouldBeSkipped(fedAuth); shouldSkip {
+		log.Infow(fmt.Sprintf("-> Skipping AtlasFederatedAuth reconciliation as annotation %s=%s", customresource.ReconciliationPolicyAnnotation, customresource.ReconciliationPolicySkip), "spec", fedAuth.Spec)
+		if !fedAuth.GetDeletionTimestamp().IsZero() {
+			if err := r.removeDeletionFinalizer(context, fedAuth); err != nil {
+				result = workflow.Terminate(workflow.Internal, err.Error())
+				log.Errorw("failed to remove finalizer", "error", err)
+				return result.ReconcileResult(), nil
+			}
+		}
+		return workflow.OK().ReconcileResult(), nil
+	}
+
+	ctx := customresource.MarkReconciliationStarted(r.Client, fedAuth, log)
+	log.Infow("-> Starting AtlasFederatedAuth reconciliation", "spec", fedAuth.Spec, "status", fedAuth.Status)
+	defer statushandler.Update(ctx, r.Client, r.EventRecorder, fedAuth)
+
+	resourceVersionIsValid := customresource.ValidateResourceVersion(ctx, fedAuth, r.Log)
+	if !resourceVersionIsValid.IsOk() {
+		r.Log.Debugf("federated auth validation result: %v", resourceVersionIsValid)
+		return resourceVersionIsValid.ReconcileResult(), nil
+	}
+
+	if err := validate.FederatedAuth(fedAuth); err != nil {
+		result := workflow.Terminate(workflow.FederatedAuthInvalidSpec, err.Error())
+		ctx.SetConditionFromResult(status.ValidationSucceeded, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.SetConditionTrue(status.ValidationSucceeded)
+
+	connection, err := atlas.ReadConnection(log, r.Client, r.GlobalAPISecret, fedAuth.ConnectionSecretObjectKey())
+	if err != nil {
+		result := workflow.Terminate(workflow.AtlasCredentialsNotProvided, err.Error())
+		ctx.SetConditionFromResult(status.FederatedAuthReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.Connection = connection
+
+	atlasClient, err := atlas.Client(r.AtlasDomain, connection, log)
+	if err != nil {
+		result := workflow.Terminate(workflow.Internal, err.Error())
+		ctx.SetConditionFromResult(status.FederatedAuthReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.Client = atlasClient
+
+	if fedAuth.GetDeletionTimestamp().IsZero() {
+		if !customresource.HaveFinalizer(fedAuth, customresource.FinalizerLabel) {
+			customresource.SetFinalizer(fedAuth, customresource.FinalizerLabel)
+			if err = r.Client.Update(context, fedAuth); err != nil {
+				result = workflow.Terminate(workflow.Internal, err.Error())
+				log.Errorw("failed to add finalizer", "error", err)
+				return result.ReconcileResult(), nil
+			}
+		}
+	} else {
+		if !customresource.HaveFinalizer(fedAuth, customresource.FinalizerLabel) {
+			return workflow.OK().ReconcileResult(), nil
+		}
+		if customresource.ResourceShouldBeLeftInAtlas(fedAuth) {
+			log.Infof("Not removing the federated authentication settings from Atlas as the '%s' annotation is set", customresource.ResourcePolicyAnnotation)
+		} else if err = deleteFederatedAuthFromAtlas(ctx, fedAuth); err != nil {
+			log.Errorf("failed to remove the federated authentication settings from Atlas: %s", err)
+			result = workflow.Terminate(workflow.Internal, err.Error())
+			ctx.SetConditionFromResult(status.FederatedAuthReadyType, result)
+			return result.ReconcileResult(), nil
+		}
+		if err = r.removeDeletionFinalizer(context, fedAuth); err != nil {
+			result = workflow.Terminate(workflow.Internal, err.Error())
+			log.Errorw("failed to remove finalizer", "error", err)
+			return result.ReconcileResult(), nil
+		}
+		return workflow.OK().ReconcileResult(), nil
+	}
+
+	if result = r.ensureFederatedAuth(ctx, connection.OrgID, fedAuth); !result.IsOk() {
+		ctx.SetConditionFromResult(status.FederatedAuthReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	ctx.SetConditionTrue(status.FederatedAuthReadyType)
+	ctx.SetConditionTrue(status.ReadyType)
+	return workflow.OK().ReconcileResult(), nil
+}
+
+func (r *AtlasFederatedAuthReconciler) removeDeletionFinalizer(ctx context.Context, fedAuth *mdbv1.AtlasFederatedAuth) error {
+	err := r.Client.Get(ctx, kube.ObjectKeyFromObject(fedAuth), fedAuth)
+	if err != nil {
+		return fmt.Errorf("cannot get AtlasFederatedAuth while removing finalizer: %w", err)
+	}
+
+	customresource.UnsetFinalizer(fedAuth, customresource.FinalizerLabel)
+	if err = r.Client.Update(ctx, fedAuth); err != nil {
+		return fmt.Errorf("failed to remove deletion finalizer from %s: %w", fedAuth.Name, err)
+	}
+	return nil
+}
+
+func (r *AtlasFederatedAuthReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c, err := controller.New("AtlasFederatedAuth", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource AtlasFederatedAuth
+	err = c.Watch(&source.Kind{Type: &mdbv1.AtlasFederatedAuth{}}, &handler.EnqueueRequestForObject{}, r.GlobalPredicates...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,310 @@
+package atlasfederatedauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+// ensureFederatedAuth configures the identity providers of the federation the organization belongs to, connects the
+// organization to the federation and syncs the role mappings of the connected organization.
+func (r *AtlasFederatedAuthReconciler) ensureFederatedAuth(ctx *workflow.Context, orgID string, fedAuth *mdbv1.AtlasFederatedAuth) workflow.Result {
+	settings, _, err := ctx.Client.FederatedSettings.Get(context.Background(), orgID)
+	if err != nil {
+		return workflow.Terminate(workflow.FederatedAuthNotConfigured, err.Error())
+	}
+	ctx.EnsureStatusOption(status.AtlasFederatedAuthSettingsIDOption(settings.ID))
+	ctx.EnsureStatusOption(status.AtlasFederatedAuthOrgIDOption(orgID))
+
+	service := atlas.NewFederatedAuthService(&ctx.Client)
+	if result := ensureIdentityProviders(ctx, service, settings.ID, fedAuth.Spec.IdentityProviders); !result.IsOk() {
+		return result
+	}
+
+	if !fedAuth.IsEnabled() {
+		if err = disconnectOrg(ctx, settings.ID, orgID); err != nil {
+			return workflow.Terminate(workflow.FederatedAuthNotConfigured, err.Error())
+		}
+		ctx.EnsureStatusOption(status.AtlasFederatedAuthRoleMappingsOption(nil))
+		return workflow.OK()
+	}
+
+	if result := ensureConnectedOrg(ctx, service, settings.ID, orgID, fedAuth.Spec); !result.IsOk() {
+		return result
+	}
+
+	return r.ensureRoleMappings(ctx, settings.ID, orgID, fedAuth)
+}
+
+func ensureIdentityProviders(ctx *workflow.Context, service atlas.FederatedAuthService, federationSettingsID string, specIdps []mdbv1.FederatedIdentityProvider) workflow.Result {
+	if len(specIdps) == 0 {
+		return workflow.OK()
+	}
+
+	atlasIdps, _, err := service.ListIdentityProviders(context.Background(), federationSettingsID)
+	if err != nil {
+		return workflow.Terminate(workflow.FederatedAuthNotConfigured, err.Error())
+	}
+
+	for _, specIdp := range specIdps {
+		current := findIdentityProvider(atlasIdps, specIdp.ID)
+		if current == nil {
+			return workflow.Terminate(workflow.FederatedAuthIdentityProviderNotFound,
+				fmt.Sprintf("identity provider %s doesn't exist in the federation %s, it must be created in Atlas first", specIdp.ID, federationSettingsID))
+		}
+		if identityProviderInSync(*current, specIdp) {
+			continue
+		}
+
+		ctx.Log.Infow("Updating identity provider", "id", specIdp.ID)
+		if _, _, err = service.UpdateIdentityProvider(context.Background(), federationSettingsID, specIdp.ID, identityProviderToAtlas(specIdp)); err != nil {
+			return workflow.Terminate(workflow.FederatedAuthIdentityProviderNotUpdated, err.Error())
+		}
+	}
+
+	return workflow.OK()
+}
+
+// findIdentityProvider looks up the identity provider by its ID. The legacy SAML identity providers are identified
+// by the Okta ID.
+func findIdentityProvider(idps []atlas.FederatedIdentityProvider, id string) *atlas.FederatedIdentityProvider {
+	for i := range idps {
+		if idps[i].ID == id || idps[i].OktaIdpID == id {
+			return &idps[i]
+		}
+	}
+	return nil
+}
+
+// identityProviderToAtlas returns the update request for the identity provider which contains only the fields
+// specified by the user
+func identityProviderToAtlas(spec mdbv1.FederatedIdentityProvider) *atlas.FederatedIdentityProvider {
+	result := &atlas.FederatedIdentityProvider{
+		FederatedSettingsIdentityProvider: mongodbatlas.FederatedSettingsIdentityProvider{
+			DisplayName:       spec.DisplayName,
+			IssuerURI:         spec.IssuerURI,
+			AssociatedDomains: spec.AssociatedDomains,
+			SsoDebugEnabled:   spec.SSODebugEnabled,
+		},
+		Description: spec.Description,
+	}
+	if spec.OIDC != nil {
+		result.Audience = spec.OIDC.Audience
+		result.ClientID = spec.OIDC.ClientID
+		result.AuthorizationType = spec.OIDC.AuthorizationType
+		result.GroupsClaim = spec.OIDC.GroupsClaim
+		result.UserClaim = spec.OIDC.UserClaim
+		result.RequestedScopes = spec.OIDC.RequestedScopes
+	}
+	return result
+}
+
+// identityProviderInSync compares only the fields specified by the user as the rest are managed in Atlas
+func identityProviderInSync(current atlas.FederatedIdentityProvider, spec mdbv1.FederatedIdentityProvider) bool {
+	desired := identityProviderToAtlas(spec)
+
+	inSync := stringInSync(current.DisplayName, desired.DisplayName) &&
+		stringInSync(current.Description, desired.Description) &&
+		stringInSync(current.IssuerURI, desired.IssuerURI) &&
+		stringInSync(current.Audience, desired.Audience) &&
+		stringInSync(current.ClientID, desired.ClientID) &&
+		stringInSync(current.AuthorizationType, desired.AuthorizationType) &&
+		stringInSync(current.GroupsClaim, desired.GroupsClaim) &&
+		stringInSync(current.UserClaim, desired.UserClaim)
+	if desired.SsoDebugEnabled != nil && (current.SsoDebugEnabled == nil || *current.SsoDebugEnabled != *desired.SsoDebugEnabled) {
+		inSync = false
+	}
+	if desired.AssociatedDomains != nil && !sameStrings(current.AssociatedDomains, desired.AssociatedDomains) {
+		inSync = false
+	}
+	if desired.RequestedScopes != nil && !sameStrings(current.RequestedScopes, desired.RequestedScopes) {
+		inSync = false
+	}
+	return inSync
+}
+
+func stringInSync(current, desired string) bool {
+	return desired == "" || current == desired
+}
+
+func ensureConnectedOrg(ctx *workflow.Context, service atlas.FederatedAuthService, federationSettingsID, orgID string, spec mdbv1.AtlasFederatedAuthSpec) workflow.Result {
+	desired := connectedOrgToAtlas(orgID, spec)
+
+	current, _, err := service.GetConnectedOrg(context.Background(), federationSettingsID, orgID)
+	if err != nil && !isNotFound(err) {
+		return workflow.Terminate(workflow.FederatedAuthNotConfigured, err.Error())
+	}
+	if current != nil && connectedOrgInSync(*current, *desired) {
+		return workflow.OK()
+	}
+
+	ctx.Log.Infow("Updating connected organization configuration", "federationSettingsID", federationSettingsID, "orgID", orgID)
+	if _, _, err = service.UpdateConnectedOrg(context.Background(), federationSettingsID, orgID, desired); err != nil {
+		return workflow.Terminate(workflow.FederatedAuthNotConfigured, err.Error())
+	}
+	return workflow.OK()
+}
+
+func connectedOrgToAtlas(orgID string, spec mdbv1.AtlasFederatedAuthSpec) *atlas.FederatedConnectedOrg {
+	return &atlas.FederatedConnectedOrg{
+		FederatedSettingsConnectedOrganization: mongodbatlas.FederatedSettingsConnectedOrganization{
+			OrgID:                    orgID,
+			IdentityProviderID:       spec.IdentityProviderID,
+			DomainAllowList:          spec.DomainAllowList,
+			DomainRestrictionEnabled: toptr.MakePtr(spec.DomainRestrictionEnabled),
+			PostAuthRoleGrants:       spec.PostAuthRoleGrants,
+		},
+		DataAccessIdentityProviderIDs: spec.DataAccessIdentityProviderIDs,
+	}
+}
+
+// connectedOrgInSync compares the settings of the connected organization ignoring the role mappings which are
+// managed separately
+func connectedOrgInSync(current, desired atlas.FederatedConnectedOrg) bool {
+	return current.IdentityProviderID == desired.IdentityProviderID &&
+		isTrue(current.DomainRestrictionEnabled) == isTrue(desired.DomainRestrictionEnabled) &&
+		sameStrings(current.DomainAllowList, desired.DomainAllowList) &&
+		sameStrings(current.PostAuthRoleGrants, desired.PostAuthRoleGrants) &&
+		sameStrings(current.DataAccessIdentityProviderIDs, desired.DataAccessIdentityProviderIDs)
+}
+
+func isTrue(value *bool) bool {
+	return value != nil && *value
+}
+
+func disconnectOrg(ctx *workflow.Context, federationSettingsID, orgID string) error {
+	_, err := ctx.Client.FederatedSettings.DeleteConnectedOrg(context.Background(), federationSettingsID, orgID)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *AtlasFederatedAuthReconciler) ensureRoleMappings(ctx *workflow.Context, federationSettingsID, orgID string, fedAuth *mdbv1.AtlasFederatedAuth) workflow.Result {
+	desired := make([]*mongodbatlas.FederatedSettingsOrganizationRoleMapping, 0, len(fedAuth.Spec.RoleMappings))
+	for _, mapping := range fedAuth.Spec.RoleMappings {
+		atlasMapping, result := r.roleMappingToAtlas(orgID, fedAuth.Namespace, mapping)
+		if !result.IsOk() {
+			return result
+		}
+		desired = append(desired, atlasMapping)
+	}
+
+	current, _, err := ctx.Client.FederatedSettings.ListRoleMappings(context.Background(), federationSettingsID, orgID, nil)
+	if err != nil {
+		return workflow.Terminate(workflow.FederatedAuthRoleMappingsNotReady, err.Error())
+	}
+	currentByGroup := map[string]*mongodbatlas.FederatedSettingsOrganizationRoleMapping{}
+	for _, mapping := range current.Results {
+		currentByGroup[mapping.ExternalGroupName] = mapping
+	}
+
+	desiredGroups := map[string]bool{}
+	mappingsStatus := make([]status.FederatedRoleMappingStatus, 0, len(desired))
+	for _, mapping := range desired {
+		desiredGroups[mapping.ExternalGroupName] = true
+		existing, found := currentByGroup[mapping.ExternalGroupName]
+
+		var result *mongodbatlas.FederatedSettingsOrganizationRoleMapping
+		switch {
+		case !found:
+			ctx.Log.Infow("Creating role mapping", "externalGroupName", mapping.ExternalGroupName)
+			result, _, err = ctx.Client.FederatedSettings.CreateRoleMapping(context.Background(), federationSettingsID, orgID, mapping)
+		case !roleAssignmentsEqual(existing.RoleAssignments, mapping.RoleAssignments):
+			ctx.Log.Infow("Updating role mapping", "externalGroupName", mapping.ExternalGroupName)
+			result, _, err = ctx.Client.FederatedSettings.UpdateRoleMapping(context.Background(), federationSettingsID, orgID, existing.ID, mapping)
+		default:
+			result = existing
+		}
+		if err != nil {
+			return workflow.Terminate(workflow.FederatedAuthRoleMappingsNotReady, err.Error())
+		}
+		mappingsStatus = append(mappingsStatus, status.FederatedRoleMappingStatus{ID: result.ID, ExternalGroupName: mapping.ExternalGroupName})
+	}
+
+	// only the role mappings managed by the operator are removed, the ones created in Atlas are kept
+	for _, managed := range fedAuth.Status.RoleMappings {
+		if desiredGroups[managed.ExternalGroupName] {
+			continue
+		}
+		ctx.Log.Infow("Removing role mapping", "externalGroupName", managed.ExternalGroupName)
+		if _, err = ctx.Client.FederatedSettings.DeleteRoleMapping(context.Background(), federationSettingsID, orgID, managed.ID); err != nil && !isNotFound(err) {
+			return workflow.Terminate(workflow.FederatedAuthRoleMappingsNotReady, err.Error())
+		}
+	}
+
+	ctx.EnsureStatusOption(status.AtlasFederatedAuthRoleMappingsOption(mappingsStatus))
+	return workflow.OK()
+}
+
+// roleMappingToAtlas converts the role mapping resolving the referenced AtlasProjects to their IDs in Atlas
+func (r *AtlasFederatedAuthReconciler) roleMappingToAtlas(orgID, namespace string, mapping mdbv1.FederatedRoleMapping) (*mongodbatlas.FederatedSettingsOrganizationRoleMapping, workflow.Result) {
+	result := &mongodbatlas.FederatedSettingsOrganizationRoleMapping{ExternalGroupName: mapping.ExternalGroupName}
+	for _, assignment := range mapping.RoleAssignments {
+		if assignment.ProjectRef == nil {
+			result.RoleAssignments = append(result.RoleAssignments, &mongodbatlas.RoleAssignments{OrgID: orgID, Role: assignment.Role})
+			continue
+		}
+
+		project := &mdbv1.AtlasProject{}
+		if err := r.Client.Get(context.Background(), *assignment.ProjectRef.GetObject(namespace), project); err != nil {
+			return nil, workflow.Terminate(workflow.FederatedAuthRoleMappingsNotReady, err.Error())
+		}
+		if project.ID() == "" {
+			return nil, workflow.InProgress(workflow.FederatedAuthRoleMappingsNotReady,
+				fmt.Sprintf("project %s referenced by the role mapping of %s is not ready yet", assignment.ProjectRef.Name, mapping.ExternalGroupName))
+		}
+		result.RoleAssignments = append(result.RoleAssignments, &mongodbatlas.RoleAssignments{GroupID: project.ID(), Role: assignment.Role})
+	}
+	return result, workflow.OK()
+}
+
+func roleAssignmentsEqual(current, desired []*mongodbatlas.RoleAssignments) bool {
+	less := func(a, b *mongodbatlas.RoleAssignments) bool {
+		if a.GroupID != b.GroupID {
+			return a.GroupID < b.GroupID
+		}
+		if a.OrgID != b.OrgID {
+			return a.OrgID < b.OrgID
+		}
+		return a.Role < b.Role
+	}
+	return cmp.Equal(current, desired, cmpopts.EquateEmpty(), cmpopts.SortSlices(less))
+}
+
+func sameStrings(current, desired []string) bool {
+	return cmp.Equal(current, desired, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b }))
+}
+
+func isNotFound(err error) bool {
+	var apiError *mongodbatlas.ErrorResponse
+	return errors.As(err, &apiError) && apiError.HTTPCode == http.StatusNotFound
+}
+
+// deleteFederatedAuthFromAtlas removes the role mappings created by the operator and disconnects the organization
+// from the federation
+func deleteFederatedAuthFromAtlas(ctx *workflow.Context, fedAuth *mdbv1.AtlasFederatedAuth) error {
+	federationSettingsID, orgID := fedAuth.Status.FederationSettingsID, fedAuth.Status.OrgID
+	if federationSettingsID == "" || orgID == "" {
+		return nil
+	}
+
+	for _, mapping := range fedAuth.Status.RoleMappings {
+		if _, err := ctx.Client.FederatedSettings.DeleteRoleMapping(context.Background(), federationSettingsID, orgID, mapping.ID); err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+
+	return disconnectOrg(ctx, federationSettingsID, orgID)
+}
@@ -0,0 +1,155 @@
+package atlasfederatedauth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+func TestIdentityProviderInSync(t *testing.T) {
+	current := atlas.FederatedIdentityProvider{
+		FederatedSettingsIdentityProvider: mongodbatlas.FederatedSettingsIdentityProvider{
+			DisplayName:       "Okta",
+			IssuerURI:         "https://example.okta.com",
+			AssociatedDomains: []string{"example.com", "example.org"},
+			SsoDebugEnabled:   toptr.MakePtr(false),
+		},
+		ID:                "idp",
+		Audience:          "atlas",
+		ClientID:          "client",
+		AuthorizationType: "GROUP",
+	}
+
+	t.Run("Fields which are not specified are ignored", func(t *testing.T) {
+		assert.True(t, identityProviderInSync(current, mdbv1.FederatedIdentityProvider{ID: "idp"}))
+	})
+	t.Run("Domains are compared regardless of the order", func(t *testing.T) {
+		spec := mdbv1.FederatedIdentityProvider{ID: "idp", AssociatedDomains: []string{"example.org", "example.com"}}
+		assert.True(t, identityProviderInSync(current, spec))
+	})
+	t.Run("Changed OIDC settings", func(t *testing.T) {
+		spec := mdbv1.FederatedIdentityProvider{ID: "idp", OIDC: &mdbv1.FederatedOIDCSettings{Audience: "atlas", ClientID: "other"}}
+		assert.False(t, identityProviderInSync(current, spec))
+	})
+	t.Run("Changed SSO debug", func(t *testing.T) {
+		spec := mdbv1.FederatedIdentityProvider{ID: "idp", SSODebugEnabled: toptr.MakePtr(true)}
+		assert.False(t, identityProviderInSync(current, spec))
+	})
+}
+
+func TestFindIdentityProvider(t *testing.T) {
+	idps := []atlas.FederatedIdentityProvider{
+		{ID: "oidc"},
+		{FederatedSettingsIdentityProvider: mongodbatlas.FederatedSettingsIdentityProvider{OktaIdpID: "saml"}},
+	}
+	assert.Equal(t, "oidc", findIdentityProvider(idps, "oidc").ID)
+	assert.Equal(t, "saml", findIdentityProvider(idps, "saml").OktaIdpID)
+	assert.Nil(t, findIdentityProvider(idps, "other"))
+}
+
+func TestConnectedOrgInSync(t *testing.T) {
+	spec := mdbv1.NewFederatedAuth("ns", "federation").WithIdentityProvider("idp").Spec
+	spec.DomainAllowList = []string{"example.com"}
+	spec.DataAccessIdentityProviderIDs = []string{"oidc"}
+
+	t.Run("Same settings", func(t *testing.T) {
+		assert.True(t, connectedOrgInSync(*connectedOrgToAtlas("org", spec), *connectedOrgToAtlas("org", spec)))
+	})
+	t.Run("Domain restriction is not set in Atlas", func(t *testing.T) {
+		current := connectedOrgToAtlas("org", spec)
+		current.DomainRestrictionEnabled = nil
+		assert.True(t, connectedOrgInSync(*current, *connectedOrgToAtlas("org", spec)))
+	})
+	t.Run("Changed data access identity providers", func(t *testing.T) {
+		current := connectedOrgToAtlas("org", spec)
+		current.DataAccessIdentityProviderIDs = nil
+		assert.False(t, connectedOrgInSync(*current, *connectedOrgToAtlas("org", spec)))
+	})
+}
+
+func TestRoleAssignmentsEqual(t *testing.T) {
+	current := []*mongodbatlas.RoleAssignments{
+		{OrgID: "org", Role: "ORG_MEMBER"},
+		{GroupID: "project", Role: "GROUP_READ_ONLY"},
+	}
+
+	t.Run("Order is ignored", func(t *testing.T) {
+		desired := []*mongodbatlas.RoleAssignments{
+			{GroupID: "project", Role: "GROUP_READ_ONLY"},
+			{OrgID: "org", Role: "ORG_MEMBER"},
+		}
+		assert.True(t, roleAssignmentsEqual(current, desired))
+	})
+	t.Run("Changed role", func(t *testing.T) {
+		desired := []*mongodbatlas.RoleAssignments{
+			{OrgID: "org", Role: "ORG_MEMBER"},
+			{GroupID: "project", Role: "GROUP_OWNER"},
+		}
+		assert.False(t, roleAssignmentsEqual(current, desired))
+	})
+}
+
+// roleMappingsStub lists the given role mappings and records the created and deleted ones
+type roleMappingsStub struct {
+	mongodbatlas.FederatedSettingsService
+
+	current []*mongodbatlas.FederatedSettingsOrganizationRoleMapping
+	created []string
+	deleted []string
+}
+
+func (s *roleMappingsStub) ListRoleMappings(context.Context, string, string, *mongodbatlas.ListOptions) (*mongodbatlas.FederatedSettingsOrganizationRoleMappings, *mongodbatlas.Response, error) {
+	return &mongodbatlas.FederatedSettingsOrganizationRoleMappings{Results: s.current, TotalCount: len(s.current)}, nil, nil
+}
+
+func (s *roleMappingsStub) CreateRoleMapping(_ context.Context, _, _ string, mapping *mongodbatlas.FederatedSettingsOrganizationRoleMapping) (*mongodbatlas.FederatedSettingsOrganizationRoleMapping, *mongodbatlas.Response, error) {
+	s.created = append(s.created, mapping.ExternalGroupName)
+	created := *mapping
+	created.ID = mapping.ExternalGroupName + "-id"
+	return &created, nil, nil
+}
+
+func (s *roleMappingsStub) DeleteRoleMapping(_ context.Context, _, _, roleMappingID string) (*mongodbatlas.Response, error) {
+	s.deleted = append(s.deleted, roleMappingID)
+	return nil, nil
+}
+
+func TestEnsureRoleMappings(t *testing.T) {
+	orgMember := []*mongodbatlas.RoleAssignments{{OrgID: "org-id", Role: "ORG_MEMBER"}}
+	stub := &roleMappingsStub{current: []*mongodbatlas.FederatedSettingsOrganizationRoleMapping{
+		{ID: "admins-id", ExternalGroupName: "admins", RoleAssignments: orgMember},
+		{ID: "removed-id", ExternalGroupName: "removed", RoleAssignments: orgMember},
+		{ID: "created-in-atlas-id", ExternalGroupName: "created-in-atlas", RoleAssignments: orgMember},
+	}}
+	ctx := workflow.NewContext(zap.S(), []status.Condition{})
+	ctx.Client = mongodbatlas.Client{FederatedSettings: stub}
+	fedAuth := mdbv1.NewFederatedAuth("ns", "federation").
+		WithRoleMapping("admins", mdbv1.FederatedRoleAssignment{Role: "ORG_MEMBER"}).
+		WithRoleMapping("developers", mdbv1.FederatedRoleAssignment{Role: "ORG_MEMBER"})
+	fedAuth.Status.RoleMappings = []status.FederatedRoleMappingStatus{
+		{ID: "admins-id", ExternalGroupName: "admins"},
+		{ID: "removed-id", ExternalGroupName: "removed"},
+	}
+
+	result := (&AtlasFederatedAuthReconciler{}).ensureRoleMappings(ctx, "federation-id", "org-id", fedAuth)
+
+	assert.True(t, result.IsOk())
+	assert.Equal(t, []string{"developers"}, stub.created)
+	assert.Equal(t, []string{"removed-id"}, stub.deleted, "the role mappings created in Atlas are kept")
+	for _, option := range ctx.StatusOptions() {
+		option.(status.AtlasFederatedAuthStatusOption)(&fedAuth.Status)
+	}
+	assert.Equal(t, []status.FederatedRoleMappingStatus{
+		{ID: "admins-id", ExternalGroupName: "admins"},
+		{ID: "developers-id", ExternalGroupName: "developers"},
+	}, fedAuth.Status.RoleMappings)
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2023 MongoDB.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package atlasnetworkcontainer
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/statushandler"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/validate"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/watch"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+)
+
+// AtlasNetworkContainerReconciler reconciles an AtlasNetworkContainer object
+type AtlasNetworkContainerReconciler struct {
+	watch.ResourceWatcher
+	Client           client.Client
+	Log              *zap.SugaredLogger
+	Scheme           *runtime.Scheme
+	AtlasDomain      string
+	GlobalAPISecret  client.ObjectKey
+	GlobalPredicates []predicate.Predicate
+	EventRecorder    record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasnetworkcontainers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasnetworkcontainers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasnetworkcontainers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasnetworkcontainers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",namespace=default,resources=events,verbs=create;patch
+
+func (r *AtlasNetworkContainerReconciler) Reconcile(context context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.With("atlasnetworkcontainer", req.NamespacedName)
+
+	networkContainer := &mdbv1.AtlasNetworkContainer{}
+	result := customresource.PrepareResource(r.Client, req, networkContainer, log)
+	if !result.IsOk() {
+		return result.ReconcileResult(), nil
+	}
+
+	if shouldSkip := customresource.ReconciliationShouldBeSkipped(networkContainer); shouldSkip {
+		log.Infow(fmt.Sprintf("-> Skipping AtlasNetworkContainer reconciliation as annotation %s=%s", customresource.ReconciliationPolicyAnnotation, customresource.ReconciliationPolicySkip), "spec", networkContainer.Spec)
+		if !networkContainer.GetDeletionTimestamp().IsZero() {
+			if err := r.removeDeletionFinalizer(context, networkContainer); err != nil {
+				result = workflow.Terminate(workflow.Internal, err.Error())
+				log.Errorw("failed to remove finalizer", "error", err)
+				return result.ReconcileResult(), nil
+			}
+		}
+		return workflow.OK().ReconcileResult(), nil
+	}
+
+	ctx := customresource.MarkReconciliationStarted(r.Client, networkContainer, log)
+	log.Infow("-> Starting AtlasNetworkContainer reconciliation", "spec", networkContainer.Spec, "status", networkContainer.Status)
+	defer statushandler.Update(ctx, r.Client, r.EventRecorder, networkContainer)
+
+	resourceVersionIsValid := customresource.ValidateResourceVersion(ctx, networkContainer, r.Log)
+	if !resourceVersionIsValid.IsOk() {
+		r.Log.Debugf("network container validation result: %v", resourceVersionIsValid)
+		return resourceVersionIsValid.ReconcileResult(), nil
+	}
+
+	if err := validate.NetworkContainer(networkContainer); err != nil {
+		result := workflow.Terminate(workflow.NetworkContainerInvalidSpec, err.Error())
+		ctx.SetConditionFromResult(status.ValidationSucceeded, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.SetConditionTrue(status.ValidationSucceeded)
+
+	project := &mdbv1.AtlasProject{}
+	if err := r.Client.Get(context, networkContainer.AtlasProjectObjectKey(), project); err != nil {
+		result := workflow.Terminate(workflow.Internal, err.Error())
+		ctx.SetConditionFromResult(status.ReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	if project.ID() == "" {
+		result := workflow.InProgress(workflow.NetworkContainerProjectNotReady, fmt.Sprintf("the project %s is not created in Atlas yet", project.Name))
+		ctx.SetConditionFromResult(status.ReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.EnsureStatusOption(status.AtlasNetworkContainerProjectIDOption(project.ID()))
+
+	connection, err := atlas.ReadConnection(log, r.Client, r.GlobalAPISecret, project.ConnectionSecretObjectKey())
+	if err != nil {
+		result := workflow.Terminate(workflow.AtlasCredentialsNotProvided, err.Error())
+		ctx.SetConditionFromResult(status.ReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.Connection = connection
+
+	atlasClient, err := atlas.Client(r.AtlasDomain, connection, log)
+	if err != nil {
+		result := workflow.Terminate(workflow.Internal, err.Error())
+		ctx.SetConditionFromResult(status.ReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.Client = atlasClient
+
+	if networkContainer.GetDeletionTimestamp().IsZero() {
+		if !customresource.HaveFinalizer(networkContainer, customresource.FinalizerLabel) {
+			customresource.SetFinalizer(networkContainer, customresource.FinalizerLabel)
+			if err = r.Client.Update(context, networkContainer); err != nil {
+				result = workflow.Terminate(workflow.Internal, err.Error())
+				log.Errorw("failed to add finalizer", "error", err)
+				return result.ReconcileResult(), nil
+			}
+		}
+	} else {
+		if !customresource.HaveFinalizer(networkContainer, customresource.FinalizerLabel) {
+			return workflow.OK().ReconcileResult(), nil
+		}
+		if customresource.ResourceShouldBeLeftInAtlas(networkContainer) {
+			log.Infof("Not removing the network container from Atlas as the '%s' annotation is set", customresource.ResourcePolicyAnnotation)
+		} else if result = deleteNetworkContainerFromAtlas(ctx, project.ID(), networkContainer); !result.IsOk() {
+			ctx.SetConditionFromResult(status.ReadyType, result)
+			return result.ReconcileResult(), nil
+		}
+		if err = r.removeDeletionFinalizer(context, networkContainer); err != nil {
+			result = workflow.Terminate(workflow.Internal, err.Error())
+			log.Errorw("failed to remove finalizer", "error", err)
+			return result.ReconcileResult(), nil
+		}
+		return workflow.OK().ReconcileResult(), nil
+	}
+
+	if result = ensureNetworkContainer(ctx, project.ID(), networkContainer); !result.IsOk() {
+		ctx.SetConditionFromResult(status.ReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	ctx.SetConditionTrue(status.ReadyType)
+	return workflow.OK().ReconcileResult(), nil
+}
+
+func (r *AtlasNetworkContainerReconciler) removeDeletionFinalizer(ctx context.Context, networkContainer *mdbv1.AtlasNetworkContainer) error {
+	err := r.Client.Get(ctx, kube.ObjectKeyFromObject(networkContainer), networkContainer)
+	if err != nil {
+		return fmt.Errorf("cannot get AtlasNetworkContainer while removing finalizer: %w", err)
+	}
+
+	customresource.UnsetFinalizer(networkContainer, customresource.FinalizerLabel)
+	if err = r.Client.Update(ctx, networkContainer); err != nil {
+		return fmt.Errorf("failed to remove deletion finalizer from %s: %w", networkContainer.Name, err)
+	}
+	return nil
+}
+
+func (r *AtlasNetworkContainerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c, err := controller.New("AtlasNetworkContainer", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource AtlasNetworkContainer
+	err = c.Watch(&source.Kind{Type: &mdbv1.AtlasNetworkContainer{}}, &handler.EnqueueRequestForObject{}, r.GlobalPredicates...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
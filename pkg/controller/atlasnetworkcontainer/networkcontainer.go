@@ -0,0 +1,114 @@
+package atlasnetworkcontainer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+)
+
+// ensureNetworkContainer creates the container in Atlas, or adopts the existing one with the same provider, region and
+// CIDR block, and updates it if the spec changed.
+func ensureNetworkContainer(ctx *workflow.Context, projectID string, networkContainer *mdbv1.AtlasNetworkContainer) workflow.Result {
+	desired := atlasContainer(networkContainer.Spec)
+	container, err := findNetworkContainer(ctx.Client, projectID, networkContainer.Status.ID, desired)
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+
+	switch {
+	case container == nil:
+		ctx.Log.Infow("Creating the network container in Atlas", "provider", desired.ProviderName, "atlasCidrBlock", desired.AtlasCIDRBlock)
+		container, _, err = ctx.Client.Containers.Create(context.Background(), projectID, desired)
+		if err != nil {
+			return workflow.Terminate(workflow.NetworkContainerNotCreated, err.Error())
+		}
+	case !containerMatches(*container, *desired):
+		ctx.Log.Infow("Updating the network container in Atlas", "id", container.ID, "atlasCidrBlock", desired.AtlasCIDRBlock)
+		container, _, err = ctx.Client.Containers.Update(context.Background(), projectID, container.ID, desired)
+		if err != nil {
+			return workflow.Terminate(workflow.NetworkContainerNotUpdated, err.Error())
+		}
+	}
+
+	ctx.EnsureStatusOption(status.AtlasNetworkContainerOption(*container))
+	return workflow.OK()
+}
+
+// deleteNetworkContainerFromAtlas removes the container from Atlas. Atlas refuses to remove the containers which are
+// still used by the clusters or the peering connections, the deletion is retried until they are gone.
+func deleteNetworkContainerFromAtlas(ctx *workflow.Context, projectID string, networkContainer *mdbv1.AtlasNetworkContainer) workflow.Result {
+	if networkContainer.Status.ID == "" {
+		return workflow.OK()
+	}
+
+	_, err := ctx.Client.Containers.Delete(context.Background(), projectID, networkContainer.Status.ID)
+	switch {
+	case err == nil || hasHTTPCode(err, http.StatusNotFound):
+		ctx.Log.Infow("Removed the network container from Atlas", "id", networkContainer.Status.ID)
+		return workflow.OK()
+	case hasHTTPCode(err, http.StatusConflict):
+		return workflow.InProgress(workflow.NetworkContainerInUse, "the container is still used by clusters or peering connections")
+	default:
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+}
+
+// findNetworkContainer returns the container created for the resource earlier or the existing container matching
+// the desired one. Nil is returned if there is none.
+func findNetworkContainer(client mongodbatlas.Client, projectID, containerID string, desired *mongodbatlas.Container) (*mongodbatlas.Container, error) {
+	if containerID != "" {
+		container, _, err := client.Containers.Get(context.Background(), projectID, containerID)
+		if err == nil {
+			return container, nil
+		}
+		if !hasHTTPCode(err, http.StatusNotFound) {
+			return nil, err
+		}
+	}
+
+	containers, _, err := client.Containers.List(context.Background(), projectID, &mongodbatlas.ContainersListOptions{ProviderName: desired.ProviderName})
+	if err != nil {
+		return nil, err
+	}
+	for i := range containers {
+		if containerMatches(containers[i], *desired) {
+			return &containers[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// atlasContainer converts the spec to the Atlas container. AWS keeps the region in the RegionName field in the
+// US_EAST_1 format, Azure keeps it in the Region field and the GCP containers have no region.
+func atlasContainer(spec mdbv1.AtlasNetworkContainerSpec) *mongodbatlas.Container {
+	container := &mongodbatlas.Container{
+		ProviderName:   string(spec.Provider),
+		AtlasCIDRBlock: spec.AtlasCIDRBlock,
+	}
+	switch spec.Provider {
+	case provider.ProviderAWS:
+		container.RegionName = strings.ToUpper(strings.ReplaceAll(spec.Region, "-", "_"))
+	case provider.ProviderAzure:
+		container.Region = spec.Region
+	}
+	return container
+}
+
+func containerMatches(container, desired mongodbatlas.Container) bool {
+	return container.AtlasCIDRBlock == desired.AtlasCIDRBlock &&
+		container.RegionName == desired.RegionName &&
+		container.Region == desired.Region
+}
+
+func hasHTTPCode(err error, code int) bool {
+	var apiError *mongodbatlas.ErrorResponse
+	return errors.As(err, &apiError) && apiError.HTTPCode == code
+}
@@ -0,0 +1,34 @@
+package atlasnetworkcontainer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
+)
+
+func TestAtlasContainer(t *testing.T) {
+	t.Run("AWS", func(t *testing.T) {
+		spec := mdbv1.NewNetworkContainer("ns", "container", "project", provider.ProviderAWS, "us-east-1", "10.8.0.0/21").Spec
+		assert.Equal(t, &mongodbatlas.Container{ProviderName: "AWS", AtlasCIDRBlock: "10.8.0.0/21", RegionName: "US_EAST_1"}, atlasContainer(spec))
+	})
+	t.Run("Azure", func(t *testing.T) {
+		spec := mdbv1.NewNetworkContainer("ns", "container", "project", provider.ProviderAzure, "US_EAST_2", "10.8.0.0/21").Spec
+		assert.Equal(t, &mongodbatlas.Container{ProviderName: "AZURE", AtlasCIDRBlock: "10.8.0.0/21", Region: "US_EAST_2"}, atlasContainer(spec))
+	})
+	t.Run("GCP", func(t *testing.T) {
+		spec := mdbv1.NewNetworkContainer("ns", "container", "project", provider.ProviderGCP, "", "10.8.0.0/18").Spec
+		assert.Equal(t, &mongodbatlas.Container{ProviderName: "GCP", AtlasCIDRBlock: "10.8.0.0/18"}, atlasContainer(spec))
+	})
+}
+
+func TestContainerMatches(t *testing.T) {
+	desired := mongodbatlas.Container{ProviderName: "AWS", AtlasCIDRBlock: "10.8.0.0/21", RegionName: "US_EAST_1"}
+
+	assert.True(t, containerMatches(mongodbatlas.Container{ID: "id", ProviderName: "AWS", AtlasCIDRBlock: "10.8.0.0/21", RegionName: "US_EAST_1", VPCID: "vpc"}, desired))
+	assert.False(t, containerMatches(mongodbatlas.Container{ID: "id", ProviderName: "AWS", AtlasCIDRBlock: "10.8.0.0/22", RegionName: "US_EAST_1"}, desired))
+	assert.False(t, containerMatches(mongodbatlas.Container{ID: "id", ProviderName: "AWS", AtlasCIDRBlock: "10.8.0.0/21", RegionName: "EU_WEST_1"}, desired))
+}
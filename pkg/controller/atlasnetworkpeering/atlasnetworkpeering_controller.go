@@ -0,0 +1,277 @@
+/*
+Copyright 2023 MongoDB.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package atlasnetworkpeering
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/statushandler"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/validate"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/watch"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+)
+
+// AtlasNetworkPeeringReconciler reconciles an AtlasNetworkPeering object
+type AtlasNetworkPeeringReconciler struct {
+	watch.ResourceWatcher
+	Client           client.Client
+	Log              *zap.SugaredLogger
+	Scheme           *runtime.Scheme
+	AtlasDomain      string
+	GlobalAPISecret  client.ObjectKey
+	GlobalPredicates []predicate.Predicate
+	EventRecorder    record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasnetworkpeerings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasnetworkpeerings/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasnetworkpeerings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasnetworkpeerings/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",namespace=default,resources=events,verbs=create;patch
+
+func (r *AtlasNetworkPeeringReconciler) Reconcile(context context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.With("atlasnetworkpeering", req.NamespacedName)
+
+	peering := &mdbv1.AtlasNetworkPeering{}
+	result := customresource.PrepareResource(r.Client, req, peering, log)
+	if !result.IsOk() {
+		return result.ReconcileResult(), nil
+	}
+
+	if shouldSkip := customresource.ReconciliationShouldBeSkipped(peering); shouldSkip {
+		log.Infow(fmt.Sprintf("-> Skipping AtlasNetworkPeering reconciliation as annotation %s=%s", customresource.ReconciliationPolicyAnnotation, customresource.ReconciliationPolicySkip), "spec", peering.Spec)
+		if !peering.GetDeletionTimestamp().IsZero() {
+			if err := r.removeDeletionFinalizer(context, peering); err != nil {
+				result = workflow.Terminate(workflow.Internal, err.Error())
+				log.Errorw("failed to remove finalizer", "error", err)
+				return result.ReconcileResult(), nil
+			}
+		}
+		return workflow.OK().ReconcileResult(), nil
+	}
+
+	ctx := customresource.MarkReconciliationStarted(r.Client, peering, log)
+	log.Infow("-> Starting AtlasNetworkPeering reconciliation", "spec", peering.Spec, "status", peering.Status)
+	defer statushandler.Update(ctx, r.Client, r.EventRecorder, peering)
+
+	resourceVersionIsValid := customresource.ValidateResourceVersion(ctx, peering, r.Log)
+	if !resourceVersionIsValid.IsOk() {
+		r.Log.Debugf("network peering connection validation result: %v", resourceVersionIsValid)
+		return resourceVersionIsValid.ReconcileResult(), nil
+	}
+
+	if err := validate.NetworkPeering(peering); err != nil {
+		result := workflow.Terminate(workflow.NetworkPeeringInvalidSpec, err.Error())
+		ctx.SetConditionFromResult(status.ValidationSucceeded, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.SetConditionTrue(status.ValidationSucceeded)
+
+	project := &mdbv1.AtlasProject{}
+	if err := r.Client.Get(context, peering.AtlasProjectObjectKey(), project); err != nil {
+		result := workflow.Terminate(workflow.Internal, err.Error())
+		ctx.SetConditionFromResult(status.ReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	if project.ID() == "" {
+		result := workflow.InProgress(workflow.NetworkPeeringProjectNotReady, fmt.Sprintf("the project %s is not created in Atlas yet", project.Name))
+		ctx.SetConditionFromResult(status.ReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.EnsureStatusOption(status.AtlasNetworkPeeringProjectIDOption(project.ID()))
+
+	connection, err := atlas.ReadConnection(log, r.Client, r.GlobalAPISecret, project.ConnectionSecretObjectKey())
+	if err != nil {
+		result := workflow.Terminate(workflow.AtlasCredentialsNotProvided, err.Error())
+		ctx.SetConditionFromResult(status.ReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.Connection = connection
+
+	atlasClient, err := atlas.Client(r.AtlasDomain, connection, log)
+	if err != nil {
+		result := workflow.Terminate(workflow.Internal, err.Error())
+		ctx.SetConditionFromResult(status.ReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.Client = atlasClient
+
+	if peering.GetDeletionTimestamp().IsZero() {
+		if !customresource.HaveFinalizer(peering, customresource.FinalizerLabel) {
+			customresource.SetFinalizer(peering, customresource.FinalizerLabel)
+			if err = r.Client.Update(context, peering); err != nil {
+				result = workflow.Terminate(workflow.Internal, err.Error())
+				log.Errorw("failed to add finalizer", "error", err)
+				return result.ReconcileResult(), nil
+			}
+		}
+	} else {
+		if !customresource.HaveFinalizer(peering, customresource.FinalizerLabel) {
+			return workflow.OK().ReconcileResult(), nil
+		}
+		if customresource.ResourceShouldBeLeftInAtlas(peering) {
+			log.Infof("Not removing the network peering connection from Atlas as the '%s' annotation is set", customresource.ResourcePolicyAnnotation)
+		} else {
+			deleted, err := deleteNetworkPeeringFromAtlas(ctx, project.ID(), peering)
+			if err != nil {
+				log.Errorf("failed to remove the network peering connection from Atlas: %s", err)
+				result = workflow.Terminate(workflow.Internal, err.Error())
+				ctx.SetConditionFromResult(status.ReadyType, result)
+				return result.ReconcileResult(), nil
+			}
+			if !deleted {
+				result = workflow.InProgress(workflow.NetworkPeeringDeleting, "Network Peering is deleting")
+				ctx.SetConditionFromResult(status.ReadyType, result)
+				return result.ReconcileResult(), nil
+			}
+		}
+		if err = r.removeDeletionFinalizer(context, peering); err != nil {
+			result = workflow.Terminate(workflow.Internal, err.Error())
+			log.Errorw("failed to remove finalizer", "error", err)
+			return result.ReconcileResult(), nil
+		}
+		return workflow.OK().ReconcileResult(), nil
+	}
+
+	containerID, result := r.resolveContainerID(context, peering)
+	if !result.IsOk() {
+		ctx.SetConditionFromResult(status.ReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	if result = ensureNetworkPeering(ctx, project.ID(), peering, containerID); !result.IsOk() {
+		ctx.SetConditionFromResult(status.ReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	ctx.SetConditionTrue(status.ReadyType)
+	return workflow.OK().ReconcileResult(), nil
+}
+
+func (r *AtlasNetworkPeeringReconciler) removeDeletionFinalizer(ctx context.Context, peering *mdbv1.AtlasNetworkPeering) error {
+	err := r.Client.Get(ctx, kube.ObjectKeyFromObject(peering), peering)
+	if err != nil {
+		return fmt.Errorf("cannot get AtlasNetworkPeering while removing finalizer: %w", err)
+	}
+
+	customresource.UnsetFinalizer(peering, customresource.FinalizerLabel)
+	if err = r.Client.Update(ctx, peering); err != nil {
+		return fmt.Errorf("failed to remove deletion finalizer from %s: %w", peering.Name, err)
+	}
+	return nil
+}
+
+func (r *AtlasNetworkPeeringReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c, err := controller.New("AtlasNetworkPeering", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource AtlasNetworkPeering
+	err = c.Watch(&source.Kind{Type: &mdbv1.AtlasNetworkPeering{}}, &handler.EnqueueRequestForObject{}, r.GlobalPredicates...)
+	if err != nil {
+		return err
+	}
+
+	// The peering connections referencing the container wait for it to be created in Atlas
+	err = c.Watch(&source.Kind{Type: &mdbv1.AtlasNetworkContainer{}}, handler.EnqueueRequestsFromMapFunc(r.containerPeeringRequests), containerIDChanged())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// resolveContainerID returns the ID of the container the peering connection uses. The referenced
+// AtlasNetworkContainer must belong to the same project and provider and be created in Atlas.
+func (r *AtlasNetworkPeeringReconciler) resolveContainerID(ctx context.Context, peering *mdbv1.AtlasNetworkPeering) (string, workflow.Result) {
+	containerKey := peering.ContainerObjectKey()
+	if containerKey == nil {
+		return peering.Spec.ContainerID, workflow.OK()
+	}
+
+	container := &mdbv1.AtlasNetworkContainer{}
+	if err := r.Client.Get(ctx, *containerKey, container); err != nil {
+		return "", workflow.Terminate(workflow.Internal, err.Error())
+	}
+	if container.AtlasProjectObjectKey() != peering.AtlasProjectObjectKey() || container.Spec.Provider != peering.Spec.Provider {
+		return "", workflow.Terminate(workflow.NetworkPeeringInvalidSpec,
+			fmt.Sprintf("the container %s doesn't belong to the project %s or to the %s provider", containerKey, peering.AtlasProjectObjectKey(), peering.Spec.Provider))
+	}
+	if container.Status.ID == "" {
+		return "", workflow.InProgress(workflow.NetworkPeeringContainerNotReady, fmt.Sprintf("the container %s is not created in Atlas yet", containerKey))
+	}
+	return container.Status.ID, workflow.OK()
+}
+
+// containerIDChanged passes the updates of the AtlasNetworkContainer changing the ID of the container in Atlas
+func containerIDChanged() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldContainer, okOld := e.ObjectOld.(*mdbv1.AtlasNetworkContainer)
+			newContainer, okNew := e.ObjectNew.(*mdbv1.AtlasNetworkContainer)
+			return okOld && okNew && oldContainer.Status.ID != newContainer.Status.ID
+		},
+		DeleteFunc: func(event.DeleteEvent) bool {
+			return false
+		},
+	}
+}
+
+// containerPeeringRequests returns the requests for the peering connections referencing the AtlasNetworkContainer.
+func (r *AtlasNetworkPeeringReconciler) containerPeeringRequests(obj client.Object) []reconcile.Request {
+	container, ok := obj.(*mdbv1.AtlasNetworkContainer)
+	if !ok {
+		return nil
+	}
+
+	list := &mdbv1.AtlasNetworkPeeringList{}
+	if err := r.Client.List(context.Background(), list); err != nil {
+		r.Log.Errorf("failed to list AtlasNetworkPeering resources: %s", err)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range list.Items {
+		if key := list.Items[i].ContainerObjectKey(); key != nil && *key == kube.ObjectKeyFromObject(container) {
+			requests = append(requests, reconcile.Request{NamespacedName: kube.ObjectKeyFromObject(&list.Items[i])})
+		}
+	}
+	return requests
+}
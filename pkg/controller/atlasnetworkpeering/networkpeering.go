@@ -0,0 +1,184 @@
+package atlasnetworkpeering
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+)
+
+const (
+	statusAvailable         = "AVAILABLE"
+	statusFailed            = "FAILED"
+	statusDeleting          = "DELETING"
+	statusTerminating       = "TERMINATING"
+	statusPendingAcceptance = "PENDING_ACCEPTANCE"
+	statusWaitingForUser    = "WAITING_FOR_USER"
+)
+
+// ensureNetworkPeering creates the peering connection in Atlas, or adopts the existing one for the same network, and
+// updates it if the spec changed. The provider side details of the connection are published in the status.
+func ensureNetworkPeering(ctx *workflow.Context, projectID string, peering *mdbv1.AtlasNetworkPeering, containerID string) workflow.Result {
+	desired := peering.NetworkPeer(containerID)
+	peer, err := findNetworkPeer(ctx.Client, projectID, peering.Status.ID, desired)
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+
+	peerToSync, err := desired.ToAtlas()
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+	switch {
+	case peer == nil:
+		ctx.Log.Infow("Creating the network peering connection in Atlas", "provider", desired.ProviderName, "containerId", containerID)
+		peer, _, err = ctx.Client.Peers.Create(context.Background(), projectID, peerToSync)
+		if err != nil {
+			return workflow.Terminate(workflow.NetworkPeeringNotCreated, err.Error())
+		}
+	case !peerMatches(*peer, desired):
+		ctx.Log.Infow("Updating the network peering connection in Atlas", "id", peer.ID)
+		peer, _, err = ctx.Client.Peers.Update(context.Background(), projectID, peer.ID, peerToSync)
+		if err != nil {
+			return workflow.Terminate(workflow.NetworkPeeringNotUpdated, err.Error())
+		}
+	}
+
+	container := mongodbatlas.Container{ID: containerID}
+	if desired.ProviderName != provider.ProviderAWS {
+		atlasContainer, _, err := ctx.Client.Containers.Get(context.Background(), projectID, containerID)
+		if err != nil {
+			return workflow.Terminate(workflow.Internal, fmt.Sprintf("failed to get the container %s: %s", containerID, err))
+		}
+		container = *atlasContainer
+	}
+
+	peerStatus := status.NewNetworkPeerStatus(*peer, desired.ProviderName, vpc(desired), container)
+	ctx.EnsureStatusOption(status.AtlasNetworkPeeringOption(peerStatus))
+	return peeringResult(peerStatus)
+}
+
+// peeringResult reports the state of the peering connection. The connections awaiting the action on the provider side
+// are reported with the details needed to complete them.
+func peeringResult(peer status.AtlasNetworkPeer) workflow.Result {
+	switch peer.GetStatus() {
+	case statusAvailable:
+		return workflow.OK()
+	case statusFailed:
+		return workflow.Terminate(workflow.NetworkPeeringFailed, peeringError(peer))
+	case statusPendingAcceptance:
+		return workflow.InProgress(workflow.NetworkPeeringPendingAcceptance,
+			fmt.Sprintf("the peering connection %s must be accepted in AWS", peer.ConnectionID))
+	case statusWaitingForUser:
+		return workflow.InProgress(workflow.NetworkPeeringPendingAcceptance,
+			fmt.Sprintf("the peering to the network %s of the GCP project %s must be created in GCP", peer.AtlasNetworkName, peer.AtlasGCPProjectID))
+	default:
+		return workflow.InProgress(workflow.NetworkPeeringNotReady, fmt.Sprintf("the peering connection is %s", peer.GetStatus()))
+	}
+}
+
+func peeringError(peer status.AtlasNetworkPeer) string {
+	for _, message := range []string{peer.ErrorMessage, peer.ErrorStateName, peer.ErrorState} {
+		if message != "" {
+			return message
+		}
+	}
+	return "the peering connection failed"
+}
+
+// deleteNetworkPeeringFromAtlas removes the peering connection from Atlas. Atlas removes the connections
+// asynchronously, so the deletion takes several reconciliations and returns true only once the connection is gone.
+func deleteNetworkPeeringFromAtlas(ctx *workflow.Context, projectID string, peering *mdbv1.AtlasNetworkPeering) (bool, error) {
+	if peering.Status.ID == "" {
+		return true, nil
+	}
+
+	peer, _, err := ctx.Client.Peers.Get(context.Background(), projectID, peering.Status.ID)
+	if err != nil {
+		if isNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	if isDeleting(*peer) {
+		return false, nil
+	}
+
+	if _, err = ctx.Client.Peers.Delete(context.Background(), projectID, peer.ID); err != nil && !isNotFound(err) {
+		return false, fmt.Errorf("failed to delete the peering connection: %w", err)
+	}
+	ctx.Log.Infow("Removing the network peering connection from Atlas", "id", peer.ID)
+	return false, nil
+}
+
+// findNetworkPeer returns the peering connection created for the resource earlier or the existing connection to the
+// same network. Nil is returned if there is none.
+func findNetworkPeer(client mongodbatlas.Client, projectID, peerID string, desired mdbv1.NetworkPeer) (*mongodbatlas.Peer, error) {
+	if peerID != "" {
+		peer, _, err := client.Peers.Get(context.Background(), projectID, peerID)
+		if err == nil && !isDeleting(*peer) {
+			return peer, nil
+		}
+		if err != nil && !isNotFound(err) {
+			return nil, err
+		}
+	}
+
+	peers, _, err := client.Peers.List(context.Background(), projectID, &mongodbatlas.ContainersListOptions{ProviderName: string(desired.ProviderName)})
+	if err != nil {
+		return nil, err
+	}
+	for i := range peers {
+		if !isDeleting(peers[i]) && peerMatches(peers[i], desired) {
+			return &peers[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func peerMatches(peer mongodbatlas.Peer, desired mdbv1.NetworkPeer) bool {
+	if peer.ContainerID != desired.ContainerID {
+		return false
+	}
+	switch desired.ProviderName {
+	case provider.ProviderGCP:
+		return peer.GCPProjectID == desired.GCPProjectID &&
+			peer.NetworkName == desired.NetworkName
+	case provider.ProviderAzure:
+		return peer.AzureDirectoryID == desired.AzureDirectoryID &&
+			peer.AzureSubscriptionID == desired.AzureSubscriptionID &&
+			peer.ResourceGroupName == desired.ResourceGroupName &&
+			peer.VNetName == desired.VNetName
+	default:
+		return peer.VpcID == desired.VpcID &&
+			peer.AWSAccountID == desired.AWSAccountID &&
+			peer.RouteTableCIDRBlock == desired.RouteTableCIDRBlock
+	}
+}
+
+func vpc(peer mdbv1.NetworkPeer) string {
+	switch peer.ProviderName {
+	case provider.ProviderGCP:
+		return peer.NetworkName
+	case provider.ProviderAzure:
+		return peer.VNetName
+	default:
+		return peer.VpcID
+	}
+}
+
+func isDeleting(peer mongodbatlas.Peer) bool {
+	return peer.Status == statusDeleting || peer.StatusName == statusDeleting || peer.StatusName == statusTerminating
+}
+
+func isNotFound(err error) bool {
+	var apiError *mongodbatlas.ErrorResponse
+	return errors.As(err, &apiError) && apiError.HTTPCode == http.StatusNotFound
+}
@@ -0,0 +1,100 @@
+package atlasnetworkpeering
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+)
+
+func TestPeeringResult(t *testing.T) {
+	assert.True(t, peeringResult(status.AtlasNetworkPeer{StatusName: "AVAILABLE"}).IsOk())
+	assert.True(t, peeringResult(status.AtlasNetworkPeer{Status: "AVAILABLE"}).IsOk())
+
+	assert.Equal(t,
+		workflow.InProgress(workflow.NetworkPeeringPendingAcceptance, "the peering connection pcx-1 must be accepted in AWS"),
+		peeringResult(status.AtlasNetworkPeer{StatusName: "PENDING_ACCEPTANCE", ConnectionID: "pcx-1"}))
+	assert.Equal(t,
+		workflow.InProgress(workflow.NetworkPeeringPendingAcceptance, "the peering to the network nt-1 of the GCP project p-1 must be created in GCP"),
+		peeringResult(status.AtlasNetworkPeer{Status: "WAITING_FOR_USER", AtlasNetworkName: "nt-1", AtlasGCPProjectID: "p-1"}))
+	assert.Equal(t,
+		workflow.Terminate(workflow.NetworkPeeringFailed, "REJECTED"),
+		peeringResult(status.AtlasNetworkPeer{StatusName: "FAILED", ErrorStateName: "REJECTED"}))
+	assert.Equal(t,
+		workflow.InProgress(workflow.NetworkPeeringNotReady, "the peering connection is ADDING_PEER"),
+		peeringResult(status.AtlasNetworkPeer{Status: "ADDING_PEER"}))
+}
+
+func TestPeerMatches(t *testing.T) {
+	peering := mdbv1.NewNetworkPeering("ns", "peering", "project", provider.ProviderAWS).
+		WithAWSVpc("123456789012", "us-east-1", "vpc-1", "10.0.0.0/16")
+	peer := mongodbatlas.Peer{ContainerID: "container", AWSAccountID: "123456789012", VpcID: "vpc-1", RouteTableCIDRBlock: "10.0.0.0/16"}
+
+	assert.True(t, peerMatches(peer, peering.NetworkPeer("container")))
+	assert.False(t, peerMatches(peer, peering.NetworkPeer("other-container")))
+
+	peer.RouteTableCIDRBlock = "10.1.0.0/16"
+	assert.False(t, peerMatches(peer, peering.NetworkPeer("container")))
+
+	gcpPeering := mdbv1.NewNetworkPeering("ns", "peering", "project", provider.ProviderGCP).WithGCPNetwork("gcp-project", "network")
+	assert.True(t, peerMatches(mongodbatlas.Peer{ContainerID: "container", GCPProjectID: "gcp-project", NetworkName: "network"}, gcpPeering.NetworkPeer("container")))
+}
+
+func TestResolveContainerID(t *testing.T) {
+	created := mdbv1.NewNetworkContainer("ns", "created", "project", provider.ProviderAWS, "us-east-1", "10.8.0.0/21")
+	created.Status.ID = "container-id"
+	pending := mdbv1.NewNetworkContainer("ns", "pending", "project", provider.ProviderAWS, "us-east-1", "10.9.0.0/21")
+	otherProject := mdbv1.NewNetworkContainer("ns", "other-project", "other", provider.ProviderAWS, "us-east-1", "10.10.0.0/21")
+	otherProject.Status.ID = "other-id"
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(mdbv1.AddToScheme(scheme))
+	r := &AtlasNetworkPeeringReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(created, pending, otherProject).Build(),
+	}
+	newPeering := func() *mdbv1.AtlasNetworkPeering {
+		return mdbv1.NewNetworkPeering("ns", "peering", "project", provider.ProviderAWS)
+	}
+
+	containerID, result := r.resolveContainerID(context.Background(), newPeering().WithContainerID("unmanaged-id"))
+	assert.True(t, result.IsOk())
+	assert.Equal(t, "unmanaged-id", containerID)
+
+	containerID, result = r.resolveContainerID(context.Background(), newPeering().WithContainerRef("created"))
+	assert.True(t, result.IsOk())
+	assert.Equal(t, "container-id", containerID)
+
+	_, result = r.resolveContainerID(context.Background(), newPeering().WithContainerRef("pending"))
+	assert.Equal(t, workflow.InProgress(workflow.NetworkPeeringContainerNotReady, "the container ns/pending is not created in Atlas yet"), result)
+
+	_, result = r.resolveContainerID(context.Background(), newPeering().WithContainerRef("other-project"))
+	assert.False(t, result.IsOk())
+	assert.True(t, result.IsWarning())
+}
+
+func TestContainerPeeringRequests(t *testing.T) {
+	container := mdbv1.NewNetworkContainer("ns", "container", "project", provider.ProviderAWS, "us-east-1", "10.8.0.0/21")
+	referencing := mdbv1.NewNetworkPeering("ns", "referencing", "project", provider.ProviderAWS).WithContainerRef("container")
+	byID := mdbv1.NewNetworkPeering("ns", "by-id", "project", provider.ProviderAWS).WithContainerID("container-id")
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(mdbv1.AddToScheme(scheme))
+	r := &AtlasNetworkPeeringReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(container, referencing, byID).Build(),
+	}
+
+	assert.Equal(t,
+		[]reconcile.Request{{NamespacedName: kube.ObjectKey("ns", "referencing")}},
+		r.containerPeeringRequests(container))
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2023 MongoDB.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package atlasprivateendpoint
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/statushandler"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/validate"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/watch"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+)
+
+// AtlasPrivateEndpointReconciler reconciles an AtlasPrivateEndpoint object
+type AtlasPrivateEndpointReconciler struct {
+	watch.ResourceWatcher
+	Client           client.Client
+	Log              *zap.SugaredLogger
+	Scheme           *runtime.Scheme
+	AtlasDomain      string
+	GlobalAPISecret  client.ObjectKey
+	GlobalPredicates []predicate.Predicate
+	EventRecorder    record.EventRecorder
+	// Provisioner creates the cloud side of the private endpoints which are not specified in the resources
+	Provisioner CloudProvisioner
+}
+
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasprivateendpoints,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasprivateendpoints/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasprivateendpoints,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasprivateendpoints/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",namespace=default,resources=events,verbs=create;patch
+
+func (r *AtlasPrivateEndpointReconciler) Reconcile(context context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.With("atlasprivateendpoint", req.NamespacedName)
+
+	privateEndpoint := &mdbv1.AtlasPrivateEndpoint{}
+	result := customresource.PrepareResource(r.Client, req, privateEndpoint, log)
+	if !result.IsOk() {
+		return result.ReconcileResult(), nil
+	}
+
+	if shouldSkip := customresource.ReconciliationShouldBeSkipped(privateEndpoint); shouldSkip {
+		log.Infow(fmt.Sprintf("-> Skipping AtlasPrivateEndpoint reconciliation as annotation %s=%s", customresource.ReconciliationPolicyAnnotation, customresource.ReconciliationPolicySkip), "spec", privateEndpoint.Spec)
+		if !privateEndpoint.GetDeletionTimestamp().IsZero() {
+			if err := r.removeDeletionFinalizer(context, privateEndpoint); err != nil {
+				result = workflow.Terminate(workflow.Internal, err.Error())
+				log.Errorw("failed to remove finalizer", "error", err)
+				return result.ReconcileResult(), nil
+			}
+		}
+		return workflow.OK().ReconcileResult(), nil
+	}
+
+	ctx := customresource.MarkReconciliationStarted(r.Client, privateEndpoint, log)
+	log.Infow("-> Starting AtlasPrivateEndpoint reconciliation", "spec", privateEndpoint.Spec, "status", privateEndpoint.Status)
+	defer statushandler.Update(ctx, r.Client, r.EventRecorder, privateEndpoint)
+
+	resourceVersionIsValid := customresource.ValidateResourceVersion(ctx, privateEndpoint, r.Log)
+	if !resourceVersionIsValid.IsOk() {
+		r.Log.Debugf("private endpoint validation result: %v", resourceVersionIsValid)
+		return resourceVersionIsValid.ReconcileResult(), nil
+	}
+
+	if err := validate.PrivateEndpoint(privateEndpoint); err != nil {
+		result := workflow.Terminate(workflow.PrivateEndpointInvalidSpec, err.Error())
+		ctx.SetConditionFromResult(status.ValidationSucceeded, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.SetConditionTrue(status.ValidationSucceeded)
+
+	project := &mdbv1.AtlasProject{}
+	if err := r.Client.Get(context, privateEndpoint.AtlasProjectObjectKey(), project); err != nil {
+		result := workflow.Terminate(workflow.Internal, err.Error())
+		ctx.SetConditionFromResult(status.ReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	if project.ID() == "" {
+		result := workflow.InProgress(workflow.PrivateEndpointProjectNotReady, fmt.Sprintf("the project %s is not created in Atlas yet", project.Name))
+		ctx.SetConditionFromResult(status.ReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.EnsureStatusOption(status.AtlasPrivateEndpointProjectIDOption(project.ID()))
+
+	connection, err := atlas.ReadConnection(log, r.Client, r.GlobalAPISecret, project.ConnectionSecretObjectKey())
+	if err != nil {
+		result := workflow.Terminate(workflow.AtlasCredentialsNotProvided, err.Error())
+		ctx.SetConditionFromResult(status.ReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.Connection = connection
+
+	atlasClient, err := atlas.Client(r.AtlasDomain, connection, log)
+	if err != nil {
+		result := workflow.Terminate(workflow.Internal, err.Error())
+		ctx.SetConditionFromResult(status.ReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.Client = atlasClient
+
+	if privateEndpoint.GetDeletionTimestamp().IsZero() {
+		if !customresource.HaveFinalizer(privateEndpoint, customresource.FinalizerLabel) {
+			customresource.SetFinalizer(privateEndpoint, customresource.FinalizerLabel)
+			if err = r.Client.Update(context, privateEndpoint); err != nil {
+				result = workflow.Terminate(workflow.Internal, err.Error())
+				log.Errorw("failed to add finalizer", "error", err)
+				return result.ReconcileResult(), nil
+			}
+		}
+	} else {
+		if !customresource.HaveFinalizer(privateEndpoint, customresource.FinalizerLabel) {
+			return workflow.OK().ReconcileResult(), nil
+		}
+		if customresource.ResourceShouldBeLeftInAtlas(privateEndpoint) {
+			log.Infof("Not removing the private endpoint from Atlas as the '%s' annotation is set", customresource.ResourcePolicyAnnotation)
+		} else {
+			deleted, err := r.deletePrivateEndpointFromAtlas(ctx, project.ID(), privateEndpoint)
+			if err != nil {
+				log.Errorf("failed to remove the private endpoint from Atlas: %s", err)
+				result = workflow.Terminate(workflow.Internal, err.Error())
+				ctx.SetConditionFromResult(status.ReadyType, result)
+				return result.ReconcileResult(), nil
+			}
+			if !deleted {
+				result = workflow.InProgress(workflow.PrivateEndpointDeleting, "Private Endpoint is deleting")
+				ctx.SetConditionFromResult(status.ReadyType, result)
+				return result.ReconcileResult(), nil
+			}
+		}
+		if err = r.removeDeletionFinalizer(context, privateEndpoint); err != nil {
+			result = workflow.Terminate(workflow.Internal, err.Error())
+			log.Errorw("failed to remove finalizer", "error", err)
+			return result.ReconcileResult(), nil
+		}
+		return workflow.OK().ReconcileResult(), nil
+	}
+
+	for _, specPE := range project.Spec.PrivateEndpoints {
+		if specPE.Identifier() == privateEndpoint.Identifier() {
+			result := workflow.Terminate(workflow.PrivateEndpointConflict,
+				fmt.Sprintf("the %s private endpoint for the region %s is already managed by the project %s", privateEndpoint.Spec.Provider, privateEndpoint.Spec.Region, project.Name))
+			ctx.SetConditionFromResult(status.ReadyType, result)
+			return result.ReconcileResult(), nil
+		}
+	}
+
+	if result = r.ensurePrivateEndpoint(ctx, project.ID(), privateEndpoint); !result.IsOk() {
+		ctx.SetConditionFromResult(status.ReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	ctx.SetConditionTrue(status.ReadyType)
+	return workflow.OK().ReconcileResult(), nil
+}
+
+func (r *AtlasPrivateEndpointReconciler) removeDeletionFinalizer(ctx context.Context, privateEndpoint *mdbv1.AtlasPrivateEndpoint) error {
+	err := r.Client.Get(ctx, kube.ObjectKeyFromObject(privateEndpoint), privateEndpoint)
+	if err != nil {
+		return fmt.Errorf("cannot get AtlasPrivateEndpoint while removing finalizer: %w", err)
+	}
+
+	customresource.UnsetFinalizer(privateEndpoint, customresource.FinalizerLabel)
+	if err = r.Client.Update(ctx, privateEndpoint); err != nil {
+		return fmt.Errorf("failed to remove deletion finalizer from %s: %w", privateEndpoint.Name, err)
+	}
+	return nil
+}
+
+func (r *AtlasPrivateEndpointReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c, err := controller.New("AtlasPrivateEndpoint", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource AtlasPrivateEndpoint
+	err = c.Watch(&source.Kind{Type: &mdbv1.AtlasPrivateEndpoint{}}, &handler.EnqueueRequestForObject{}, r.GlobalPredicates...)
+	if err != nil {
+		return err
+	}
+
+	// The external tools write the interface endpoint back to the annotations which don't bump the generation
+	err = c.Watch(&source.Kind{Type: &mdbv1.AtlasPrivateEndpoint{}}, &handler.EnqueueRequestForObject{}, watch.AnnotationChanged(mdbv1.PrivateEndpointIDAnnotation))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
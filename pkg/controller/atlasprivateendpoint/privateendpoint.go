@@ -0,0 +1,268 @@
+package atlasprivateendpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+	"golang.org/x/exp/slices"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+)
+
+// ensurePrivateEndpoint creates the Atlas private endpoint service, publishes its details in the status and, once the
+// service is available, connects the interface endpoint created in the cloud provider to it.
+func (r *AtlasPrivateEndpointReconciler) ensurePrivateEndpoint(ctx *workflow.Context, projectID string, privateEndpoint *mdbv1.AtlasPrivateEndpoint) workflow.Result {
+	service, err := findPrivateEndpointService(ctx.Client, projectID, privateEndpoint)
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+	if service == nil {
+		ctx.Log.Infow("Creating the private endpoint service in Atlas", "provider", privateEndpoint.Spec.Provider, "region", privateEndpoint.Spec.Region)
+		service, _, err = ctx.Client.PrivateEndpoints.Create(context.Background(), projectID, &mongodbatlas.PrivateEndpointConnection{
+			ProviderName: string(privateEndpoint.Spec.Provider),
+			Region:       privateEndpoint.Spec.Region,
+		})
+		if err != nil {
+			result := workflow.Terminate(workflow.PrivateEndpointServiceNotCreated, err.Error())
+			ctx.SetConditionFromResult(status.PrivateEndpointServiceReadyType, result)
+			return result
+		}
+	}
+	ctx.EnsureStatusOption(status.AtlasPrivateEndpointServiceOption(serviceStatus(privateEndpoint.Spec.Provider, service)))
+
+	switch {
+	case isFailed(service.Status):
+		result := workflow.Terminate(workflow.PrivateEndpointServiceFailed, service.ErrorMessage)
+		ctx.SetConditionFromResult(status.PrivateEndpointServiceReadyType, result)
+		return result
+	case !isAvailable(service.Status):
+		result := workflow.InProgress(workflow.PrivateEndpointServiceNotReady, "Private Endpoint Service is not ready")
+		ctx.SetConditionFromResult(status.PrivateEndpointServiceReadyType, result)
+		return result
+	}
+	ctx.SetConditionTrue(status.PrivateEndpointServiceReadyType)
+
+	endpoint, result := r.resolveInterface(privateEndpoint, service)
+	if !result.IsOk() {
+		ctx.SetConditionFromResult(status.PrivateEndpointReadyType, result)
+		return result
+	}
+
+	result = ensureInterface(ctx, projectID, privateEndpoint.Spec.Provider, service, endpoint)
+	if !result.IsOk() {
+		ctx.SetConditionFromResult(status.PrivateEndpointReadyType, result)
+		return result
+	}
+	ctx.SetConditionTrue(status.PrivateEndpointReadyType)
+	return workflow.OK()
+}
+
+// resolveInterface returns the interface endpoint of the cloud provider. The endpoint specified in the resource takes
+// precedence over the one written back to the annotations, the cloud provisioner is used if there is none.
+func (r *AtlasPrivateEndpointReconciler) resolveInterface(privateEndpoint *mdbv1.AtlasPrivateEndpoint, service *mongodbatlas.PrivateEndpointConnection) (*mdbv1.PrivateEndpointInterface, workflow.Result) {
+	if privateEndpoint.Spec.Interface != nil {
+		return privateEndpoint.Spec.Interface, workflow.OK()
+	}
+	if endpoint := privateEndpoint.InterfaceFromAnnotations(); endpoint != nil {
+		return endpoint, workflow.OK()
+	}
+
+	endpoint, err := r.Provisioner.Provision(context.Background(), privateEndpoint, service)
+	if err != nil {
+		return nil, workflow.Terminate(workflow.PrivateEndpointProvisioningFailed, err.Error())
+	}
+	if endpoint == nil {
+		return nil, workflow.InProgress(workflow.PrivateEndpointInterfaceAwaited,
+			fmt.Sprintf("Waiting for the interface endpoint to be created in %s and specified in the resource or in the %s annotation", privateEndpoint.Spec.Provider, mdbv1.PrivateEndpointIDAnnotation))
+	}
+	return endpoint, workflow.OK()
+}
+
+func ensureInterface(ctx *workflow.Context, projectID string, providerName provider.ProviderName, service *mongodbatlas.PrivateEndpointConnection, endpoint *mdbv1.PrivateEndpointInterface) workflow.Result {
+	interfaceID := interfaceEndpointID(providerName, endpoint)
+	if interfaceID == "" {
+		return workflow.Terminate(workflow.PrivateEndpointInterfaceNotCreated, "the interface endpoint must have either id or endpointGroupName")
+	}
+
+	if !slices.Contains(interfaceEndpointIDs(service), interfaceID) {
+		connection := &mongodbatlas.InterfaceEndpointConnection{
+			ID:                       endpoint.ID,
+			PrivateEndpointIPAddress: endpoint.IP,
+			EndpointGroupName:        endpoint.EndpointGroupName,
+			GCPProjectID:             endpoint.GCPProjectID,
+		}
+		if gcpEndpoints, err := endpoint.Endpoints.ConvertToAtlas(); err == nil {
+			connection.Endpoints = gcpEndpoints
+		}
+		if _, _, err := ctx.Client.PrivateEndpoints.AddOnePrivateEndpoint(context.Background(), projectID, string(providerName), service.ID, connection); err != nil {
+			return workflow.Terminate(workflow.PrivateEndpointInterfaceNotCreated, err.Error())
+		}
+	}
+
+	connection, _, err := ctx.Client.PrivateEndpoints.GetOnePrivateEndpoint(context.Background(), projectID, string(providerName), service.ID, interfaceID)
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+	ctx.EnsureStatusOption(status.AtlasPrivateEndpointInterfaceOption(interfaceID, interfaceStatus(connection), gcpEndpointsStatus(connection)))
+
+	available, failureMessage := interfaceIsAvailable(connection)
+	if failureMessage != "" {
+		return workflow.Terminate(workflow.PrivateEndpointInterfaceFailed, failureMessage)
+	}
+	if !available {
+		return workflow.InProgress(workflow.PrivateEndpointInterfaceNotReady, "Interface Private Endpoint is not ready")
+	}
+	return workflow.OK()
+}
+
+// deletePrivateEndpointFromAtlas removes the cloud side endpoint, the interface endpoint and the private endpoint
+// service. Atlas removes the interface endpoints asynchronously, so the deletion takes several reconciliations and
+// returns true only once the service is removed.
+func (r *AtlasPrivateEndpointReconciler) deletePrivateEndpointFromAtlas(ctx *workflow.Context, projectID string, privateEndpoint *mdbv1.AtlasPrivateEndpoint) (bool, error) {
+	if err := r.Provisioner.Deprovision(context.Background(), privateEndpoint); err != nil {
+		return false, fmt.Errorf("failed to deprovision the interface endpoint: %w", err)
+	}
+
+	service, err := findPrivateEndpointService(ctx.Client, projectID, privateEndpoint)
+	if err != nil {
+		return false, err
+	}
+	if service == nil {
+		return true, nil
+	}
+	if isDeleting(service.Status) {
+		return false, nil
+	}
+
+	providerName := string(privateEndpoint.Spec.Provider)
+	if interfaceIDs := interfaceEndpointIDs(service); len(interfaceIDs) != 0 {
+		for _, interfaceID := range interfaceIDs {
+			if _, err = ctx.Client.PrivateEndpoints.DeleteOnePrivateEndpoint(context.Background(), projectID, providerName, service.ID, interfaceID); err != nil && !isNotFound(err) {
+				return false, fmt.Errorf("failed to delete the interface endpoint %s: %w", interfaceID, err)
+			}
+		}
+		return false, nil
+	}
+
+	if _, err = ctx.Client.PrivateEndpoints.Delete(context.Background(), projectID, providerName, service.ID); err != nil && !isNotFound(err) {
+		return false, fmt.Errorf("failed to delete the private endpoint service: %w", err)
+	}
+	ctx.Log.Infow("Removed the private endpoint service from Atlas", "provider", providerName, "region", privateEndpoint.Spec.Region)
+	return false, nil
+}
+
+// findPrivateEndpointService returns the Atlas private endpoint service for the provider and region of the resource
+// or nil if it doesn't exist.
+func findPrivateEndpointService(client mongodbatlas.Client, projectID string, privateEndpoint *mdbv1.AtlasPrivateEndpoint) (*mongodbatlas.PrivateEndpointConnection, error) {
+	providerName := string(privateEndpoint.Spec.Provider)
+	services, _, err := client.PrivateEndpoints.List(context.Background(), projectID, providerName, &mongodbatlas.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range services {
+		service := &services[i]
+		if providerName+status.TransformRegionToID(service.RegionName) == privateEndpoint.Identifier() {
+			return service, nil
+		}
+	}
+	return nil, nil
+}
+
+func serviceStatus(providerName provider.ProviderName, service *mongodbatlas.PrivateEndpointConnection) status.PrivateEndpointService {
+	result := status.PrivateEndpointService{
+		ServiceID:     service.ID,
+		ServiceStatus: service.Status,
+	}
+	switch providerName {
+	case provider.ProviderAWS:
+		result.ServiceName = service.EndpointServiceName
+		result.ServiceResourceID = service.ID
+	case provider.ProviderAzure:
+		result.ServiceName = service.PrivateLinkServiceName
+		result.ServiceResourceID = service.PrivateLinkServiceResourceID
+	case provider.ProviderGCP:
+		result.ServiceAttachmentNames = service.ServiceAttachmentNames
+	}
+	return result
+}
+
+func interfaceEndpointID(providerName provider.ProviderName, endpoint *mdbv1.PrivateEndpointInterface) string {
+	if providerName == provider.ProviderGCP {
+		return endpoint.EndpointGroupName
+	}
+	return endpoint.ID
+}
+
+func interfaceEndpointIDs(service *mongodbatlas.PrivateEndpointConnection) []string {
+	switch {
+	case len(service.InterfaceEndpoints) != 0:
+		return service.InterfaceEndpoints
+	case len(service.PrivateEndpoints) != 0:
+		return service.PrivateEndpoints
+	default:
+		return service.EndpointGroupNames
+	}
+}
+
+func interfaceStatus(connection *mongodbatlas.InterfaceEndpointConnection) string {
+	if connection.AWSConnectionStatus != "" {
+		return connection.AWSConnectionStatus
+	}
+	return connection.Status
+}
+
+func gcpEndpointsStatus(connection *mongodbatlas.InterfaceEndpointConnection) []status.GCPEndpoint {
+	if len(connection.Endpoints) == 0 {
+		return nil
+	}
+	result := make([]status.GCPEndpoint, 0, len(connection.Endpoints))
+	for _, endpoint := range connection.Endpoints {
+		result = append(result, status.GCPEndpoint{
+			Status:       endpoint.Status,
+			EndpointName: endpoint.EndpointName,
+			IPAddress:    endpoint.IPAddress,
+		})
+	}
+	return result
+}
+
+// interfaceIsAvailable checks if the interface and all of its nested endpoints are available and also returns the
+// failure message if any of them failed
+func interfaceIsAvailable(connection *mongodbatlas.InterfaceEndpointConnection) (bool, string) {
+	if isFailed(connection.Status) {
+		return false, connection.ErrorMessage
+	}
+	available := isAvailable(connection.Status) || isAvailable(connection.AWSConnectionStatus)
+	for _, endpoint := range connection.Endpoints {
+		if isFailed(endpoint.Status) {
+			return false, connection.ErrorMessage
+		}
+		if !isAvailable(endpoint.Status) {
+			available = false
+		}
+	}
+	return available, ""
+}
+
+func isAvailable(status string) bool {
+	return status == "AVAILABLE"
+}
+
+func isDeleting(status string) bool {
+	return status == "DELETING"
+}
+
+func isFailed(status string) bool {
+	return status == "FAILED"
+}
+
+func isNotFound(err error) bool {
+	var apiError *mongodbatlas.ErrorResponse
+	return errors.As(err, &apiError) && apiError.HTTPCode == http.StatusNotFound
+}
@@ -0,0 +1,133 @@
+package atlasprivateendpoint
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+)
+
+// fakeProvisioner returns the preconfigured endpoint and records the services it was asked to connect to
+type fakeProvisioner struct {
+	endpoint      *mdbv1.PrivateEndpointInterface
+	err           error
+	provisioned   []string
+	deprovisioned int
+}
+
+func (f *fakeProvisioner) Provision(_ context.Context, _ *mdbv1.AtlasPrivateEndpoint, service *mongodbatlas.PrivateEndpointConnection) (*mdbv1.PrivateEndpointInterface, error) {
+	f.provisioned = append(f.provisioned, service.ID)
+	return f.endpoint, f.err
+}
+
+func (f *fakeProvisioner) Deprovision(context.Context, *mdbv1.AtlasPrivateEndpoint) error {
+	f.deprovisioned++
+	return f.err
+}
+
+func TestResolveInterface(t *testing.T) {
+	service := &mongodbatlas.PrivateEndpointConnection{ID: "service", EndpointServiceName: "com.amazonaws.vpce.us-east-1.vpce-svc-1"}
+	newPrivateEndpoint := func() *mdbv1.AtlasPrivateEndpoint {
+		return mdbv1.NewPrivateEndpoint("ns", "pe", "project", provider.ProviderAWS, "us-east-1")
+	}
+
+	t.Run("Interface specified in the resource", func(t *testing.T) {
+		provisioner := &fakeProvisioner{endpoint: &mdbv1.PrivateEndpointInterface{ID: "vpce-provisioned"}}
+		r := &AtlasPrivateEndpointReconciler{Provisioner: provisioner}
+		privateEndpoint := newPrivateEndpoint().WithInterface(mdbv1.PrivateEndpointInterface{ID: "vpce-spec"})
+		privateEndpoint.Annotations = map[string]string{mdbv1.PrivateEndpointIDAnnotation: "vpce-annotation"}
+
+		endpoint, result := r.resolveInterface(privateEndpoint, service)
+		assert.True(t, result.IsOk())
+		assert.Equal(t, "vpce-spec", endpoint.ID)
+		assert.Empty(t, provisioner.provisioned)
+	})
+	t.Run("Interface written back to the annotations", func(t *testing.T) {
+		provisioner := &fakeProvisioner{endpoint: &mdbv1.PrivateEndpointInterface{ID: "vpce-provisioned"}}
+		r := &AtlasPrivateEndpointReconciler{Provisioner: provisioner}
+		privateEndpoint := newPrivateEndpoint()
+		privateEndpoint.Annotations = map[string]string{
+			mdbv1.PrivateEndpointIDAnnotation: "vpce-annotation",
+			mdbv1.PrivateEndpointIPAnnotation: "10.0.0.5",
+		}
+
+		endpoint, result := r.resolveInterface(privateEndpoint, service)
+		assert.True(t, result.IsOk())
+		assert.Equal(t, &mdbv1.PrivateEndpointInterface{ID: "vpce-annotation", IP: "10.0.0.5"}, endpoint)
+		assert.Empty(t, provisioner.provisioned)
+	})
+	t.Run("Interface created by the provisioner", func(t *testing.T) {
+		provisioner := &fakeProvisioner{endpoint: &mdbv1.PrivateEndpointInterface{ID: "vpce-provisioned"}}
+		r := &AtlasPrivateEndpointReconciler{Provisioner: provisioner}
+
+		endpoint, result := r.resolveInterface(newPrivateEndpoint(), service)
+		assert.True(t, result.IsOk())
+		assert.Equal(t, "vpce-provisioned", endpoint.ID)
+		assert.Equal(t, []string{"service"}, provisioner.provisioned)
+	})
+	t.Run("Interface is awaited with the noop provisioner", func(t *testing.T) {
+		r := &AtlasPrivateEndpointReconciler{Provisioner: NoopProvisioner{}}
+
+		endpoint, result := r.resolveInterface(newPrivateEndpoint(), service)
+		assert.Nil(t, endpoint)
+		assert.False(t, result.IsOk())
+		assert.False(t, result.IsWarning())
+	})
+	t.Run("Provisioning failed", func(t *testing.T) {
+		r := &AtlasPrivateEndpointReconciler{Provisioner: &fakeProvisioner{err: errors.New("quota exceeded")}}
+
+		_, result := r.resolveInterface(newPrivateEndpoint(), service)
+		assert.Equal(t, workflow.Terminate(workflow.PrivateEndpointProvisioningFailed, "quota exceeded"), result)
+	})
+}
+
+func TestServiceStatus(t *testing.T) {
+	t.Run("AWS", func(t *testing.T) {
+		service := &mongodbatlas.PrivateEndpointConnection{ID: "aws-service", EndpointServiceName: "vpce-svc", Status: "AVAILABLE"}
+		assert.Equal(t, status.PrivateEndpointService{
+			ServiceID:         "aws-service",
+			ServiceName:       "vpce-svc",
+			ServiceResourceID: "aws-service",
+			ServiceStatus:     "AVAILABLE",
+		}, serviceStatus(provider.ProviderAWS, service))
+	})
+	t.Run("Azure", func(t *testing.T) {
+		service := &mongodbatlas.PrivateEndpointConnection{ID: "azure-service", PrivateLinkServiceName: "pls", PrivateLinkServiceResourceID: "/subscriptions/pls", Status: "INITIATING"}
+		assert.Equal(t, status.PrivateEndpointService{
+			ServiceID:         "azure-service",
+			ServiceName:       "pls",
+			ServiceResourceID: "/subscriptions/pls",
+			ServiceStatus:     "INITIATING",
+		}, serviceStatus(provider.ProviderAzure, service))
+	})
+	t.Run("GCP", func(t *testing.T) {
+		service := &mongodbatlas.PrivateEndpointConnection{ID: "gcp-service", ServiceAttachmentNames: []string{"attachment-1"}, Status: "AVAILABLE"}
+		assert.Equal(t, status.PrivateEndpointService{
+			ServiceID:              "gcp-service",
+			ServiceAttachmentNames: []string{"attachment-1"},
+			ServiceStatus:          "AVAILABLE",
+		}, serviceStatus(provider.ProviderGCP, service))
+	})
+}
+
+func TestInterfaceIsAvailable(t *testing.T) {
+	available, failure := interfaceIsAvailable(&mongodbatlas.InterfaceEndpointConnection{AWSConnectionStatus: "AVAILABLE"})
+	assert.True(t, available)
+	assert.Empty(t, failure)
+
+	available, _ = interfaceIsAvailable(&mongodbatlas.InterfaceEndpointConnection{
+		Status:    "AVAILABLE",
+		Endpoints: []*mongodbatlas.GCPEndpoint{{Status: "AVAILABLE"}, {Status: "INITIATING"}},
+	})
+	assert.False(t, available)
+
+	_, failure = interfaceIsAvailable(&mongodbatlas.InterfaceEndpointConnection{Status: "FAILED", ErrorMessage: "rejected"})
+	assert.Equal(t, "rejected", failure)
+}
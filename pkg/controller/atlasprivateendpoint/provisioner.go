@@ -0,0 +1,32 @@
+package atlasprivateendpoint
+
+import (
+	"context"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+)
+
+// CloudProvisioner creates the cloud side of the private endpoint: the AWS VPC endpoint, the Azure private endpoint
+// or the GCP forwarding rules connecting to the Atlas private endpoint service.
+type CloudProvisioner interface {
+	// Provision ensures the endpoint connecting to the available Atlas service exists in the cloud provider and
+	// returns it. The nil endpoint means the endpoint is not ready yet and the reconciliation is retried later.
+	Provision(ctx context.Context, privateEndpoint *mdbv1.AtlasPrivateEndpoint, service *mongodbatlas.PrivateEndpointConnection) (*mdbv1.PrivateEndpointInterface, error)
+
+	// Deprovision removes the endpoint created by Provision from the cloud provider.
+	Deprovision(ctx context.Context, privateEndpoint *mdbv1.AtlasPrivateEndpoint) error
+}
+
+// NoopProvisioner doesn't create anything in the cloud provider. The endpoints must be specified in the resources or
+// written back to their annotations by the external tools.
+type NoopProvisioner struct{}
+
+func (NoopProvisioner) Provision(context.Context, *mdbv1.AtlasPrivateEndpoint, *mongodbatlas.PrivateEndpointConnection) (*mdbv1.PrivateEndpointInterface, error) {
+	return nil, nil
+}
+
+func (NoopProvisioner) Deprovision(context.Context, *mdbv1.AtlasPrivateEndpoint) error {
+	return nil
+}
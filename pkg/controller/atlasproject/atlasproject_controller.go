@@ -57,6 +57,9 @@ type AtlasProjectReconciler struct {
 	GlobalAPISecret  client.ObjectKey
 	GlobalPredicates []predicate.Predicate
 	EventRecorder    record.EventRecorder
+	// WatchNodes enables the IP Access List sources selecting Nodes. The Nodes are cluster-scoped so they can be
+	// read only by the operator watching all namespaces.
+	WatchNodes bool
 }
 
 // Dev note: duplicate the permissions in both sections below to generate both Role and ClusterRoles
@@ -65,17 +68,27 @@ type AtlasProjectReconciler struct {
 // +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasprojects/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 
 // +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasprojects,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasprojects/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",namespace=default,resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",namespace=default,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",namespace=default,resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",namespace=default,resources=configmaps,verbs=get;list;watch
 
 // +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasteams,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasteams/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasteams,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasteams/status,verbs=get;update;patch
 
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasipaccesslistentries,verbs=get;list;watch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasipaccesslistentries/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasipaccesslistentries,verbs=get;list;watch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasipaccesslistentries/status,verbs=get;update;patch
+
 func (r *AtlasProjectReconciler) Reconcile(context context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = context
 	log := r.Log.With("atlasproject", req.NamespacedName)
@@ -99,11 +112,14 @@ func (r *AtlasProjectReconciler) Reconcile(context context.Context, req ctrl.Req
 		return workflow.OK().ReconcileResult(), nil
 	}
 
+	watchedSecrets := ldapSecretKeys(project)
 	if project.ConnectionSecretObjectKey() != nil {
 		// Note, that we are not watching the global connection secret - seems there is no point in reconciling all
 		// the projects once that secret is changed
-		r.EnsureResourcesAreWatched(req.NamespacedName, "Secret", log, *project.ConnectionSecretObjectKey())
+		watchedSecrets = append(watchedSecrets, *project.ConnectionSecretObjectKey())
 	}
+	r.EnsureResourcesAreWatched(req.NamespacedName, "Secret", log, watchedSecrets...)
+	r.ensureIPAccessListSourcesAreWatched(project, log)
 	ctx := customresource.MarkReconciliationStarted(r.Client, project, log)
 
 	log.Infow("-> Starting AtlasProject reconciliation", "spec", project.Spec)
@@ -223,25 +239,30 @@ func (r *AtlasProjectReconciler) ensureDeletionFinalizer(ctx *workflow.Context,
 	return workflow.OK()
 }
 
-// ensureProjectResources ensures IP Access List, Private Endpoints, Integrations, Maintenance Window and Encryption at Rest
+// ensureProjectResources ensures IP Access List, Private Endpoints, Integrations, Maintenance Window, Encryption at Rest and LDAP
 func (r *AtlasProjectReconciler) ensureProjectResources(ctx *workflow.Context, projectID string, project *mdbv1.AtlasProject, context context.Context) (results []workflow.Result) {
 	var result workflow.Result
-	if result = ensureIPAccessList(ctx, projectID, project); result.IsOk() {
+	if result = r.ensureIPAccessList(ctx, projectID, project); result.IsOk() {
 		r.EventRecorder.Event(project, "Normal", string(status.IPAccessListReadyType), "")
 	}
 	results = append(results, result)
 
-	if result = ensurePrivateEndpoint(ctx, projectID, project); result.IsOk() {
+	if result = r.ensurePrivateEndpoint(ctx, projectID, project); result.IsOk() {
 		r.EventRecorder.Event(project, "Normal", string(status.PrivateEndpointReadyType), "")
 	}
 	results = append(results, result)
 
+	if result = ensureRegionalizedPrivateEndpoints(ctx, projectID, project); result.IsOk() {
+		r.EventRecorder.Event(project, "Normal", string(status.RegionalizedPrivateEndpointReadyType), "")
+	}
+	results = append(results, result)
+
 	if result = ensureProviderAccessStatus(context, ctx, project, projectID); result.IsOk() {
 		r.EventRecorder.Event(project, "Normal", string(status.CloudProviderAccessReadyType), "")
 	}
 	results = append(results, result)
 
-	if result = ensureNetworkPeers(ctx, projectID, project); result.IsOk() {
+	if result = r.ensureNetworkPeers(ctx, projectID, project); result.IsOk() {
 		r.EventRecorder.Event(project, "Normal", string(status.NetworkPeerReadyType), "")
 	}
 	results = append(results, result)
@@ -271,6 +292,11 @@ func (r *AtlasProjectReconciler) ensureProjectResources(ctx *workflow.Context, p
 	}
 	results = append(results, result)
 
+	if result = r.ensureLDAPConfiguration(ctx, projectID, project); result.IsOk() {
+		r.EventRecorder.Event(project, "Normal", string(status.LDAPReadyType), "")
+	}
+	results = append(results, result)
+
 	if result = ensureProjectSettings(ctx, projectID, project); result.IsOk() {
 		r.EventRecorder.Event(project, "Normal", string(status.ProjectSettingsReadyType), "")
 	}
@@ -326,6 +352,31 @@ func (r *AtlasProjectReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	// Watch for the IP Access List entries referencing the projects. The status updates made by the project
+	// controller itself are filtered out
+	err = c.Watch(&source.Kind{Type: &mdbv1.AtlasIPAccessListEntry{}}, handler.EnqueueRequestsFromMapFunc(ipAccessListEntryProjectRequest), watch.CommonPredicates())
+	if err != nil {
+		return err
+	}
+
+	// Watch for the Nodes, Services and ConfigMaps the IP Access List entries are discovered from
+	if r.WatchNodes {
+		err = c.Watch(&source.Kind{Type: &corev1.Node{}}, watch.NewNodeHandler(r.WatchedResources))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = c.Watch(&source.Kind{Type: &corev1.Service{}}, watch.NewServiceHandler(r.WatchedResources))
+	if err != nil {
+		return err
+	}
+
+	err = c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, watch.NewConfigMapHandler(r.WatchedResources))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -8,7 +8,9 @@ import (
 	"go.uber.org/zap"
 
 	v1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
 )
 
@@ -31,7 +33,7 @@ func ensureProviderAccessStatus(ctx context.Context, customContext *workflow.Con
 }
 
 func syncProviderAccessStatus(ctx context.Context, customContext *workflow.Context, specs []v1.CloudProviderAccessRole, statuses []status.CloudProviderAccessRole, groupID string) (workflow.Result, status.ConditionType) {
-	client := customContext.Client
+	client := atlas.NewCloudProviderAccessService(&customContext.Client)
 	logger := customContext.Log
 	specToStatusMap, haveDuplicate, cantMatch := checkStatuses(specs, statuses)
 	if haveDuplicate {
@@ -46,42 +48,41 @@ func syncProviderAccessStatus(ctx context.Context, customContext *workflow.Conte
 		SetNewStatuses(customContext, specToStatusMap)
 	}()
 
-	diff, err := sortAccessRoles(ctx, client.CloudProviderAccess, logger, specToStatusMap, groupID)
+	diff, err := sortAccessRoles(ctx, client, logger, specToStatusMap, groupID)
 	if err != nil {
 		return workflow.Terminate(workflow.ProjectCloudAccessRolesIsNotReadyInAtlas, fmt.Sprintf("failed to sort access roles: %s", err)),
 			status.CloudProviderAccessReadyType
 	}
-	err = deleteAccessRoles(ctx, client.CloudProviderAccess, logger, diff.toDelete, groupID)
+	err = deleteAccessRoles(ctx, client, logger, diff.toDelete, groupID)
 	if err != nil {
 		return workflow.Terminate(workflow.ProjectCloudAccessRolesIsNotReadyInAtlas, fmt.Sprintf("failed to delete access roles: %s", err)),
 			status.CloudProviderAccessReadyType
 	}
-	err = createAccessRoles(ctx, client.CloudProviderAccess, logger, diff.toCreate, specToStatusMap, groupID)
+	err = createAccessRoles(ctx, client, logger, diff.toCreate, specToStatusMap, groupID)
 	if err != nil {
 		return workflow.Terminate(workflow.ProjectCloudAccessRolesIsNotReadyInAtlas, fmt.Sprintf("failed to create access roles: %s", err)),
 			status.CloudProviderAccessReadyType
 	}
 
-	tryToAuthorize(ctx, client.CloudProviderAccess, logger, specToStatusMap, groupID)
+	tryToAuthorize(ctx, client, logger, specToStatusMap, groupID)
 	updateAccessRoles(diff.toUpdate, specToStatusMap)
 	return ensureCloudProviderAccessStatus(specToStatusMap)
 }
 
-func tryToAuthorize(ctx context.Context, access mongodbatlas.CloudProviderAccessService, logger *zap.SugaredLogger, statusMap map[v1.CloudProviderAccessRole]status.CloudProviderAccessRole, groupID string) {
+func tryToAuthorize(ctx context.Context, access atlas.CloudProviderAccessService, logger *zap.SugaredLogger, statusMap map[v1.CloudProviderAccessRole]status.CloudProviderAccessRole, groupID string) {
 	for spec, roleStatus := range statusMap {
-		if roleStatus.Status == status.StatusCreated {
-			request := mongodbatlas.CloudProviderAuthorizationRequest{
-				ProviderName:      spec.ProviderName,
-				IAMAssumedRoleARN: spec.IamAssumedRoleArn,
-			}
-			role, _, err := access.AuthorizeRole(ctx, groupID, roleStatus.RoleID, &request)
+		// GCP service accounts don't need the authorization, Atlas reports them ready once they are provisioned
+		if roleStatus.Status == status.StatusCreated && spec.ProviderName != string(provider.ProviderGCP) {
+			request := accessRoleRequest(spec)
+			request.IAMAssumedRoleARN = spec.IamAssumedRoleArn
+			role, _, err := access.AuthorizeRole(ctx, groupID, roleStatus.RoleID, request)
 			if err != nil {
 				roleStatus.FailedToAuthorise(fmt.Sprintf("cant authorize role. %s", err))
 				logger.Errorw("cant authorize role", "role", roleStatus.RoleID, "error", err)
 				statusMap[spec] = roleStatus
 				continue
 			}
-			roleStatus.Update(*role, roleStatus.IsEmptyARN())
+			updateRoleStatus(&roleStatus, *role)
 			statusMap[spec] = roleStatus
 		}
 	}
@@ -109,23 +110,21 @@ func ensureCloudProviderAccessStatus(statusMap map[v1.CloudProviderAccessRole]st
 	return workflow.OK(), status.CloudProviderAccessReadyType
 }
 
-func updateAccessRoles(toUpdate []mongodbatlas.AWSIAMRole, specToStatus map[v1.CloudProviderAccessRole]status.CloudProviderAccessRole) {
+func updateAccessRoles(toUpdate []atlas.CloudProviderAccessRole, specToStatus map[v1.CloudProviderAccessRole]status.CloudProviderAccessRole) {
 	for _, role := range toUpdate {
 		for spec, roleStatus := range specToStatus {
-			if role.RoleID == roleStatus.RoleID {
-				roleStatus.Update(role, roleStatus.IsEmptyARN())
+			if role.GetRoleID() == roleStatus.RoleID {
+				updateRoleStatus(&roleStatus, role)
 				specToStatus[spec] = roleStatus
 			}
 		}
 	}
 }
 
-func createAccessRoles(ctx context.Context, accessClient mongodbatlas.CloudProviderAccessService, logger *zap.SugaredLogger,
+func createAccessRoles(ctx context.Context, accessClient atlas.CloudProviderAccessService, logger *zap.SugaredLogger,
 	toCreate []v1.CloudProviderAccessRole, specToStatus map[v1.CloudProviderAccessRole]status.CloudProviderAccessRole, groupID string) error {
 	for _, spec := range toCreate {
-		role, _, err := accessClient.CreateRole(ctx, groupID, &mongodbatlas.CloudProviderAccessRoleRequest{
-			ProviderName: spec.ProviderName,
-		})
+		role, _, err := accessClient.CreateRole(ctx, groupID, accessRoleRequest(spec))
 		if err != nil {
 			logger.Error("failed to create access role", zap.Error(err))
 			roleStatus, ok := specToStatus[spec]
@@ -143,13 +142,70 @@ func createAccessRoles(ctx context.Context, accessClient mongodbatlas.CloudProvi
 			specToStatus[spec] = roleStatus
 			continue
 		}
-		roleStatus.Update(*role, roleStatus.IsEmptyARN())
+		updateRoleStatus(&roleStatus, *role)
 		specToStatus[spec] = roleStatus
 	}
 	return nil
 }
 
-func deleteAccessRoles(ctx context.Context, accessClient mongodbatlas.CloudProviderAccessService, logger *zap.SugaredLogger, toDelete map[string]string, groupID string) error {
+// accessRoleRequest returns the request creating the role. The AWS IAM role ARN is only known when the role is
+// authorized.
+func accessRoleRequest(spec v1.CloudProviderAccessRole) *atlas.CloudProviderAccessRoleRequest {
+	return &atlas.CloudProviderAccessRoleRequest{
+		ProviderName:       spec.ProviderName,
+		AtlasAzureAppID:    spec.AtlasAzureAppID,
+		ServicePrincipalID: spec.ServicePrincipalID,
+		TenantID:           spec.TenantID,
+	}
+}
+
+// updateRoleStatus updates the status from the role in Atlas according to the way the roles of the provider are
+// authorized
+func updateRoleStatus(roleStatus *status.CloudProviderAccessRole, role atlas.CloudProviderAccessRole) {
+	common := role.AWSIAMRole
+	common.RoleID = role.GetRoleID()
+
+	switch role.ProviderName {
+	case string(provider.ProviderAzure):
+		roleStatus.UpdateAzure(common, role.AtlasAzureAppID, role.ServicePrincipalID, role.TenantID)
+	case string(provider.ProviderGCP):
+		roleStatus.UpdateGCP(common, role.GCPServiceAccountForAtlas, role.Status)
+	default:
+		roleStatus.Update(common, roleStatus.IsEmptyARN())
+	}
+}
+
+// roleMatchesStatus returns true if the status belongs to the role from the spec. There can be only one GCP service
+// account in the project.
+func roleMatchesStatus(spec v1.CloudProviderAccessRole, roleStatus status.CloudProviderAccessRole) bool {
+	if spec.ProviderName != roleStatus.ProviderName {
+		return false
+	}
+
+	switch spec.ProviderName {
+	case string(provider.ProviderAzure):
+		return spec.AtlasAzureAppID == roleStatus.AtlasAzureAppID &&
+			spec.ServicePrincipalID == roleStatus.ServicePrincipalID &&
+			spec.TenantID == roleStatus.TenantID
+	case string(provider.ProviderGCP):
+		return true
+	default:
+		return spec.IamAssumedRoleArn == roleStatus.IamAssumedRoleArn
+	}
+}
+
+func newRoleStatus(spec v1.CloudProviderAccessRole) status.CloudProviderAccessRole {
+	switch spec.ProviderName {
+	case string(provider.ProviderAzure):
+		return status.NewAzureCloudProviderAccessRole(spec.AtlasAzureAppID, spec.ServicePrincipalID, spec.TenantID)
+	case string(provider.ProviderGCP):
+		return status.NewGCPCloudProviderAccessRole()
+	default:
+		return status.NewCloudProviderAccessRole(spec.ProviderName, spec.IamAssumedRoleArn)
+	}
+}
+
+func deleteAccessRoles(ctx context.Context, accessClient atlas.CloudProviderAccessService, logger *zap.SugaredLogger, toDelete map[string]string, groupID string) error {
 	for roleID, providerName := range toDelete {
 		request := mongodbatlas.CloudProviderDeauthorizationRequest{
 			ProviderName: providerName,
@@ -173,7 +229,7 @@ func checkStatuses(specs []v1.CloudProviderAccessRole, statuses []status.CloudPr
 	for _, spec := range specs {
 		isCreated := false
 		for _, existedStatus := range statuses {
-			if spec.ProviderName == existedStatus.ProviderName && spec.IamAssumedRoleArn == existedStatus.IamAssumedRoleArn {
+			if roleMatchesStatus(spec, existedStatus) {
 				isCreated = true
 				if _, ok := result[spec]; !ok {
 					result[spec] = existedStatus
@@ -187,6 +243,10 @@ func checkStatuses(specs []v1.CloudProviderAccessRole, statuses []status.CloudPr
 				emptyArnRoleStatus = existedStatus
 			}
 		}
+		if !isCreated && spec.ProviderName != string(provider.ProviderAWS) {
+			result[spec] = newRoleStatus(spec)
+			continue
+		}
 		if !isCreated {
 			if emptyRoleIsAssign {
 				return nil, false, true
@@ -201,7 +261,7 @@ func checkStatuses(specs []v1.CloudProviderAccessRole, statuses []status.CloudPr
 					result[spec] = emptyArnRoleStatus
 				}
 			} else {
-				newStatus := status.NewCloudProviderAccessRole(spec.ProviderName, spec.IamAssumedRoleArn)
+				newStatus := newRoleStatus(spec)
 				result[spec] = newStatus
 				statuses = append(statuses, newStatus)
 			}
@@ -212,31 +272,31 @@ func checkStatuses(specs []v1.CloudProviderAccessRole, statuses []status.CloudPr
 
 type accessRoleDiff struct {
 	toCreate []v1.CloudProviderAccessRole
-	toUpdate []mongodbatlas.AWSIAMRole
+	toUpdate []atlas.CloudProviderAccessRole
 	toDelete map[string]string // roleId -> providerName
 }
 
-func sortAccessRoles(ctx context.Context, accessClient mongodbatlas.CloudProviderAccessService, logger *zap.SugaredLogger, expectedRoles map[v1.CloudProviderAccessRole]status.CloudProviderAccessRole, groupID string) (accessRoleDiff, error) {
+func sortAccessRoles(ctx context.Context, accessClient atlas.CloudProviderAccessService, logger *zap.SugaredLogger, expectedRoles map[v1.CloudProviderAccessRole]status.CloudProviderAccessRole, groupID string) (accessRoleDiff, error) {
 	roleList, _, err := accessClient.ListRoles(ctx, groupID)
 	if err != nil {
 		logger.Error("failed to list access roles", zap.Error(err))
 		return accessRoleDiff{}, err
 	}
-	logger.Debugf("found %d access roles", len(roleList.AWSIAMRoles))
-	existedRoles := roleList.AWSIAMRoles
+	existedRoles := roleList.All()
+	logger.Debugf("found %d access roles", len(existedRoles))
 	diff := accessRoleDiff{}
 	diff.toDelete = make(map[string]string)
 	for _, existedRole := range existedRoles {
 		toDelete := true
 		for _, status := range expectedRoles {
-			if status.RoleID == existedRole.RoleID {
+			if status.RoleID == existedRole.GetRoleID() {
 				toDelete = false
 				diff.toUpdate = append(diff.toUpdate, existedRole)
 				break
 			}
 		}
 		if toDelete {
-			diff.toDelete[existedRole.RoleID] = existedRole.ProviderName
+			diff.toDelete[existedRole.GetRoleID()] = existedRole.ProviderName
 		}
 	}
 
@@ -0,0 +1,85 @@
+package atlasproject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	v1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+)
+
+func TestCheckStatuses(t *testing.T) {
+	aws := v1.CloudProviderAccessRole{ProviderName: "AWS", IamAssumedRoleArn: "arn:aws:iam::123:role/test"}
+	azure := v1.CloudProviderAccessRole{ProviderName: "AZURE", AtlasAzureAppID: "app", ServicePrincipalID: "principal", TenantID: "tenant"}
+	gcp := v1.CloudProviderAccessRole{ProviderName: "GCP"}
+
+	t.Run("New roles of all providers", func(t *testing.T) {
+		result, duplicate, cantMatch := checkStatuses([]v1.CloudProviderAccessRole{aws, azure, gcp}, nil)
+		assert.False(t, duplicate)
+		assert.False(t, cantMatch)
+		assert.Equal(t, status.StatusCreated, result[aws].Status)
+		assert.Equal(t, status.StatusCreated, result[azure].Status)
+		assert.Equal(t, "principal", result[azure].ServicePrincipalID)
+		assert.Equal(t, status.StatusCreated, result[gcp].Status)
+	})
+	t.Run("Azure role doesn't take the status of the AWS role with empty ARN", func(t *testing.T) {
+		statuses := []status.CloudProviderAccessRole{{ProviderName: "AWS", RoleID: "aws-role", Status: status.StatusEmptyARN}}
+		result, _, _ := checkStatuses([]v1.CloudProviderAccessRole{azure}, statuses)
+		assert.Empty(t, result[azure].RoleID)
+	})
+	t.Run("Existing statuses are matched by provider fields", func(t *testing.T) {
+		statuses := []status.CloudProviderAccessRole{
+			{ProviderName: "AZURE", RoleID: "azure-role", AtlasAzureAppID: "app", ServicePrincipalID: "principal", TenantID: "tenant", Status: status.StatusReady},
+			{ProviderName: "GCP", RoleID: "gcp-role", Status: status.StatusReady},
+		}
+		result, _, _ := checkStatuses([]v1.CloudProviderAccessRole{azure, gcp}, statuses)
+		assert.Equal(t, "azure-role", result[azure].RoleID)
+		assert.Equal(t, "gcp-role", result[gcp].RoleID)
+	})
+}
+
+func TestUpdateRoleStatus(t *testing.T) {
+	t.Run("Azure service principal is identified by _id and ready once authorized", func(t *testing.T) {
+		roleStatus := status.NewAzureCloudProviderAccessRole("app", "principal", "tenant")
+		role := atlas.CloudProviderAccessRole{
+			AWSIAMRole:         mongodbatlas.AWSIAMRole{ProviderName: "AZURE"},
+			ID:                 "azure-role",
+			AtlasAzureAppID:    "app",
+			ServicePrincipalID: "principal",
+			TenantID:           "tenant",
+		}
+		updateRoleStatus(&roleStatus, role)
+		assert.Equal(t, "azure-role", roleStatus.RoleID)
+		assert.Equal(t, status.StatusCreated, roleStatus.Status)
+
+		role.AuthorizedDate = "2023-01-01T00:00:00Z"
+		updateRoleStatus(&roleStatus, role)
+		assert.Equal(t, status.StatusReady, roleStatus.Status)
+	})
+	t.Run("GCP service account is ready once provisioned", func(t *testing.T) {
+		roleStatus := status.NewGCPCloudProviderAccessRole()
+		role := atlas.CloudProviderAccessRole{
+			AWSIAMRole:                mongodbatlas.AWSIAMRole{ProviderName: "GCP", RoleID: "gcp-role"},
+			GCPServiceAccountForAtlas: "atlas@project.iam.gserviceaccount.com",
+			Status:                    "IN_PROGRESS",
+		}
+		updateRoleStatus(&roleStatus, role)
+		assert.Equal(t, status.StatusCreated, roleStatus.Status)
+		assert.Equal(t, "atlas@project.iam.gserviceaccount.com", roleStatus.GCPServiceAccountForAtlas)
+
+		role.Status = "COMPLETE"
+		updateRoleStatus(&roleStatus, role)
+		assert.Equal(t, status.StatusReady, roleStatus.Status)
+	})
+	t.Run("AWS role is ready once the ARN matches", func(t *testing.T) {
+		roleStatus := status.NewCloudProviderAccessRole("AWS", "arn:aws:iam::123:role/test")
+		role := atlas.CloudProviderAccessRole{
+			AWSIAMRole: mongodbatlas.AWSIAMRole{ProviderName: "AWS", RoleID: "aws-role", IAMAssumedRoleARN: "arn:aws:iam::123:role/test"},
+		}
+		updateRoleStatus(&roleStatus, role)
+		assert.Equal(t, status.StatusReady, roleStatus.Status)
+	})
+}
@@ -5,7 +5,9 @@ import (
 	"reflect"
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
 
@@ -60,14 +62,14 @@ func fetchEncryptionAtRests(ctx *workflow.Context, projectID string) (*mongodbat
 }
 
 func syncEncryptionAtRestsInAtlas(ctx *workflow.Context, projectID string, project *mdbv1.AtlasProject) error {
-	requestBody := mongodbatlas.EncryptionAtRest{
+	requestBody := atlas.EncryptionAtRest{
 		GroupID:        projectID,
 		AwsKms:         getAwsKMS(project),
 		AzureKeyVault:  getAzureKeyVault(project),
 		GoogleCloudKms: getGoogleCloudKms(project),
 	}
 
-	if _, _, err := ctx.Client.EncryptionsAtRest.Create(context.Background(), &requestBody); err != nil { // Create() sends PATCH request
+	if _, _, err := atlas.NewEncryptionAtRestService(&ctx.Client).Update(context.Background(), &requestBody); err != nil {
 		return err
 	}
 
@@ -176,37 +178,76 @@ func getAwsKMS(project *mdbv1.AtlasProject) (result mongodbatlas.AwsKms) {
 	return
 }
 
-func getAzureKeyVault(project *mdbv1.AtlasProject) (result mongodbatlas.AzureKeyVault) {
+func getAzureKeyVault(project *mdbv1.AtlasProject) (result atlas.AzureKeyVault) {
 	if project.Spec.EncryptionAtRest == nil {
 		return
 	}
 
-	result = mongodbatlas.AzureKeyVault(project.Spec.EncryptionAtRest.AzureKeyVault)
+	spec := project.Spec.EncryptionAtRest.AzureKeyVault
+	result = atlas.AzureKeyVault{
+		AzureKeyVault: mongodbatlas.AzureKeyVault{
+			Enabled:           spec.Enabled,
+			ClientID:          spec.ClientID,
+			AzureEnvironment:  spec.AzureEnvironment,
+			SubscriptionID:    spec.SubscriptionID,
+			ResourceGroupName: spec.ResourceGroupName,
+			KeyVaultName:      spec.KeyVaultName,
+			KeyIdentifier:     spec.KeyIdentifier,
+			Secret:            spec.Secret,
+			TenantID:          spec.TenantID,
+		},
+		RoleID: spec.RoleID,
+	}
 
-	if (result == mongodbatlas.AzureKeyVault{}) {
+	if (result == atlas.AzureKeyVault{}) {
 		result.Enabled = toptr.MakePtr(false)
 	}
 
+	// The service principal is used only if no secret is provided
+	if isNotNilAndTrue(result.Enabled) && result.RoleID == "" && result.Secret == "" {
+		azureRole, foundRole := selectRole(project.Status.CloudProviderAccessRoles, string(provider.ProviderAzure))
+		if foundRole {
+			result.RoleID = azureRole.RoleID
+		}
+	}
+
 	return
 }
 
-func getGoogleCloudKms(project *mdbv1.AtlasProject) (result mongodbatlas.GoogleCloudKms) {
+func getGoogleCloudKms(project *mdbv1.AtlasProject) (result atlas.GoogleCloudKms) {
 	if project.Spec.EncryptionAtRest == nil {
 		return
 	}
 
-	result = mongodbatlas.GoogleCloudKms(project.Spec.EncryptionAtRest.GoogleCloudKms)
+	spec := project.Spec.EncryptionAtRest.GoogleCloudKms
+	result = atlas.GoogleCloudKms{
+		GoogleCloudKms: mongodbatlas.GoogleCloudKms{
+			Enabled:              spec.Enabled,
+			ServiceAccountKey:    spec.ServiceAccountKey,
+			KeyVersionResourceID: spec.KeyVersionResourceID,
+		},
+		RoleID: spec.RoleID,
+	}
 
-	if (result == mongodbatlas.GoogleCloudKms{}) {
+	if (result == atlas.GoogleCloudKms{}) {
 		result.Enabled = toptr.MakePtr(false)
 	}
 
+	// The service account is used only if no service account key is provided
+	if isNotNilAndTrue(result.Enabled) && result.RoleID == "" && result.ServiceAccountKey == "" {
+		gcpRole, foundRole := selectRole(project.Status.CloudProviderAccessRoles, string(provider.ProviderGCP))
+		if foundRole {
+			result.RoleID = gcpRole.RoleID
+		}
+	}
+
 	return
 }
 
+// selectRole returns the first ready role of the provider
 func selectRole(accessRoles []status.CloudProviderAccessRole, providerName string) (result status.CloudProviderAccessRole, found bool) {
 	for _, role := range accessRoles {
-		if role.ProviderName == providerName {
+		if role.ProviderName == providerName && role.Status == status.StatusReady {
 			return role, true
 		}
 	}
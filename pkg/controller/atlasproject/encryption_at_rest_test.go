@@ -7,6 +7,7 @@ import (
 	"go.mongodb.org/atlas/mongodbatlas"
 
 	v1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
 )
 
@@ -109,3 +110,33 @@ func TestAtlasInSync(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, areInSync, "Realistic exampel. should be equal")
 }
+
+func TestKeyVaultRoleSelection(t *testing.T) {
+	project := &v1.AtlasProject{
+		Spec: v1.AtlasProjectSpec{
+			EncryptionAtRest: &v1.EncryptionAtRest{
+				AzureKeyVault:  v1.AzureKeyVault{Enabled: toptr.MakePtr(true), KeyVaultName: "vault"},
+				GoogleCloudKms: v1.GoogleCloudKms{Enabled: toptr.MakePtr(true), KeyVersionResourceID: "key"},
+			},
+		},
+		Status: status.AtlasProjectStatus{
+			CloudProviderAccessRoles: []status.CloudProviderAccessRole{
+				{ProviderName: "AZURE", RoleID: "azure-pending", Status: status.StatusCreated},
+				{ProviderName: "AZURE", RoleID: "azure-role", Status: status.StatusReady},
+				{ProviderName: "GCP", RoleID: "gcp-role", Status: status.StatusReady},
+			},
+		},
+	}
+
+	t.Run("Ready roles of the provider are used", func(t *testing.T) {
+		assert.Equal(t, "azure-role", getAzureKeyVault(project).RoleID)
+		assert.Equal(t, "gcp-role", getGoogleCloudKms(project).RoleID)
+	})
+	t.Run("Role is not used with the credentials", func(t *testing.T) {
+		withSecret := project.DeepCopy()
+		withSecret.Spec.EncryptionAtRest.AzureKeyVault.Secret = "secret"
+		withSecret.Spec.EncryptionAtRest.GoogleCloudKms.ServiceAccountKey = "{}"
+		assert.Empty(t, getAzureKeyVault(withSecret).RoleID)
+		assert.Empty(t, getGoogleCloudKms(withSecret).RoleID)
+	})
+}
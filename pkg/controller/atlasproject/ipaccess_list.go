@@ -40,16 +40,40 @@ func (i atlasProjectIPAccessList) Identifier() interface{} {
 }
 
 // ensureIPAccessList ensures that the state of the Atlas IP Access List matches the
-// state of the IP Access list specified in the project CR. Any Access Lists which exist
-// in Atlas but are not specified in the CR are deleted.
-func ensureIPAccessList(ctx *workflow.Context, projectID string, project *mdbv1.AtlasProject) workflow.Result {
-	result := syncIPAccessListWithAtlas(ctx, projectID, project)
+// state of the IP Access list specified in the project CR together with the entries discovered from the IP Access
+// List sources and the entries of the AtlasIPAccessListEntry resources referencing the project. Any Access Lists
+// which exist in Atlas but are not specified in the CR are deleted.
+func (r *AtlasProjectReconciler) ensureIPAccessList(ctx *workflow.Context, projectID string, project *mdbv1.AtlasProject) workflow.Result {
+	if !r.WatchNodes && project.HasNodesIPAccessListSource() {
+		result := workflow.Terminate(workflow.ProjectIPAccessListSourceNotResolved, "the nodes IP Access List source is supported only by the operator watching all namespaces")
+		ctx.SetConditionFromResult(status.IPAccessListReadyType, result)
+		return result
+	}
+
+	sourced, err := resolveIPAccessListSources(r.Client, project)
+	if err != nil {
+		result := workflow.Terminate(workflow.ProjectIPAccessListSourceNotResolved, err.Error())
+		ctx.SetConditionFromResult(status.IPAccessListReadyType, result)
+		return result
+	}
+
+	entryStates, err := collectIPAccessListEntries(r.Client, project, sourced)
+	if err != nil {
+		result := workflow.Terminate(workflow.Internal, err.Error())
+		ctx.SetConditionFromResult(status.IPAccessListReadyType, result)
+		return result
+	}
+	entries, entryOwners := acceptedIPAccessListEntries(entryStates)
+	ctx.EnsureStatusOption(status.AtlasProjectIPAccessListEntriesOption(entryOwners))
+
+	result := syncIPAccessListWithAtlas(ctx, projectID, project, append(sourced, entries...))
+	r.updateIPAccessListEntries(ctx, projectID, entryStates, result)
 	if !result.IsOk() {
 		ctx.SetConditionFromResult(status.IPAccessListReadyType, result)
 		return result
 	}
 
-	if len(project.Spec.ProjectIPAccessList) == 0 {
+	if len(project.Spec.ProjectIPAccessList) == 0 && len(project.Spec.IPAccessListSources) == 0 && len(entryStates) == 0 {
 		ctx.UnsetCondition(status.IPAccessListReadyType)
 		return workflow.OK()
 	}
@@ -58,11 +82,17 @@ func ensureIPAccessList(ctx *workflow.Context, projectID string, project *mdbv1.
 	return result
 }
 
-func syncIPAccessListWithAtlas(ctx *workflow.Context, projectID string, project *mdbv1.AtlasProject) workflow.Result {
+// syncIPAccessListWithAtlas syncs the IP Access List of the project and the additional entries which are not specified
+// in the project spec directly.
+func syncIPAccessListWithAtlas(ctx *workflow.Context, projectID string, project *mdbv1.AtlasProject, additional []project.IPAccessList) workflow.Result {
 	if err := validateIPAccessLists(project.Spec.ProjectIPAccessList); err != nil {
 		return workflow.Terminate(workflow.ProjectIPAccessInvalid, err.Error())
 	}
+	if err := validateIPAccessLists(additional); err != nil {
+		return workflow.Terminate(workflow.ProjectIPAccessListSourceNotResolved, err.Error())
+	}
 	active, expired := filterActiveIPAccessLists(project.Spec.ProjectIPAccessList)
+	active = append(active, additional...)
 
 	if result := createOrDeleteInAtlas(ctx.Client, projectID, active, ctx.Log); !result.IsOk() {
 		return result
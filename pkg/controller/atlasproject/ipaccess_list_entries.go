@@ -0,0 +1,169 @@
+package atlasproject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/statushandler"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+)
+
+// ipAccessListEntryState is the AtlasIPAccessListEntry referencing the project. The rejected resources keep the
+// reason of the rejection, the accepted ones are added to the IP Access List of the project.
+type ipAccessListEntryState struct {
+	resource *mdbv1.AtlasIPAccessListEntry
+	rejected *workflow.Result
+}
+
+// collectIPAccessListEntries returns the AtlasIPAccessListEntry resources referencing the project ordered by their
+// creation. The resources from the namespaces not allowed by the project, the invalid ones and the ones duplicating
+// the entries which are already owned by the project (either specified or sourced) or by the older resources are
+// rejected.
+func collectIPAccessListEntries(kubeClient client.Client, atlasProject *mdbv1.AtlasProject, sourced []project.IPAccessList) ([]ipAccessListEntryState, error) {
+	list := &mdbv1.AtlasIPAccessListEntryList{}
+	if err := kubeClient.List(context.Background(), list); err != nil {
+		return nil, fmt.Errorf("failed to list AtlasIPAccessListEntry resources: %w", err)
+	}
+
+	projectKey := kube.ObjectKeyFromObject(atlasProject)
+	resources := make([]*mdbv1.AtlasIPAccessListEntry, 0, len(list.Items))
+	for i := range list.Items {
+		resource := &list.Items[i]
+		if resource.AtlasProjectObjectKey() == projectKey && resource.DeletionTimestamp.IsZero() {
+			resources = append(resources, resource)
+		}
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		if !resources[i].CreationTimestamp.Equal(&resources[j].CreationTimestamp) {
+			return resources[i].CreationTimestamp.Before(&resources[j].CreationTimestamp)
+		}
+		return kube.ObjectKeyFromObject(resources[i]).String() < kube.ObjectKeyFromObject(resources[j]).String()
+	})
+
+	known := map[interface{}]bool{}
+	for _, entry := range atlasProject.Spec.ProjectIPAccessList {
+		known[entry.Identifier()] = true
+	}
+	for _, entry := range sourced {
+		known[entry.Identifier()] = true
+	}
+
+	result := make([]ipAccessListEntryState, 0, len(resources))
+	for _, resource := range resources {
+		state := ipAccessListEntryState{resource: resource}
+		entry := resource.Spec.IPAccessList
+		validationErr := validateIPAccessListEntry(entry)
+		switch {
+		case !atlasProject.AllowsIPAccessListEntriesFrom(resource.Namespace):
+			state.rejected = rejectIPAccessListEntry(workflow.IPAccessListEntryNamespaceNotAllowed,
+				fmt.Sprintf("the project %s doesn't allow the IP Access List entries from the namespace %s", projectKey, resource.Namespace))
+		case validationErr != nil:
+			state.rejected = rejectIPAccessListEntry(workflow.IPAccessListEntryInvalidSpec, validationErr.Error())
+		case known[entry.Identifier()]:
+			state.rejected = rejectIPAccessListEntry(workflow.IPAccessListEntryConflict,
+				fmt.Sprintf("the entry %s is already in the IP Access List of the project %s", entry.Identifier(), projectKey))
+		default:
+			known[entry.Identifier()] = true
+		}
+		result = append(result, state)
+	}
+	return result, nil
+}
+
+func rejectIPAccessListEntry(reason workflow.ConditionReason, message string) *workflow.Result {
+	result := workflow.Terminate(reason, message)
+	return &result
+}
+
+func validateIPAccessListEntry(entry project.IPAccessList) error {
+	if entry.Identifier() == "" {
+		return errors.New("one of ipAddress, cidrBlock or awsSecurityGroup must be specified")
+	}
+	return validateSingleIPAccessList(entry)
+}
+
+// acceptedIPAccessListEntries returns the entries of the accepted resources which are not expired yet together with
+// the ownership of these entries.
+func acceptedIPAccessListEntries(states []ipAccessListEntryState) ([]project.IPAccessList, []status.ProjectIPAccessListEntry) {
+	var entries []project.IPAccessList
+	var owners []status.ProjectIPAccessListEntry
+	for _, state := range states {
+		if state.rejected != nil {
+			continue
+		}
+		active, _ := filterActiveIPAccessLists([]project.IPAccessList{state.resource.Spec.IPAccessList})
+		if len(active) == 0 {
+			continue
+		}
+		entries = append(entries, active...)
+		owners = append(owners, status.ProjectIPAccessListEntry{
+			Entry: state.resource.Spec.Identifier().(string),
+			Owner: common.ResourceRefNamespaced{Name: state.resource.Name, Namespace: state.resource.Namespace},
+		})
+	}
+	return entries, owners
+}
+
+// updateIPAccessListEntries reports the state of the entries in Atlas on the AtlasIPAccessListEntry resources.
+// The result of the IP Access List synchronization is reported instead if it failed.
+func (r *AtlasProjectReconciler) updateIPAccessListEntries(ctx *workflow.Context, projectID string, states []ipAccessListEntryState, syncResult workflow.Result) {
+	for _, state := range states {
+		entryCtx := workflow.NewContext(ctx.Log.With("atlasipaccesslistentry", kube.ObjectKeyFromObject(state.resource)), state.resource.Status.Conditions)
+		entryCtx.EnsureStatusOption(status.AtlasIPAccessListEntryProjectIDOption(projectID))
+
+		entryStatus, result := ipAccessListEntryStatus(ctx, projectID, state, syncResult)
+		entryCtx.EnsureStatusOption(status.AtlasIPAccessListEntryStateOption(entryStatus))
+		if result.IsOk() {
+			entryCtx.SetConditionTrue(status.ReadyType)
+		} else {
+			entryCtx.SetConditionFromResult(status.ReadyType, result)
+		}
+		statushandler.Update(entryCtx, r.Client, r.EventRecorder, state.resource)
+	}
+}
+
+func ipAccessListEntryStatus(ctx *workflow.Context, projectID string, state ipAccessListEntryState, syncResult workflow.Result) (string, workflow.Result) {
+	if state.rejected != nil {
+		return "", *state.rejected
+	}
+	entry := state.resource.Spec.IPAccessList
+	if active, _ := filterActiveIPAccessLists([]project.IPAccessList{entry}); len(active) == 0 {
+		return "", workflow.Terminate(workflow.IPAccessListEntryExpired, fmt.Sprintf("the entry expired at %s", entry.DeleteAfterDate))
+	}
+	if syncResult.IsWarning() {
+		return "", workflow.Terminate(workflow.IPAccessListEntryNotSynced, fmt.Sprintf("the IP Access List of the project is not synced: %s", syncResult.GetMessage()))
+	}
+
+	atlasEntry, err := entry.ToAtlas()
+	if err != nil {
+		return "", workflow.Terminate(workflow.Internal, err.Error())
+	}
+	atlasEntry.GroupID = projectID
+	entryStatus, err := GetIPAccessListStatus(ctx.Client, *atlasEntry)
+	if err != nil {
+		return "", workflow.Terminate(workflow.IPAccessListEntryNotSynced, err.Error())
+	}
+	if entryStatus.Status != string(IPAccessListActive) {
+		return entryStatus.Status, workflow.InProgress(workflow.IPAccessListEntryNotActive, fmt.Sprintf("the entry is not yet active, current state: %s", entryStatus.Status))
+	}
+	return entryStatus.Status, workflow.OK()
+}
+
+// ipAccessListEntryProjectRequest returns the request for the project referenced by the AtlasIPAccessListEntry.
+func ipAccessListEntryProjectRequest(obj client.Object) []reconcile.Request {
+	entry, ok := obj.(*mdbv1.AtlasIPAccessListEntry)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: entry.AtlasProjectObjectKey()}}
+}
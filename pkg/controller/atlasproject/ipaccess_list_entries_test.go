@@ -0,0 +1,78 @@
+package atlasproject
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/timeutil"
+)
+
+func TestCollectIPAccessListEntries(t *testing.T) {
+	created := func(entry *mdbv1.AtlasIPAccessListEntry, minutesAgo int) *mdbv1.AtlasIPAccessListEntry {
+		entry.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Duration(minutesAgo) * time.Minute).Truncate(time.Second))
+		return entry
+	}
+	atlasProject := mdbv1.NewProject("ns", "project", "project").
+		WithIPAccessList(project.NewIPAccessList().WithIP("10.0.0.1"))
+	atlasProject.Spec.IPAccessListEntryNamespaces = []string{"app"}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(mdbv1.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		created(mdbv1.NewIPAccessListEntry("app", "newer", "project", project.NewIPAccessList().WithCIDR("10.1.0.0/16")).WithProjectNamespace("ns"), 1),
+		created(mdbv1.NewIPAccessListEntry("app", "older", "project", project.NewIPAccessList().WithCIDR("10.1.0.0/16")).WithProjectNamespace("ns"), 5),
+		created(mdbv1.NewIPAccessListEntry("ns", "static", "project", project.NewIPAccessList().WithIP("10.0.0.1")), 5),
+		created(mdbv1.NewIPAccessListEntry("ns", "sourced", "project", project.NewIPAccessList().WithIP("34.1.1.1")), 5),
+		created(mdbv1.NewIPAccessListEntry("ns", "empty", "project", project.NewIPAccessList()), 5),
+		created(mdbv1.NewIPAccessListEntry("other", "forbidden", "project", project.NewIPAccessList().WithIP("10.0.0.2")).WithProjectNamespace("ns"), 5),
+		created(mdbv1.NewIPAccessListEntry("ns", "other-project", "other", project.NewIPAccessList().WithIP("10.0.0.3")), 5),
+	).Build()
+
+	states, err := collectIPAccessListEntries(k8sClient, atlasProject, []project.IPAccessList{project.NewIPAccessList().WithIP("34.1.1.1")})
+	require.NoError(t, err)
+
+	rejections := map[string]*workflow.Result{}
+	for _, state := range states {
+		rejections[state.resource.Name] = state.rejected
+	}
+	conflict := func(entry string) *workflow.Result {
+		return rejectIPAccessListEntry(workflow.IPAccessListEntryConflict, "the entry "+entry+" is already in the IP Access List of the project ns/project")
+	}
+	assert.Equal(t, map[string]*workflow.Result{
+		"older":     nil,
+		"newer":     conflict("10.1.0.0/16"),
+		"static":    conflict("10.0.0.1"),
+		"sourced":   conflict("34.1.1.1"),
+		"empty":     rejectIPAccessListEntry(workflow.IPAccessListEntryInvalidSpec, "one of ipAddress, cidrBlock or awsSecurityGroup must be specified"),
+		"forbidden": rejectIPAccessListEntry(workflow.IPAccessListEntryNamespaceNotAllowed, "the project ns/project doesn't allow the IP Access List entries from the namespace other"),
+	}, rejections)
+
+	entries, owners := acceptedIPAccessListEntries(states)
+	assert.Equal(t, []project.IPAccessList{project.NewIPAccessList().WithCIDR("10.1.0.0/16")}, entries)
+	assert.Equal(t, []status.ProjectIPAccessListEntry{
+		{Entry: "10.1.0.0/16", Owner: common.ResourceRefNamespaced{Name: "older", Namespace: "app"}},
+	}, owners)
+}
+
+func TestAcceptedIPAccessListEntriesSkipsExpired(t *testing.T) {
+	expired := mdbv1.NewIPAccessListEntry("ns", "expired", "project",
+		project.NewIPAccessList().WithIP("10.0.0.1").WithDeleteAfterDate(timeutil.FormatISO8601(time.Now().Add(-time.Hour))))
+
+	entries, owners := acceptedIPAccessListEntries([]ipAccessListEntryState{{resource: expired}})
+	assert.Empty(t, entries)
+	assert.Empty(t, owners)
+	assert.Equal(t, kube.ObjectKey("ns", "project"), expired.AtlasProjectObjectKey())
+}
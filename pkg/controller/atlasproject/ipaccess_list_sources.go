@@ -0,0 +1,158 @@
+package atlasproject
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+)
+
+// ipAccessListSourceComment is the prefix of the comment of the IP Access List entries discovered in the cluster.
+// It only tells the users where the entry comes from: the operator owns the whole IP Access List of the project, so the
+// entries which are neither specified nor discovered are removed regardless of their comment.
+const ipAccessListSourceComment = "Managed by Atlas Kubernetes Operator"
+
+// resolveIPAccessListSources returns the IP Access List entries discovered from the sources of the project.
+// The entries which are already specified in the ProjectIPAccessList are skipped.
+func resolveIPAccessListSources(kubeClient client.Client, atlasProject *mdbv1.AtlasProject) ([]project.IPAccessList, error) {
+	known := map[interface{}]bool{}
+	for _, entry := range atlasProject.Spec.ProjectIPAccessList {
+		known[entry.Identifier()] = true
+	}
+
+	var result []project.IPAccessList
+	add := func(entry project.IPAccessList) {
+		if !known[entry.Identifier()] {
+			known[entry.Identifier()] = true
+			result = append(result, entry)
+		}
+	}
+
+	for _, source := range atlasProject.Spec.IPAccessListSources {
+		var entries []project.IPAccessList
+		var err error
+		switch {
+		case source.Nodes != nil:
+			entries, err = nodeIPAccessList(kubeClient, source.Nodes)
+		case source.Services != nil:
+			entries, err = serviceIPAccessList(kubeClient, atlasProject.Namespace, source.Services)
+		case source.ConfigMap != nil:
+			entries, err = configMapIPAccessList(kubeClient, atlasProject.Namespace, source.ConfigMap)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			add(entry)
+		}
+	}
+	return result, nil
+}
+
+func nodeIPAccessList(kubeClient client.Client, selector *metav1.LabelSelector) ([]project.IPAccessList, error) {
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nodes selector: %w", err)
+	}
+	nodes := &corev1.NodeList{}
+	if err = kubeClient.List(context.Background(), nodes, client.MatchingLabelsSelector{Selector: labelSelector}); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	var result []project.IPAccessList
+	for _, node := range nodes.Items {
+		for _, address := range node.Status.Addresses {
+			if address.Type == corev1.NodeExternalIP {
+				result = append(result, sourcedIPAccessList(address.Address, "node "+node.Name))
+			}
+		}
+	}
+	return result, nil
+}
+
+func serviceIPAccessList(kubeClient client.Client, namespace string, selector *metav1.LabelSelector) ([]project.IPAccessList, error) {
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid services selector: %w", err)
+	}
+	services := &corev1.ServiceList{}
+	if err = kubeClient.List(context.Background(), services, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: labelSelector}); err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	var result []project.IPAccessList
+	for _, service := range services.Items {
+		if service.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+		// The load balancers exposed by the host names only can't be added to the access list
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" {
+				result = append(result, sourcedIPAccessList(ingress.IP, "service "+service.Name))
+			}
+		}
+	}
+	return result, nil
+}
+
+func configMapIPAccessList(kubeClient client.Client, namespace string, ref *project.ConfigMapKeyRef) ([]project.IPAccessList, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := kubeClient.Get(context.Background(), kube.ObjectKey(namespace, ref.Name), configMap); err != nil {
+		return nil, fmt.Errorf("failed to read ConfigMap %s: %w", ref.Name, err)
+	}
+	data, ok := configMap.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("ConfigMap %s doesn't contain the key %s", ref.Name, ref.Key)
+	}
+
+	var result []project.IPAccessList
+	values := strings.FieldsFunc(data, func(r rune) bool { return unicode.IsSpace(r) || r == ',' })
+	for _, value := range values {
+		entry := sourcedIPAccessList(value, "configmap "+ref.Name)
+		if strings.Contains(value, "/") {
+			entry.IPAddress = ""
+			entry.CIDRBlock = value
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+func sourcedIPAccessList(ip, source string) project.IPAccessList {
+	return project.NewIPAccessList().
+		WithIP(ip).
+		WithComment(fmt.Sprintf("%s: %s", ipAccessListSourceComment, source))
+}
+
+// ipAccessListSourceWatches returns the objects the IP Access List sources depend on by their kinds. The Nodes and the
+// Services are selected by labels so they are watched by the empty names.
+func ipAccessListSourceWatches(atlasProject *mdbv1.AtlasProject) map[string][]types.NamespacedName {
+	result := map[string][]types.NamespacedName{"ConfigMap": nil, "Node": nil, "Service": nil}
+	for _, source := range atlasProject.Spec.IPAccessListSources {
+		switch {
+		case source.Nodes != nil:
+			result["Node"] = []types.NamespacedName{kube.ObjectKey("", "")}
+		case source.Services != nil:
+			result["Service"] = []types.NamespacedName{kube.ObjectKey(atlasProject.Namespace, "")}
+		case source.ConfigMap != nil:
+			result["ConfigMap"] = append(result["ConfigMap"], kube.ObjectKey(atlasProject.Namespace, source.ConfigMap.Name))
+		}
+	}
+	return result
+}
+
+func (r *AtlasProjectReconciler) ensureIPAccessListSourcesAreWatched(atlasProject *mdbv1.AtlasProject, log *zap.SugaredLogger) {
+	for kind, keys := range ipAccessListSourceWatches(atlasProject) {
+		r.EnsureResourcesAreWatched(kube.ObjectKeyFromObject(atlasProject), kind, log, keys...)
+	}
+}
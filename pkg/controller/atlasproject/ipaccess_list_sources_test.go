@@ -0,0 +1,80 @@
+package atlasproject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+)
+
+func TestResolveIPAccessListSources(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"pool": "egress"}},
+		Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+			{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+			{Type: corev1.NodeExternalIP, Address: "34.1.1.1"},
+		}},
+	}
+	otherNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-2"},
+		Status:     corev1.NodeStatus{Addresses: []corev1.NodeAddress{{Type: corev1.NodeExternalIP, Address: "34.2.2.2"}}},
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "gateway", Labels: map[string]string{"app": "gateway"}},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{
+			{IP: "35.1.1.1"},
+			{Hostname: "gateway.example.com"},
+		}}},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "egress"},
+		Data:       map[string]string{"ips": "34.1.1.1, 192.168.0.0/24\n36.1.1.1"},
+	}
+	k8sClient := fake.NewClientBuilder().WithObjects(node, otherNode, service, configMap).Build()
+
+	atlasProject := mdbv1.NewProject("ns", "project", "project").
+		WithIPAccessList(project.NewIPAccessList().WithIP("36.1.1.1"))
+	atlasProject.Spec.IPAccessListSources = []project.IPAccessListSource{
+		{Nodes: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "egress"}}},
+		{Services: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "gateway"}}},
+		{ConfigMap: &project.ConfigMapKeyRef{Name: "egress", Key: "ips"}},
+	}
+
+	t.Run("Entries are collected from all sources", func(t *testing.T) {
+		entries, err := resolveIPAccessListSources(k8sClient, atlasProject)
+		assert.NoError(t, err)
+		assert.Equal(t, []project.IPAccessList{
+			{IPAddress: "34.1.1.1", Comment: ipAccessListSourceComment + ": node node-1"},
+			{IPAddress: "35.1.1.1", Comment: ipAccessListSourceComment + ": service gateway"},
+			{CIDRBlock: "192.168.0.0/24", Comment: ipAccessListSourceComment + ": configmap egress"},
+		}, entries)
+	})
+	t.Run("Missing ConfigMap key", func(t *testing.T) {
+		broken := atlasProject.DeepCopy()
+		broken.Spec.IPAccessListSources = []project.IPAccessListSource{{ConfigMap: &project.ConfigMapKeyRef{Name: "egress", Key: "other"}}}
+		_, err := resolveIPAccessListSources(k8sClient, broken)
+		assert.Error(t, err)
+	})
+}
+
+func TestIPAccessListSourceWatches(t *testing.T) {
+	atlasProject := mdbv1.NewProject("ns", "project", "project")
+	atlasProject.Spec.IPAccessListSources = []project.IPAccessListSource{
+		{Services: &metav1.LabelSelector{}},
+		{ConfigMap: &project.ConfigMapKeyRef{Name: "egress", Key: "ips"}},
+	}
+
+	assert.Equal(t, map[string][]types.NamespacedName{
+		"ConfigMap": {kube.ObjectKey("ns", "egress")},
+		"Node":      nil,
+		"Service":   {kube.ObjectKey("ns", "")},
+	}, ipAccessListSourceWatches(atlasProject))
+}
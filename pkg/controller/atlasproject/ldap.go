@@ -0,0 +1,245 @@
+package atlasproject
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.mongodb.org/atlas/mongodbatlas"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/stringutil"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/timeutil"
+)
+
+// ldapUnreachableValidations are the LDAP validations failing when the LDAP server can't be reached, for example
+// during an outage. The verifications failed by them are requested again after a backoff, the other failures mean
+// the configuration is invalid and are only verified again once it changes.
+var ldapUnreachableValidations = []string{"CONNECT", "QUERY_SERVER"}
+
+const (
+	ldapVerificationMinBackoff = 5 * time.Minute
+	ldapVerificationMaxBackoff = time.Hour
+)
+
+func (r *AtlasProjectReconciler) ensureLDAPConfiguration(ctx *workflow.Context, projectID string, project *mdbv1.AtlasProject) workflow.Result {
+	result := r.syncLDAPConfiguration(ctx, projectID, project, time.Now())
+	if !result.IsOk() {
+		ctx.SetConditionFromResult(status.LDAPReadyType, result)
+		return result
+	}
+
+	if project.Spec.LDAP == nil {
+		ctx.UnsetCondition(status.LDAPReadyType)
+		return workflow.OK()
+	}
+
+	ctx.SetConditionTrue(status.LDAPReadyType)
+	return workflow.OK()
+}
+
+// syncLDAPConfiguration verifies the LDAP configuration each time the connection settings change and saves it only
+// after Atlas has confirmed that the LDAP server can be reached with them. The verifications failed because the LDAP
+// server couldn't be reached are requested again after a backoff.
+func (r *AtlasProjectReconciler) syncLDAPConfiguration(ctx *workflow.Context, projectID string, project *mdbv1.AtlasProject, now time.Time) workflow.Result {
+	spec := project.Spec.LDAP
+	if spec == nil {
+		// The operator removes only the LDAP configuration it has created
+		if project.Status.LDAP != nil {
+			ctx.Log.Infow("Removing LDAP configuration", "projectID", projectID)
+			if _, _, err := ctx.Client.LDAPConfigurations.Delete(context.Background(), projectID); err != nil {
+				return workflow.Terminate(workflow.ProjectLDAPNotConfigured, err.Error())
+			}
+			ctx.EnsureStatusOption(status.AtlasProjectLDAPOption(nil))
+		}
+		return workflow.OK()
+	}
+
+	bindPassword, passwordVersion, err := readLDAPSecret(r.Client, spec.BindPasswordSecretObjectKey(project.Namespace), "password")
+	if err != nil {
+		return workflow.Terminate(workflow.ProjectLDAPNotConfigured, err.Error())
+	}
+	var caCertificate, caVersion string
+	if key := spec.CACertificateSecretObjectKey(project.Namespace); key != nil {
+		if caCertificate, err = readX509CertFromSecret(r.Client, *key, ctx.Log); err != nil {
+			return workflow.Terminate(workflow.ProjectLDAPNotConfigured, err.Error())
+		}
+		if _, caVersion, err = readLDAPSecret(r.Client, *key, ""); err != nil {
+			return workflow.Terminate(workflow.ProjectLDAPNotConfigured, err.Error())
+		}
+	}
+	configVersion := fmt.Sprintf("%s:%d/%s/%s/%s", spec.Hostname, spec.Port, spec.BindUsername, passwordVersion, caVersion)
+	desired := spec.ToAtlas(bindPassword, caCertificate)
+
+	ldapStatus := project.Status.LDAP
+	if ldapStatus == nil || ldapStatus.ConfigVersion != configVersion || ldapVerificationRetryDue(ldapStatus, now) {
+		ctx.Log.Infow("Requesting LDAP configuration verification", "projectID", projectID, "hostname", spec.Hostname)
+		verification, _, err := ctx.Client.LDAPConfigurations.Verify(context.Background(), projectID, desired)
+		if err != nil {
+			return workflow.Terminate(workflow.ProjectLDAPVerificationFailed, err.Error())
+		}
+		failedAttempts := 0
+		if ldapStatus != nil && ldapStatus.ConfigVersion == configVersion {
+			failedAttempts = ldapStatus.FailedAttempts
+		}
+		ctx.EnsureStatusOption(status.AtlasProjectLDAPOption(&status.LDAPStatus{
+			RequestID:          verification.RequestID,
+			VerificationStatus: verification.Status,
+			ConfigVersion:      configVersion,
+			FailedAttempts:     failedAttempts,
+		}))
+		return workflow.InProgress(workflow.ProjectLDAPVerificationPending, "LDAP configuration is being verified by Atlas")
+	}
+
+	if ldapStatus.VerificationStatus == status.LDAPVerificationFailed {
+		// The failure is already recorded, the configuration is verified again once it changes or the backoff is over
+		return ldapVerificationFailed(ldapStatus, now)
+	}
+
+	if ldapStatus.VerificationStatus != status.LDAPVerificationSuccess {
+		verification, _, err := ctx.Client.LDAPConfigurations.GetStatus(context.Background(), projectID, ldapStatus.RequestID)
+		if err != nil {
+			return workflow.Terminate(workflow.ProjectLDAPVerificationFailed, err.Error())
+		}
+		updated := &status.LDAPStatus{
+			RequestID:          ldapStatus.RequestID,
+			VerificationStatus: verification.Status,
+			FailedValidations:  failedLDAPValidations(verification.Validations),
+			ConfigVersion:      configVersion,
+			FailedAttempts:     ldapStatus.FailedAttempts,
+		}
+		if verification.Status == status.LDAPVerificationFailed {
+			updated.FailedAt = timeutil.FormatISO8601(now.UTC())
+			updated.FailedAttempts++
+		}
+		ctx.EnsureStatusOption(status.AtlasProjectLDAPOption(updated))
+
+		switch verification.Status {
+		case status.LDAPVerificationSuccess:
+			// The configuration gets saved below as it has been verified for the first time
+		case status.LDAPVerificationFailed:
+			return ldapVerificationFailed(updated, now)
+		default:
+			return workflow.InProgress(workflow.ProjectLDAPVerificationPending, "LDAP configuration is being verified by Atlas")
+		}
+	} else {
+		current, _, err := ctx.Client.LDAPConfigurations.Get(context.Background(), projectID)
+		if err != nil {
+			return workflow.Terminate(workflow.ProjectLDAPNotConfigured, err.Error())
+		}
+		if ldapInSync(current.LDAP, desired) {
+			return workflow.OK()
+		}
+	}
+
+	ctx.Log.Infow("Saving LDAP configuration", "projectID", projectID, "hostname", spec.Hostname)
+	if _, _, err = ctx.Client.LDAPConfigurations.Save(context.Background(), projectID, &mongodbatlas.LDAPConfiguration{LDAP: desired}); err != nil {
+		return workflow.Terminate(workflow.ProjectLDAPNotConfigured, err.Error())
+	}
+
+	return workflow.OK()
+}
+
+// ldapInSync compares the LDAP configurations ignoring the bind password and CA certificate which Atlas doesn't
+// return back. The changes to those are detected by the 'ResourceVersion' of the Secrets instead.
+func ldapInSync(current, desired *mongodbatlas.LDAP) bool {
+	if current == nil {
+		return false
+	}
+	return cmp.Equal(current, desired, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(mongodbatlas.LDAP{}, "BindPassword", "CaCertificate"))
+}
+
+// ldapVerificationFailed returns the result of the failed verification which is retried once the backoff is over if
+// the LDAP server couldn't be reached
+func ldapVerificationFailed(ldapStatus *status.LDAPStatus, now time.Time) workflow.Result {
+	message := fmt.Sprintf("LDAP configuration verification failed: %s", strings.Join(ldapStatus.FailedValidations, ", "))
+	retryAt := ldapVerificationRetryAt(ldapStatus)
+	if retryAt.IsZero() {
+		return workflow.Terminate(workflow.ProjectLDAPVerificationFailed, message)
+	}
+	retry := retryAt.Sub(now)
+	if retry < workflow.DefaultRetry {
+		retry = workflow.DefaultRetry
+	}
+	return workflow.Terminate(workflow.ProjectLDAPVerificationFailed,
+		fmt.Sprintf("%s. The LDAP server couldn't be reached, verifying again in %s", message, retry.Truncate(time.Second))).WithRetry(retry)
+}
+
+// ldapVerificationRetryAt returns the time the failed verification is requested again at or the zero time if it's not
+// retried. The backoff doubles with each failed attempt.
+func ldapVerificationRetryAt(ldapStatus *status.LDAPStatus) time.Time {
+	if ldapStatus.VerificationStatus != status.LDAPVerificationFailed || !ldapServerUnreachable(ldapStatus.FailedValidations) {
+		return time.Time{}
+	}
+	failedAt, err := timeutil.ParseISO8601(ldapStatus.FailedAt)
+	if err != nil {
+		// The failures recorded without the time are retried right away
+		failedAt = time.Time{}
+	}
+	backoff := ldapVerificationMinBackoff
+	for i := 1; i < ldapStatus.FailedAttempts && backoff < ldapVerificationMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > ldapVerificationMaxBackoff {
+		backoff = ldapVerificationMaxBackoff
+	}
+	return failedAt.Add(backoff)
+}
+
+func ldapVerificationRetryDue(ldapStatus *status.LDAPStatus, now time.Time) bool {
+	retryAt := ldapVerificationRetryAt(ldapStatus)
+	return !retryAt.IsZero() && !now.Before(retryAt)
+}
+
+func ldapServerUnreachable(failedValidations []string) bool {
+	for _, validation := range failedValidations {
+		if stringutil.Contains(ldapUnreachableValidations, validation) {
+			return true
+		}
+	}
+	return false
+}
+
+func failedLDAPValidations(validations []*mongodbatlas.LDAPValidation) []string {
+	var failed []string
+	for _, validation := range validations {
+		if validation != nil && validation.Status == status.LDAPVerificationFailed {
+			failed = append(failed, validation.ValidationType)
+		}
+	}
+	return failed
+}
+
+// readLDAPSecret returns the value of the field (if specified) and the 'ResourceVersion' of the Secret
+func readLDAPSecret(kubeClient client.Client, key client.ObjectKey, field string) (string, string, error) {
+	secret := &corev1.Secret{}
+	if err := kubeClient.Get(context.Background(), key, secret); err != nil {
+		return "", "", err
+	}
+	if field == "" {
+		return "", secret.ResourceVersion, nil
+	}
+	value, exist := secret.Data[field]
+	if !exist || len(value) == 0 {
+		return "", "", fmt.Errorf("secret %s is invalid: the '%s' field is missing or empty", secret.Name, field)
+	}
+	return string(value), secret.ResourceVersion, nil
+}
+
+func ldapSecretKeys(project *mdbv1.AtlasProject) []client.ObjectKey {
+	if project.Spec.LDAP == nil {
+		return nil
+	}
+	keys := []client.ObjectKey{project.Spec.LDAP.BindPasswordSecretObjectKey(project.Namespace)}
+	if key := project.Spec.LDAP.CACertificateSecretObjectKey(project.Namespace); key != nil {
+		keys = append(keys, *key)
+	}
+	return keys
+}
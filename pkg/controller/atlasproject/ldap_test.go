@@ -0,0 +1,192 @@
+package atlasproject
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/timeutil"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+func TestLDAPInSync(t *testing.T) {
+	spec := &v1.LDAPConfiguration{
+		AuthenticationEnabled: true,
+		Hostname:              "ldap.example.com",
+		Port:                  636,
+		BindUsername:          "CN=bind,DC=example,DC=com",
+		BindPasswordSecretRef: common.ResourceRefNamespaced{Name: "ldap-bind"},
+		UserToDNMapping:       []v1.LDAPUserToDNMapping{{Match: "(.+)", Substitution: "CN={0},DC=example,DC=com"}},
+	}
+	desired := spec.ToAtlas("secret", "-----BEGIN CERTIFICATE-----")
+
+	t.Run("Nothing configured in Atlas", func(t *testing.T) {
+		assert.False(t, ldapInSync(nil, desired))
+	})
+	t.Run("Bind password and CA certificate are not compared", func(t *testing.T) {
+		current := spec.ToAtlas("", "")
+		assert.True(t, ldapInSync(current, desired))
+	})
+	t.Run("Changed mapping", func(t *testing.T) {
+		current := spec.ToAtlas("", "")
+		current.UserToDNMapping[0].Substitution = "CN={0},DC=example,DC=org"
+		assert.False(t, ldapInSync(current, desired))
+	})
+	t.Run("Authorization enabled in Atlas", func(t *testing.T) {
+		current := spec.ToAtlas("", "")
+		current.AuthorizationEnabled = toptr.MakePtr(true)
+		assert.False(t, ldapInSync(current, desired))
+	})
+}
+
+func TestFailedLDAPValidations(t *testing.T) {
+	validations := []*mongodbatlas.LDAPValidation{
+		{ValidationType: "CONNECT", Status: "OK"},
+		{ValidationType: "AUTHENTICATE", Status: "FAILED"},
+		{ValidationType: "PARSE_AUTHZ_QUERY", Status: "FAILED"},
+	}
+	assert.Equal(t, []string{"AUTHENTICATE", "PARSE_AUTHZ_QUERY"}, failedLDAPValidations(validations))
+	assert.Empty(t, failedLDAPValidations(nil))
+}
+
+func TestReadLDAPSecret(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap-bind", Namespace: "ns"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+	k8sClient := fake.NewClientBuilder().WithObjects(secret).Build()
+
+	t.Run("Field is read together with the version", func(t *testing.T) {
+		value, version, err := readLDAPSecret(k8sClient, kube.ObjectKey("ns", "ldap-bind"), "password")
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", value)
+		assert.NotEmpty(t, version)
+	})
+	t.Run("Missing field", func(t *testing.T) {
+		_, _, err := readLDAPSecret(k8sClient, kube.ObjectKey("ns", "ldap-bind"), "token")
+		assert.Error(t, err)
+	})
+	t.Run("Missing Secret", func(t *testing.T) {
+		_, _, err := readLDAPSecret(k8sClient, kube.ObjectKey("ns", "other"), "password")
+		assert.Error(t, err)
+	})
+}
+
+func TestLDAPVerificationRetryAt(t *testing.T) {
+	failedAt := time.Date(2023, 6, 9, 12, 0, 0, 0, time.UTC)
+	failed := func(attempts int, validations ...string) *status.LDAPStatus {
+		return &status.LDAPStatus{
+			VerificationStatus: status.LDAPVerificationFailed,
+			FailedValidations:  validations,
+			FailedAt:           timeutil.FormatISO8601(failedAt),
+			FailedAttempts:     attempts,
+		}
+	}
+
+	t.Run("Unreachable server is verified again after the backoff", func(t *testing.T) {
+		assert.Equal(t, failedAt.Add(5*time.Minute), ldapVerificationRetryAt(failed(1, "CONNECT", "AUTHENTICATE")))
+		assert.Equal(t, failedAt.Add(20*time.Minute), ldapVerificationRetryAt(failed(3, "QUERY_SERVER")))
+		assert.Equal(t, failedAt.Add(time.Hour), ldapVerificationRetryAt(failed(10, "CONNECT")))
+	})
+	t.Run("Invalid configuration isn't verified again", func(t *testing.T) {
+		assert.True(t, ldapVerificationRetryAt(failed(1, "AUTHENTICATE", "PARSE_AUTHZ_QUERY")).IsZero())
+	})
+	t.Run("Failure recorded without the time is verified again right away", func(t *testing.T) {
+		ldapStatus := failed(0, "CONNECT")
+		ldapStatus.FailedAt = ""
+		assert.True(t, ldapVerificationRetryDue(ldapStatus, failedAt))
+	})
+	t.Run("Successful verification isn't retried", func(t *testing.T) {
+		assert.True(t, ldapVerificationRetryAt(&status.LDAPStatus{VerificationStatus: status.LDAPVerificationSuccess}).IsZero())
+	})
+}
+
+// ldapConfigurationsStub fails the verifications with the given validations and records the verifications requested
+type ldapConfigurationsStub struct {
+	mongodbatlas.LDAPConfigurationsService
+
+	failedValidation string
+	verified         int
+}
+
+func (s *ldapConfigurationsStub) Verify(context.Context, string, *mongodbatlas.LDAP) (*mongodbatlas.LDAPConfiguration, *mongodbatlas.Response, error) {
+	s.verified++
+	return &mongodbatlas.LDAPConfiguration{RequestID: "request-id", Status: status.LDAPVerificationPending}, nil, nil
+}
+
+func (s *ldapConfigurationsStub) GetStatus(context.Context, string, string) (*mongodbatlas.LDAPConfiguration, *mongodbatlas.Response, error) {
+	return &mongodbatlas.LDAPConfiguration{
+		RequestID:   "request-id",
+		Status:      status.LDAPVerificationFailed,
+		Validations: []*mongodbatlas.LDAPValidation{{ValidationType: s.failedValidation, Status: status.LDAPVerificationFailed}},
+	}, nil, nil
+}
+
+func TestSyncLDAPConfigurationRetriesFailedVerification(t *testing.T) {
+	now := time.Date(2023, 6, 9, 12, 0, 0, 0, time.UTC)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap-bind", Namespace: "ns"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+	reconciler := &AtlasProjectReconciler{Client: fake.NewClientBuilder().WithObjects(secret).Build()}
+
+	// sync runs the reconciliation at the given time and applies the status changes to the project
+	sync := func(project *v1.AtlasProject, stub *ldapConfigurationsStub, now time.Time) workflow.Result {
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		ctx.Client = mongodbatlas.Client{LDAPConfigurations: stub}
+		result := reconciler.syncLDAPConfiguration(ctx, "project-id", project, now)
+		for _, option := range ctx.StatusOptions() {
+			option.(status.AtlasProjectStatusOption)(&project.Status)
+		}
+		return result
+	}
+	// failedProject returns the project with the failed verification recorded
+	failedProject := func(t *testing.T, stub *ldapConfigurationsStub) *v1.AtlasProject {
+		project := v1.DefaultProject("ns", "")
+		project.Spec.LDAP = &v1.LDAPConfiguration{
+			Hostname:              "ldap.example.com",
+			Port:                  636,
+			BindUsername:          "CN=bind,DC=example,DC=com",
+			BindPasswordSecretRef: common.ResourceRefNamespaced{Name: "ldap-bind"},
+		}
+		assert.Equal(t, workflow.ProjectLDAPVerificationPending, sync(project, stub, now).GetReason())
+		assert.Equal(t, workflow.ProjectLDAPVerificationFailed, sync(project, stub, now).GetReason())
+		require.NotNil(t, project.Status.LDAP)
+		assert.Equal(t, 1, project.Status.LDAP.FailedAttempts)
+		return project
+	}
+
+	t.Run("Unreachable server is verified again after the backoff", func(t *testing.T) {
+		stub := &ldapConfigurationsStub{failedValidation: "CONNECT"}
+		project := failedProject(t, stub)
+
+		result := sync(project, stub, now.Add(time.Minute))
+		assert.Equal(t, workflow.ProjectLDAPVerificationFailed, result.GetReason())
+		assert.Equal(t, 4*time.Minute, result.ReconcileResult().RequeueAfter)
+		assert.Equal(t, 1, stub.verified)
+
+		assert.Equal(t, workflow.ProjectLDAPVerificationPending, sync(project, stub, now.Add(5*time.Minute)).GetReason())
+		assert.Equal(t, 2, stub.verified)
+		assert.Equal(t, 1, project.Status.LDAP.FailedAttempts)
+	})
+	t.Run("Invalid configuration isn't verified again", func(t *testing.T) {
+		stub := &ldapConfigurationsStub{failedValidation: "AUTHENTICATE"}
+		project := failedProject(t, stub)
+
+		assert.Equal(t, workflow.ProjectLDAPVerificationFailed, sync(project, stub, now.Add(2*time.Hour)).GetReason())
+		assert.Equal(t, 1, stub.verified)
+	})
+}
@@ -8,12 +8,14 @@ import (
 
 	"go.mongodb.org/atlas/mongodbatlas"
 	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
 )
 
 const (
@@ -29,12 +31,19 @@ type networkPeerDiff struct {
 	PeersToUpdate []mongodbatlas.Peer
 }
 
-func ensureNetworkPeers(ctx *workflow.Context, groupID string, project *mdbv1.AtlasProject) workflow.Result {
+func (r *AtlasProjectReconciler) ensureNetworkPeers(ctx *workflow.Context, groupID string, project *mdbv1.AtlasProject) workflow.Result {
 	networkPeerStatus := project.Status.DeepCopy().NetworkPeers
 	networkPeerSpec := project.Spec.DeepCopy().NetworkPeers
 
+	managed, err := networkResourcesManagedByResources(r.Client, project)
+	if err != nil {
+		result := workflow.Terminate(workflow.Internal, err.Error())
+		ctx.SetConditionFromResult(status.NetworkPeerReadyType, result)
+		return result
+	}
+
 	backgroundContext := context.Background()
-	result, condition := SyncNetworkPeer(backgroundContext, ctx, groupID, networkPeerStatus, networkPeerSpec)
+	result, condition := SyncNetworkPeer(backgroundContext, ctx, groupID, networkPeerStatus, networkPeerSpec, managed)
 	if !result.IsOk() {
 		ctx.SetConditionFromResult(condition, result)
 		return result
@@ -65,7 +74,7 @@ func failedPeerStatus(errMessage string, peer mdbv1.NetworkPeer) status.AtlasNet
 	}
 }
 
-func SyncNetworkPeer(context context.Context, ctx *workflow.Context, groupID string, peerStatuses []status.AtlasNetworkPeer, peerSpecs []mdbv1.NetworkPeer) (workflow.Result, status.ConditionType) {
+func SyncNetworkPeer(context context.Context, ctx *workflow.Context, groupID string, peerStatuses []status.AtlasNetworkPeer, peerSpecs []mdbv1.NetworkPeer, managed managedNetworkResources) (workflow.Result, status.ConditionType) {
 	defer ctx.EnsureStatusOption(status.AtlasProjectSetNetworkPeerOption(&peerStatuses))
 	logger := ctx.Log
 	mongoClient := ctx.Client
@@ -76,6 +85,13 @@ func SyncNetworkPeer(context context.Context, ctx *workflow.Context, groupID str
 		return workflow.Terminate(workflow.ProjectNetworkPeerIsNotReadyInAtlas, "failed to get all network peers"),
 			status.NetworkPeerReadyType
 	}
+	list, err = managed.withoutPeers(list, mongoClient.Containers, groupID)
+	if err != nil {
+		// the peers which might be managed by the AtlasNetworkPeering resources are never deleted
+		logger.Errorf("failed to match network peers with AtlasNetworkPeering resources: %v", err)
+		return workflow.Terminate(workflow.ProjectNetworkPeerIsNotReadyInAtlas, err.Error()),
+			status.NetworkPeerReadyType
+	}
 
 	diff, err := sortPeers(list, peerSpecs, logger, mongoClient.Containers, groupID)
 	if err != nil {
@@ -102,7 +118,7 @@ func SyncNetworkPeer(context context.Context, ctx *workflow.Context, groupID str
 		return workflow.Terminate(workflow.ProjectNetworkPeerIsNotReadyInAtlas,
 			"failed to update network peer statuses"), status.NetworkPeerReadyType
 	}
-	err = deleteUnusedContainers(context, mongoClient.Containers, groupID, getPeerIDs(peerStatuses))
+	err = deleteUnusedContainers(context, mongoClient.Containers, groupID, getPeerIDs(peerStatuses), managed)
 	if err != nil {
 		logger.Errorf("failed to delete unused containers: %v", err)
 		return workflow.Terminate(workflow.ProjectNetworkPeerIsNotReadyInAtlas,
@@ -139,13 +155,13 @@ func getPeerIDs(statuses []status.AtlasNetworkPeer) []string {
 	return ids
 }
 
-func deleteUnusedContainers(context context.Context, containerService mongodbatlas.ContainersService, groupID string, doNotDelete []string) error {
+func deleteUnusedContainers(context context.Context, containerService mongodbatlas.ContainersService, groupID string, doNotDelete []string, managed managedNetworkResources) error {
 	containers, _, err := containerService.List(context, groupID, nil)
 	if err != nil {
 		return err
 	}
 	for _, container := range containers {
-		if !util.Contains(doNotDelete, container.ID) {
+		if !util.Contains(doNotDelete, container.ID) && !managed.ownsContainer(container) {
 			response, errDelete := containerService.Delete(context, groupID, container.ID)
 			if errDelete != nil && response.StatusCode != http.StatusConflict { // AWS peer does not contain container id
 				return errDelete
@@ -366,6 +382,13 @@ func isPeerDeleting(peer mongodbatlas.Peer) bool {
 }
 
 func comparePeersPair(existedPeer mongodbatlas.Peer, expectedPeer mdbv1.NetworkPeer, containerService mongodbatlas.ContainersService, groupID string) bool {
+	matches, _ := matchPeersPair(existedPeer, expectedPeer, containerService, groupID)
+	return matches
+}
+
+// matchPeersPair compares the peers as comparePeersPair does but reports the failure to read the container of the
+// existing peer instead of treating the peers as different
+func matchPeersPair(existedPeer mongodbatlas.Peer, expectedPeer mdbv1.NetworkPeer, containerService mongodbatlas.ContainersService, groupID string) (bool, error) {
 	if expectedPeer.ProviderName == "" {
 		expectedPeer.ProviderName = provider.ProviderAWS
 	}
@@ -380,7 +403,7 @@ func comparePeersPair(existedPeer mongodbatlas.Peer, expectedPeer mdbv1.NetworkP
 
 	if expectedPeer.ContainerID != "" {
 		if existedPeer.ContainerID != expectedPeer.ContainerID {
-			return false
+			return false, nil
 		}
 	}
 
@@ -389,12 +412,12 @@ func comparePeersPair(existedPeer mongodbatlas.Peer, expectedPeer mdbv1.NetworkP
 			// existed peer doesn't contain AtlasCIDRBlock. so we have to get it by containerID
 			get, _, err := containerService.Get(context.Background(), groupID, existedPeer.ContainerID)
 			if err != nil {
-				return false
+				return false, fmt.Errorf("failed to get the container %s of the peer %s: %w", existedPeer.ContainerID, existedPeer.ID, err)
 			}
 			existedPeer.AtlasCIDRBlock = get.AtlasCIDRBlock
 		}
 		if existedPeer.AtlasCIDRBlock != expectedPeer.AtlasCIDRBlock {
-			return false
+			return false, nil
 		}
 	}
 
@@ -403,26 +426,26 @@ func comparePeersPair(existedPeer mongodbatlas.Peer, expectedPeer mdbv1.NetworkP
 		if existedPeer.VpcID == expectedPeer.VpcID &&
 			expectedPeer.AWSAccountID == existedPeer.AWSAccountID &&
 			expectedPeer.RouteTableCIDRBlock == existedPeer.RouteTableCIDRBlock {
-			return true
+			return true, nil
 		}
-		return false
+		return false, nil
 	case provider.ProviderGCP:
 		if existedPeer.GCPProjectID == expectedPeer.GCPProjectID &&
 			existedPeer.NetworkName == expectedPeer.NetworkName {
-			return true
+			return true, nil
 		}
-		return false
+		return false, nil
 	case provider.ProviderAzure:
 
 		if existedPeer.AzureSubscriptionID == expectedPeer.AzureSubscriptionID &&
 			existedPeer.AzureDirectoryID == expectedPeer.AzureDirectoryID &&
 			existedPeer.ResourceGroupName == expectedPeer.ResourceGroupName &&
 			existedPeer.VNetName == expectedPeer.VNetName {
-			return true
+			return true, nil
 		}
-		return false
+		return false, nil
 	default:
-		return false
+		return false, nil
 	}
 }
 
@@ -587,3 +610,84 @@ func deleteAllNetworkPeers(ctx context.Context, groupID string, service mongodba
 	}
 	return nil
 }
+
+// managedNetworkResources are the AtlasNetworkContainer and AtlasNetworkPeering resources referencing the project.
+// The project neither deletes the containers and the peering connections they manage nor reports their state.
+type managedNetworkResources struct {
+	containers []mdbv1.AtlasNetworkContainer
+	peerings   []mdbv1.AtlasNetworkPeering
+}
+
+func networkResourcesManagedByResources(kubeClient client.Client, project *mdbv1.AtlasProject) (managedNetworkResources, error) {
+	var result managedNetworkResources
+	projectKey := kube.ObjectKeyFromObject(project)
+
+	containers := &mdbv1.AtlasNetworkContainerList{}
+	if err := kubeClient.List(context.Background(), containers); err != nil {
+		return result, fmt.Errorf("failed to list AtlasNetworkContainer resources: %w", err)
+	}
+	for _, container := range containers.Items {
+		if container.AtlasProjectObjectKey() == projectKey {
+			result.containers = append(result.containers, container)
+		}
+	}
+
+	peerings := &mdbv1.AtlasNetworkPeeringList{}
+	if err := kubeClient.List(context.Background(), peerings); err != nil {
+		return result, fmt.Errorf("failed to list AtlasNetworkPeering resources: %w", err)
+	}
+	for _, peering := range peerings.Items {
+		if peering.AtlasProjectObjectKey() == projectKey {
+			result.peerings = append(result.peerings, peering)
+		}
+	}
+	return result, nil
+}
+
+// ownsContainer checks if the container is managed by an AtlasNetworkContainer or used by an AtlasNetworkPeering.
+// The containers are also matched by the CIDR block as the resource might not have published the ID yet.
+func (m managedNetworkResources) ownsContainer(container mongodbatlas.Container) bool {
+	for _, networkContainer := range m.containers {
+		if networkContainer.Status.ID == container.ID ||
+			(string(networkContainer.Spec.Provider) == container.ProviderName && networkContainer.Spec.AtlasCIDRBlock == container.AtlasCIDRBlock) {
+			return true
+		}
+	}
+	for _, peering := range m.peerings {
+		if peering.Spec.ContainerID == container.ID || peering.Status.ContainerID == container.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutPeers filters out the peering connections managed by the AtlasNetworkPeering resources. The connections are
+// also matched by the network as the resource might not have published the ID yet.
+func (m managedNetworkResources) withoutPeers(peers []mongodbatlas.Peer, containerService mongodbatlas.ContainersService, groupID string) ([]mongodbatlas.Peer, error) {
+	result := make([]mongodbatlas.Peer, 0, len(peers))
+	for _, peer := range peers {
+		owned := false
+		for _, peering := range m.peerings {
+			if peering.Status.ID == peer.ID {
+				owned = true
+				break
+			}
+			containerID := peering.Spec.ContainerID
+			if containerID == "" {
+				containerID = peering.Status.ContainerID
+			}
+			matches, err := matchPeersPair(peer, peering.NetworkPeer(containerID), containerService, groupID)
+			if err != nil {
+				return nil, err
+			}
+			if matches {
+				owned = true
+				break
+			}
+		}
+		if !owned {
+			result = append(result, peer)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,88 @@
+package atlasproject
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
+)
+
+func TestNetworkResourcesManagedByResources(t *testing.T) {
+	atlasProject := mdbv1.NewProject("ns", "project", "project")
+
+	created := mdbv1.NewNetworkContainer("ns", "created", "project", provider.ProviderAWS, "us-east-1", "10.8.0.0/21")
+	created.Status.ID = "created-container"
+	pending := mdbv1.NewNetworkContainer("ns", "pending", "project", provider.ProviderAWS, "eu-west-1", "10.9.0.0/21")
+	otherProject := mdbv1.NewNetworkContainer("ns", "other-project", "other", provider.ProviderAWS, "us-east-1", "10.10.0.0/21")
+	otherProject.Status.ID = "other-container"
+
+	peering := mdbv1.NewNetworkPeering("ns", "peering", "project", provider.ProviderAWS).
+		WithContainerID("unmanaged-container").
+		WithAWSVpc("123456789012", "us-east-1", "vpc-1", "10.0.0.0/16")
+	peering.Status.ID = "peering"
+	pendingPeering := mdbv1.NewNetworkPeering("ns", "pending-peering", "project", provider.ProviderAWS).
+		WithContainerRef("created").
+		WithAWSVpc("123456789012", "us-east-1", "vpc-2", "10.1.0.0/16")
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(mdbv1.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(created, pending, otherProject, peering, pendingPeering).Build()
+
+	managed, err := networkResourcesManagedByResources(k8sClient, atlasProject)
+	require.NoError(t, err)
+
+	t.Run("Containers", func(t *testing.T) {
+		assert.True(t, managed.ownsContainer(mongodbatlas.Container{ID: "created-container", ProviderName: "AWS", AtlasCIDRBlock: "10.8.0.0/21"}))
+		assert.True(t, managed.ownsContainer(mongodbatlas.Container{ID: "pending-container", ProviderName: "AWS", AtlasCIDRBlock: "10.9.0.0/21"}))
+		assert.True(t, managed.ownsContainer(mongodbatlas.Container{ID: "unmanaged-container", ProviderName: "AWS", AtlasCIDRBlock: "10.11.0.0/21"}))
+		assert.False(t, managed.ownsContainer(mongodbatlas.Container{ID: "other-container", ProviderName: "AWS", AtlasCIDRBlock: "10.10.0.0/21"}))
+	})
+	t.Run("Peers", func(t *testing.T) {
+		peers := []mongodbatlas.Peer{
+			{ID: "peering", ContainerID: "unmanaged-container", AWSAccountID: "123456789012", VpcID: "vpc-1", RouteTableCIDRBlock: "10.0.0.0/16"},
+			{ID: "pending-peering", ContainerID: "created-container", AWSAccountID: "123456789012", VpcID: "vpc-2", RouteTableCIDRBlock: "10.1.0.0/16"},
+			{ID: "project-peer", ContainerID: "created-container", AWSAccountID: "123456789012", VpcID: "vpc-3", RouteTableCIDRBlock: "10.2.0.0/16"},
+		}
+		unmanaged, err := managed.withoutPeers(peers, nil, "project-id")
+		require.NoError(t, err)
+		assert.Equal(t, []mongodbatlas.Peer{peers[2]}, unmanaged)
+	})
+}
+
+// failingContainersStub fails to read the containers
+type failingContainersStub struct {
+	mongodbatlas.ContainersService
+}
+
+func (s *failingContainersStub) Get(context.Context, string, string) (*mongodbatlas.Container, *mongodbatlas.Response, error) {
+	return nil, nil, errors.New("unavailable")
+}
+
+func TestMatchPeersPair(t *testing.T) {
+	peer := mongodbatlas.Peer{ID: "peer", ContainerID: "container", AWSAccountID: "123456789012", VpcID: "vpc-1", RouteTableCIDRBlock: "10.0.0.0/16"}
+	expected := mdbv1.NetworkPeer{
+		ProviderName:        provider.ProviderAWS,
+		AWSAccountID:        "123456789012",
+		VpcID:               "vpc-1",
+		RouteTableCIDRBlock: "10.0.0.0/16",
+		AtlasCIDRBlock:      "10.8.0.0/21",
+	}
+
+	_, err := matchPeersPair(peer, expected, &failingContainersStub{}, "project-id")
+	assert.Error(t, err)
+	assert.False(t, comparePeersPair(peer, expected, &failingContainersStub{}, "project-id"))
+
+	peer.AtlasCIDRBlock = "10.8.0.0/21"
+	matches, err := matchPeersPair(peer, expected, &failingContainersStub{}, "project-id")
+	require.NoError(t, err)
+	assert.True(t, matches)
+}
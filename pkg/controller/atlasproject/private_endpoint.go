@@ -3,20 +3,23 @@ package atlasproject
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"golang.org/x/exp/slices"
 
 	"go.mongodb.org/atlas/mongodbatlas"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/set"
 )
 
-func ensurePrivateEndpoint(ctx *workflow.Context, projectID string, project *mdbv1.AtlasProject) workflow.Result {
+func (r *AtlasProjectReconciler) ensurePrivateEndpoint(ctx *workflow.Context, projectID string, project *mdbv1.AtlasProject) workflow.Result {
 	specPEs := project.Spec.DeepCopy().PrivateEndpoints
 
 	atlasPEs, err := getAllPrivateEndpoints(ctx.Client, projectID)
@@ -24,6 +27,12 @@ func ensurePrivateEndpoint(ctx *workflow.Context, projectID string, project *mdb
 		return workflow.Terminate(workflow.Internal, err.Error())
 	}
 
+	managed, err := privateEndpointsManagedByResources(r.Client, project)
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+	atlasPEs = withoutManagedPrivateEndpoints(atlasPEs, managed)
+
 	result, conditionType := syncPrivateEndpointsWithAtlas(ctx, projectID, specPEs, atlasPEs)
 	if !result.IsOk() {
 		if conditionType == status.PrivateEndpointServiceReadyType {
@@ -64,6 +73,35 @@ func ensurePrivateEndpoint(ctx *workflow.Context, projectID string, project *mdb
 	return interfaceStatus
 }
 
+// ensureRegionalizedPrivateEndpoints enables or disables the regionalized private endpoints mode of the project if the
+// setting is specified
+func ensureRegionalizedPrivateEndpoints(ctx *workflow.Context, projectID string, project *mdbv1.AtlasProject) workflow.Result {
+	if project.Spec.RegionalizedPrivateEndpoints == nil {
+		ctx.UnsetCondition(status.RegionalizedPrivateEndpointReadyType)
+		return workflow.OK()
+	}
+
+	enabled := *project.Spec.RegionalizedPrivateEndpoints
+	setting, _, err := ctx.Client.PrivateEndpoints.GetRegionalizedPrivateEndpointSetting(context.Background(), projectID)
+	if err != nil {
+		result := workflow.Terminate(workflow.ProjectRegionalizedPEModeNotSynced, err.Error())
+		ctx.SetConditionFromResult(status.RegionalizedPrivateEndpointReadyType, result)
+		return result
+	}
+
+	if setting.Enabled != enabled {
+		ctx.Log.Infow("Updating the regionalized private endpoints mode", "enabled", enabled)
+		if _, _, err = ctx.Client.PrivateEndpoints.UpdateRegionalizedPrivateEndpointSetting(context.Background(), projectID, enabled); err != nil {
+			result := workflow.Terminate(workflow.ProjectRegionalizedPEModeNotSynced, err.Error())
+			ctx.SetConditionFromResult(status.RegionalizedPrivateEndpointReadyType, result)
+			return result
+		}
+	}
+
+	ctx.SetConditionTrue(status.RegionalizedPrivateEndpointReadyType)
+	return workflow.OK()
+}
+
 func syncPrivateEndpointsWithAtlas(ctx *workflow.Context, projectID string, specPEs []mdbv1.PrivateEndpoint, atlasPEs []atlasPE) (workflow.Result, status.ConditionType) {
 	log := ctx.Log
 
@@ -176,6 +214,35 @@ func setPEStatusOption(ctx *workflow.Context, projectID string, atlasPeConnectio
 	ctx.EnsureStatusOption(status.AtlasProjectSetPrivateEnpointsOption(statusPEs))
 }
 
+// privateEndpointsManagedByResources returns the identifiers of the private endpoint services managed by the
+// AtlasPrivateEndpoint resources referencing the project.
+func privateEndpointsManagedByResources(kubeClient client.Client, project *mdbv1.AtlasProject) (map[interface{}]bool, error) {
+	list := &mdbv1.AtlasPrivateEndpointList{}
+	if err := kubeClient.List(context.Background(), list); err != nil {
+		return nil, fmt.Errorf("failed to list AtlasPrivateEndpoint resources: %w", err)
+	}
+
+	result := map[interface{}]bool{}
+	for _, privateEndpoint := range list.Items {
+		if privateEndpoint.AtlasProjectObjectKey() == kube.ObjectKeyFromObject(project) {
+			result[privateEndpoint.Identifier()] = true
+		}
+	}
+	return result, nil
+}
+
+// withoutManagedPrivateEndpoints filters out the private endpoint services managed by the AtlasPrivateEndpoint
+// resources so that the project neither deletes them nor reports their state.
+func withoutManagedPrivateEndpoints(atlasPEs []atlasPE, managed map[interface{}]bool) []atlasPE {
+	result := make([]atlasPE, 0, len(atlasPEs))
+	for _, pe := range atlasPEs {
+		if !managed[pe.Identifier()] {
+			result = append(result, pe)
+		}
+	}
+	return result
+}
+
 type atlasPE mongodbatlas.PrivateEndpointConnection
 
 func (a atlasPE) Identifier() interface{} {
@@ -4,6 +4,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	v1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
@@ -76,3 +80,24 @@ func TestGetEndpointsNotInSpec(t *testing.T) {
 	uniqueItems = getEndpointsNotInSpec(specPEs, atlasPEs)
 	assert.Equalf(t, 1, len(uniqueItems), "getEndpointsNotInSpec should get a spec item")
 }
+
+func TestWithoutManagedPrivateEndpoints(t *testing.T) {
+	atlasProject := v1.NewProject("ns", "project", "project")
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(v1.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		v1.NewPrivateEndpoint("ns", "managed", "project", provider.ProviderAWS, "us-east-1"),
+		v1.NewPrivateEndpoint("ns", "other-project", "other", provider.ProviderAWS, "eu-west-1"),
+	).Build()
+
+	managed, err := privateEndpointsManagedByResources(k8sClient, atlasProject)
+	require.NoError(t, err)
+
+	atlasPEs := []atlasPE{
+		{ProviderName: string(provider.ProviderAWS), RegionName: "US_EAST_1"},
+		{ProviderName: string(provider.ProviderAWS), RegionName: "EU_WEST_1"},
+		{ProviderName: string(provider.ProviderAzure), RegionName: "US_EAST_1"},
+	}
+	assert.Equal(t, []atlasPE{atlasPEs[1], atlasPEs[2]}, withoutManagedPrivateEndpoints(atlasPEs, managed))
+}
@@ -2,20 +2,16 @@ package atlasproject
 
 import (
 	"context"
-
-	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/common"
-	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+	"fmt"
+	"strings"
 
 	v1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
 
-	controllerruntime "sigs.k8s.io/controller-runtime"
-
 	"go.mongodb.org/atlas/mongodbatlas"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/statushandler"
-	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/watch"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
 )
 
@@ -26,16 +22,18 @@ type TeamDataContainer struct {
 }
 
 func (r *AtlasProjectReconciler) ensureAssignedTeams(ctx *workflow.Context, projectID string, project *v1.AtlasProject) workflow.Result {
-	resourcesToWatch := make([]watch.WatchedObject, 0, len(project.Spec.Teams))
+	teamsToWatch := make([]types.NamespacedName, 0, len(project.Spec.Teams))
 	defer func() {
-		r.EnsureMultiplesResourcesAreWatched(
+		// The teams are watched separately from the other kinds of resources the project depends on
+		r.EnsureResourcesAreWatched(
 			types.NamespacedName{Namespace: project.Namespace, Name: project.Name},
-			r.Log, resourcesToWatch...,
+			"AtlasTeam", r.Log, teamsToWatch...,
 		)
 		r.Log.Debugf("watching team resources: %v\r\n", r.WatchedResources)
 	}()
 
 	teamsToAssign := map[string]*v1.Team{}
+	pendingTeams := make([]string, 0)
 	for _, entry := range project.Spec.Teams {
 		assignedTeam := entry
 
@@ -49,21 +47,23 @@ func (r *AtlasProjectReconciler) ensureAssignedTeams(ctx *workflow.Context, proj
 			assignedTeam.TeamRef.Namespace = project.Namespace
 		}
 
-		team := &v1.AtlasTeam{}
-		teamReconciler := r.teamReconcile(team, ctx.Connection)
-		_, err := teamReconciler(
-			context.Background(),
-			controllerruntime.Request{NamespacedName: types.NamespacedName{Name: assignedTeam.TeamRef.Name, Namespace: assignedTeam.TeamRef.Namespace}},
+		teamsToWatch = append(
+			teamsToWatch,
+			types.NamespacedName{Name: assignedTeam.TeamRef.Name, Namespace: assignedTeam.TeamRef.Namespace},
 		)
-		if err != nil {
-			ctx.Log.Warnf("unable to reconcile team %s. skipping assignment. %s", assignedTeam.TeamRef.GetObject(""), err.Error())
+
+		// Teams are created in Atlas by the AtlasTeam controller, the project is reconciled again once the team is ready
+		team := &v1.AtlasTeam{}
+		if err := r.Client.Get(context.Background(), *assignedTeam.TeamRef.GetObject(""), team); err != nil {
+			ctx.Log.Warnf("unable to get team %s. skipping assignment. %s", assignedTeam.TeamRef.GetObject(""), err.Error())
+			pendingTeams = append(pendingTeams, assignedTeam.TeamRef.Name)
+			continue
+		}
+		if team.Status.ID == "" {
+			ctx.Log.Debugf("team %s is not created in Atlas yet. skipping assignment", assignedTeam.TeamRef.GetObject(""))
+			pendingTeams = append(pendingTeams, assignedTeam.TeamRef.Name)
 			continue
 		}
-
-		resourcesToWatch = append(
-			resourcesToWatch,
-			watch.WatchedObject{ResourceKind: team.Kind, Resource: types.NamespacedName{Name: assignedTeam.TeamRef.Name, Namespace: assignedTeam.TeamRef.Namespace}},
-		)
 
 		teamsToAssign[team.Status.ID] = &assignedTeam
 	}
@@ -74,6 +74,12 @@ func (r *AtlasProjectReconciler) ensureAssignedTeams(ctx *workflow.Context, proj
 		return workflow.Terminate(workflow.ProjectTeamUnavailable, err.Error())
 	}
 
+	if len(pendingTeams) > 0 {
+		result := workflow.InProgress(workflow.ProjectTeamUnavailable, fmt.Sprintf("teams are not ready yet: %s", strings.Join(pendingTeams, ", ")))
+		ctx.SetConditionFromResult(status.ProjectTeamsReadyType, result)
+		return result
+	}
+
 	ctx.SetConditionTrue(status.ProjectTeamsReadyType)
 
 	if len(project.Spec.Teams) == 0 {
@@ -132,15 +138,6 @@ func (r *AtlasProjectReconciler) syncAssignedTeams(ctx *workflow.Context, projec
 			ctx.Log.Warnf("failed to remove team %s from project: %s", atlasAssignedTeam.TeamID, err.Error())
 		}
 
-		teamRef := getTeamRefFromProjectStatus(project, atlasAssignedTeam.TeamID)
-		if teamRef == nil {
-			ctx.Log.Warnf("unable to find team %s status in the project", atlasAssignedTeam.TeamID)
-		} else {
-			if err = r.updateTeamState(ctx, project, teamRef, true); err != nil {
-				ctx.Log.Warnf("failed to update team %s status with removed project: %s", atlasAssignedTeam.TeamID, err.Error())
-			}
-		}
-
 		delete(currentProjectsStatus, atlasAssignedTeam.TeamID)
 	}
 
@@ -153,10 +150,6 @@ func (r *AtlasProjectReconciler) syncAssignedTeams(ctx *workflow.Context, projec
 				ID:      teamID,
 				TeamRef: assignedTeam.TeamRef,
 			}
-
-			if err = r.updateTeamState(ctx, project, &assignedTeam.TeamRef, false); err != nil {
-				ctx.Log.Warnf("failed to update team %s status with added project: %s", teamID, err.Error())
-			}
 		}
 
 		_, _, err = ctx.Client.Projects.AddTeamsToProject(context.Background(), projectID, projectTeams)
@@ -174,66 +167,6 @@ func (r *AtlasProjectReconciler) syncAssignedTeams(ctx *workflow.Context, projec
 	return nil
 }
 
-func (r *AtlasProjectReconciler) updateTeamState(ctx *workflow.Context, project *v1.AtlasProject, teamRef *common.ResourceRefNamespaced, isRemoval bool) error {
-	team := &v1.AtlasTeam{}
-	objKey := kube.ObjectKey(teamRef.Namespace, teamRef.Name)
-	err := r.Client.Get(context.Background(), objKey, team)
-	if err != nil {
-		return err
-	}
-
-	assignedProjects := make([]status.TeamProject, 0, len(team.Status.Projects)+1)
-
-	if !isRemoval {
-		assignedProjects = append(
-			assignedProjects,
-			status.TeamProject{
-				ID:   project.Status.ID,
-				Name: project.Spec.Name,
-			},
-		)
-	}
-
-	for _, projectStat := range team.Status.Projects {
-		if projectStat.ID == project.Status.ID && isRemoval {
-			continue
-		}
-
-		assignedProjects = append(assignedProjects, projectStat)
-	}
-
-	log := r.Log.With("atlasteam", teamRef)
-	teamCtx, err := createTeamContextFromParent(team, r.Client, ctx.Connection, r.AtlasDomain, log)
-	if err != nil {
-		return err
-	}
-
-	if len(assignedProjects) == 0 {
-		log.Debugf("team %s has no project associated to it. removing from atlas.", team.Spec.Name)
-		_, err = teamCtx.Client.Teams.RemoveTeamFromOrganization(context.Background(), teamCtx.Connection.OrgID, team.Status.ID)
-		if err != nil {
-			return err
-		}
-
-		teamCtx.EnsureStatusOption(status.AtlasTeamUnsetID())
-	}
-
-	teamCtx.EnsureStatusOption(status.AtlasTeamSetProjects(assignedProjects))
-	statushandler.Update(teamCtx, r.Client, r.EventRecorder, team)
-
-	return nil
-}
-
-func getTeamRefFromProjectStatus(project *v1.AtlasProject, teamID string) *common.ResourceRefNamespaced {
-	for _, stat := range project.Status.Teams {
-		if stat.ID == teamID {
-			return &stat.TeamRef
-		}
-	}
-
-	return nil
-}
-
 func hasTeamRolesChanged(current []string, desired []v1.TeamRole) bool {
 	desiredMap := map[string]struct{}{}
 	for _, desiredRole := range desired {
@@ -0,0 +1,308 @@
+/*
+Copyright 2023 MongoDB.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package atlasteam
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/statushandler"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/watch"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+)
+
+// AtlasTeamReconciler reconciles an AtlasTeam object
+type AtlasTeamReconciler struct {
+	watch.ResourceWatcher
+	Client           client.Client
+	Log              *zap.SugaredLogger
+	Scheme           *runtime.Scheme
+	AtlasDomain      string
+	GlobalAPISecret  client.ObjectKey
+	GlobalPredicates []predicate.Predicate
+	EventRecorder    record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasteams,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasteams/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasteams,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasteams/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasprojects,verbs=get;list;watch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasprojects,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",namespace=default,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",namespace=default,resources=events,verbs=create;patch
+
+func (r *AtlasTeamReconciler) Reconcile(context context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.With("atlasteam", req.NamespacedName)
+
+	team := &mdbv1.AtlasTeam{}
+	result := customresource.PrepareResource(r.Client, req, team, log)
+	if !result.IsOk() {
+		return result.ReconcileResult(), nil
+	}
+
+	if shouldSkip := customresource.ReconciliationShouldBeSkipped(team); shouldSkip {
+		log.Infow(fmt.Sprintf("-> Skipping AtlasTeam reconciliation as annotation %s=%s", customresource.ReconciliationPolicyAnnotation, customresource.ReconciliationPolicySkip), "spec", team.Spec)
+		if !team.GetDeletionTimestamp().IsZero() {
+			if err := r.removeDeletionFinalizer(context, team); err != nil {
+				result = workflow.Terminate(workflow.Internal, err.Error())
+				log.Errorw("failed to remove finalizer", "error", err)
+				return result.ReconcileResult(), nil
+			}
+		}
+		return workflow.OK().ReconcileResult(), nil
+	}
+
+	ctx := customresource.MarkReconciliationStarted(r.Client, team, log)
+	log.Infow("-> Starting AtlasTeam reconciliation", "spec", team.Spec, "status", team.Status)
+	defer statushandler.Update(ctx, r.Client, r.EventRecorder, team)
+
+	resourceVersionIsValid := customresource.ValidateResourceVersion(ctx, team, r.Log)
+	if !resourceVersionIsValid.IsOk() {
+		r.Log.Debugf("team validation result: %v", resourceVersionIsValid)
+		return resourceVersionIsValid.ReconcileResult(), nil
+	}
+
+	connectionSecretKey, err := r.connectionSecretKey(context, team)
+	if err != nil {
+		result := workflow.Terminate(workflow.Internal, err.Error())
+		ctx.SetConditionFromResult(status.TeamReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	connection, err := atlas.ReadConnection(log, r.Client, r.GlobalAPISecret, connectionSecretKey)
+	if err != nil {
+		result := workflow.Terminate(workflow.AtlasCredentialsNotProvided, err.Error())
+		ctx.SetConditionFromResult(status.TeamReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.Connection = connection
+
+	atlasClient, err := atlas.Client(r.AtlasDomain, connection, log)
+	if err != nil {
+		result := workflow.Terminate(workflow.Internal, err.Error())
+		ctx.SetConditionFromResult(status.TeamReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.Client = atlasClient
+
+	projects, err := r.assignedProjects(context, team)
+	if err != nil {
+		result := workflow.Terminate(workflow.Internal, err.Error())
+		ctx.SetConditionFromResult(status.TeamReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.EnsureStatusOption(status.AtlasTeamSetProjects(projects))
+
+	if team.GetDeletionTimestamp().IsZero() {
+		if !customresource.HaveFinalizer(team, customresource.FinalizerLabel) {
+			customresource.SetFinalizer(team, customresource.FinalizerLabel)
+			if err = r.Client.Update(context, team); err != nil {
+				result = workflow.Terminate(workflow.Internal, err.Error())
+				log.Errorw("failed to add finalizer", "error", err)
+				return result.ReconcileResult(), nil
+			}
+		}
+	} else {
+		if !customresource.HaveFinalizer(team, customresource.FinalizerLabel) {
+			return workflow.OK().ReconcileResult(), nil
+		}
+		if len(projects) > 0 {
+			names := make([]string, 0, len(projects))
+			for _, project := range projects {
+				names = append(names, project.Name)
+			}
+			result = workflow.Terminate(workflow.TeamAssignedToProject, fmt.Sprintf("the team is assigned to the projects %s. Remove it from all the projects before deleting", strings.Join(names, ", ")))
+			ctx.SetConditionFromResult(status.TeamReadyType, result)
+			return result.ReconcileResult(), nil
+		}
+		if customresource.ResourceShouldBeLeftInAtlas(team) {
+			log.Infof("Not removing the Atlas Team from Atlas as the '%s' annotation is set", customresource.ResourcePolicyAnnotation)
+		} else if err = deleteTeamFromAtlas(context, ctx, team); err != nil {
+			log.Errorf("failed to remove the team from Atlas: %s", err)
+			result = workflow.Terminate(workflow.Internal, err.Error())
+			ctx.SetConditionFromResult(status.TeamReadyType, result)
+			return result.ReconcileResult(), nil
+		}
+		if err = r.removeDeletionFinalizer(context, team); err != nil {
+			result = workflow.Terminate(workflow.Internal, err.Error())
+			log.Errorw("failed to remove finalizer", "error", err)
+			return result.ReconcileResult(), nil
+		}
+		return workflow.OK().ReconcileResult(), nil
+	}
+
+	teamID, result := ensureTeamState(context, ctx, team)
+	if !result.IsOk() {
+		ctx.SetConditionFromResult(status.TeamReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.EnsureStatusOption(status.AtlasTeamSetID(teamID))
+
+	if result = ensureTeamUsersAreInSync(context, ctx, teamID, team); !result.IsOk() {
+		ctx.SetConditionFromResult(status.TeamReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	ctx.SetConditionTrue(status.TeamReadyType)
+	ctx.SetConditionTrue(status.ReadyType)
+	return workflow.OK().ReconcileResult(), nil
+}
+
+// connectionSecretKey returns the connection Secret the team is reconciled with. The team without its own Secret uses
+// the Secret of a project referencing it, as the teams were reconciled by the projects before. The operator Secret is
+// used only if none of the projects referencing the team has its own Secret.
+func (r *AtlasTeamReconciler) connectionSecretKey(ctx context.Context, team *mdbv1.AtlasTeam) (*client.ObjectKey, error) {
+	if key := team.ConnectionSecretObjectKey(); key != nil {
+		return key, nil
+	}
+
+	projects := &mdbv1.AtlasProjectList{}
+	if err := r.Client.List(ctx, projects); err != nil {
+		return nil, fmt.Errorf("failed to list the projects referencing the team: %w", err)
+	}
+	// the projects are sorted so the same Secret is used on every reconciliation
+	sort.Slice(projects.Items, func(i, j int) bool {
+		return kube.ObjectKeyFromObject(&projects.Items[i]).String() < kube.ObjectKeyFromObject(&projects.Items[j]).String()
+	})
+	for i := range projects.Items {
+		project := &projects.Items[i]
+		key := project.ConnectionSecretObjectKey()
+		if key == nil {
+			continue
+		}
+		for _, projectTeam := range project.Spec.Teams {
+			if *projectTeam.TeamRef.GetObject(project.Namespace) == kube.ObjectKeyFromObject(team) {
+				return key, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// assignedProjects returns the projects the team is assigned to according to the status of the AtlasProject resources
+func (r *AtlasTeamReconciler) assignedProjects(ctx context.Context, team *mdbv1.AtlasTeam) ([]status.TeamProject, error) {
+	projects := &mdbv1.AtlasProjectList{}
+	if err := r.Client.List(ctx, projects); err != nil {
+		return nil, fmt.Errorf("failed to list the projects the team is assigned to: %w", err)
+	}
+
+	result := make([]status.TeamProject, 0)
+	for _, project := range projects.Items {
+		for _, projectTeam := range project.Status.Teams {
+			if *projectTeam.TeamRef.GetObject(project.Namespace) == kube.ObjectKeyFromObject(team) {
+				result = append(result, status.TeamProject{ID: project.ID(), Name: project.Spec.Name})
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (r *AtlasTeamReconciler) removeDeletionFinalizer(ctx context.Context, team *mdbv1.AtlasTeam) error {
+	err := r.Client.Get(ctx, kube.ObjectKeyFromObject(team), team)
+	if err != nil {
+		return fmt.Errorf("cannot get AtlasTeam while removing finalizer: %w", err)
+	}
+
+	customresource.UnsetFinalizer(team, customresource.FinalizerLabel)
+	if err = r.Client.Update(ctx, team); err != nil {
+		return fmt.Errorf("failed to remove deletion finalizer from %s: %w", team.Spec.Name, err)
+	}
+	return nil
+}
+
+func (r *AtlasTeamReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c, err := controller.New("AtlasTeam", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource AtlasTeam
+	err = c.Watch(&source.Kind{Type: &mdbv1.AtlasTeam{}}, &handler.EnqueueRequestForObject{}, r.GlobalPredicates...)
+	if err != nil {
+		return err
+	}
+
+	// Watch for the projects assigning the teams to keep the list of the projects in the team status up to date
+	err = c.Watch(&source.Kind{Type: &mdbv1.AtlasProject{}}, handler.EnqueueRequestsFromMapFunc(projectTeamsRequests), projectTeamsChanged())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// projectTeamsRequests returns the requests for all the teams either referenced by the project or assigned to it
+func projectTeamsRequests(obj client.Object) []reconcile.Request {
+	project, ok := obj.(*mdbv1.AtlasProject)
+	if !ok {
+		return nil
+	}
+
+	seen := map[client.ObjectKey]bool{}
+	requests := make([]reconcile.Request, 0, len(project.Spec.Teams)+len(project.Status.Teams))
+	addRequest := func(key client.ObjectKey) {
+		if key.Name != "" && !seen[key] {
+			seen[key] = true
+			requests = append(requests, reconcile.Request{NamespacedName: key})
+		}
+	}
+	for _, team := range project.Spec.Teams {
+		addRequest(*team.TeamRef.GetObject(project.Namespace))
+	}
+	for _, team := range project.Status.Teams {
+		addRequest(*team.TeamRef.GetObject(project.Namespace))
+	}
+	return requests
+}
+
+// projectTeamsChanged filters out the project updates which don't change the teams assigned to the project
+func projectTeamsChanged() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldProject, okOld := e.ObjectOld.(*mdbv1.AtlasProject)
+			newProject, okNew := e.ObjectNew.(*mdbv1.AtlasProject)
+			if !okOld || !okNew {
+				return false
+			}
+			return !reflect.DeepEqual(oldProject.Spec.Teams, newProject.Spec.Teams) ||
+				!reflect.DeepEqual(oldProject.Status.Teams, newProject.Status.Teams)
+		},
+	}
+}
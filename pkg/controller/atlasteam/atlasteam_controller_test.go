@@ -0,0 +1,132 @@
+package atlasteam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+)
+
+func projectWithTeams(namespace, name, id string, teams ...common.ResourceRefNamespaced) *mdbv1.AtlasProject {
+	project := mdbv1.NewProject(namespace, name, name)
+	project.Status.ID = id
+	for _, team := range teams {
+		project.Spec.Teams = append(project.Spec.Teams, mdbv1.Team{TeamRef: team})
+		project.Status.Teams = append(project.Status.Teams, status.ProjectTeamStatus{TeamRef: team})
+	}
+	return project
+}
+
+func TestAssignedProjects(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(mdbv1.AddToScheme(scheme))
+	r := &AtlasTeamReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		projectWithTeams("ns", "first", "first-id", common.ResourceRefNamespaced{Name: "team", Namespace: "ns"}),
+		projectWithTeams("other", "second", "second-id", common.ResourceRefNamespaced{Name: "team", Namespace: "ns"}),
+		projectWithTeams("other", "third", "third-id", common.ResourceRefNamespaced{Name: "team", Namespace: "other"}),
+		projectWithTeams("ns", "fourth", "fourth-id"),
+	).Build()}
+
+	team := &mdbv1.AtlasTeam{}
+	team.Name = "team"
+	team.Namespace = "ns"
+
+	projects, err := r.assignedProjects(context.Background(), team)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []status.TeamProject{{ID: "first-id", Name: "first"}, {ID: "second-id", Name: "second"}}, projects)
+}
+
+func TestProjectTeamsRequests(t *testing.T) {
+	project := projectWithTeams("ns", "project", "project-id",
+		common.ResourceRefNamespaced{Name: "first"},
+		common.ResourceRefNamespaced{Name: "second", Namespace: "other"},
+	)
+	project.Status.Teams = append(project.Status.Teams, status.ProjectTeamStatus{TeamRef: common.ResourceRefNamespaced{Name: "removed", Namespace: "ns"}})
+
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: kube.ObjectKey("ns", "first")},
+		{NamespacedName: kube.ObjectKey("other", "second")},
+		{NamespacedName: kube.ObjectKey("ns", "removed")},
+	}, projectTeamsRequests(project))
+}
+
+func TestProjectTeamsChanged(t *testing.T) {
+	oldProject := projectWithTeams("ns", "project", "project-id", common.ResourceRefNamespaced{Name: "team"})
+
+	newProject := oldProject.DeepCopy()
+	newProject.Spec.Name = "renamed"
+	assert.False(t, projectTeamsChanged().Update(event.UpdateEvent{ObjectOld: oldProject, ObjectNew: newProject}))
+
+	newProject.Status.Teams = nil
+	assert.True(t, projectTeamsChanged().Update(event.UpdateEvent{ObjectOld: oldProject, ObjectNew: newProject}))
+}
+
+func TestConnectionSecretKey(t *testing.T) {
+	globalSecret := kube.ObjectKey("operator", "global-secret")
+	secret := func(key client.ObjectKey, orgID string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Data:       atlas.Connection{OrgID: orgID, PublicKey: "public", PrivateKey: "private"}.SecretData(),
+		}
+	}
+	newReconciler := func(objects ...client.Object) *AtlasTeamReconciler {
+		scheme := runtime.NewScheme()
+		utilruntime.Must(corev1.AddToScheme(scheme))
+		utilruntime.Must(mdbv1.AddToScheme(scheme))
+		objects = append(objects, secret(globalSecret, "global-org"))
+		return &AtlasTeamReconciler{
+			Client:          fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+			GlobalAPISecret: globalSecret,
+		}
+	}
+	newTeam := func() *mdbv1.AtlasTeam {
+		team := &mdbv1.AtlasTeam{}
+		team.Name = "team"
+		team.Namespace = "ns"
+		return team
+	}
+	readOrgID := func(t *testing.T, r *AtlasTeamReconciler, team *mdbv1.AtlasTeam) string {
+		key, err := r.connectionSecretKey(context.Background(), team)
+		require.NoError(t, err)
+		connection, err := atlas.ReadConnection(zap.S(), r.Client, r.GlobalAPISecret, key)
+		require.NoError(t, err)
+		return connection.OrgID
+	}
+
+	t.Run("Team without the Secret uses the Secret of the project referencing it", func(t *testing.T) {
+		project := projectWithTeams("ns", "project", "project-id", common.ResourceRefNamespaced{Name: "team"}).WithConnectionSecret("project-secret")
+		other := projectWithTeams("ns", "other", "other-id").WithConnectionSecret("other-secret")
+		r := newReconciler(project, other, secret(kube.ObjectKey("ns", "project-secret"), "project-org"), secret(kube.ObjectKey("ns", "other-secret"), "other-org"))
+
+		assert.Equal(t, "project-org", readOrgID(t, r, newTeam()))
+	})
+	t.Run("Team's own Secret takes precedence", func(t *testing.T) {
+		project := projectWithTeams("ns", "project", "project-id", common.ResourceRefNamespaced{Name: "team"}).WithConnectionSecret("project-secret")
+		team := newTeam()
+		team.Spec.ConnectionSecret = &common.ResourceRef{Name: "team-secret"}
+		r := newReconciler(project, secret(kube.ObjectKey("ns", "project-secret"), "project-org"), secret(kube.ObjectKey("ns", "team-secret"), "team-org"))
+
+		assert.Equal(t, "team-org", readOrgID(t, r, team))
+	})
+	t.Run("Team referenced by the projects without Secrets uses the operator Secret", func(t *testing.T) {
+		r := newReconciler(projectWithTeams("ns", "project", "project-id", common.ResourceRefNamespaced{Name: "team"}))
+
+		assert.Equal(t, "global-org", readOrgID(t, r, newTeam()))
+	})
+}
@@ -1,131 +1,38 @@
-package atlasproject
+package atlasteam
 
 import (
 	"context"
 	"errors"
-	"fmt"
 	"net/http"
 	"sync"
 
 	v1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
 
 	"go.mongodb.org/atlas/mongodbatlas"
-	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
-	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
-	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
-	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/statushandler"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
 )
 
-func (r *AtlasProjectReconciler) teamReconcile(
-	team *v1.AtlasTeam,
-	connection atlas.Connection,
-) reconcile.Func {
-	return func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
-		log := r.Log.With("atlasteam", req.NamespacedName)
-
-		result := customresource.PrepareResource(r.Client, req, team, log)
-		if !result.IsOk() {
-			return result.ReconcileResult(), nil
-		}
-
-		if shouldSkip := customresource.ReconciliationShouldBeSkipped(team); shouldSkip {
-			log.Infow(fmt.Sprintf("-> Skipping AtlasTeam reconciliation as annotation %s=%s", customresource.ReconciliationPolicyAnnotation, customresource.ReconciliationPolicySkip), "spec", team.Spec)
-			return workflow.OK().ReconcileResult(), nil
-		}
-
-		teamCtx, err := createTeamContextFromParent(team, r.Client, connection, r.AtlasDomain, log)
-		if err != nil {
-			teamCtx.SetConditionFalse(status.ReadyType)
-			return workflow.Terminate(workflow.Internal, err.Error()).ReconcileResult(), nil
-		}
-
-		defer statushandler.Update(teamCtx, r.Client, r.EventRecorder, team)
-
-		resourceVersionIsValid := customresource.ValidateResourceVersion(teamCtx, team, r.Log)
-		if !resourceVersionIsValid.IsOk() {
-			r.Log.Debugf("team validation result: %v", resourceVersionIsValid)
-			return resourceVersionIsValid.ReconcileResult(), nil
-		}
-
-		log.Infow("-> Starting AtlasTeam reconciliation", "spec", team.Spec)
-
-		teamID, result := ensureTeamState(ctx, teamCtx, team)
-		if !result.IsOk() {
-			teamCtx.SetConditionFromResult(status.ReadyType, result)
-			if result.IsWarning() {
-				teamCtx.Log.Warnf("failed to ensure team state %v: %s", team.Spec, result.GetMessage())
-			}
-
-			return result.ReconcileResult(), nil
-		}
-
-		teamCtx.EnsureStatusOption(status.AtlasTeamSetID(teamID))
-
-		result = ensureTeamUsersAreInSync(ctx, teamCtx, teamID, team)
-		if !result.IsOk() {
-			teamCtx.SetConditionFromResult(status.ReadyType, result)
-			return result.ReconcileResult(), nil
-		}
-
-		if team.GetDeletionTimestamp().IsZero() {
-			if len(team.Status.Projects) > 0 {
-				log.Debugw("Adding deletion finalizer", "name", customresource.FinalizerLabel)
-				customresource.SetFinalizer(team, customresource.FinalizerLabel)
-			} else {
-				log.Debugw("Removing deletion finalizer", "name", customresource.FinalizerLabel)
-				customresource.UnsetFinalizer(team, customresource.FinalizerLabel)
-			}
-
-			if err = r.Client.Update(ctx, team); err != nil {
-				result = workflow.Terminate(workflow.Internal, err.Error())
-				log.Errorw("Failed to update finalizer", "error", err)
-				return result.ReconcileResult(), nil
-			}
-		}
-
-		if !team.GetDeletionTimestamp().IsZero() {
-			if customresource.HaveFinalizer(team, customresource.FinalizerLabel) {
-				log.Warnf("team %s is assigned to a project. Remove it from all projects before delete", team.Name)
-			} else if customresource.ResourceShouldBeLeftInAtlas(team) {
-				log.Infof("Not removing the Atlas Team from Atlas as the '%s' annotation is set", customresource.ResourcePolicyAnnotation)
-			} else {
-				log.Infow("-> Starting AtlasTeam deletion", "spec", team.Spec)
-				_, err := teamCtx.Client.Teams.RemoveTeamFromOrganization(ctx, teamCtx.Connection.OrgID, team.Status.ID)
-				var apiError *mongodbatlas.ErrorResponse
-				if errors.As(err, &apiError) && apiError.ErrorCode == atlas.NotInGroup {
-					log.Infow("team does not exist", "projectID", team.Status.ID)
-					return workflow.Terminate(workflow.TeamDoesNotExist, err.Error()).ReconcileResult(), nil
-				}
-			}
-		}
-
-		teamCtx.SetConditionTrue(status.ReadyType)
-		return workflow.OK().ReconcileResult(), nil
+// deleteTeamFromAtlas removes the team from the Atlas organization
+func deleteTeamFromAtlas(ctx context.Context, workflowCtx *workflow.Context, team *v1.AtlasTeam) error {
+	if team.Status.ID == "" {
+		return nil
 	}
-}
 
-func createTeamContextFromParent(
-	team *v1.AtlasTeam,
-	kubeClient client.Client,
-	atlasConnection atlas.Connection,
-	atlasDomain string,
-	logger *zap.SugaredLogger,
-) (*workflow.Context, error) {
-	teamCtx := customresource.MarkReconciliationStarted(kubeClient, team, logger)
-	teamCtx.Connection = atlasConnection
-	atlasClient, err := atlas.Client(atlasDomain, atlasConnection, logger)
+	workflowCtx.Log.Infow("-> Starting AtlasTeam deletion", "spec", team.Spec)
+	_, err := workflowCtx.Client.Teams.RemoveTeamFromOrganization(ctx, workflowCtx.Connection.OrgID, team.Status.ID)
+	if isTeamNotFound(err) {
+		workflowCtx.Log.Infow("Team doesn't exist or is already deleted", "teamID", team.Status.ID)
+		return nil
+	}
 	if err != nil {
-		return nil, err
+		return err
 	}
-	teamCtx.Client = atlasClient
 
-	return teamCtx, nil
+	workflowCtx.Log.Infow("Team removed from Atlas", "teamID", team.Status.ID)
+	return nil
 }
 
 func ensureTeamState(ctx context.Context, workflowCtx *workflow.Context, team *v1.AtlasTeam) (string, workflow.Result) {
@@ -134,16 +41,20 @@ func ensureTeamState(ctx context.Context, workflowCtx *workflow.Context, team *v
 
 	if team.Status.ID != "" {
 		atlasTeam, err = fetchTeamByID(ctx, workflowCtx, team.Status.ID)
-		if err != nil {
+		if err != nil && !isTeamNotFound(err) {
 			return "", workflow.Terminate(workflow.TeamNotCreatedInAtlas, err.Error())
 		}
 
-		atlasTeam, err = renameTeam(ctx, workflowCtx, atlasTeam, team.Spec.Name)
-		if err != nil {
-			return "", workflow.Terminate(workflow.TeamNotUpdatedInAtlas, err.Error())
+		if atlasTeam != nil {
+			atlasTeam, err = renameTeam(ctx, workflowCtx, atlasTeam, team.Spec.Name)
+			if err != nil {
+				return "", workflow.Terminate(workflow.TeamNotUpdatedInAtlas, err.Error())
+			}
+
+			return atlasTeam.ID, workflow.OK()
 		}
 
-		return atlasTeam.ID, workflow.OK()
+		workflowCtx.Log.Infof("team %s was removed from Atlas. creating it again", team.Status.ID)
 	}
 
 	atlasTeam, err = fetchTeamByName(ctx, workflowCtx, team.Spec.Name)
@@ -248,6 +159,11 @@ func ensureTeamUsersAreInSync(ctx context.Context, workflowCtx *workflow.Context
 	return workflow.OK()
 }
 
+func isTeamNotFound(err error) bool {
+	var apiError *mongodbatlas.ErrorResponse
+	return errors.As(err, &apiError) && (apiError.HTTPCode == http.StatusNotFound || apiError.ErrorCode == atlas.NotInGroup)
+}
+
 func fetchTeamByID(ctx context.Context, workflowCtx *workflow.Context, teamID string) (*mongodbatlas.Team, error) {
 	workflowCtx.Log.Debugf("fetching team %s from atlas", teamID)
 	atlasTeam, _, err := workflowCtx.Client.Teams.Get(ctx, workflowCtx.Connection.OrgID, teamID)
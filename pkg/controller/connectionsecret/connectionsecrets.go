@@ -7,10 +7,12 @@ import (
 
 	"go.mongodb.org/atlas/mongodbatlas"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/stringutil"
@@ -24,11 +26,28 @@ func CreateOrUpdateConnectionSecrets(ctx *workflow.Context, k8sClient client.Cli
 		return workflow.Terminate(workflow.DatabaseUserConnectionSecretsNotCreated, err.Error())
 	}
 
+	archivedDeployments, err := deploymentsWithOnlineArchives(k8sClient, project)
+	if err != nil {
+		return workflow.Terminate(workflow.DatabaseUserConnectionSecretsNotCreated, err.Error())
+	}
+	var onlineArchiveInstances []atlas.DataFederationInstance
+	if len(archivedDeployments) > 0 {
+		if onlineArchiveInstances, err = listOnlineArchiveInstances(ctx, project.ID()); err != nil {
+			return workflow.Terminate(workflow.DatabaseUserConnectionSecretsNotCreated, err.Error())
+		}
+	}
+
 	var deploymentSecrets []deploymentSecret
 	for _, c := range advancedDeployments.Results {
+		onlineArchiveConnURL := ""
+		if stringutil.Contains(archivedDeployments, c.Name) {
+			onlineArchiveConnURL = atlas.OnlineArchiveConnectionString(onlineArchiveInstances, project.ID(), c.Name)
+		}
 		deploymentSecrets = append(deploymentSecrets, deploymentSecret{
-			name:              c.Name,
-			connectionStrings: c.ConnectionStrings,
+			name:                 c.Name,
+			scopeType:            mdbv1.DeploymentScopeType,
+			connectionStrings:    c.ConnectionStrings,
+			onlineArchiveConnURL: onlineArchiveConnURL,
 		})
 	}
 
@@ -49,11 +68,18 @@ func CreateOrUpdateConnectionSecrets(ctx *workflow.Context, k8sClient client.Cli
 		if !found {
 			deploymentSecrets = append(deploymentSecrets, deploymentSecret{
 				name:              c.Name,
+				scopeType:         mdbv1.DeploymentScopeType,
 				connectionStrings: c.ConnectionStrings,
 			})
 		}
 	}
 
+	dataFederationSecrets, err := dataFederationSecretsForUser(ctx, project.ID(), dbUser)
+	if err != nil {
+		return workflow.Terminate(workflow.DatabaseUserConnectionSecretsNotCreated, err.Error())
+	}
+	deploymentSecrets = append(deploymentSecrets, dataFederationSecrets...)
+
 	// ensure secrets for both deployments and advanced deployment.
 	if result := createOrUpdateConnectionSecretsFromDeploymentSecrets(ctx, k8sClient, recorder, project, dbUser, deploymentSecrets); !result.IsOk() {
 		return result
@@ -64,8 +90,84 @@ func CreateOrUpdateConnectionSecrets(ctx *workflow.Context, k8sClient client.Cli
 
 // deploymentSecret holds the information required to ensure a secret for a user in a given deployment.
 type deploymentSecret struct {
-	name              string
-	connectionStrings *mongodbatlas.ConnectionStrings
+	name                 string
+	scopeType            mdbv1.ScopeType
+	connectionStrings    *mongodbatlas.ConnectionStrings
+	onlineArchiveConnURL string
+}
+
+// OnlineArchiveConnURL returns the connection string to query the cluster together with its Online Archive or an empty
+// string if it's not available yet.
+func OnlineArchiveConnURL(ctx *workflow.Context, projectID, clusterName string) (string, error) {
+	instances, err := listOnlineArchiveInstances(ctx, projectID)
+	if err != nil {
+		return "", err
+	}
+	return atlas.OnlineArchiveConnectionString(instances, projectID, clusterName), nil
+}
+
+// listOnlineArchiveInstances returns the federated database instances of the Online Archives in the project.
+func listOnlineArchiveInstances(ctx *workflow.Context, projectID string) ([]atlas.DataFederationInstance, error) {
+	instances, _, err := atlas.NewDataFederationService(&ctx.Client).ListOnlineArchiveInstances(context.Background(), projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the Online Archive connection strings: %w", err)
+	}
+	return instances, nil
+}
+
+// deploymentsWithOnlineArchives returns the names in Atlas of the deployments of the project which have Online
+// Archives configured. Only these deployments get the Online Archive connection strings.
+func deploymentsWithOnlineArchives(k8sClient client.Client, project mdbv1.AtlasProject) ([]string, error) {
+	deployments := &mdbv1.AtlasDeploymentList{}
+	if err := k8sClient.List(context.Background(), deployments); err != nil {
+		return nil, fmt.Errorf("failed to list the deployments: %w", err)
+	}
+	var names []string
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		if len(deployment.Spec.OnlineArchives) > 0 && deployment.AtlasProjectObjectKey() == kube.ObjectKeyFromObject(&project) {
+			names = append(names, deployment.GetDeploymentName())
+		}
+	}
+	return names, nil
+}
+
+// dataFederationSecretsForUser returns the secrets for the federated database instances listed in the 'DATA_LAKE'
+// scopes of the user.
+func dataFederationSecretsForUser(ctx *workflow.Context, projectID string, dbUser mdbv1.AtlasDatabaseUser) ([]deploymentSecret, error) {
+	scopes := dbUser.GetScopes(mdbv1.DataLakeScopeType)
+	if len(scopes) == 0 {
+		return nil, nil
+	}
+
+	instances, _, err := atlas.NewDataFederationService(&ctx.Client).List(context.Background(), projectID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting federated database instances: %w", err)
+	}
+
+	var result []deploymentSecret
+	for _, instance := range instances {
+		if !stringutil.Contains(scopes, instance.Name) {
+			continue
+		}
+		result = append(result, deploymentSecret{
+			name:              instance.Name,
+			scopeType:         mdbv1.DataLakeScopeType,
+			connectionStrings: &mongodbatlas.ConnectionStrings{Standard: instance.ConnectionString()},
+		})
+	}
+	return result, nil
+}
+
+// HasAccess returns true if the database user is allowed to connect to the deployment or federated database instance.
+// Users without 'DEPLOYMENT' scopes have access to all the deployments of the project while federated database
+// instances need to be listed in the scopes explicitly.
+func HasAccess(dbUser mdbv1.AtlasDatabaseUser, scopeType mdbv1.ScopeType, name string) bool {
+	scopes := dbUser.GetScopes(scopeType)
+	if len(scopes) == 0 {
+		return scopeType == mdbv1.DeploymentScopeType
+	}
+	return stringutil.Contains(scopes, name)
 }
 
 func createOrUpdateConnectionSecretsFromDeploymentSecrets(ctx *workflow.Context, k8sClient client.Client, recorder record.EventRecorder, project mdbv1.AtlasProject, dbUser mdbv1.AtlasDatabaseUser, deploymentSecrets []deploymentSecret) workflow.Result {
@@ -73,28 +175,22 @@ func createOrUpdateConnectionSecretsFromDeploymentSecrets(ctx *workflow.Context,
 	secrets := make([]string, 0)
 
 	for _, ds := range deploymentSecrets {
-		scopes := dbUser.GetScopes(mdbv1.DeploymentScopeType)
-		if len(scopes) != 0 && !stringutil.Contains(scopes, ds.name) {
+		if !HasAccess(dbUser, ds.scopeType, ds.name) {
 			continue
 		}
 		// Deployment may be not ready yet, so no connection urls - skipping
-		// Note, that Atlas usually returns the not-nil connection strings with empty fields in it
-		if ds.connectionStrings == nil || ds.connectionStrings.StandardSrv == "" {
+		// Note, that Atlas usually returns the not-nil connection strings with empty fields in it.
+		// Federated database instances don't have SRV connection strings.
+		if ds.connectionStrings == nil || (ds.connectionStrings.StandardSrv == "" && ds.scopeType == mdbv1.DeploymentScopeType) ||
+			(ds.connectionStrings.Standard == "" && ds.scopeType == mdbv1.DataLakeScopeType) {
 			ctx.Log.Debugw("Deployment is not ready yet - not creating a connection Secret", "deployment", ds.name)
 			requeue = true
 			continue
 		}
-		password, err := dbUser.ReadPassword(k8sClient)
+		data, err := NewConnectionData(k8sClient, dbUser, ds.connectionStrings, ds.onlineArchiveConnURL)
 		if err != nil {
 			return workflow.Terminate(workflow.DatabaseUserConnectionSecretsNotCreated, err.Error())
 		}
-		data := ConnectionData{
-			DBUserName: dbUser.Spec.Username,
-			Password:   password,
-			ConnURL:    ds.connectionStrings.Standard,
-			SrvConnURL: ds.connectionStrings.StandardSrv,
-		}
-		FillPrivateConnStrings(ds.connectionStrings, &data)
 
 		var secretName string
 		if secretName, err = Ensure(k8sClient, dbUser.Namespace, project.Spec.Name, project.ID(), ds.name, data); err != nil {
@@ -118,6 +214,53 @@ func createOrUpdateConnectionSecretsFromDeploymentSecrets(ctx *workflow.Context,
 	return workflow.OK()
 }
 
+// NewConnectionData returns the data of the connection Secret of the database user for the deployment or federated
+// database instance with the given connection strings. Both the database user and the deployment controllers write
+// the same Secret, so both build its data here: the credentials or the authentication mechanism of the user and the
+// Atlas-managed X.509 certificate.
+func NewConnectionData(k8sClient client.Client, dbUser mdbv1.AtlasDatabaseUser, connectionStrings *mongodbatlas.ConnectionStrings, onlineArchiveConnURL string) (ConnectionData, error) {
+	password, err := dbUser.ReadPassword(k8sClient)
+	if err != nil {
+		return ConnectionData{}, err
+	}
+	data := ConnectionData{
+		DBUserName:           dbUser.Spec.Username,
+		Password:             password,
+		ConnURL:              connectionStrings.Standard,
+		SrvConnURL:           connectionStrings.StandardSrv,
+		OnlineArchiveConnURL: onlineArchiveConnURL,
+	}
+	data.AuthMechanism, data.AuthUserName = PasswordlessAuth(dbUser)
+	if dbUser.HasManagedCertificate() {
+		certificate := &corev1.Secret{}
+		if err = k8sClient.Get(context.Background(), dbUser.X509CertificateSecretObjectKey(), certificate); err != nil {
+			return ConnectionData{}, fmt.Errorf("failed to read the X.509 certificate of the user: %w", err)
+		}
+		data.Certificate, data.PrivateKey = certificate.Data[corev1.TLSCertKey], certificate.Data[corev1.TLSPrivateKeyKey]
+	}
+	FillPrivateConnStrings(connectionStrings, &data)
+	return data, nil
+}
+
+// PasswordlessAuth returns the authentication mechanism the clients of the passwordless user connect with and the
+// username to put into the connection strings. Both are empty for the users authenticating with a password.
+func PasswordlessAuth(dbUser mdbv1.AtlasDatabaseUser) (string, string) {
+	switch {
+	case dbUser.Spec.AWSIAMType == mdbv1.AWSIAMTypeUser || dbUser.Spec.AWSIAMType == mdbv1.AWSIAMTypeRole:
+		return "MONGODB-AWS", ""
+	case dbUser.Spec.LDAPAuthType == mdbv1.LDAPAuthTypeUser:
+		return "PLAIN", dbUser.Spec.Username
+	case dbUser.Spec.LDAPAuthType == mdbv1.LDAPAuthTypeGroup:
+		// The members of the LDAP group connect with their own usernames
+		return "PLAIN", ""
+	case dbUser.Spec.OIDCAuthType == mdbv1.OIDCAuthTypeIDPGroup || dbUser.Spec.OIDCAuthType == mdbv1.OIDCAuthTypeUser:
+		return "MONGODB-OIDC", ""
+	case dbUser.Spec.X509Type != "" && dbUser.Spec.X509Type != mdbv1.NoneAuthType:
+		return "MONGODB-X509", ""
+	}
+	return "", ""
+}
+
 func cleanupStaleSecrets(ctx *workflow.Context, k8sClient client.Client, projectID string, user mdbv1.AtlasDatabaseUser) error {
 	if err := removeStaleByScope(ctx, k8sClient, projectID, user); err != nil {
 		return err
@@ -131,21 +274,19 @@ func cleanupStaleSecrets(ctx *workflow.Context, k8sClient client.Client, project
 }
 
 // removeStaleByScope removes the secrets that are not relevant due to changes to 'scopes' field for the AtlasDatabaseUser.
+// The secrets of the deployments and of the federated database instances are checked against the scopes of their own
+// type, so the users without 'DEPLOYMENT' scopes keep the secrets of all the deployments.
 func removeStaleByScope(ctx *workflow.Context, k8sClient client.Client, projectID string, user mdbv1.AtlasDatabaseUser) error {
-	scopes := user.GetScopes(mdbv1.DeploymentScopeType)
-	if len(scopes) == 0 {
-		return nil
-	}
 	secrets, err := ListByUserName(k8sClient, user.Namespace, projectID, user.Spec.Username)
 	if err != nil {
 		return err
 	}
 	for i, s := range secrets {
-		deployment, ok := s.Labels[ClusterLabelKey]
+		name, ok := s.Labels[ClusterLabelKey]
 		if !ok {
 			continue
 		}
-		if !stringutil.Contains(scopes, deployment) {
+		if !HasAccess(user, secretScopeType(&secrets[i]), name) {
 			if err = k8sClient.Delete(context.Background(), &secrets[i]); err != nil {
 				return err
 			}
@@ -155,6 +296,15 @@ func removeStaleByScope(ctx *workflow.Context, k8sClient client.Client, projectI
 	return nil
 }
 
+// secretScopeType returns the type of the scope the connection Secret is created for. Federated database instances
+// don't have SRV connection strings, so only their secrets have the SRV connection string empty.
+func secretScopeType(secret *corev1.Secret) mdbv1.ScopeType {
+	if len(secret.Data[standardKeySrv]) == 0 {
+		return mdbv1.DataLakeScopeType
+	}
+	return mdbv1.DeploymentScopeType
+}
+
 // RemoveStaleSecretsByUserName removes the stale secrets when the database user name changes (as it's used as a part of Secret name)
 func RemoveStaleSecretsByUserName(k8sClient client.Client, projectID, userName string, user mdbv1.AtlasDatabaseUser, log *zap.SugaredLogger) error {
 	secrets, err := ListByUserName(k8sClient, user.Namespace, projectID, userName)
@@ -187,9 +337,14 @@ func FillPrivateConnStrings(connStrings *mongodbatlas.ConnectionStrings, data *C
 	}
 
 	for _, pe := range connStrings.PrivateEndpoint {
+		endpoints := make([]PrivateLinkEndpoint, 0, len(pe.Endpoints))
+		for _, endpoint := range pe.Endpoints {
+			endpoints = append(endpoints, PrivateLinkEndpoint{ID: endpoint.EndpointID, Region: endpoint.Region})
+		}
 		data.PrivateConnURLs = append(data.PrivateConnURLs, PrivateLinkConnURLs{
 			PvtConnURL:    pe.ConnectionString,
 			PvtSrvConnURL: pe.SRVConnectionString,
+			Endpoints:     endpoints,
 		})
 	}
 }
@@ -0,0 +1,84 @@
+package connectionsecret
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+)
+
+func TestHasAccess(t *testing.T) {
+	t.Run("User without scopes", func(t *testing.T) {
+		user := *mdbv1.DefaultDBUser("ns", "user", "project")
+		assert.True(t, HasAccess(user, mdbv1.DeploymentScopeType, "c1"))
+		assert.False(t, HasAccess(user, mdbv1.DataLakeScopeType, "df1"))
+	})
+	t.Run("User with DATA_LAKE scopes only has access to all the deployments", func(t *testing.T) {
+		user := *mdbv1.DefaultDBUser("ns", "user", "project").WithScope(mdbv1.DataLakeScopeType, "df1")
+		assert.True(t, HasAccess(user, mdbv1.DeploymentScopeType, "c1"))
+		assert.True(t, HasAccess(user, mdbv1.DataLakeScopeType, "df1"))
+		assert.False(t, HasAccess(user, mdbv1.DataLakeScopeType, "df2"))
+	})
+	t.Run("User with DEPLOYMENT scopes", func(t *testing.T) {
+		user := *mdbv1.DefaultDBUser("ns", "user", "project").WithScope(mdbv1.DeploymentScopeType, "c1")
+		assert.True(t, HasAccess(user, mdbv1.DeploymentScopeType, "c1"))
+		assert.False(t, HasAccess(user, mdbv1.DeploymentScopeType, "c2"))
+	})
+}
+
+func TestRemoveStaleByScope(t *testing.T) {
+	const projectID = "603e7bf38a94956835659ae5"
+	scheme := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(scheme))
+
+	// removeStaleSecrets returns the deployments of the secrets kept after the cleanup
+	removeStaleSecrets := func(t *testing.T, user *mdbv1.AtlasDatabaseUser) []string {
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		for _, name := range []string{"c1", "c2", "df1", "df2"} {
+			data := dataForSecret()
+			data.DBUserName = user.Spec.Username
+			if strings.HasPrefix(name, "df") {
+				// federated database instances don't have SRV connection strings
+				data.SrvConnURL = ""
+			}
+			_, err := Ensure(k8sClient, "ns", "p1", projectID, name, data)
+			require.NoError(t, err)
+		}
+
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		require.NoError(t, removeStaleByScope(ctx, k8sClient, projectID, *user))
+
+		secrets, err := ListByUserName(k8sClient, "ns", projectID, user.Spec.Username)
+		require.NoError(t, err)
+		var names []string
+		for _, secret := range secrets {
+			names = append(names, secret.Labels[ClusterLabelKey])
+		}
+		return names
+	}
+
+	t.Run("User without scopes keeps the secrets of the deployments only", func(t *testing.T) {
+		user := mdbv1.DefaultDBUser("ns", "user", "project")
+		assert.ElementsMatch(t, []string{"c1", "c2"}, removeStaleSecrets(t, user))
+	})
+	t.Run("User with DATA_LAKE scopes only keeps the secrets of the deployments", func(t *testing.T) {
+		user := mdbv1.DefaultDBUser("ns", "user", "project").WithScope(mdbv1.DataLakeScopeType, "df1")
+		assert.ElementsMatch(t, []string{"c1", "c2", "df1"}, removeStaleSecrets(t, user))
+	})
+	t.Run("Secrets out of the scopes are removed", func(t *testing.T) {
+		user := mdbv1.DefaultDBUser("ns", "user", "project").
+			WithScope(mdbv1.DeploymentScopeType, "c1").
+			WithScope(mdbv1.DataLakeScopeType, "df1")
+		assert.ElementsMatch(t, []string{"c1", "df1"}, removeStaleSecrets(t, user))
+	})
+}
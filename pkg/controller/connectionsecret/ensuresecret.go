@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strings"
+	"unicode"
 
 	corev1 "k8s.io/api/core/v1"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
 )
 
@@ -19,12 +22,13 @@ const (
 	TypeLabelKey           = "atlas.mongodb.com/type"
 	CredLabelVal           = "credentials"
 
-	standardKey    string = "connectionStringStandard"
-	standardKeySrv string = "connectionStringStandardSrv"
-	privateKey     string = "connectionStringPrivate"
-	privateKeySrv  string = "connectionStringPrivateSrv"
-	userNameKey    string = "username"
-	passwordKey    string = "password"
+	standardKey      string = "connectionStringStandard"
+	standardKeySrv   string = "connectionStringStandardSrv"
+	privateKey       string = "connectionStringPrivate"
+	privateKeySrv    string = "connectionStringPrivateSrv"
+	onlineArchiveKey string = "connectionStringOnlineArchive"
+	userNameKey      string = "username"
+	passwordKey      string = "password"
 )
 
 type ConnectionData struct {
@@ -33,11 +37,31 @@ type ConnectionData struct {
 	ConnURL         string
 	SrvConnURL      string
 	PrivateConnURLs []PrivateLinkConnURLs
+	// OnlineArchiveConnURL is the connection string to query the deployment together with its Online Archive
+	OnlineArchiveConnURL string
+	// AuthMechanism is the authentication mechanism of the users whose credentials are managed outside of Atlas.
+	// The connection strings of such users get no password.
+	AuthMechanism string
+	// AuthUserName is the username put into the connection strings together with the AuthMechanism. It's empty if
+	// the client gets the identity from its environment (AWS IAM role, X.509 certificate or OIDC token).
+	AuthUserName string
+	// Certificate and PrivateKey are the PEM encoded Atlas-managed X.509 certificate of the user
+	Certificate []byte
+	PrivateKey  []byte
 }
 
 type PrivateLinkConnURLs struct {
 	PvtConnURL    string
 	PvtSrvConnURL string
+	// Endpoints are the private endpoints the connection strings are used through. They are empty for the
+	// connection strings of the peered networks.
+	Endpoints []PrivateLinkEndpoint
+}
+
+// PrivateLinkEndpoint is the private endpoint the connection strings are used through
+type PrivateLinkEndpoint struct {
+	ID     string
+	Region string
 }
 
 // Ensure creates or updates the connection Secret for the specific cluster and db user. Returns the name of the Secret
@@ -64,17 +88,20 @@ func Ensure(client client.Client, namespace, projectName, projectID, clusterName
 
 func fillSecret(secret *corev1.Secret, projectID string, clusterName string, data ConnectionData) error {
 	var err error
-	if data.ConnURL, err = AddCredentialsToConnectionURL(data.ConnURL, data.DBUserName, data.Password); err != nil {
+	if data.ConnURL, err = data.credentialsURL(data.ConnURL); err != nil {
+		return err
+	}
+	if data.SrvConnURL, err = data.credentialsURL(data.SrvConnURL); err != nil {
 		return err
 	}
-	if data.SrvConnURL, err = AddCredentialsToConnectionURL(data.SrvConnURL, data.DBUserName, data.Password); err != nil {
+	if data.OnlineArchiveConnURL, err = data.credentialsURL(data.OnlineArchiveConnURL); err != nil {
 		return err
 	}
 	for idx, privateConn := range data.PrivateConnURLs {
-		if data.PrivateConnURLs[idx].PvtConnURL, err = AddCredentialsToConnectionURL(privateConn.PvtConnURL, data.DBUserName, data.Password); err != nil {
+		if data.PrivateConnURLs[idx].PvtConnURL, err = data.credentialsURL(privateConn.PvtConnURL); err != nil {
 			return err
 		}
-		if data.PrivateConnURLs[idx].PvtSrvConnURL, err = AddCredentialsToConnectionURL(privateConn.PvtSrvConnURL, data.DBUserName, data.Password); err != nil {
+		if data.PrivateConnURLs[idx].PvtSrvConnURL, err = data.credentialsURL(privateConn.PvtSrvConnURL); err != nil {
 			return err
 		}
 	}
@@ -100,9 +127,73 @@ func fillSecret(secret *corev1.Secret, projectID string, clusterName string, dat
 		secret.Data[privateKeySrv+suffix] = []byte(privateConn.PvtSrvConnURL)
 	}
 
+	for suffix, privateConn := range privateEndpointConnURLs(data.PrivateConnURLs) {
+		secret.Data[privateKey+"-"+suffix] = []byte(privateConn.PvtConnURL)
+		secret.Data[privateKeySrv+"-"+suffix] = []byte(privateConn.PvtSrvConnURL)
+	}
+
+	if data.OnlineArchiveConnURL != "" {
+		secret.Data[onlineArchiveKey] = []byte(data.OnlineArchiveConnURL)
+	}
+
+	if len(data.Certificate) > 0 {
+		secret.Data[corev1.TLSCertKey] = data.Certificate
+		secret.Data[corev1.TLSPrivateKeyKey] = data.PrivateKey
+	}
+
 	return nil
 }
 
+// credentialsURL adds the credentials of the user to the connection string
+func (data ConnectionData) credentialsURL(connURL string) (string, error) {
+	if data.AuthMechanism != "" {
+		return AddAuthMechanismToConnectionURL(connURL, data.AuthUserName, data.AuthMechanism)
+	}
+	return AddCredentialsToConnectionURL(connURL, data.DBUserName, data.Password)
+}
+
+// privateEndpointConnURLs returns the private endpoint connection strings keyed by both the endpoint ID and the
+// region. If several endpoints are in the same region the region key gets the connection strings of the endpoint
+// with the lowest ID, so the choice doesn't depend on the order Atlas returns the endpoints in.
+func privateEndpointConnURLs(privateConns []PrivateLinkConnURLs) map[string]PrivateLinkConnURLs {
+	result := map[string]PrivateLinkConnURLs{}
+	regionEndpoints := map[string]string{}
+	for _, privateConn := range privateConns {
+		for _, endpoint := range privateConn.Endpoints {
+			if endpoint.ID != "" {
+				result[keySuffix(endpoint.ID)] = privateConn
+			}
+			if endpoint.Region == "" {
+				continue
+			}
+			region := keySuffix(endpoint.Region)
+			if endpointID, ok := regionEndpoints[region]; !ok || endpoint.ID < endpointID {
+				regionEndpoints[region] = endpoint.ID
+				result[region] = privateConn
+			}
+		}
+	}
+	return result
+}
+
+// keySuffix converts the endpoint ID or region to the valid Secret key suffix: the Azure resource IDs are shortened
+// to the endpoint name and the Atlas region names are converted to the cloud provider format (US_EAST_1 -> us-east-1).
+func keySuffix(value string) string {
+	if idx := strings.LastIndex(value, "/"); idx >= 0 {
+		value = value[idx+1:]
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return unicode.ToLower(r)
+		default:
+			return '-'
+		}
+	}, value)
+}
+
 func getSuffix(idx int) string {
 	if idx == 0 {
 		return ""
@@ -120,6 +211,10 @@ func formatSecretName(projectName, clusterName, dbUserName string) string {
 }
 
 func AddCredentialsToConnectionURL(connURL, userName, password string) (string, error) {
+	// Some connection strings may be missing (e.g. federated database instances don't have SRV ones)
+	if connURL == "" {
+		return "", nil
+	}
 	cs, err := url.Parse(connURL)
 	if err != nil {
 		return "", err
@@ -127,3 +222,24 @@ func AddCredentialsToConnectionURL(connURL, userName, password string) (string,
 	cs.User = url.UserPassword(userName, password)
 	return cs.String(), nil
 }
+
+// AddAuthMechanismToConnectionURL configures the connection string to authenticate with the specified mechanism
+// against the '$external' database. The userName is optional as some mechanisms take the identity from the client.
+func AddAuthMechanismToConnectionURL(connURL, userName, authMechanism string) (string, error) {
+	if connURL == "" {
+		return "", nil
+	}
+	cs, err := url.Parse(connURL)
+	if err != nil {
+		return "", err
+	}
+	cs.User = nil
+	if userName != "" {
+		cs.User = url.User(userName)
+	}
+	query := cs.Query()
+	query.Set("authSource", mdbv1.ExternalDatabaseName)
+	query.Set("authMechanism", authMechanism)
+	cs.RawQuery = query.Encode()
+	return cs.String(), nil
+}
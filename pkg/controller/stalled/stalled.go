@@ -0,0 +1,153 @@
+// Package stalled reports the long-running Atlas operations in progress for longer than their timeout. Only the
+// operations of the AtlasDeployment resources are tracked.
+package stalled
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/timeutil"
+)
+
+const StalledEvent = "OperationStalled"
+
+var (
+	operationDuration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "atlas_operator",
+		Name:      "operation_duration_seconds",
+		Help:      "Time the long-running Atlas operation of the resource has been in progress",
+	}, []string{"resource_kind", "namespace", "name", "operation"})
+
+	operationStalled = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "atlas_operator",
+		Name:      "operation_stalled",
+		Help:      "Whether the long-running Atlas operation of the resource exceeded its timeout",
+	}, []string{"resource_kind", "namespace", "name", "operation"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(operationDuration, operationStalled)
+}
+
+// Timeouts are the durations per kind of operation after which the operation is considered stalled. The kinds
+// without the timeout use workflow.DefaultTimeout.
+type Timeouts map[string]time.Duration
+
+func (t Timeouts) For(kind string) time.Duration {
+	if timeout, ok := t[kind]; ok {
+		return timeout
+	}
+	return workflow.DefaultTimeout
+}
+
+func (t Timeouts) String() string {
+	values := make([]string, 0, len(t))
+	for kind, timeout := range t {
+		values = append(values, fmt.Sprintf("%s=%s", kind, timeout))
+	}
+	sort.Strings(values)
+	return strings.Join(values, ",")
+}
+
+// ParseTimeouts parses the timeouts in the form of "Creating=1h,Updating=2h"
+func ParseTimeouts(value string) (Timeouts, error) {
+	timeouts := Timeouts{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kind, duration, found := strings.Cut(entry, "=")
+		if !found || kind == "" {
+			return nil, fmt.Errorf("invalid operation timeout %q, expected <kind>=<duration>", entry)
+		}
+		timeout, err := time.ParseDuration(duration)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid operation timeout %q, expected positive duration", entry)
+		}
+		timeouts[kind] = timeout
+	}
+	return timeouts, nil
+}
+
+// Tracker records the long-running Atlas operations of the resources of one kind and reports the operations in
+// progress for longer than their timeout with the Stalled condition, a Warning event and the metrics.
+type Tracker struct {
+	ResourceKind  string
+	Timeouts      Timeouts
+	EventRecorder record.EventRecorder
+}
+
+// Track records the operation of the given kind in progress at the moment now. The current operation is kept if it
+// is of the same kind so its start time is preserved. It returns the operation to store in the status.
+func (t Tracker) Track(ctx *workflow.Context, resource mdbv1.AtlasCustomResource, current *status.Operation, kind string, now time.Time) *status.Operation {
+	operation := current
+	if operation == nil || operation.Kind != kind {
+		t.forget(resource, current)
+		operation = &status.Operation{Kind: kind, StartedAt: timeutil.FormatISO8601(now.UTC())}
+	}
+
+	startedAt, err := timeutil.ParseISO8601(operation.StartedAt)
+	if err != nil {
+		startedAt = now
+		operation = &status.Operation{Kind: kind, StartedAt: timeutil.FormatISO8601(now.UTC())}
+	}
+	elapsed := now.Sub(startedAt)
+	timeout := t.Timeouts.For(kind)
+
+	labels := t.labels(resource, kind)
+	operationDuration.With(labels).Set(elapsed.Seconds())
+	if elapsed < timeout {
+		operationStalled.With(labels).Set(0)
+		ctx.UnsetCondition(status.StalledType)
+		return operation
+	}
+
+	operationStalled.With(labels).Set(1)
+	message := fmt.Sprintf("%s has been in progress in Atlas for %s which exceeds the timeout of %s", kind, elapsed.Truncate(time.Second), timeout)
+	if condition, found := ctx.GetCondition(status.StalledType); !found || condition.Status != corev1.ConditionTrue {
+		ctx.Log.Warnw("Long-running Atlas operation stalled", "operation", kind, "startedAt", operation.StartedAt, "timeout", timeout)
+		t.EventRecorder.Event(resource, "Warning", StalledEvent, message)
+	}
+	ctx.EnsureCondition(status.Condition{
+		Type:    status.StalledType,
+		Status:  corev1.ConditionTrue,
+		Reason:  string(workflow.OperationStalled),
+		Message: message,
+	})
+	return operation
+}
+
+// Finish removes the Stalled condition and the metrics of the finished operation
+func (t Tracker) Finish(ctx *workflow.Context, resource mdbv1.AtlasCustomResource, current *status.Operation) {
+	t.forget(resource, current)
+	ctx.UnsetCondition(status.StalledType)
+}
+
+func (t Tracker) forget(resource mdbv1.AtlasCustomResource, operation *status.Operation) {
+	if operation == nil {
+		return
+	}
+	labels := t.labels(resource, operation.Kind)
+	operationDuration.Delete(labels)
+	operationStalled.Delete(labels)
+}
+
+func (t Tracker) labels(resource mdbv1.AtlasCustomResource, kind string) prometheus.Labels {
+	return prometheus.Labels{
+		"resource_kind": t.ResourceKind,
+		"namespace":     resource.GetNamespace(),
+		"name":          resource.GetName(),
+		"operation":     kind,
+	}
+}
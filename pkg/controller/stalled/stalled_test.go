@@ -0,0 +1,83 @@
+package stalled
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+)
+
+func TestParseTimeouts(t *testing.T) {
+	timeouts, err := ParseTimeouts("Creating=1h, Updating=90m")
+	require.NoError(t, err)
+	assert.Equal(t, Timeouts{"Creating": time.Hour, "Updating": 90 * time.Minute}, timeouts)
+	assert.Equal(t, time.Hour, timeouts.For("Creating"))
+	assert.Equal(t, workflow.DefaultTimeout, timeouts.For("Deleting"))
+	assert.Equal(t, "Creating=1h0m0s,Updating=1h30m0s", timeouts.String())
+
+	for _, value := range []string{"Creating", "=1h", "Creating=soon", "Creating=-1h"} {
+		_, err = ParseTimeouts(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestTracker(t *testing.T) {
+	deployment := mdbv1.DefaultAwsAdvancedDeployment("ns", "stalled-test")
+	recorder := record.NewFakeRecorder(10)
+	tracker := Tracker{ResourceKind: "AtlasDeployment", Timeouts: Timeouts{"Updating": time.Hour}, EventRecorder: recorder}
+	startedAt := time.Date(2023, 6, 9, 12, 0, 0, 0, time.UTC)
+	labels := tracker.labels(deployment, "Updating")
+
+	t.Run("Operation is started", func(t *testing.T) {
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+
+		operation := tracker.Track(ctx, deployment, nil, "Updating", startedAt)
+		assert.Equal(t, &status.Operation{Kind: "Updating", StartedAt: "2023-06-09T12:00:00Z"}, operation)
+		_, found := ctx.GetCondition(status.StalledType)
+		assert.False(t, found)
+	})
+	t.Run("Operation is stalled after the timeout", func(t *testing.T) {
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		current := &status.Operation{Kind: "Updating", StartedAt: "2023-06-09T12:00:00Z"}
+
+		operation := tracker.Track(ctx, deployment, current, "Updating", startedAt.Add(2*time.Hour))
+		assert.Equal(t, current, operation)
+		condition, found := ctx.GetCondition(status.StalledType)
+		require.True(t, found)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Equal(t, string(workflow.OperationStalled), condition.Reason)
+		assert.Equal(t, float64(7200), testutil.ToFloat64(operationDuration.With(labels)))
+		assert.Equal(t, float64(1), testutil.ToFloat64(operationStalled.With(labels)))
+		require.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, "Warning OperationStalled Updating has been in progress in Atlas for 2h0m0s")
+
+		// the event is raised once while the condition is set
+		tracker.Track(ctx, deployment, current, "Updating", startedAt.Add(3*time.Hour))
+		assert.Empty(t, recorder.Events)
+	})
+	t.Run("New kind of operation restarts the tracking", func(t *testing.T) {
+		ctx := workflow.NewContext(zap.S(), []status.Condition{})
+		current := &status.Operation{Kind: "Updating", StartedAt: "2023-06-09T12:00:00Z"}
+
+		operation := tracker.Track(ctx, deployment, current, "Repairing", startedAt.Add(2*time.Hour))
+		assert.Equal(t, &status.Operation{Kind: "Repairing", StartedAt: "2023-06-09T14:00:00Z"}, operation)
+		assert.Equal(t, 1, testutil.CollectAndCount(operationDuration))
+	})
+	t.Run("Finished operation is forgotten", func(t *testing.T) {
+		ctx := workflow.NewContext(zap.S(), []status.Condition{status.TrueCondition(status.StalledType)})
+
+		tracker.Finish(ctx, deployment, &status.Operation{Kind: "Repairing", StartedAt: "2023-06-09T14:00:00Z"})
+		_, found := ctx.GetCondition(status.StalledType)
+		assert.False(t, found)
+		assert.Equal(t, 0, testutil.CollectAndCount(operationDuration))
+	})
+}
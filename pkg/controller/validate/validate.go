@@ -3,7 +3,10 @@ package validate
 import (
 	"errors"
 	"fmt"
+	"net"
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -11,6 +14,9 @@ import (
 	"github.com/hashicorp/go-multierror"
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/cron"
 )
 
 func DeploymentSpec(deploymentSpec mdbv1.AtlasDeploymentSpec) error {
@@ -43,6 +49,203 @@ func DeploymentSpec(deploymentSpec mdbv1.AtlasDeploymentSpec) error {
 		if autoscalingErr != nil {
 			err = multierror.Append(err, autoscalingErr)
 		}
+
+		if searchIndexesErr := searchIndexes(deploymentSpec.AdvancedDeploymentSpec.SearchIndexes); searchIndexesErr != nil {
+			err = multierror.Append(err, searchIndexesErr)
+		}
+	}
+
+	if deploymentSpec.ServerlessSpec != nil && len(deploymentSpec.OnlineArchives) > 0 {
+		err = multierror.Append(err, errors.New("online archives are not supported by serverless deployments"))
+	}
+
+	if onlineArchivesErr := onlineArchives(deploymentSpec.OnlineArchives); onlineArchivesErr != nil {
+		err = multierror.Append(err, onlineArchivesErr)
+	}
+
+	if scheduleErr := deploymentSchedule(deploymentSpec); scheduleErr != nil {
+		err = multierror.Append(err, scheduleErr)
+	}
+
+	if scalingProfilesErr := scalingProfiles(deploymentSpec); scalingProfilesErr != nil {
+		err = multierror.Append(err, scalingProfilesErr)
+	}
+
+	if upgradeErr := majorVersionUpgrade(deploymentSpec); upgradeErr != nil {
+		err = multierror.Append(err, upgradeErr)
+	}
+
+	if deletionPolicyErr := deletionPolicy(deploymentSpec); deletionPolicyErr != nil {
+		err = multierror.Append(err, deletionPolicyErr)
+	}
+
+	if restoreErr := restoreFrom(deploymentSpec); restoreErr != nil {
+		err = multierror.Append(err, restoreErr)
+	}
+
+	return err
+}
+
+func deploymentSchedule(deploymentSpec mdbv1.AtlasDeploymentSpec) error {
+	schedule := deploymentSpec.Schedule
+	if schedule == nil {
+		return nil
+	}
+
+	var err error
+	if deploymentSpec.ServerlessSpec != nil {
+		err = multierror.Append(err, errors.New("schedule is not supported by serverless deployments"))
+	}
+	if (deploymentSpec.AdvancedDeploymentSpec != nil && deploymentSpec.AdvancedDeploymentSpec.Paused != nil) ||
+		(deploymentSpec.DeploymentSpec != nil && deploymentSpec.DeploymentSpec.Paused != nil) {
+		err = multierror.Append(err, errors.New("paused must not be set together with the schedule"))
+	}
+	if windowErr := cronWindow(schedule.Pause, schedule.Resume, schedule.TimeZone); windowErr != nil {
+		err = multierror.Append(err, fmt.Errorf("invalid schedule: %w", windowErr))
+	}
+	return err
+}
+
+func scalingProfiles(deploymentSpec mdbv1.AtlasDeploymentSpec) error {
+	if len(deploymentSpec.ScalingProfiles) == 0 {
+		return nil
+	}
+
+	var err error
+	if deploymentSpec.ServerlessSpec != nil {
+		err = multierror.Append(err, errors.New("scaling profiles are not supported by serverless deployments"))
+	}
+
+	names := map[string]bool{}
+	for _, profile := range deploymentSpec.ScalingProfiles {
+		if names[profile.Name] {
+			err = multierror.Append(err, fmt.Errorf("scaling profile %s: the name must be unique", profile.Name))
+		}
+		names[profile.Name] = true
+
+		if len(profile.RegionConfigs) == 0 {
+			err = multierror.Append(err, fmt.Errorf("scaling profile %s: at least one region config must be overridden", profile.Name))
+		}
+		if profile.Start == "" && profile.End == "" {
+			continue
+		}
+		if windowErr := cronWindow(profile.Start, profile.End, profile.TimeZone); windowErr != nil {
+			err = multierror.Append(err, fmt.Errorf("scaling profile %s: %w", profile.Name, windowErr))
+		}
+	}
+	return err
+}
+
+func majorVersionUpgrade(deploymentSpec mdbv1.AtlasDeploymentSpec) error {
+	upgrade := deploymentSpec.MajorVersionUpgrade
+	if upgrade == nil {
+		return nil
+	}
+
+	var err error
+	if deploymentSpec.ServerlessSpec != nil {
+		err = multierror.Append(err, errors.New("major version upgrade is not supported by serverless deployments"))
+	}
+	if upgrade.Window != nil {
+		if windowErr := cronWindow(upgrade.Window.Start, upgrade.Window.End, upgrade.Window.TimeZone); windowErr != nil {
+			err = multierror.Append(err, fmt.Errorf("invalid upgrade window: %w", windowErr))
+		}
+	}
+	return err
+}
+
+func deletionPolicy(deploymentSpec mdbv1.AtlasDeploymentSpec) error {
+	policy := deploymentSpec.DeletionPolicy
+	if policy == nil {
+		return nil
+	}
+
+	var err error
+	if deploymentSpec.ServerlessSpec != nil {
+		err = multierror.Append(err, errors.New("deletion policy is not supported by serverless deployments"))
+	}
+	return err
+}
+
+func restoreFrom(deploymentSpec mdbv1.AtlasDeploymentSpec) error {
+	restore := deploymentSpec.RestoreFrom
+	if restore == nil {
+		return nil
+	}
+
+	var err error
+	if deploymentSpec.ServerlessSpec != nil {
+		err = multierror.Append(err, errors.New("restoring the backup is not supported by serverless deployments"))
+	}
+	if restore.DeploymentRef.Name == "" {
+		err = multierror.Append(err, errors.New("restoreFrom.deploymentRef.name must be set"))
+	}
+	if restore.SnapshotID != "" && restore.PointInTime != "" {
+		err = multierror.Append(err, errors.New("only one of restoreFrom.snapshotId and restoreFrom.pointInTime can be set"))
+	}
+	if restore.PointInTime != "" {
+		if _, timeErr := time.Parse(time.RFC3339, restore.PointInTime); timeErr != nil {
+			err = multierror.Append(err, fmt.Errorf("invalid restoreFrom.pointInTime, expected RFC 3339 time: %w", timeErr))
+		}
+	}
+	return err
+}
+
+func cronWindow(start, end, timeZone string) error {
+	var err error
+	if _, cronErr := cron.Parse(start); cronErr != nil {
+		err = multierror.Append(err, fmt.Errorf("invalid start: %w", cronErr))
+	}
+	if _, cronErr := cron.Parse(end); cronErr != nil {
+		err = multierror.Append(err, fmt.Errorf("invalid end: %w", cronErr))
+	}
+	if _, locationErr := time.LoadLocation(timeZone); locationErr != nil {
+		err = multierror.Append(err, fmt.Errorf("invalid time zone: %w", locationErr))
+	}
+	return err
+}
+
+func onlineArchives(onlineArchives []mdbv1.OnlineArchive) error {
+	var err error
+
+	namespaces := map[interface{}]bool{}
+	for _, archive := range onlineArchives {
+		if namespaces[archive.Identifier()] {
+			err = multierror.Append(err, fmt.Errorf("online archive %s: only one online archive can be configured for the collection", archive.Identifier()))
+		}
+		namespaces[archive.Identifier()] = true
+
+		switch archive.Criteria.Type {
+		case mdbv1.OnlineArchiveCriteriaDate:
+			if archive.Criteria.DateField == "" || archive.Criteria.ExpireAfterDays == 0 {
+				err = multierror.Append(err, fmt.Errorf("online archive %s: dateField and expireAfterDays are required for the DATE criteria", archive.Identifier()))
+			}
+		case mdbv1.OnlineArchiveCriteriaCustom:
+			if archive.Criteria.Query == "" {
+				err = multierror.Append(err, fmt.Errorf("online archive %s: query is required for the CUSTOM criteria", archive.Identifier()))
+			}
+		}
+	}
+
+	return err
+}
+
+func searchIndexes(searchIndexes []mdbv1.SearchIndex) error {
+	var err error
+
+	indexes := map[interface{}]bool{}
+	for _, index := range searchIndexes {
+		if indexes[index.Identifier()] {
+			err = multierror.Append(err, fmt.Errorf("search index %s: index names must be unique within the collection", index.Identifier()))
+		}
+		indexes[index.Identifier()] = true
+
+		if index.DefinitionRef != nil && index.HasInlineDefinition() {
+			err = multierror.Append(err, fmt.Errorf("search index %s: only one of definitionRef or the inline definition can be specified", index.Identifier()))
+		}
+		if index.DefinitionRef == nil && index.Mappings == nil {
+			err = multierror.Append(err, fmt.Errorf("search index %s: you must specify either mappings or definitionRef", index.Identifier()))
+		}
 	}
 
 	return err
@@ -53,13 +256,323 @@ func Project(project *mdbv1.AtlasProject) error {
 		return err
 	}
 
+	if err := projectLDAP(project.Spec.LDAP); err != nil {
+		return err
+	}
+
+	if err := projectCloudProviderAccessRoles(project.Spec.CloudProviderAccessRoles); err != nil {
+		return err
+	}
+
+	if err := projectIPAccessListSources(project.Spec.IPAccessListSources); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-func DatabaseUser(_ *mdbv1.AtlasDatabaseUser) error {
+func projectIPAccessListSources(sources []project.IPAccessListSource) error {
+	var err error
+	for i, source := range sources {
+		if getNonNilCount(source.Nodes, source.Services, source.ConfigMap) != 1 {
+			err = multierror.Append(err, fmt.Errorf("ipAccessListSources[%d]: exactly one of nodes, services or configMap must be specified", i))
+			continue
+		}
+		if source.ConfigMap != nil && (source.ConfigMap.Name == "" || source.ConfigMap.Key == "") {
+			err = multierror.Append(err, fmt.Errorf("ipAccessListSources[%d]: configMap must have both name and key", i))
+		}
+	}
+
+	return err
+}
+
+func projectCloudProviderAccessRoles(roles []mdbv1.CloudProviderAccessRole) error {
+	var err error
+	azureRoles := map[mdbv1.CloudProviderAccessRole]bool{}
+	gcpRoles := 0
+	for _, role := range roles {
+		azureFields := 0
+		for _, field := range []string{role.AtlasAzureAppID, role.ServicePrincipalID, role.TenantID} {
+			if field != "" {
+				azureFields++
+			}
+		}
+
+		switch role.ProviderName {
+		case string(provider.ProviderAzure):
+			if azureFields != 3 {
+				err = multierror.Append(err, errors.New("cloudProviderAccessRoles: atlasAzureAppId, servicePrincipalId and tenantId must be specified for the AZURE role"))
+			}
+			if azureRoles[role] {
+				err = multierror.Append(err, fmt.Errorf("cloudProviderAccessRoles: the AZURE role for the service principal %s is specified more than once", role.ServicePrincipalID))
+			}
+			azureRoles[role] = true
+		case string(provider.ProviderGCP):
+			gcpRoles++
+			if azureFields != 0 || role.IamAssumedRoleArn != "" {
+				err = multierror.Append(err, errors.New("cloudProviderAccessRoles: the GCP role can't have any AWS or Azure fields"))
+			}
+		default:
+			if azureFields != 0 {
+				err = multierror.Append(err, fmt.Errorf("cloudProviderAccessRoles: the %s role can't have the Azure fields", role.ProviderName))
+			}
+		}
+		if role.ProviderName != string(provider.ProviderAWS) && role.IamAssumedRoleArn != "" {
+			err = multierror.Append(err, fmt.Errorf("cloudProviderAccessRoles: iamAssumedRoleArn can't be specified for the %s role", role.ProviderName))
+		}
+	}
+	if gcpRoles > 1 {
+		err = multierror.Append(err, errors.New("cloudProviderAccessRoles: only one GCP role can be specified"))
+	}
+
+	return err
+}
+
+func projectLDAP(ldap *mdbv1.LDAPConfiguration) error {
+	if ldap == nil {
+		return nil
+	}
+
+	var err error
+	if ldap.AuthorizationEnabled && ldap.AuthzQueryTemplate == "" {
+		err = multierror.Append(err, errors.New("ldap: authzQueryTemplate must be specified when authorization is enabled"))
+	}
+	for _, mapping := range ldap.UserToDNMapping {
+		if (mapping.Substitution == "") == (mapping.LDAPQuery == "") {
+			err = multierror.Append(err, fmt.Errorf("ldap: userToDNMapping for %q must have exactly one of substitution or ldapQuery", mapping.Match))
+		}
+	}
+
+	return err
+}
+
+func DatabaseUser(dbUser *mdbv1.AtlasDatabaseUser) error {
+	if dbUser.Spec.X509Certificate != nil && !dbUser.HasManagedCertificate() {
+		return errors.New("x509Certificate can only be specified for the users with x509Type MANAGED")
+	}
+
+	authTypes := dbUser.ExternalAuthTypes()
+	if len(authTypes) == 0 {
+		return nil
+	}
+	if len(authTypes) > 1 {
+		return fmt.Errorf("only one of x509Type, awsIAMType, ldapAuthType or oidcAuthType can be set, got %s", strings.Join(authTypes, ", "))
+	}
+	if dbUser.Spec.PasswordSecret != nil {
+		return fmt.Errorf("passwordSecretRef can't be specified together with %s", authTypes[0])
+	}
+
+	externalDatabase := true
+	switch {
+	case dbUser.Spec.LDAPAuthType == mdbv1.LDAPAuthTypeGroup:
+		externalDatabase = false
+	case dbUser.Spec.OIDCAuthType == mdbv1.OIDCAuthTypeIDPGroup:
+		externalDatabase = false
+	}
+	if externalDatabase && dbUser.Spec.DatabaseName != mdbv1.ExternalDatabaseName {
+		return fmt.Errorf("databaseName must be %s for the users with %s set", mdbv1.ExternalDatabaseName, authTypes[0])
+	}
+	if !externalDatabase && dbUser.Spec.DatabaseName == mdbv1.ExternalDatabaseName {
+		return fmt.Errorf("databaseName can't be %s for the groups with %s set", mdbv1.ExternalDatabaseName, authTypes[0])
+	}
+
 	return nil
 }
 
+func DataFederation(dataFederation *mdbv1.AtlasDataFederation) error {
+	var err error
+
+	if dataFederation.Spec.CloudProviderConfig != nil && dataFederation.Spec.CloudProviderConfig.AWS != nil {
+		aws := dataFederation.Spec.CloudProviderConfig.AWS
+		if aws.RoleID == "" && aws.IamAssumedRoleArn == "" {
+			err = multierror.Append(err, errors.New("cloudProviderConfig.aws: you must specify either roleId or iamAssumedRoleArn"))
+		}
+		if aws.RoleID != "" && aws.IamAssumedRoleArn != "" {
+			err = multierror.Append(err, errors.New("cloudProviderConfig.aws: only one of roleId or iamAssumedRoleArn can be specified"))
+		}
+	}
+
+	if dataFederation.Spec.Storage == nil {
+		return err
+	}
+
+	stores := map[string]mdbv1.DataFederationStoreProvider{}
+	for _, store := range dataFederation.Spec.Storage.Stores {
+		if _, ok := stores[store.Name]; ok {
+			err = multierror.Append(err, fmt.Errorf("store %q: store names must be unique", store.Name))
+		}
+		stores[store.Name] = store.Provider
+
+		switch store.Provider {
+		case mdbv1.DataFederationStoreAtlas:
+			if store.ClusterName == "" {
+				err = multierror.Append(err, fmt.Errorf("store %q: clusterName is required for the atlas provider", store.Name))
+			}
+		case mdbv1.DataFederationStoreS3:
+			if store.Bucket == "" || store.Region == "" {
+				err = multierror.Append(err, fmt.Errorf("store %q: bucket and region are required for the s3 provider", store.Name))
+			}
+		case mdbv1.DataFederationStoreHTTP:
+			if len(store.URLs) == 0 {
+				err = multierror.Append(err, fmt.Errorf("store %q: urls are required for the http provider", store.Name))
+			}
+		}
+	}
+
+	for _, database := range dataFederation.Spec.Storage.Databases {
+		for _, collection := range database.Collections {
+			for _, dataSource := range collection.DataSources {
+				provider, ok := stores[dataSource.StoreName]
+				if !ok {
+					err = multierror.Append(err, fmt.Errorf("collection %s.%s: data source references unknown store %q", database.Name, collection.Name, dataSource.StoreName))
+					continue
+				}
+				if provider == mdbv1.DataFederationStoreS3 && dataFederation.Spec.CloudProviderConfig == nil {
+					err = multierror.Append(err, fmt.Errorf("collection %s.%s: s3 store %q requires cloudProviderConfig.aws to be specified", database.Name, collection.Name, dataSource.StoreName))
+				}
+			}
+		}
+	}
+
+	return err
+}
+
+func APIKey(apiKey *mdbv1.AtlasAPIKey) error {
+	var err error
+
+	roles := map[mdbv1.APIKeyRole]bool{}
+	for _, role := range apiKey.Spec.Roles {
+		if roles[role] {
+			err = multierror.Append(err, fmt.Errorf("role %s is specified more than once", role))
+		}
+		roles[role] = true
+	}
+
+	for _, entry := range apiKey.Spec.AccessList {
+		if (entry.IPAddress == "") == (entry.CIDRBlock == "") {
+			err = multierror.Append(err, errors.New("accessList: you must specify exactly one of ipAddress or cidrBlock"))
+			continue
+		}
+		if entry.IPAddress != "" && net.ParseIP(entry.IPAddress) == nil {
+			err = multierror.Append(err, fmt.Errorf("accessList: invalid ip address %q", entry.IPAddress))
+		}
+		if entry.CIDRBlock != "" {
+			if _, _, cidrErr := net.ParseCIDR(entry.CIDRBlock); cidrErr != nil {
+				err = multierror.Append(err, fmt.Errorf("accessList: invalid cidr block %q", entry.CIDRBlock))
+			}
+		}
+	}
+
+	return err
+}
+
+func FederatedAuth(fedAuth *mdbv1.AtlasFederatedAuth) error {
+	var err error
+
+	if fedAuth.IsEnabled() && fedAuth.Spec.IdentityProviderID == "" {
+		err = multierror.Append(err, errors.New("identityProviderId must be set to connect the organization to the federation"))
+	}
+
+	idps := map[string]bool{}
+	for _, idp := range fedAuth.Spec.IdentityProviders {
+		if idps[idp.ID] {
+			err = multierror.Append(err, fmt.Errorf("identity provider %s is specified more than once", idp.ID))
+		}
+		idps[idp.ID] = true
+		if idp.OIDC != nil && (idp.OIDC.Audience == "" || idp.OIDC.ClientID == "") {
+			err = multierror.Append(err, fmt.Errorf("identity provider %s: oidc audience and clientId must be set", idp.ID))
+		}
+	}
+
+	groups := map[string]bool{}
+	for _, mapping := range fedAuth.Spec.RoleMappings {
+		if groups[mapping.ExternalGroupName] {
+			err = multierror.Append(err, fmt.Errorf("role mapping for the group %s is specified more than once", mapping.ExternalGroupName))
+		}
+		groups[mapping.ExternalGroupName] = true
+
+		for _, assignment := range mapping.RoleAssignments {
+			switch {
+			case strings.HasPrefix(assignment.Role, "ORG_") && assignment.ProjectRef != nil:
+				err = multierror.Append(err, fmt.Errorf("role mapping %s: organization role %s can't reference a project", mapping.ExternalGroupName, assignment.Role))
+			case strings.HasPrefix(assignment.Role, "GROUP_") && assignment.ProjectRef == nil:
+				err = multierror.Append(err, fmt.Errorf("role mapping %s: project role %s requires projectRef", mapping.ExternalGroupName, assignment.Role))
+			case !strings.HasPrefix(assignment.Role, "ORG_") && !strings.HasPrefix(assignment.Role, "GROUP_"):
+				err = multierror.Append(err, fmt.Errorf("role mapping %s: unknown role %s", mapping.ExternalGroupName, assignment.Role))
+			}
+		}
+	}
+
+	return err
+}
+
+func PrivateEndpoint(privateEndpoint *mdbv1.AtlasPrivateEndpoint) error {
+	endpoint := privateEndpoint.Spec.Interface
+	if endpoint == nil {
+		return nil
+	}
+
+	var err error
+	switch privateEndpoint.Spec.Provider {
+	case provider.ProviderGCP:
+		if endpoint.GCPProjectID == "" || endpoint.EndpointGroupName == "" || len(endpoint.Endpoints) == 0 {
+			err = multierror.Append(err, errors.New("interface: gcpProjectId, endpointGroupName and endpoints must be specified for the GCP private endpoint"))
+		}
+		if endpoint.ID != "" || endpoint.IP != "" {
+			err = multierror.Append(err, errors.New("interface: id and ip can't be specified for the GCP private endpoint"))
+		}
+	case provider.ProviderAzure:
+		if endpoint.ID == "" || endpoint.IP == "" {
+			err = multierror.Append(err, errors.New("interface: id and ip must be specified for the AZURE private endpoint"))
+		}
+	default:
+		if endpoint.ID == "" {
+			err = multierror.Append(err, fmt.Errorf("interface: id must be specified for the %s private endpoint", privateEndpoint.Spec.Provider))
+		}
+	}
+
+	return err
+}
+
+func NetworkContainer(container *mdbv1.AtlasNetworkContainer) error {
+	var err error
+
+	if _, _, cidrErr := net.ParseCIDR(container.Spec.AtlasCIDRBlock); cidrErr != nil {
+		err = multierror.Append(err, fmt.Errorf("atlasCidrBlock is not a valid CIDR block: %w", cidrErr))
+	}
+	if container.Spec.Provider != provider.ProviderGCP && container.Spec.Region == "" {
+		err = multierror.Append(err, fmt.Errorf("region must be specified for the %s container", container.Spec.Provider))
+	}
+
+	return err
+}
+
+func NetworkPeering(peering *mdbv1.AtlasNetworkPeering) error {
+	var err error
+
+	if (peering.Spec.Container == nil) == (peering.Spec.ContainerID == "") {
+		err = multierror.Append(err, errors.New("exactly one of containerRef or containerId must be specified"))
+	}
+
+	spec := peering.Spec
+	switch spec.Provider {
+	case provider.ProviderAWS:
+		if spec.AccepterRegionName == "" || spec.AWSAccountID == "" || spec.RouteTableCIDRBlock == "" || spec.VpcID == "" {
+			err = multierror.Append(err, errors.New("accepterRegionName, awsAccountId, routeTableCidrBlock and vpcId must be specified for the AWS peering connection"))
+		}
+	case provider.ProviderGCP:
+		if spec.GCPProjectID == "" || spec.NetworkName == "" {
+			err = multierror.Append(err, errors.New("gcpProjectId and networkName must be specified for the GCP peering connection"))
+		}
+	case provider.ProviderAzure:
+		if spec.AzureDirectoryID == "" || spec.AzureSubscriptionID == "" || spec.ResourceGroupName == "" || spec.VNetName == "" {
+			err = multierror.Append(err, errors.New("azureDirectoryId, azureSubscriptionId, resourceGroupName and vnetName must be specified for the AZURE peering connection"))
+		}
+	}
+
+	return err
+}
+
 func BackupSchedule(bSchedule *mdbv1.AtlasBackupSchedule, deployment *mdbv1.AtlasDeployment) error {
 	var err error
 
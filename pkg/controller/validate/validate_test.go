@@ -3,11 +3,15 @@ package validate
 import (
 	"testing"
 
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
 
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
 )
@@ -247,6 +251,114 @@ func TestClusterValidation(t *testing.T) {
 	})
 }
 
+func TestDeploymentScheduleValidation(t *testing.T) {
+	newSpec := func(schedule mdbv1.DeploymentSchedule) mdbv1.AtlasDeploymentSpec {
+		return mdbv1.AtlasDeploymentSpec{AdvancedDeploymentSpec: &mdbv1.AdvancedDeploymentSpec{}, Schedule: &schedule}
+	}
+
+	assert.NoError(t, DeploymentSpec(newSpec(mdbv1.DeploymentSchedule{Pause: "0 20 * * mon-fri", Resume: "0 8 * * mon-fri", TimeZone: "Europe/Dublin"})))
+
+	t.Run("Invalid cron expressions", func(t *testing.T) {
+		assert.Error(t, DeploymentSpec(newSpec(mdbv1.DeploymentSchedule{Pause: "0 25 * * *", Resume: "0 8 * * *"})))
+		assert.Error(t, DeploymentSpec(newSpec(mdbv1.DeploymentSchedule{Pause: "0 20 * * *", Resume: "daily"})))
+	})
+	t.Run("Invalid time zone", func(t *testing.T) {
+		assert.Error(t, DeploymentSpec(newSpec(mdbv1.DeploymentSchedule{Pause: "0 20 * * *", Resume: "0 8 * * *", TimeZone: "Mars/Olympus"})))
+	})
+	t.Run("Paused set together with the schedule", func(t *testing.T) {
+		spec := newSpec(mdbv1.DeploymentSchedule{Pause: "0 20 * * *", Resume: "0 8 * * *"})
+		spec.AdvancedDeploymentSpec.Paused = toptr.MakePtr(false)
+		assert.Error(t, DeploymentSpec(spec))
+	})
+	t.Run("Serverless deployment", func(t *testing.T) {
+		spec := mdbv1.AtlasDeploymentSpec{
+			ServerlessSpec: &mdbv1.ServerlessSpec{},
+			Schedule:       &mdbv1.DeploymentSchedule{Pause: "0 20 * * *", Resume: "0 8 * * *"},
+		}
+		assert.Error(t, DeploymentSpec(spec))
+	})
+}
+
+func TestScalingProfilesValidation(t *testing.T) {
+	newProfile := func(name, start, end string) mdbv1.ScalingProfile {
+		return mdbv1.ScalingProfile{
+			Name:          name,
+			Start:         start,
+			End:           end,
+			RegionConfigs: []mdbv1.ScalingProfileRegionConfig{{ProviderName: "AWS", RegionName: "US_EAST_1", ElectableSpecs: &mdbv1.Specs{InstanceSize: "M60"}}},
+		}
+	}
+	newSpec := func(profiles ...mdbv1.ScalingProfile) mdbv1.AtlasDeploymentSpec {
+		return mdbv1.AtlasDeploymentSpec{AdvancedDeploymentSpec: &mdbv1.AdvancedDeploymentSpec{}, ScalingProfiles: profiles}
+	}
+
+	assert.NoError(t, DeploymentSpec(newSpec(newProfile("month-end", "0 0 28 * *", "0 0 1 * *"), newProfile("manual", "", ""))))
+
+	t.Run("Duplicate names", func(t *testing.T) {
+		assert.Error(t, DeploymentSpec(newSpec(newProfile("month-end", "0 0 28 * *", "0 0 1 * *"), newProfile("month-end", "", ""))))
+	})
+	t.Run("Start without end", func(t *testing.T) {
+		assert.Error(t, DeploymentSpec(newSpec(newProfile("month-end", "0 0 28 * *", ""))))
+	})
+	t.Run("No region configs", func(t *testing.T) {
+		profile := newProfile("month-end", "0 0 28 * *", "0 0 1 * *")
+		profile.RegionConfigs = nil
+		assert.Error(t, DeploymentSpec(newSpec(profile)))
+	})
+}
+
+func TestMajorVersionUpgradeValidation(t *testing.T) {
+	spec := mdbv1.AtlasDeploymentSpec{
+		AdvancedDeploymentSpec: &mdbv1.AdvancedDeploymentSpec{MongoDBMajorVersion: "6.0"},
+		MajorVersionUpgrade:    &mdbv1.MajorVersionUpgrade{SnapshotBeforeUpgrade: true},
+	}
+	assert.NoError(t, DeploymentSpec(spec))
+
+	spec.MajorVersionUpgrade.Window = &mdbv1.UpgradeWindow{Start: "0 22 * * sat", End: "0 4 * * sun", TimeZone: "America/New_York"}
+	assert.NoError(t, DeploymentSpec(spec))
+
+	spec.MajorVersionUpgrade.Window.End = "0 4 * * sunday"
+	assert.Error(t, DeploymentSpec(spec))
+}
+
+func TestDeletionPolicyValidation(t *testing.T) {
+	spec := mdbv1.AtlasDeploymentSpec{
+		AdvancedDeploymentSpec: &mdbv1.AdvancedDeploymentSpec{},
+		DeletionPolicy:         &mdbv1.DeletionPolicy{SnapshotBeforeDelete: true},
+	}
+	assert.NoError(t, DeploymentSpec(spec))
+
+	spec.DeletionPolicy.RetainBackups = toptr.MakePtr(false)
+	assert.NoError(t, DeploymentSpec(spec))
+
+	spec.DeletionPolicy = &mdbv1.DeletionPolicy{RetainBackups: toptr.MakePtr(true)}
+	assert.NoError(t, DeploymentSpec(spec))
+
+	spec.AdvancedDeploymentSpec = nil
+	spec.ServerlessSpec = &mdbv1.ServerlessSpec{}
+	assert.Error(t, DeploymentSpec(spec))
+}
+
+func TestRestoreFromValidation(t *testing.T) {
+	spec := mdbv1.AtlasDeploymentSpec{
+		AdvancedDeploymentSpec: &mdbv1.AdvancedDeploymentSpec{},
+		RestoreFrom:            &mdbv1.RestoreFrom{DeploymentRef: common.ResourceRefNamespaced{Name: "production"}},
+	}
+	assert.NoError(t, DeploymentSpec(spec))
+
+	spec.RestoreFrom.PointInTime = "2023-06-09T12:00:00Z"
+	assert.NoError(t, DeploymentSpec(spec))
+
+	spec.RestoreFrom.SnapshotID = "snapshot-id"
+	assert.Error(t, DeploymentSpec(spec))
+
+	spec.RestoreFrom = &mdbv1.RestoreFrom{DeploymentRef: common.ResourceRefNamespaced{Name: "production"}, PointInTime: "yesterday"}
+	assert.Error(t, DeploymentSpec(spec))
+
+	spec.RestoreFrom = &mdbv1.RestoreFrom{}
+	assert.Error(t, DeploymentSpec(spec))
+}
+
 func TestProjectValidation(t *testing.T) {
 	t.Run("custom roles spec", func(t *testing.T) {
 		t.Run("empty custom roles spec", func(t *testing.T) {
@@ -300,6 +412,88 @@ func TestProjectValidation(t *testing.T) {
 	})
 }
 
+func TestProjectLDAPValidation(t *testing.T) {
+	withLDAP := func(ldap *mdbv1.LDAPConfiguration) *mdbv1.AtlasProject {
+		project := mdbv1.NewProject("ns", "project", "project")
+		project.Spec.LDAP = ldap
+		return project
+	}
+
+	t.Run("valid configuration", func(t *testing.T) {
+		project := withLDAP(&mdbv1.LDAPConfiguration{
+			AuthorizationEnabled: true,
+			AuthzQueryTemplate:   "{USER}?memberOf?base",
+			UserToDNMapping:      []mdbv1.LDAPUserToDNMapping{{Match: "(.+)", Substitution: "CN={0},DC=example,DC=com"}},
+		})
+		assert.NoError(t, Project(project))
+	})
+	t.Run("authorization without query template", func(t *testing.T) {
+		assert.Error(t, Project(withLDAP(&mdbv1.LDAPConfiguration{AuthorizationEnabled: true})))
+	})
+	t.Run("mapping with both substitution and query", func(t *testing.T) {
+		project := withLDAP(&mdbv1.LDAPConfiguration{
+			UserToDNMapping: []mdbv1.LDAPUserToDNMapping{{Match: "(.+)", Substitution: "CN={0}", LDAPQuery: "DC=example??sub?(uid={0})"}},
+		})
+		assert.Error(t, Project(project))
+	})
+}
+
+func TestProjectCloudProviderAccessRolesValidation(t *testing.T) {
+	withRoles := func(roles ...mdbv1.CloudProviderAccessRole) *mdbv1.AtlasProject {
+		project := mdbv1.NewProject("ns", "project", "project")
+		project.Spec.CloudProviderAccessRoles = roles
+		return project
+	}
+	azure := mdbv1.CloudProviderAccessRole{ProviderName: "AZURE", AtlasAzureAppID: "app", ServicePrincipalID: "principal", TenantID: "tenant"}
+
+	t.Run("roles of all providers", func(t *testing.T) {
+		project := withRoles(
+			mdbv1.CloudProviderAccessRole{ProviderName: "AWS", IamAssumedRoleArn: "arn:aws:iam::123:role/test"},
+			azure,
+			mdbv1.CloudProviderAccessRole{ProviderName: "GCP"},
+		)
+		assert.NoError(t, Project(project))
+	})
+	t.Run("azure role without tenant", func(t *testing.T) {
+		assert.Error(t, Project(withRoles(mdbv1.CloudProviderAccessRole{ProviderName: "AZURE", AtlasAzureAppID: "app", ServicePrincipalID: "principal"})))
+	})
+	t.Run("duplicated azure role", func(t *testing.T) {
+		assert.Error(t, Project(withRoles(azure, azure)))
+	})
+	t.Run("aws role with azure fields", func(t *testing.T) {
+		assert.Error(t, Project(withRoles(mdbv1.CloudProviderAccessRole{ProviderName: "AWS", TenantID: "tenant"})))
+	})
+	t.Run("more than one gcp role", func(t *testing.T) {
+		assert.Error(t, Project(withRoles(mdbv1.CloudProviderAccessRole{ProviderName: "GCP"}, mdbv1.CloudProviderAccessRole{ProviderName: "GCP"})))
+	})
+}
+
+func TestProjectIPAccessListSourcesValidation(t *testing.T) {
+	withSources := func(sources ...project.IPAccessListSource) *mdbv1.AtlasProject {
+		atlasProject := mdbv1.NewProject("ns", "project", "project")
+		atlasProject.Spec.IPAccessListSources = sources
+		return atlasProject
+	}
+
+	t.Run("sources of all kinds", func(t *testing.T) {
+		atlasProject := withSources(
+			project.IPAccessListSource{Nodes: &metav1.LabelSelector{}},
+			project.IPAccessListSource{Services: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+			project.IPAccessListSource{ConfigMap: &project.ConfigMapKeyRef{Name: "egress", Key: "ips"}},
+		)
+		assert.NoError(t, Project(atlasProject))
+	})
+	t.Run("empty source", func(t *testing.T) {
+		assert.Error(t, Project(withSources(project.IPAccessListSource{})))
+	})
+	t.Run("more than one kind in the source", func(t *testing.T) {
+		assert.Error(t, Project(withSources(project.IPAccessListSource{Nodes: &metav1.LabelSelector{}, Services: &metav1.LabelSelector{}})))
+	})
+	t.Run("configMap without key", func(t *testing.T) {
+		assert.Error(t, Project(withSources(project.IPAccessListSource{ConfigMap: &project.ConfigMapKeyRef{Name: "egress"}})))
+	})
+}
+
 func TestBackupScheduleValidation(t *testing.T) {
 	t.Run("auto export is enabled without export policy", func(t *testing.T) {
 		bSchedule := &mdbv1.AtlasBackupSchedule{
@@ -441,3 +635,330 @@ func TestBackupScheduleValidation(t *testing.T) {
 		})
 	})
 }
+
+func TestDataFederationValidation(t *testing.T) {
+	t.Run("valid data federation", func(t *testing.T) {
+		dataFederation := mdbv1.NewDataFederationInstance("ns", "df", "df", "project").
+			WithStore(mdbv1.DataFederationStore{Name: "cluster", Provider: mdbv1.DataFederationStoreAtlas, ClusterName: "Cluster0"}).
+			WithStore(mdbv1.DataFederationStore{Name: "http", Provider: mdbv1.DataFederationStoreHTTP, URLs: []string{"https://example.com/data.json"}}).
+			WithDatabase(mdbv1.DataFederationDatabase{
+				Name: "db",
+				Collections: []mdbv1.DataFederationCollection{
+					{Name: "col", DataSources: []mdbv1.DataFederationDataSource{{StoreName: "cluster", Database: "sample", Collection: "col"}}},
+					{Name: "web", DataSources: []mdbv1.DataFederationDataSource{{StoreName: "http"}}},
+				},
+			})
+		assert.NoError(t, DataFederation(dataFederation))
+	})
+	t.Run("duplicated store names", func(t *testing.T) {
+		dataFederation := mdbv1.NewDataFederationInstance("ns", "df", "df", "project").
+			WithStore(mdbv1.DataFederationStore{Name: "cluster", Provider: mdbv1.DataFederationStoreAtlas, ClusterName: "Cluster0"}).
+			WithStore(mdbv1.DataFederationStore{Name: "cluster", Provider: mdbv1.DataFederationStoreAtlas, ClusterName: "Cluster1"})
+		assert.Error(t, DataFederation(dataFederation))
+	})
+	t.Run("provider specific fields are missing", func(t *testing.T) {
+		dataFederation := mdbv1.NewDataFederationInstance("ns", "df", "df", "project").
+			WithStore(mdbv1.DataFederationStore{Name: "s3", Provider: mdbv1.DataFederationStoreS3, Bucket: "bucket"})
+		assert.Error(t, DataFederation(dataFederation))
+	})
+	t.Run("data source references unknown store", func(t *testing.T) {
+		dataFederation := mdbv1.NewDataFederationInstance("ns", "df", "df", "project").
+			WithDatabase(mdbv1.DataFederationDatabase{
+				Name:        "db",
+				Collections: []mdbv1.DataFederationCollection{{Name: "col", DataSources: []mdbv1.DataFederationDataSource{{StoreName: "missing"}}}},
+			})
+		assert.Error(t, DataFederation(dataFederation))
+	})
+	t.Run("s3 store without cloud provider config", func(t *testing.T) {
+		dataFederation := mdbv1.NewDataFederationInstance("ns", "df", "df", "project").
+			WithStore(mdbv1.DataFederationStore{Name: "s3", Provider: mdbv1.DataFederationStoreS3, Bucket: "bucket", Region: "us-east-1"}).
+			WithDatabase(mdbv1.DataFederationDatabase{
+				Name:        "db",
+				Collections: []mdbv1.DataFederationCollection{{Name: "col", DataSources: []mdbv1.DataFederationDataSource{{StoreName: "s3", Path: "/{*}"}}}},
+			})
+		assert.Error(t, DataFederation(dataFederation))
+	})
+	t.Run("both role id and arn are specified", func(t *testing.T) {
+		dataFederation := mdbv1.NewDataFederationInstance("ns", "df", "df", "project")
+		dataFederation.Spec.CloudProviderConfig = &mdbv1.DataFederationCloudProviderConfig{
+			AWS: &mdbv1.DataFederationAWSConfig{RoleID: "id", IamAssumedRoleArn: "arn:aws:iam::123:role/test", TestS3Bucket: "bucket"},
+		}
+		assert.Error(t, DataFederation(dataFederation))
+	})
+}
+
+func TestAPIKeyValidation(t *testing.T) {
+	t.Run("valid api key", func(t *testing.T) {
+		apiKey := mdbv1.NewAPIKey("ns", "key", "project", "CI key", "ci-key").
+			WithRoles("GROUP_READ_ONLY", "GROUP_CLUSTER_MANAGER").
+			WithAccessListEntry(mdbv1.APIKeyAccessListEntry{IPAddress: "10.0.0.1"}).
+			WithAccessListEntry(mdbv1.APIKeyAccessListEntry{CIDRBlock: "192.168.0.0/16"})
+		assert.NoError(t, APIKey(apiKey))
+	})
+	t.Run("duplicated roles", func(t *testing.T) {
+		apiKey := mdbv1.NewAPIKey("ns", "key", "project", "CI key", "ci-key").
+			WithRoles("GROUP_READ_ONLY", "GROUP_READ_ONLY")
+		assert.Error(t, APIKey(apiKey))
+	})
+	t.Run("both ip address and cidr block", func(t *testing.T) {
+		apiKey := mdbv1.NewAPIKey("ns", "key", "project", "CI key", "ci-key").
+			WithRoles("GROUP_READ_ONLY").
+			WithAccessListEntry(mdbv1.APIKeyAccessListEntry{IPAddress: "10.0.0.1", CIDRBlock: "10.0.0.0/8"})
+		assert.Error(t, APIKey(apiKey))
+	})
+	t.Run("invalid cidr block", func(t *testing.T) {
+		apiKey := mdbv1.NewAPIKey("ns", "key", "project", "CI key", "ci-key").
+			WithRoles("GROUP_READ_ONLY").
+			WithAccessListEntry(mdbv1.APIKeyAccessListEntry{CIDRBlock: "10.0.0.1"})
+		assert.Error(t, APIKey(apiKey))
+	})
+}
+
+func TestFederatedAuthValidation(t *testing.T) {
+	projectRef := &common.ResourceRefNamespaced{Name: "project"}
+
+	t.Run("valid federated auth", func(t *testing.T) {
+		fedAuth := mdbv1.NewFederatedAuth("ns", "federation").
+			WithIdentityProvider("idp").
+			WithRoleMapping("admins", mdbv1.FederatedRoleAssignment{Role: "ORG_OWNER"}).
+			WithRoleMapping("developers", mdbv1.FederatedRoleAssignment{Role: "GROUP_READ_ONLY", ProjectRef: projectRef})
+		assert.NoError(t, FederatedAuth(fedAuth))
+	})
+	t.Run("identity provider is required when enabled", func(t *testing.T) {
+		fedAuth := mdbv1.NewFederatedAuth("ns", "federation")
+		assert.Error(t, FederatedAuth(fedAuth))
+
+		fedAuth.Spec.Enabled = nil
+		assert.Error(t, FederatedAuth(fedAuth))
+
+		fedAuth.Spec.Enabled = toptr.MakePtr(false)
+		assert.NoError(t, FederatedAuth(fedAuth))
+	})
+	t.Run("duplicated role mappings", func(t *testing.T) {
+		fedAuth := mdbv1.NewFederatedAuth("ns", "federation").
+			WithIdentityProvider("idp").
+			WithRoleMapping("admins", mdbv1.FederatedRoleAssignment{Role: "ORG_OWNER"}).
+			WithRoleMapping("admins", mdbv1.FederatedRoleAssignment{Role: "ORG_MEMBER"})
+		assert.Error(t, FederatedAuth(fedAuth))
+	})
+	t.Run("organization role referencing a project", func(t *testing.T) {
+		fedAuth := mdbv1.NewFederatedAuth("ns", "federation").
+			WithIdentityProvider("idp").
+			WithRoleMapping("admins", mdbv1.FederatedRoleAssignment{Role: "ORG_OWNER", ProjectRef: projectRef})
+		assert.Error(t, FederatedAuth(fedAuth))
+	})
+	t.Run("project role without a project", func(t *testing.T) {
+		fedAuth := mdbv1.NewFederatedAuth("ns", "federation").
+			WithIdentityProvider("idp").
+			WithRoleMapping("developers", mdbv1.FederatedRoleAssignment{Role: "GROUP_OWNER"})
+		assert.Error(t, FederatedAuth(fedAuth))
+	})
+	t.Run("oidc identity provider without client id", func(t *testing.T) {
+		fedAuth := mdbv1.NewFederatedAuth("ns", "federation").WithIdentityProvider("idp")
+		fedAuth.Spec.IdentityProviders = []mdbv1.FederatedIdentityProvider{
+			{ID: "oidc", OIDC: &mdbv1.FederatedOIDCSettings{Audience: "atlas"}},
+		}
+		assert.Error(t, FederatedAuth(fedAuth))
+	})
+}
+
+func TestPrivateEndpointValidation(t *testing.T) {
+	t.Run("interface is not specified", func(t *testing.T) {
+		pe := mdbv1.NewPrivateEndpoint("ns", "pe", "project", provider.ProviderAWS, "us-east-1")
+		assert.NoError(t, PrivateEndpoint(pe))
+	})
+	t.Run("AWS interface without id", func(t *testing.T) {
+		pe := mdbv1.NewPrivateEndpoint("ns", "pe", "project", provider.ProviderAWS, "us-east-1").
+			WithInterface(mdbv1.PrivateEndpointInterface{IP: "10.0.0.5"})
+		assert.Error(t, PrivateEndpoint(pe))
+	})
+	t.Run("Azure interface without ip", func(t *testing.T) {
+		pe := mdbv1.NewPrivateEndpoint("ns", "pe", "project", provider.ProviderAzure, "eastus2").
+			WithInterface(mdbv1.PrivateEndpointInterface{ID: "/subscriptions/sub/privateEndpoints/pe"})
+		assert.Error(t, PrivateEndpoint(pe))
+	})
+	t.Run("valid GCP interface", func(t *testing.T) {
+		pe := mdbv1.NewPrivateEndpoint("ns", "pe", "project", provider.ProviderGCP, "europe-west1").
+			WithInterface(mdbv1.PrivateEndpointInterface{
+				GCPProjectID:      "gcp-project",
+				EndpointGroupName: "group",
+				Endpoints:         mdbv1.GCPEndpoints{{EndpointName: "endpoint-0", IPAddress: "10.0.0.10"}},
+			})
+		assert.NoError(t, PrivateEndpoint(pe))
+	})
+	t.Run("GCP interface with id", func(t *testing.T) {
+		pe := mdbv1.NewPrivateEndpoint("ns", "pe", "project", provider.ProviderGCP, "europe-west1").
+			WithInterface(mdbv1.PrivateEndpointInterface{
+				ID:                "id",
+				GCPProjectID:      "gcp-project",
+				EndpointGroupName: "group",
+				Endpoints:         mdbv1.GCPEndpoints{{EndpointName: "endpoint-0", IPAddress: "10.0.0.10"}},
+			})
+		assert.Error(t, PrivateEndpoint(pe))
+	})
+}
+
+func TestNetworkContainerValidation(t *testing.T) {
+	t.Run("valid AWS container", func(t *testing.T) {
+		container := mdbv1.NewNetworkContainer("ns", "container", "project", provider.ProviderAWS, "us-east-1", "10.8.0.0/21")
+		assert.NoError(t, NetworkContainer(container))
+	})
+	t.Run("GCP container without region", func(t *testing.T) {
+		container := mdbv1.NewNetworkContainer("ns", "container", "project", provider.ProviderGCP, "", "10.8.0.0/18")
+		assert.NoError(t, NetworkContainer(container))
+	})
+	t.Run("Azure container without region", func(t *testing.T) {
+		container := mdbv1.NewNetworkContainer("ns", "container", "project", provider.ProviderAzure, "", "10.8.0.0/21")
+		assert.Error(t, NetworkContainer(container))
+	})
+	t.Run("invalid CIDR block", func(t *testing.T) {
+		container := mdbv1.NewNetworkContainer("ns", "container", "project", provider.ProviderAWS, "us-east-1", "10.8.0.0")
+		assert.Error(t, NetworkContainer(container))
+	})
+}
+
+func TestNetworkPeeringValidation(t *testing.T) {
+	t.Run("valid AWS peering", func(t *testing.T) {
+		peering := mdbv1.NewNetworkPeering("ns", "peering", "project", provider.ProviderAWS).
+			WithContainerRef("container").
+			WithAWSVpc("123456789012", "us-east-1", "vpc-1", "10.0.0.0/16")
+		assert.NoError(t, NetworkPeering(peering))
+	})
+	t.Run("both container reference and id", func(t *testing.T) {
+		peering := mdbv1.NewNetworkPeering("ns", "peering", "project", provider.ProviderAWS).
+			WithContainerRef("container").
+			WithContainerID("container-id").
+			WithAWSVpc("123456789012", "us-east-1", "vpc-1", "10.0.0.0/16")
+		assert.Error(t, NetworkPeering(peering))
+	})
+	t.Run("no container", func(t *testing.T) {
+		peering := mdbv1.NewNetworkPeering("ns", "peering", "project", provider.ProviderGCP).
+			WithGCPNetwork("gcp-project", "network")
+		assert.Error(t, NetworkPeering(peering))
+	})
+	t.Run("GCP peering without network", func(t *testing.T) {
+		peering := mdbv1.NewNetworkPeering("ns", "peering", "project", provider.ProviderGCP).
+			WithContainerID("container-id").
+			WithGCPNetwork("gcp-project", "")
+		assert.Error(t, NetworkPeering(peering))
+	})
+	t.Run("Azure peering without vnet", func(t *testing.T) {
+		peering := mdbv1.NewNetworkPeering("ns", "peering", "project", provider.ProviderAzure).WithContainerID("container-id")
+		peering.Spec.AzureDirectoryID = "directory"
+		peering.Spec.AzureSubscriptionID = "subscription"
+		peering.Spec.ResourceGroupName = "group"
+		assert.Error(t, NetworkPeering(peering))
+	})
+}
+
+func TestDatabaseUserValidation(t *testing.T) {
+	passwordless := func(databaseName string, spec mdbv1.AtlasDatabaseUserSpec) *mdbv1.AtlasDatabaseUser {
+		user := mdbv1.DefaultDBUser("ns", "user", "project")
+		user.Spec.PasswordSecret = nil
+		user.Spec.DatabaseName = databaseName
+		user.Spec.X509Type = spec.X509Type
+		user.Spec.AWSIAMType = spec.AWSIAMType
+		user.Spec.LDAPAuthType = spec.LDAPAuthType
+		user.Spec.OIDCAuthType = spec.OIDCAuthType
+		return user
+	}
+
+	t.Run("password user", func(t *testing.T) {
+		user := mdbv1.DefaultDBUser("ns", "user", "project").WithPasswordSecret("user-password")
+		user.Spec.AWSIAMType = mdbv1.NoneAuthType
+		assert.NoError(t, DatabaseUser(user))
+	})
+	t.Run("valid passwordless users", func(t *testing.T) {
+		assert.NoError(t, DatabaseUser(passwordless("$external", mdbv1.AtlasDatabaseUserSpec{X509Type: "MANAGED"})))
+		assert.NoError(t, DatabaseUser(passwordless("$external", mdbv1.AtlasDatabaseUserSpec{AWSIAMType: mdbv1.AWSIAMTypeRole})))
+		assert.NoError(t, DatabaseUser(passwordless("$external", mdbv1.AtlasDatabaseUserSpec{LDAPAuthType: mdbv1.LDAPAuthTypeUser})))
+		assert.NoError(t, DatabaseUser(passwordless("admin", mdbv1.AtlasDatabaseUserSpec{LDAPAuthType: mdbv1.LDAPAuthTypeGroup})))
+		assert.NoError(t, DatabaseUser(passwordless("admin", mdbv1.AtlasDatabaseUserSpec{OIDCAuthType: mdbv1.OIDCAuthTypeIDPGroup})))
+		assert.NoError(t, DatabaseUser(passwordless("$external", mdbv1.AtlasDatabaseUserSpec{OIDCAuthType: mdbv1.OIDCAuthTypeUser})))
+	})
+	t.Run("several authentication types", func(t *testing.T) {
+		user := passwordless("$external", mdbv1.AtlasDatabaseUserSpec{AWSIAMType: mdbv1.AWSIAMTypeUser, LDAPAuthType: mdbv1.LDAPAuthTypeUser})
+		assert.Error(t, DatabaseUser(user))
+	})
+	t.Run("password secret for passwordless user", func(t *testing.T) {
+		user := passwordless("$external", mdbv1.AtlasDatabaseUserSpec{AWSIAMType: mdbv1.AWSIAMTypeRole})
+		user.Spec.PasswordSecret = &common.ResourceRef{Name: "user-password"}
+		assert.Error(t, DatabaseUser(user))
+	})
+	t.Run("wrong database name", func(t *testing.T) {
+		assert.Error(t, DatabaseUser(passwordless("admin", mdbv1.AtlasDatabaseUserSpec{AWSIAMType: mdbv1.AWSIAMTypeRole})))
+		assert.Error(t, DatabaseUser(passwordless("$external", mdbv1.AtlasDatabaseUserSpec{LDAPAuthType: mdbv1.LDAPAuthTypeGroup})))
+	})
+}
+
+func TestSearchIndexesValidation(t *testing.T) {
+	withSearchIndexes := func(indexes ...mdbv1.SearchIndex) mdbv1.AtlasDeploymentSpec {
+		return mdbv1.AtlasDeploymentSpec{AdvancedDeploymentSpec: &mdbv1.AdvancedDeploymentSpec{SearchIndexes: indexes}}
+	}
+
+	t.Run("valid indexes", func(t *testing.T) {
+		spec := withSearchIndexes(
+			mdbv1.SearchIndex{Name: "default", Database: "db", CollectionName: "col", Mappings: &mdbv1.SearchIndexMappings{Dynamic: true}},
+			mdbv1.SearchIndex{Name: "default", Database: "db", CollectionName: "other", DefinitionRef: &common.ResourceRefNamespaced{Name: "definition"}},
+		)
+		assert.NoError(t, DeploymentSpec(spec))
+	})
+	t.Run("duplicated index names", func(t *testing.T) {
+		spec := withSearchIndexes(
+			mdbv1.SearchIndex{Name: "default", Database: "db", CollectionName: "col", Mappings: &mdbv1.SearchIndexMappings{Dynamic: true}},
+			mdbv1.SearchIndex{Name: "default", Database: "db", CollectionName: "col", Mappings: &mdbv1.SearchIndexMappings{Dynamic: false}},
+		)
+		assert.Error(t, DeploymentSpec(spec))
+	})
+	t.Run("both definitionRef and inline definition", func(t *testing.T) {
+		spec := withSearchIndexes(mdbv1.SearchIndex{
+			Name:           "default",
+			Database:       "db",
+			CollectionName: "col",
+			Analyzer:       "lucene.english",
+			DefinitionRef:  &common.ResourceRefNamespaced{Name: "definition"},
+		})
+		assert.Error(t, DeploymentSpec(spec))
+	})
+	t.Run("no mappings", func(t *testing.T) {
+		spec := withSearchIndexes(mdbv1.SearchIndex{Name: "default", Database: "db", CollectionName: "col"})
+		assert.Error(t, DeploymentSpec(spec))
+	})
+}
+
+func TestOnlineArchivesValidation(t *testing.T) {
+	dateArchive := mdbv1.OnlineArchive{
+		DBName:   "db",
+		CollName: "col",
+		Criteria: mdbv1.OnlineArchiveCriteria{Type: mdbv1.OnlineArchiveCriteriaDate, DateField: "created", ExpireAfterDays: 30},
+	}
+	withOnlineArchives := func(archives ...mdbv1.OnlineArchive) mdbv1.AtlasDeploymentSpec {
+		return mdbv1.AtlasDeploymentSpec{AdvancedDeploymentSpec: &mdbv1.AdvancedDeploymentSpec{}, OnlineArchives: archives}
+	}
+
+	t.Run("valid online archives", func(t *testing.T) {
+		customArchive := mdbv1.OnlineArchive{
+			DBName:   "db",
+			CollName: "other",
+			Criteria: mdbv1.OnlineArchiveCriteria{Type: mdbv1.OnlineArchiveCriteriaCustom, Query: `{"status":"archived"}`},
+		}
+		assert.NoError(t, DeploymentSpec(withOnlineArchives(dateArchive, customArchive)))
+	})
+	t.Run("duplicated collections", func(t *testing.T) {
+		assert.Error(t, DeploymentSpec(withOnlineArchives(dateArchive, dateArchive)))
+	})
+	t.Run("date criteria without date field", func(t *testing.T) {
+		archive := dateArchive
+		archive.Criteria.DateField = ""
+		assert.Error(t, DeploymentSpec(withOnlineArchives(archive)))
+	})
+	t.Run("custom criteria without query", func(t *testing.T) {
+		archive := dateArchive
+		archive.Criteria = mdbv1.OnlineArchiveCriteria{Type: mdbv1.OnlineArchiveCriteriaCustom}
+		assert.Error(t, DeploymentSpec(withOnlineArchives(archive)))
+	})
+	t.Run("serverless deployment", func(t *testing.T) {
+		spec := mdbv1.AtlasDeploymentSpec{ServerlessSpec: &mdbv1.ServerlessSpec{}, OnlineArchives: []mdbv1.OnlineArchive{dateArchive}}
+		assert.Error(t, DeploymentSpec(spec))
+	})
+}
@@ -21,6 +21,24 @@ func CommonPredicates() predicate.Funcs {
 	}
 }
 
+// AnnotationChanged returns a predicate that only passes the updates changing the value of the annotation
+func AnnotationChanged(annotation string) predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(ce event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.ObjectOld.GetAnnotations()[annotation] != e.ObjectNew.GetAnnotations()[annotation]
+		},
+		DeleteFunc: func(ce event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(ce event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
 // DeleteOnly returns a predicate that will filter out everything except the Delete event
 func DeleteOnly() predicate.Funcs {
 	return predicate.Funcs{
@@ -47,9 +47,11 @@ func (r *ResourceWatcher) addWatchedResourceIfNotAdded(watchedObjectKey client.O
 	r.WatchedResources[key][dependentResourceNsName] = true
 }
 
+// cleanNonWatchedResources removes the dependant from the watched resources of the kind which are not referenced any
+// more. The resources of other kinds are left untouched as the dependant may watch several kinds independently.
 func (r ResourceWatcher) cleanNonWatchedResources(dependant client.ObjectKey, resourceKind string, watchedKeys []client.ObjectKey) {
 	for k, v := range r.WatchedResources {
-		if !contains(watchedKeys, k.Resource) || k.ResourceKind != resourceKind {
+		if k.ResourceKind == resourceKind && !contains(watchedKeys, k.Resource) {
 			delete(v, dependant)
 		}
 	}
@@ -122,5 +122,28 @@ func TestEnsureResourcesAreWatched(t *testing.T) {
 
 		assert.Equal(t, expectedWatched, watcher.WatchedResources)
 	})
-	// TODO: add test for different kind of resources
+	t.Run("Resources of other kinds stay watched", func(t *testing.T) {
+		watcher := NewResourceWatcher()
+		project1 := kube.ObjectKey("test", "project1")
+		connectionSecret := kube.ObjectKey("test", "connectionSecret")
+		configMap := kube.ObjectKey("test", "configMap")
+
+		watcher.EnsureResourcesAreWatched(project1, "Secret", zap.S(), connectionSecret)
+		watcher.EnsureResourcesAreWatched(project1, "ConfigMap", zap.S(), configMap)
+		watcher.EnsureResourcesAreWatched(project1, "Secret", zap.S(), connectionSecret)
+
+		expectedWatched := map[WatchedObject]map[client.ObjectKey]bool{
+			{ResourceKind: "Secret", Resource: connectionSecret}: {project1: true},
+			{ResourceKind: "ConfigMap", Resource: configMap}:     {project1: true},
+		}
+		assert.Equal(t, expectedWatched, watcher.WatchedResources)
+
+		watcher.EnsureResourcesAreWatched(project1, "ConfigMap", zap.S())
+
+		expectedWatched = map[WatchedObject]map[client.ObjectKey]bool{
+			{ResourceKind: "Secret", Resource: connectionSecret}: {project1: true},
+			{ResourceKind: "ConfigMap", Resource: configMap}:     {},
+		}
+		assert.Equal(t, expectedWatched, watcher.WatchedResources)
+	})
 }
@@ -32,6 +32,9 @@ func (w WatchedObject) String() string {
 type ResourcesHandler struct {
 	ResourceKind     string
 	TrackedResources map[WatchedObject]map[client.ObjectKey]bool
+	// AnyName makes the handler match the dependants watching all resources of the namespace (the empty name) which
+	// is the case for the resources selected by labels. The removal of such resources triggers the reconciliation too.
+	AnyName bool
 }
 
 // NewSecretHandler TODO Igor: refactor this to create generic constructor
@@ -51,11 +54,28 @@ func NewAtlasTeamHandler(tracked map[WatchedObject]map[client.ObjectKey]bool) *R
 	return &ResourcesHandler{ResourceKind: "AtlasTeam", TrackedResources: tracked}
 }
 
+func NewConfigMapHandler(tracked map[WatchedObject]map[client.ObjectKey]bool) *ResourcesHandler {
+	return &ResourcesHandler{ResourceKind: "ConfigMap", TrackedResources: tracked}
+}
+
+// NewNodeHandler returns the handler for the Nodes which are watched by the label selectors, so the dependants are
+// registered for the Node with empty name
+func NewNodeHandler(tracked map[WatchedObject]map[client.ObjectKey]bool) *ResourcesHandler {
+	return &ResourcesHandler{ResourceKind: "Node", TrackedResources: tracked, AnyName: true}
+}
+
+// NewServiceHandler returns the handler for the Services which are watched by the label selectors, so the dependants
+// are registered for the Service with empty name in the namespace
+func NewServiceHandler(tracked map[WatchedObject]map[client.ObjectKey]bool) *ResourcesHandler {
+	return &ResourcesHandler{ResourceKind: "Service", TrackedResources: tracked, AnyName: true}
+}
+
 // Create handles the Create event for the resource.
 // Note that we implement Create in addition to Update to be able to handle cases when config map or secret is deleted
 // and then created again.
 func (c *ResourcesHandler) Create(e event.CreateEvent, q workqueue.RateLimitingInterface) {
-	c.doHandle(e.Object.GetNamespace(), e.Object.GetName(), e.Object.GetObjectKind().GroupVersionKind().Kind, q)
+	// The kind of the objects coming from the cache may be empty, see Update
+	c.doHandle(e.Object.GetNamespace(), e.Object.GetName(), c.ResourceKind, q)
 }
 
 func (c *ResourcesHandler) Update(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
@@ -77,12 +97,23 @@ func shouldHandleUpdate(e event.UpdateEvent) bool {
 	case *corev1.Secret:
 		return !reflect.DeepEqual(v.Data, e.ObjectNew.(*corev1.Secret).Data)
 	case *v1.AtlasTeam:
-		return !reflect.DeepEqual(v.Spec, e.ObjectNew.(*v1.AtlasTeam).Spec)
+		// Projects only need the ID of the team which is set once the team is created in Atlas
+		return v.Status.ID != e.ObjectNew.(*v1.AtlasTeam).Status.ID
+	case *corev1.Node:
+		// Nodes are updated by the heartbeats all the time, only the addresses and the labels matter
+		newNode := e.ObjectNew.(*corev1.Node)
+		return !reflect.DeepEqual(v.Status.Addresses, newNode.Status.Addresses) || !reflect.DeepEqual(v.Labels, newNode.Labels)
+	case *corev1.Service:
+		newService := e.ObjectNew.(*corev1.Service)
+		return !reflect.DeepEqual(v.Status.LoadBalancer, newService.Status.LoadBalancer) || !reflect.DeepEqual(v.Labels, newService.Labels)
 	}
 	return true
 }
 
 func (c *ResourcesHandler) doHandle(namespace, name, kind string, q workqueue.RateLimitingInterface) {
+	if c.AnyName {
+		name = ""
+	}
 	watchedResource := WatchedObject{
 		ResourceKind: kind,
 		Resource:     types.NamespacedName{Name: name, Namespace: namespace},
@@ -93,8 +124,12 @@ func (c *ResourcesHandler) doHandle(namespace, name, kind string, q workqueue.Ra
 	}
 }
 
-// Delete (Seems we don't need to react on watched resources removal..)
-func (c *ResourcesHandler) Delete(event.DeleteEvent, workqueue.RateLimitingInterface) {}
+// Delete (Seems we don't need to react on watched resources removal..) unless the resources are selected by labels
+func (c *ResourcesHandler) Delete(e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	if c.AnyName {
+		c.doHandle(e.Object.GetNamespace(), e.Object.GetName(), c.ResourceKind, q)
+	}
+}
 
 func (c *ResourcesHandler) Generic(e event.GenericEvent, w workqueue.RateLimitingInterface) {
 }
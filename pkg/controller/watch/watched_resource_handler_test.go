@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	v1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
 )
 
@@ -81,6 +82,38 @@ func TestHandleUpdate(t *testing.T) {
 	})
 }
 
+func TestHandleResourcesSelectedByLabels(t *testing.T) {
+	dependentResourceKey := kube.ObjectKey("ns", "testAtlasProject")
+	tracked := map[WatchedObject]map[client.ObjectKey]bool{
+		{ResourceKind: "Node", Resource: kube.ObjectKey("", "")}:      {dependentResourceKey: true},
+		{ResourceKind: "Service", Resource: kube.ObjectKey("ns", "")}: {dependentResourceKey: true},
+	}
+
+	t.Run("Any Node triggers the reconciliation", func(t *testing.T) {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+		queue := controllertest.Queue{Interface: workqueue.New()}
+
+		NewNodeHandler(tracked).Create(event.CreateEvent{Object: node}, &queue)
+		assert.Equal(t, 1, queue.Len())
+		enqueued, _ := queue.Get()
+		assert.Equal(t, reconcile.Request{NamespacedName: dependentResourceKey}, enqueued)
+	})
+	t.Run("Removed Node triggers the reconciliation", func(t *testing.T) {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+		queue := controllertest.Queue{Interface: workqueue.New()}
+
+		NewNodeHandler(tracked).Delete(event.DeleteEvent{Object: node}, &queue)
+		assert.Equal(t, 1, queue.Len())
+	})
+	t.Run("Service of other namespace is ignored", func(t *testing.T) {
+		service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "service", Namespace: "other"}}
+		queue := controllertest.Queue{Interface: workqueue.New()}
+
+		NewServiceHandler(tracked).Create(event.CreateEvent{Object: service}, &queue)
+		assert.Zero(t, queue.Len())
+	})
+}
+
 func TestShouldHandleUpdate(t *testing.T) {
 	t.Run("Update shouldn't happen if Secrets data hasn't changed", func(t *testing.T) {
 		oldObj := secretForTesting("testValue")
@@ -97,6 +130,26 @@ func TestShouldHandleUpdate(t *testing.T) {
 
 		assert.True(t, shouldHandleUpdate(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj}))
 	})
+	t.Run("Update should happen only if the ID of the AtlasTeam has changed", func(t *testing.T) {
+		oldObj := &v1.AtlasTeam{Spec: v1.TeamSpec{Name: "team"}}
+		newObj := oldObj.DeepCopy()
+		newObj.Spec.Usernames = []v1.TeamUser{"user@example.com"}
+
+		assert.False(t, shouldHandleUpdate(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj}))
+
+		newObj.Status.ID = "team-id"
+		assert.True(t, shouldHandleUpdate(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj}))
+	})
+	t.Run("Update should happen only if the addresses or the labels of the Node have changed", func(t *testing.T) {
+		oldObj := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}
+		newObj := oldObj.DeepCopy()
+		newObj.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}
+
+		assert.False(t, shouldHandleUpdate(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj}))
+
+		newObj.Status.Addresses = []corev1.NodeAddress{{Type: corev1.NodeExternalIP, Address: "203.0.113.1"}}
+		assert.True(t, shouldHandleUpdate(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj}))
+	})
 }
 
 func secretForTesting(name string) *corev1.Secret {
@@ -10,6 +10,7 @@ const (
 	Internal                      ConditionReason = "InternalError"
 	AtlasResourceVersionMismatch  ConditionReason = "AtlasResourceVersionMismatch"
 	AtlasResourceVersionIsInvalid ConditionReason = "AtlasResourceVersionIsInvalid"
+	OperationStalled              ConditionReason = "OperationStalled"
 )
 
 // Atlas Project reasons
@@ -25,7 +26,9 @@ const (
 	ProjectWindowNotAutoDeferredInAtlas        ConditionReason = "ProjectWindowNotAutoDeferredInAtlas"
 	ProjectPEServiceIsNotReadyInAtlas          ConditionReason = "ProjectPrivateEndpointServiceIsNotReadyInAtlas"
 	ProjectPEInterfaceIsNotReadyInAtlas        ConditionReason = "ProjectPrivateEndpointIsNotReadyInAtlas"
+	ProjectRegionalizedPEModeNotSynced         ConditionReason = "ProjectRegionalizedPrivateEndpointModeNotSynced"
 	ProjectIPAccessListNotActive               ConditionReason = "ProjectIPAccessListNotActive"
+	ProjectIPAccessListSourceNotResolved       ConditionReason = "ProjectIPAccessListSourceNotResolved"
 	ProjectIntegrationInternal                 ConditionReason = "ProjectIntegrationInternalError"
 	ProjectIntegrationRequest                  ConditionReason = "ProjectIntegrationRequestError"
 	ProjectIntegrationReady                    ConditionReason = "ProjectIntegrationReady"
@@ -34,6 +37,9 @@ const (
 	ProjectEncryptionAtRestReady               ConditionReason = "ProjectEncryptionAtRestReady"
 	ProjectCloudAccessRolesIsNotReadyInAtlas   ConditionReason = "ProjectCloudAccessRolesIsNotReadyInAtlas"
 	ProjectAuditingReady                       ConditionReason = "ProjectAuditingReady"
+	ProjectLDAPNotConfigured                   ConditionReason = "ProjectLDAPNotConfigured"
+	ProjectLDAPVerificationPending             ConditionReason = "ProjectLDAPVerificationPending"
+	ProjectLDAPVerificationFailed              ConditionReason = "ProjectLDAPVerificationFailed"
 	ProjectSettingsReady                       ConditionReason = "ProjectSettingsReady"
 	ProjectAlertConfigurationIsNotReadyInAtlas ConditionReason = "ProjectAlertConfigurationIsNotReadyInAtlas"
 	ProjectCustomRolesReady                    ConditionReason = "ProjectCustomRolesReady"
@@ -46,11 +52,38 @@ const (
 	DeploymentNotUpdatedInAtlas           ConditionReason = "DeploymentNotUpdatedInAtlas"
 	DeploymentCreating                    ConditionReason = "DeploymentCreating"
 	DeploymentUpdating                    ConditionReason = "DeploymentUpdating"
+	DeploymentRepairing                   ConditionReason = "DeploymentRepairing"
+	DeploymentDeleting                    ConditionReason = "DeploymentDeleting"
+	DeploymentDeleted                     ConditionReason = "DeploymentDeleted"
+	DeploymentStateUnknown                ConditionReason = "DeploymentStateUnknown"
+	DeploymentDeletionBlocked             ConditionReason = "DeploymentDeletionBlocked"
+	DeploymentFinalSnapshotInProgress     ConditionReason = "DeploymentFinalSnapshotInProgress"
+	DeploymentFinalSnapshotNotRecorded    ConditionReason = "DeploymentFinalSnapshotNotRecorded"
+	DeploymentFinalSnapshotFailed         ConditionReason = "DeploymentFinalSnapshotFailed"
+	DeploymentRestoring                   ConditionReason = "DeploymentRestoring"
+	DeploymentRestoreFailed               ConditionReason = "DeploymentRestoreFailed"
+	DeploymentRestoreInvalid              ConditionReason = "DeploymentRestoreInvalid"
 	DeploymentConnectionSecretsNotCreated ConditionReason = "DeploymentConnectionSecretsNotCreated"
 	DeploymentAdvancedOptionsReady        ConditionReason = "DeploymentAdvancedOptionsReady"
 	ServerlessPrivateEndpointReady        ConditionReason = "ServerlessPrivateEndpointReady"
 	ManagedNamespacesReady                ConditionReason = "ManagedNamespacesReady"
 	CustomZoneMappingReady                ConditionReason = "CustomZoneMappingReady"
+	SearchIndexesNotReady                 ConditionReason = "SearchIndexesNotReady"
+	SearchIndexesFailed                   ConditionReason = "SearchIndexesFailed"
+	OnlineArchivesNotReady                ConditionReason = "OnlineArchivesNotReady"
+	OnlineArchivesFailed                  ConditionReason = "OnlineArchivesFailed"
+	OnlineArchivesInvalid                 ConditionReason = "OnlineArchivesInvalid"
+	DeploymentScheduleInvalid             ConditionReason = "DeploymentScheduleInvalid"
+	DeploymentScalingProfileInvalid       ConditionReason = "DeploymentScalingProfileInvalid"
+	MajorVersionUpgradeInvalid            ConditionReason = "MajorVersionUpgradeInvalid"
+	MajorVersionUpgradePending            ConditionReason = "MajorVersionUpgradePending"
+	MajorVersionUpgradeSnapshotInProgress ConditionReason = "MajorVersionUpgradeSnapshotInProgress"
+	MajorVersionUpgradeSnapshotFailed     ConditionReason = "MajorVersionUpgradeSnapshotFailed"
+	MajorVersionUpgrading                 ConditionReason = "MajorVersionUpgrading"
+	MajorVersionUpgradeFailed             ConditionReason = "MajorVersionUpgradeFailed"
+	TenantUpgrading                       ConditionReason = "TenantUpgrading"
+	TenantUpgradeFailed                   ConditionReason = "TenantUpgradeFailed"
+	TenantDowngradeInvalid                ConditionReason = "TenantDowngradeInvalid"
 )
 
 // Atlas Database User reasons
@@ -62,6 +95,23 @@ const (
 	DatabaseUserDeploymentAppliedChanges    ConditionReason = "DeploymentAppliedDatabaseUsersChanges"
 	DatabaseUserInvalidSpec                 ConditionReason = "DatabaseUserInvalidSpec"
 	DatabaseUserExpired                     ConditionReason = "DatabaseUserExpired"
+	DatabaseUserCertificateNotCreated       ConditionReason = "DatabaseUserCertificateNotCreated"
+)
+
+// Atlas Data Federation reasons
+const (
+	DataFederationNotCreatedInAtlas ConditionReason = "DataFederationNotCreatedInAtlas"
+	DataFederationNotUpdatedInAtlas ConditionReason = "DataFederationNotUpdatedInAtlas"
+	DataFederationInvalidSpec       ConditionReason = "DataFederationInvalidSpec"
+)
+
+// Atlas API Key reasons
+const (
+	APIKeyNotCreatedInAtlas  ConditionReason = "APIKeyNotCreatedInAtlas"
+	APIKeyNotUpdatedInAtlas  ConditionReason = "APIKeyNotUpdatedInAtlas"
+	APIKeySecretNotCreated   ConditionReason = "APIKeySecretNotCreated"
+	APIKeyAccessListNotReady ConditionReason = "APIKeyAccessListNotReady"
+	APIKeyInvalidSpec        ConditionReason = "APIKeyInvalidSpec"
 )
 
 const (
@@ -70,4 +120,62 @@ const (
 	TeamInvalidSpec       ConditionReason = "TeamInvalidSpec"
 	TeamUsersNotReady     ConditionReason = "TeamUsersNotReady"
 	TeamDoesNotExist      ConditionReason = "TeamDoesNotExist"
+	TeamAssignedToProject ConditionReason = "TeamAssignedToProject"
+)
+
+// Atlas Federated Auth reasons
+const (
+	FederatedAuthNotConfigured              ConditionReason = "FederatedAuthNotConfigured"
+	FederatedAuthIdentityProviderNotFound   ConditionReason = "FederatedAuthIdentityProviderNotFound"
+	FederatedAuthIdentityProviderNotUpdated ConditionReason = "FederatedAuthIdentityProviderNotUpdated"
+	FederatedAuthRoleMappingsNotReady       ConditionReason = "FederatedAuthRoleMappingsNotReady"
+	FederatedAuthInvalidSpec                ConditionReason = "FederatedAuthInvalidSpec"
+)
+
+// Atlas IP Access List Entry reasons
+const (
+	IPAccessListEntryNamespaceNotAllowed ConditionReason = "IPAccessListEntryNamespaceNotAllowed"
+	IPAccessListEntryInvalidSpec         ConditionReason = "IPAccessListEntryInvalidSpec"
+	IPAccessListEntryConflict            ConditionReason = "IPAccessListEntryConflict"
+	IPAccessListEntryExpired             ConditionReason = "IPAccessListEntryExpired"
+	IPAccessListEntryNotActive           ConditionReason = "IPAccessListEntryNotActive"
+	IPAccessListEntryNotSynced           ConditionReason = "IPAccessListEntryNotSynced"
+)
+
+// Atlas Private Endpoint reasons
+const (
+	PrivateEndpointServiceNotCreated   ConditionReason = "PrivateEndpointServiceNotCreated"
+	PrivateEndpointServiceFailed       ConditionReason = "PrivateEndpointServiceFailed"
+	PrivateEndpointServiceNotReady     ConditionReason = "PrivateEndpointServiceNotReady"
+	PrivateEndpointInterfaceAwaited    ConditionReason = "PrivateEndpointInterfaceAwaited"
+	PrivateEndpointInterfaceNotCreated ConditionReason = "PrivateEndpointInterfaceNotCreated"
+	PrivateEndpointInterfaceFailed     ConditionReason = "PrivateEndpointInterfaceFailed"
+	PrivateEndpointInterfaceNotReady   ConditionReason = "PrivateEndpointInterfaceNotReady"
+	PrivateEndpointProvisioningFailed  ConditionReason = "PrivateEndpointProvisioningFailed"
+	PrivateEndpointConflict            ConditionReason = "PrivateEndpointConflict"
+	PrivateEndpointDeleting            ConditionReason = "PrivateEndpointDeleting"
+	PrivateEndpointProjectNotReady     ConditionReason = "PrivateEndpointProjectNotReady"
+	PrivateEndpointInvalidSpec         ConditionReason = "PrivateEndpointInvalidSpec"
+)
+
+// Atlas Network Container reasons
+const (
+	NetworkContainerNotCreated      ConditionReason = "NetworkContainerNotCreated"
+	NetworkContainerNotUpdated      ConditionReason = "NetworkContainerNotUpdated"
+	NetworkContainerInUse           ConditionReason = "NetworkContainerInUse"
+	NetworkContainerProjectNotReady ConditionReason = "NetworkContainerProjectNotReady"
+	NetworkContainerInvalidSpec     ConditionReason = "NetworkContainerInvalidSpec"
+)
+
+// Atlas Network Peering reasons
+const (
+	NetworkPeeringNotCreated        ConditionReason = "NetworkPeeringNotCreated"
+	NetworkPeeringNotUpdated        ConditionReason = "NetworkPeeringNotUpdated"
+	NetworkPeeringFailed            ConditionReason = "NetworkPeeringFailed"
+	NetworkPeeringPendingAcceptance ConditionReason = "NetworkPeeringPendingAcceptance"
+	NetworkPeeringNotReady          ConditionReason = "NetworkPeeringNotReady"
+	NetworkPeeringDeleting          ConditionReason = "NetworkPeeringDeleting"
+	NetworkPeeringContainerNotReady ConditionReason = "NetworkPeeringContainerNotReady"
+	NetworkPeeringProjectNotReady   ConditionReason = "NetworkPeeringProjectNotReady"
+	NetworkPeeringInvalidSpec       ConditionReason = "NetworkPeeringInvalidSpec"
 )
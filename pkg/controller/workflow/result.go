@@ -91,6 +91,10 @@ func (r Result) GetMessage() string {
 	return r.message
 }
 
+func (r Result) GetReason() ConditionReason {
+	return r.reason
+}
+
 func (r Result) ReconcileResult() reconcile.Result {
 	if r.requeueAfter < 0 {
 		return reconcile.Result{}
@@ -0,0 +1,171 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// The operator image doesn't ship the time zone database
+	_ "time/tzdata"
+)
+
+// searchLimit bounds the search of the next activation so that the expressions which never match (like February 30)
+// don't loop forever
+const searchLimit = 5 * 366 * 24 * time.Hour
+
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField     = field{name: "minute", min: 0, max: 59}
+	hourField       = field{name: "hour", min: 0, max: 23}
+	dayOfMonthField = field{name: "day of month", min: 1, max: 31}
+	monthField      = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dayOfWeekField = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// Schedule is a parsed standard cron expression with the five fields: minute, hour, day of month, month and day of
+// week. Each field supports '*', single values, ranges, steps and comma-separated lists, months and days of week
+// can also be specified by the three-letter names.
+type Schedule struct {
+	minutes, hours, daysOfMonth, months, daysOfWeek uint64
+	// restricted days are matched by either of the fields as the standard cron does
+	daysOfMonthRestricted, daysOfWeekRestricted bool
+}
+
+// Parse parses the cron expression
+func Parse(expression string) (*Schedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in the cron expression %q but got %d", expression, len(fields))
+	}
+
+	s := &Schedule{}
+	var err error
+	if s.minutes, err = minuteField.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if s.hours, err = hourField.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if s.daysOfMonth, err = dayOfMonthField.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if s.months, err = monthField.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if s.daysOfWeek, err = dayOfWeekField.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	// both 0 and 7 stand for Sunday
+	if s.daysOfWeek&(1<<7) != 0 {
+		s.daysOfWeek |= 1
+	}
+	// like the standard cron, the fields starting with '*' (e.g. "*/2") don't restrict the days
+	s.daysOfMonthRestricted = !strings.HasPrefix(fields[2], "*")
+	s.daysOfWeekRestricted = !strings.HasPrefix(fields[4], "*")
+	return s, nil
+}
+
+// Next returns the first activation of the schedule strictly after t in the location of t. The zero time is returned
+// if the schedule doesn't activate within the next five years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(searchLimit)
+
+	for t.Before(limit) {
+		switch {
+		case !has(s.months, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case !has(s.hours, t.Hour()):
+			// moving by the duration rather than by the wall clock doesn't get stuck on the daylight saving changes
+			t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+		case !has(s.minutes, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dayOfMonth := has(s.daysOfMonth, t.Day())
+	dayOfWeek := has(s.daysOfWeek, int(t.Weekday()))
+	if s.daysOfMonthRestricted && s.daysOfWeekRestricted {
+		return dayOfMonth || dayOfWeek
+	}
+	return dayOfMonth && dayOfWeek
+}
+
+func has(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
+
+func (f field) parse(expression string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expression, ",") {
+		rangeExpression, stepExpression, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepExpression); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q in the %s field", stepExpression, f.name)
+			}
+		}
+
+		low, high := f.min, f.max
+		switch {
+		case rangeExpression == "*":
+		case strings.Contains(rangeExpression, "-"):
+			lowExpression, highExpression, _ := strings.Cut(rangeExpression, "-")
+			var err error
+			if low, err = f.value(lowExpression); err != nil {
+				return 0, err
+			}
+			if high, err = f.value(highExpression); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid range %q in the %s field", rangeExpression, f.name)
+			}
+		default:
+			value, err := f.value(rangeExpression)
+			if err != nil {
+				return 0, err
+			}
+			low = value
+			// "5/10" means starting from 5 with the step 10
+			if !hasStep {
+				high = value
+			}
+		}
+
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+func (f field) value(expression string) (int, error) {
+	if value, ok := f.names[strings.ToLower(expression)]; ok {
+		return value, nil
+	}
+	value, err := strconv.Atoi(expression)
+	if err != nil || value < f.min || value > f.max {
+		return 0, fmt.Errorf("invalid value %q in the %s field, expected %d-%d", expression, f.name, f.min, f.max)
+	}
+	return value, nil
+}
@@ -0,0 +1,66 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, expression := range []string{"* * * * *", "0 20 * * mon-fri", "*/15 8-18 1,15 JAN-jun 0,7", "30 6 * * 1-5/2"} {
+		_, err := Parse(expression)
+		assert.NoError(t, err, expression)
+	}
+
+	for _, expression := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "* * * * fri-mon", "*/0 * * * *", "a * * * *"} {
+		_, err := Parse(expression)
+		assert.Error(t, err, expression)
+	}
+}
+
+func TestNext(t *testing.T) {
+	// 2023-06-09 is Friday
+	from := time.Date(2023, 6, 9, 19, 30, 15, 0, time.UTC)
+
+	tests := []struct {
+		expression string
+		expected   time.Time
+	}{
+		{expression: "* * * * *", expected: time.Date(2023, 6, 9, 19, 31, 0, 0, time.UTC)},
+		{expression: "0 20 * * mon-fri", expected: time.Date(2023, 6, 9, 20, 0, 0, 0, time.UTC)},
+		{expression: "0 8 * * mon-fri", expected: time.Date(2023, 6, 12, 8, 0, 0, 0, time.UTC)},
+		{expression: "30 19 * * *", expected: time.Date(2023, 6, 10, 19, 30, 0, 0, time.UTC)},
+		{expression: "0 0 1 * *", expected: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{expression: "0 0 * * 7", expected: time.Date(2023, 6, 11, 0, 0, 0, 0, time.UTC)},
+		// either the day of month or the day of week matches
+		{expression: "0 0 20 * mon", expected: time.Date(2023, 6, 12, 0, 0, 0, 0, time.UTC)},
+		// the stepped '*' doesn't restrict the days so both fields must match
+		{expression: "0 0 */2 * mon", expected: time.Date(2023, 6, 19, 0, 0, 0, 0, time.UTC)},
+		{expression: "0 0 20 * */2", expected: time.Date(2023, 6, 20, 0, 0, 0, 0, time.UTC)},
+		{expression: "0 0 29 2 *", expected: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{expression: "0 0 30 2 *", expected: time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			schedule, err := Parse(tt.expression)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, schedule.Next(from))
+		})
+	}
+}
+
+func TestNextInLocation(t *testing.T) {
+	location, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	schedule, err := Parse("30 2 * * *")
+	require.NoError(t, err)
+
+	// 2:30 doesn't exist on the day the clocks move forward
+	next := schedule.Next(time.Date(2023, 3, 25, 12, 0, 0, 0, location))
+	assert.Equal(t, time.Date(2023, 3, 27, 2, 30, 0, 0, location), next)
+
+	next = schedule.Next(time.Date(2023, 6, 9, 12, 0, 0, 0, location))
+	assert.Equal(t, time.Date(2023, 6, 10, 0, 30, 0, 0, time.UTC), next.UTC())
+}
@@ -137,9 +137,11 @@ func ensureTeamsStatus(g Gomega, testData model.TestDataProvider, teams []v1.Tea
 }
 
 func teamWasCreated(team *v1.AtlasTeam) bool {
-	return team.Status.ID != ""
+	return team.Status.ID != "" && len(team.Status.Projects) > 0
 }
 
+// teamWasRemoved checks the team was unassigned from the project. The team itself stays in Atlas until the AtlasTeam
+// resource is deleted.
 func teamWasRemoved(team *v1.AtlasTeam) bool {
-	return team.Status.ID == ""
+	return len(team.Status.Projects) == 0
 }
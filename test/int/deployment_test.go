@@ -1260,8 +1260,12 @@ func validateDeploymentUpdatingFunc(g Gomega) func(a mdbv1.AtlasCustomResource)
 		// When the create request has been made to Atlas - we expect the following status
 		if !isIdle {
 			g.Expect(c.Status.StateName).To(Or(Equal("UPDATING"), Equal("REPAIRING")), fmt.Sprintf("Current conditions: %+v", c.Status.Conditions))
+			expectedReadyCondition := status.FalseCondition(status.DeploymentReadyType).WithReason(string(workflow.DeploymentUpdating)).WithMessageRegexp("deployment is updating")
+			if c.Status.StateName == "REPAIRING" {
+				expectedReadyCondition = status.FalseCondition(status.DeploymentReadyType).WithReason(string(workflow.DeploymentRepairing)).WithMessageRegexp("deployment is being repaired")
+			}
 			expectedConditionsMatchers := testutil.MatchConditions(
-				status.FalseCondition(status.DeploymentReadyType).WithReason(string(workflow.DeploymentUpdating)).WithMessageRegexp("deployment is updating"),
+				expectedReadyCondition,
 				status.FalseCondition(status.ReadyType),
 				status.TrueCondition(status.ValidationSucceeded),
 				status.TrueCondition(status.ResourceVersionStatus),